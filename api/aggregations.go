@@ -0,0 +1,68 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/database"
+	"github.com/ava-labs/awm-relayer/utils"
+	"go.uber.org/zap"
+)
+
+const AggregationsAPIPath = "/aggregations"
+
+// HandleAggregations serves the persisted aggregation record for the warp message ID
+// given by the "message-id" query parameter.
+func HandleAggregations(
+	logger logging.Logger,
+	store *database.AggregationStore,
+	relayerIDs []database.RelayerID,
+) {
+	http.Handle(AggregationsAPIPath, aggregationsAPIHandler(logger, store, relayerIDs))
+}
+
+func aggregationsAPIHandler(
+	logger logging.Logger,
+	store *database.AggregationStore,
+	relayerIDs []database.RelayerID,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		rawMessageID := r.URL.Query().Get("message-id")
+		messageID, err := utils.HexOrCB58ToID(rawMessageID)
+		if err != nil {
+			logger.Warn("Invalid messageID", zap.String("messageID", rawMessageID))
+			http.Error(w, "invalid messageID: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		for _, relayerID := range relayerIDs {
+			record, err := store.Get(relayerID.ID, messageID.String())
+			if database.IsKeyNotFoundError(err) {
+				continue
+			}
+			if err != nil {
+				logger.Error("Error reading aggregation record", zap.Error(err))
+				http.Error(w, "error reading aggregation record: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			resp, err := json.Marshal(record)
+			if err != nil {
+				logger.Error("Error marshaling response", zap.Error(err))
+				http.Error(w, "error marshaling response: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			_, err = w.Write(resp)
+			if err != nil {
+				logger.Error("Error writing response", zap.Error(err))
+			}
+			return
+		}
+		http.Error(w, "aggregation record not found", http.StatusNotFound)
+	})
+}
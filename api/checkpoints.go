@@ -0,0 +1,36 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/relayer"
+	"go.uber.org/zap"
+)
+
+const CheckpointsAPIPath = "/checkpoints"
+
+// HandleCheckpoints serves the committed and pending heights of each source blockchain
+func HandleCheckpoints(logger logging.Logger, messageCoordinator *relayer.MessageCoordinator) {
+	http.Handle(CheckpointsAPIPath, checkpointsAPIHandler(logger, messageCoordinator))
+}
+
+func checkpointsAPIHandler(logger logging.Logger, messageCoordinator *relayer.MessageCoordinator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		resp, err := json.Marshal(messageCoordinator.Checkpoints())
+		if err != nil {
+			logger.Error("Error marshaling response", zap.Error(err))
+			http.Error(w, "error marshaling response: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, err = w.Write(resp)
+		if err != nil {
+			logger.Error("Error writing response", zap.Error(err))
+		}
+	})
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/database"
+	"github.com/ava-labs/awm-relayer/relayer"
+	"github.com/ava-labs/awm-relayer/types"
+	"github.com/ava-labs/awm-relayer/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/zap"
+)
+
+const (
+	DeadLettersAPIPath  = "/deadletters"
+	deadLetterRetryPath = "/retry"
+)
+
+// HandleDeadLetters serves the messages that failed to be delivered at DeadLettersAPIPath, and re-attempts
+// the delivery of the message with a given Warp message ID at DeadLettersAPIPath/{id}/retry.
+func HandleDeadLetters(
+	logger logging.Logger,
+	messageCoordinator *relayer.MessageCoordinator,
+	store *database.DeadLetterStore,
+	relayerIDs []database.RelayerID,
+) {
+	http.Handle(DeadLettersAPIPath, deadLettersAPIHandler(logger, store, relayerIDs))
+	http.Handle(DeadLettersAPIPath+"/", deadLetterRetryAPIHandler(logger, messageCoordinator, store, relayerIDs))
+}
+
+func deadLettersAPIHandler(
+	logger logging.Logger,
+	store *database.DeadLetterStore,
+	relayerIDs []database.RelayerID,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		deadLetters := []*database.DeadLetter{}
+		for _, relayerID := range relayerIDs {
+			list, err := store.List(relayerID.ID)
+			if err != nil {
+				logger.Error("Error reading dead letters", zap.Error(err))
+				http.Error(w, "error reading dead letters: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			deadLetters = append(deadLetters, list...)
+		}
+
+		resp, err := json.Marshal(deadLetters)
+		if err != nil {
+			logger.Error("Error marshaling response", zap.Error(err))
+			http.Error(w, "error marshaling response: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, err = w.Write(resp)
+		if err != nil {
+			logger.Error("Error writing response", zap.Error(err))
+		}
+	})
+}
+
+func deadLetterRetryAPIHandler(
+	logger logging.Logger,
+	messageCoordinator *relayer.MessageCoordinator,
+	store *database.DeadLetterStore,
+	relayerIDs []database.RelayerID,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawMessageID, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, DeadLettersAPIPath+"/"), deadLetterRetryPath)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		messageID, err := utils.HexOrCB58ToID(rawMessageID)
+		if err != nil {
+			logger.Warn("Invalid messageID", zap.String("messageID", rawMessageID))
+			http.Error(w, "invalid messageID: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var deadLetter *database.DeadLetter
+		for _, relayerID := range relayerIDs {
+			deadLetter, err = store.Get(relayerID.ID, messageID.String())
+			if database.IsKeyNotFoundError(err) {
+				continue
+			}
+			if err != nil {
+				logger.Error("Error reading dead letter", zap.Error(err))
+				http.Error(w, "error reading dead letter: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			break
+		}
+		if deadLetter == nil {
+			http.Error(w, "dead letter not found", http.StatusNotFound)
+			return
+		}
+
+		unsignedMessageBytes, err := hexutil.Decode(deadLetter.UnsignedMessage)
+		if err != nil {
+			logger.Error("Error decoding dead letter", zap.Error(err))
+			http.Error(w, "error decoding dead letter: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		unsignedMessage, err := types.UnpackWarpMessage(unsignedMessageBytes)
+		if err != nil {
+			logger.Error("Error unpacking warp message", zap.Error(err))
+			http.Error(w, "error unpacking warp message: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// The dead letter is removed once the message is delivered, or replaced if it fails again
+		txHash, err := messageCoordinator.ProcessWarpMessage(&types.WarpMessageInfo{
+			SourceAddress:   common.HexToAddress(deadLetter.SourceAddress),
+			UnsignedMessage: unsignedMessage,
+		})
+		if err != nil {
+			logger.Error("Error processing message", zap.Error(err))
+			http.Error(w, "error processing message: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		resp, err := json.Marshal(
+			RelayMessageResponse{
+				TransactionHash: txHash.Hex(),
+			},
+		)
+		if err != nil {
+			logger.Error("Error marshaling response", zap.Error(err))
+			http.Error(w, "error marshaling response: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, err = w.Write(resp)
+		if err != nil {
+			logger.Error("Error writing response", zap.Error(err))
+		}
+	})
+}
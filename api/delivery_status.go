@@ -0,0 +1,84 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/relayer"
+	"github.com/ava-labs/awm-relayer/utils"
+	"go.uber.org/zap"
+)
+
+const DeliveryStatusAPIPath = "/delivery-status"
+
+// HandleDeliveryStatus serves whether the message given by the "message-id" query parameter, sent from the
+// blockchain given by the "source-blockchain-id" query parameter, has been delivered to each of the source's
+// destinations. The optional "destination-blockchain-id" query parameter restricts the query to one destination.
+func HandleDeliveryStatus(logger logging.Logger, messageCoordinator *relayer.MessageCoordinator) {
+	http.Handle(DeliveryStatusAPIPath, deliveryStatusAPIHandler(logger, messageCoordinator))
+}
+
+func deliveryStatusAPIHandler(logger logging.Logger, messageCoordinator *relayer.MessageCoordinator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		query := r.URL.Query()
+		rawSourceBlockchainID := query.Get("source-blockchain-id")
+		sourceBlockchainID, err := utils.HexOrCB58ToID(rawSourceBlockchainID)
+		if err != nil {
+			logger.Warn("Invalid sourceBlockchainID", zap.String("sourceBlockchainID", rawSourceBlockchainID))
+			http.Error(w, "invalid sourceBlockchainID: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		rawMessageID := query.Get("message-id")
+		messageID, err := utils.HexOrCB58ToID(rawMessageID)
+		if err != nil {
+			logger.Warn("Invalid messageID", zap.String("messageID", rawMessageID))
+			http.Error(w, "invalid messageID: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		var destinationBlockchainID ids.ID
+		if rawDestinationBlockchainID := query.Get("destination-blockchain-id"); rawDestinationBlockchainID != "" {
+			destinationBlockchainID, err = utils.HexOrCB58ToID(rawDestinationBlockchainID)
+			if err != nil {
+				logger.Warn(
+					"Invalid destinationBlockchainID",
+					zap.String("destinationBlockchainID", rawDestinationBlockchainID),
+				)
+				http.Error(w, "invalid destinationBlockchainID: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		statuses, err := messageCoordinator.GetDeliveryStatus(
+			r.Context(),
+			sourceBlockchainID,
+			messageID,
+			destinationBlockchainID,
+		)
+		if relayer.IsDeliveryStatusUnavailableError(err) {
+			logger.Warn("Delivery status unavailable", zap.Error(err))
+			http.Error(w, "delivery status unavailable: "+err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			logger.Error("Error getting delivery status", zap.Error(err))
+			http.Error(w, "error getting delivery status: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resp, err := json.Marshal(statuses)
+		if err != nil {
+			logger.Error("Error marshaling response", zap.Error(err))
+			http.Error(w, "error marshaling response: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, err = w.Write(resp)
+		if err != nil {
+			logger.Error("Error writing response", zap.Error(err))
+		}
+	})
+}
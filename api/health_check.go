@@ -8,24 +8,44 @@ import (
 	"github.com/alexliesenfeld/health"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/utils"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
 const HealthAPIPath = "/health"
 
-func HandleHealthCheck(logger logging.Logger, relayerHealth map[ids.ID]*atomic.Bool) {
-	http.Handle(HealthAPIPath, healthCheckHandler(logger, relayerHealth))
+// PChainClient is used to check that the P-Chain API is reachable
+type PChainClient interface {
+	GetCurrentHeight(ctx context.Context) (uint64, error)
 }
 
-func healthCheckHandler(logger logging.Logger, relayerHealth map[ids.ID]*atomic.Bool) http.Handler {
+// HandleHealthCheck reports the relayer as unhealthy if any listener has encountered an unrecoverable error
+// or is reconnecting its source subscription, if the P-Chain API is unreachable, or if the stake of any source
+// with a configured minimum stake is below that minimum. Unhealthy checks are reported with a 503 status code.
+// [relayerHealth] returns the health of each running listener, which may change as the configuration is reloaded.
+func HandleHealthCheck(
+	logger logging.Logger,
+	relayerHealth func() map[ids.ID]*atomic.Bool,
+	sourceStakeHealth map[ids.ID]*atomic.Bool,
+	pChainClient PChainClient,
+) {
+	http.Handle(HealthAPIPath, healthCheckHandler(logger, relayerHealth, sourceStakeHealth, pChainClient))
+}
+
+func healthCheckHandler(
+	logger logging.Logger,
+	relayerHealth func() map[ids.ID]*atomic.Bool,
+	sourceStakeHealth map[ids.ID]*atomic.Bool,
+	pChainClient PChainClient,
+) http.Handler {
 	return health.NewHandler(health.NewChecker(
 		health.WithCheck(health.Check{
 			Name: "relayers-all",
 			Check: func(context.Context) error {
 				// Store the IDs as the cb58 encoding
 				var unhealthyRelayers []string
-				for id, health := range relayerHealth {
+				for id, health := range relayerHealth() {
 					if !health.Load() {
 						unhealthyRelayers = append(unhealthyRelayers, id.String())
 					}
@@ -38,5 +58,33 @@ func healthCheckHandler(logger logging.Logger, relayerHealth map[ids.ID]*atomic.
 				return nil
 			},
 		}),
+		health.WithCheck(health.Check{
+			Name:    "p-chain-api",
+			Timeout: utils.DefaultRPCRetryTimeout,
+			Check: func(ctx context.Context) error {
+				if _, err := pChainClient.GetCurrentHeight(ctx); err != nil {
+					logger.Warn("P-Chain API is unreachable", zap.Error(err))
+					return fmt.Errorf("p-chain api is unreachable: %w", err)
+				}
+				return nil
+			},
+		}),
+		health.WithCheck(health.Check{
+			Name: "source-stake",
+			Check: func(context.Context) error {
+				var insecureSources []string
+				for id, secure := range sourceStakeHealth {
+					if !secure.Load() {
+						insecureSources = append(insecureSources, id.String())
+					}
+				}
+
+				if len(insecureSources) > 0 {
+					logger.Warn("Source subnet stake below configured minimum", zap.Strings("blockchains", insecureSources))
+					return fmt.Errorf("source subnet stake below configured minimum for blockchains %v", insecureSources)
+				}
+				return nil
+			},
+		}),
 	))
 }
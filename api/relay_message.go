@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"math/big"
 	"net/http"
@@ -18,13 +19,19 @@ const (
 	RelayMessageAPIPath = RelayAPIPath + "/message"
 )
 
+// The message to relay is identified either by its message ID and block number,
+// or by the hash of the transaction that sent it and its event index.
 type RelayMessageRequest struct {
 	// Required. cb58-encoded or "0x" prefixed hex-encoded source blockchain ID for the message
 	BlockchainID string `json:"blockchain-id"`
-	// Required. cb58-encoded or "0x" prefixed hex-encoded warp message ID
+	// cb58-encoded or "0x" prefixed hex-encoded warp message ID
 	MessageID string `json:"message-id"`
-	// Required. Block number that the message was sent in
+	// Block number that the message was sent in
 	BlockNum uint64 `json:"block-num"`
+	// "0x" prefixed hex-encoded hash of the transaction that sent the message
+	TransactionHash string `json:"transaction-hash"`
+	// Index of the message among the Warp messages sent by the transaction
+	EventIndex uint `json:"event-index"`
 }
 
 type RelayMessageResponse struct {
@@ -109,14 +116,32 @@ func relayAPIHandler(logger logging.Logger, messageCoordinator *relayer.MessageC
 			http.Error(w, "invalid blockchainID: "+err.Error(), http.StatusBadRequest)
 			return
 		}
-		messageID, err := utils.HexOrCB58ToID(req.MessageID)
-		if err != nil {
-			logger.Warn("Invalid messageID", zap.String("messageID", req.MessageID))
-			http.Error(w, "invalid messageID: "+err.Error(), http.StatusBadRequest)
-			return
-		}
 
-		txHash, err := messageCoordinator.ProcessMessageID(blockchainID, messageID, new(big.Int).SetUint64(req.BlockNum))
+		var txHash common.Hash
+		if req.TransactionHash != "" {
+			if !isHexHash(req.TransactionHash) {
+				logger.Warn("Invalid transactionHash", zap.String("transactionHash", req.TransactionHash))
+				http.Error(w, "invalid transactionHash: "+req.TransactionHash, http.StatusBadRequest)
+				return
+			}
+			txHash, err = messageCoordinator.ProcessTransactionLog(
+				blockchainID,
+				common.HexToHash(req.TransactionHash),
+				req.EventIndex,
+			)
+		} else {
+			messageID, parseErr := utils.HexOrCB58ToID(req.MessageID)
+			if parseErr != nil {
+				logger.Warn("Invalid messageID", zap.String("messageID", req.MessageID))
+				http.Error(w, "invalid messageID: "+parseErr.Error(), http.StatusBadRequest)
+				return
+			}
+			txHash, err = messageCoordinator.ProcessMessageID(
+				blockchainID,
+				messageID,
+				new(big.Int).SetUint64(req.BlockNum),
+			)
+		}
 		if err != nil {
 			logger.Error("Error processing message", zap.Error(err))
 			http.Error(w, "error processing message: "+err.Error(), http.StatusInternalServerError)
@@ -140,3 +165,12 @@ func relayAPIHandler(logger logging.Logger, messageCoordinator *relayer.MessageC
 		}
 	})
 }
+
+func isHexHash(s string) bool {
+	s = utils.SanitizeHexString(s)
+	if len(s) != 2*common.HashLength {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/relayer"
+	"github.com/ava-labs/awm-relayer/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	PauseAPIPath  = "/pause"
+	ResumeAPIPath = "/resume"
+	RoutesAPIPath = "/routes"
+)
+
+// Identifies a route by its source and destination blockchains
+type RouteRequest struct {
+	// cb58-encoded or "0x" prefixed hex-encoded source blockchain ID
+	SourceBlockchainID string `json:"source-blockchain-id"`
+	// cb58-encoded or "0x" prefixed hex-encoded destination blockchain ID
+	DestinationBlockchainID string `json:"destination-blockchain-id"`
+}
+
+// HandleRoutes serves the pause state of each route, and allows routes to be paused and resumed.
+func HandleRoutes(logger logging.Logger, messageCoordinator *relayer.MessageCoordinator) {
+	http.Handle(PauseAPIPath, routePauseAPIHandler(logger, messageCoordinator.PauseRoute))
+	http.Handle(ResumeAPIPath, routePauseAPIHandler(logger, messageCoordinator.ResumeRoute))
+	http.Handle(RoutesAPIPath, routesAPIHandler(logger, messageCoordinator))
+}
+
+// routePauseAPIHandler applies [setPaused] to the route given in the request body.
+func routePauseAPIHandler(
+	logger logging.Logger,
+	setPaused func(sourceBlockchainID ids.ID, destinationBlockchainID ids.ID) error,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var req RouteRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			logger.Warn("Could not decode request body")
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		sourceBlockchainID, err := utils.HexOrCB58ToID(req.SourceBlockchainID)
+		if err != nil {
+			logger.Warn("Invalid sourceBlockchainID", zap.String("sourceBlockchainID", req.SourceBlockchainID))
+			http.Error(w, "invalid sourceBlockchainID: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		destinationBlockchainID, err := utils.HexOrCB58ToID(req.DestinationBlockchainID)
+		if err != nil {
+			logger.Warn(
+				"Invalid destinationBlockchainID",
+				zap.String("destinationBlockchainID", req.DestinationBlockchainID),
+			)
+			http.Error(w, "invalid destinationBlockchainID: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := setPaused(sourceBlockchainID, destinationBlockchainID); err != nil {
+			logger.Warn("Error updating route", zap.Error(err))
+			http.Error(w, "error updating route: "+err.Error(), http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func routesAPIHandler(logger logging.Logger, messageCoordinator *relayer.MessageCoordinator) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		resp, err := json.Marshal(messageCoordinator.Routes())
+		if err != nil {
+			logger.Error("Error marshaling response", zap.Error(err))
+			http.Error(w, "error marshaling response: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, err = w.Write(resp)
+		if err != nil {
+			logger.Error("Error writing response", zap.Error(err))
+		}
+	})
+}
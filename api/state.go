@@ -0,0 +1,97 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/database"
+	"go.uber.org/zap"
+)
+
+const (
+	StateExportAPIPath = "/state/export"
+	StateImportAPIPath = "/state/import"
+)
+
+type StateImportResponse struct {
+	ImportedEntries int `json:"imported-entries"`
+}
+
+// HandleStateExport serves the relayer state stored in [db] for [relayerIDs], such as checkpoints and
+// delivery records, in the format accepted by StateImportAPIPath.
+func HandleStateExport(logger logging.Logger, db database.RelayerDatabase, relayerIDs []database.RelayerID) {
+	http.Handle(StateExportAPIPath, stateExportAPIHandler(logger, db, relayerIDs))
+}
+
+// HandleStateImport writes relayer state in the format served by StateExportAPIPath to [db].
+func HandleStateImport(logger logging.Logger, db database.RelayerDatabase) {
+	http.Handle(StateImportAPIPath, stateImportAPIHandler(logger, db))
+}
+
+func stateExportAPIHandler(
+	logger logging.Logger,
+	db database.RelayerDatabase,
+	relayerIDs []database.RelayerID,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		entries, err := database.ExportState(db, relayerIDs)
+		if err != nil {
+			logger.Error("Error exporting relayer state", zap.Error(err))
+			http.Error(w, "error exporting relayer state: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		resp, err := json.Marshal(entries)
+		if err != nil {
+			logger.Error("Error marshaling response", zap.Error(err))
+			http.Error(w, "error marshaling response: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, err = w.Write(resp)
+		if err != nil {
+			logger.Error("Error writing response", zap.Error(err))
+		}
+	})
+}
+
+func stateImportAPIHandler(logger logging.Logger, db database.RelayerDatabase) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var entries []*database.StateEntry
+		if err := json.NewDecoder(r.Body).Decode(&entries); err != nil {
+			logger.Warn("Could not decode request body", zap.Error(err))
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := database.ImportState(db, entries); errors.Is(err, database.ErrUnsupportedStateKey) {
+			logger.Warn("Invalid relayer state", zap.Error(err))
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		} else if err != nil {
+			logger.Error("Error importing relayer state", zap.Error(err))
+			http.Error(w, "error importing relayer state: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		logger.Info("Imported relayer state", zap.Int("entries", len(entries)))
+
+		resp, err := json.Marshal(StateImportResponse{ImportedEntries: len(entries)})
+		if err != nil {
+			logger.Error("Error marshaling response", zap.Error(err))
+			http.Error(w, "error marshaling response: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, err = w.Write(resp)
+		if err != nil {
+			logger.Error("Error writing response", zap.Error(err))
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/peers"
+	"github.com/ava-labs/awm-relayer/utils"
+	"go.uber.org/zap"
+)
+
+const ValidatorsAPIPath = "/validators/"
+
+// HandleValidators serves the current validator set of the subnet that validates the source blockchain given by
+// the path following ValidatorsAPIPath, as known to the relayer's p2p network.
+func HandleValidators(logger logging.Logger, network *peers.AppRequestNetwork, cfg *config.Config) {
+	sourceSubnetIDs := make(map[ids.ID]ids.ID, len(cfg.SourceBlockchains))
+	for _, sourceBlockchain := range cfg.SourceBlockchains {
+		sourceSubnetIDs[sourceBlockchain.GetBlockchainID()] = sourceBlockchain.GetSubnetID()
+	}
+	http.Handle(ValidatorsAPIPath, validatorsAPIHandler(logger, network, sourceSubnetIDs))
+}
+
+func validatorsAPIHandler(
+	logger logging.Logger,
+	network *peers.AppRequestNetwork,
+	sourceSubnetIDs map[ids.ID]ids.ID,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		rawBlockchainID := strings.TrimPrefix(r.URL.Path, ValidatorsAPIPath)
+		blockchainID, err := utils.HexOrCB58ToID(rawBlockchainID)
+		if err != nil {
+			logger.Warn("Invalid blockchainID", zap.String("blockchainID", rawBlockchainID))
+			http.Error(w, "invalid blockchainID: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		subnetID, ok := sourceSubnetIDs[blockchainID]
+		if !ok {
+			logger.Warn("Unknown source blockchain", zap.String("blockchainID", blockchainID.String()))
+			http.Error(w, "unknown source blockchain: "+blockchainID.String(), http.StatusNotFound)
+			return
+		}
+
+		validators, err := network.GetSubnetValidators(r.Context(), subnetID)
+		if err != nil {
+			logger.Error("Error getting validators", zap.Error(err))
+			http.Error(w, "error getting validators: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resp, err := json.Marshal(validators)
+		if err != nil {
+			logger.Error("Error marshaling response", zap.Error(err))
+			http.Error(w, "error marshaling response: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, err = w.Write(resp)
+		if err != nil {
+			logger.Error("Error writing response", zap.Error(err))
+		}
+	})
+}
@@ -0,0 +1,165 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package client is a Go client for the API served by a running relayer, for services that request the relay of
+// messages programmatically.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/awm-relayer/api"
+	"github.com/ava-labs/awm-relayer/relayer"
+)
+
+// APIError is returned when the relayer responds to a request with a non-200 status code.
+type APIError struct {
+	StatusCode int
+	// The error message returned by the relayer
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("relayer API returned status %d: %s", e.StatusCode, e.Message)
+}
+
+// GetMessageStatusRequest identifies the message to query the delivery status of.
+type GetMessageStatusRequest struct {
+	// Required. The blockchain the message was sent from
+	SourceBlockchainID ids.ID
+	// Required. The message protocol's ID for the message, such as the Teleporter message ID
+	MessageID ids.ID
+	// If non-empty, only the delivery status to this destination is queried
+	DestinationBlockchainID ids.ID
+}
+
+// Client submits requests to the API of a running relayer.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient returns a client for the relayer API served at [baseURL], such as "http://localhost:8080".
+// Requests are made with [httpClient], or http.DefaultClient if it is nil.
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// RelayMessage relays the message identified by [req], and returns the hash of the transaction that delivered it.
+func (c *Client) RelayMessage(
+	ctx context.Context,
+	req *api.RelayMessageRequest,
+) (*api.RelayMessageResponse, error) {
+	var resp api.RelayMessageResponse
+	if err := c.do(ctx, http.MethodPost, api.RelayAPIPath, nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RelayWarpMessage relays the unsigned Warp message given by [req], rather than one read from the source
+// blockchain, and returns the hash of the transaction that delivered it.
+func (c *Client) RelayWarpMessage(
+	ctx context.Context,
+	req *api.ManualWarpMessageRequest,
+) (*api.RelayMessageResponse, error) {
+	var resp api.RelayMessageResponse
+	if err := c.do(ctx, http.MethodPost, api.RelayMessageAPIPath, nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetMessageStatus returns whether the message identified by [req] has been delivered to each of its source's
+// destinations, or only to the requested destination. The relayer responds with a 404 status code if the delivery
+// status of messages from the source cannot be queried.
+func (c *Client) GetMessageStatus(
+	ctx context.Context,
+	req *GetMessageStatusRequest,
+) ([]relayer.DeliveryStatus, error) {
+	query := url.Values{}
+	query.Set("source-blockchain-id", req.SourceBlockchainID.String())
+	query.Set("message-id", req.MessageID.String())
+	if req.DestinationBlockchainID != ids.Empty {
+		query.Set("destination-blockchain-id", req.DestinationBlockchainID.String())
+	}
+	var resp []relayer.DeliveryStatus
+	if err := c.do(ctx, http.MethodGet, api.DeliveryStatusAPIPath, query, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListCheckpoints returns the progress of each source blockchain's Application Relayers through its blocks.
+func (c *Client) ListCheckpoints(ctx context.Context) ([]relayer.SourceCheckpoints, error) {
+	var resp []relayer.SourceCheckpoints
+	if err := c.do(ctx, http.MethodGet, api.CheckpointsAPIPath, nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// do sends a request to [path] with [query] and the JSON encoding of [body], if non-nil, and decodes the JSON
+// response into [resp].
+func (c *Client) do(
+	ctx context.Context,
+	method string,
+	path string,
+	query url.Values,
+	body interface{},
+	resp interface{},
+) error {
+	reqURL := c.baseURL + path
+	if len(query) != 0 {
+		reqURL += "?" + query.Encode()
+	}
+	var reqBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reqBody = bytes.NewReader(encoded)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer httpResp.Body.Close()
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return &APIError{
+			StatusCode: httpResp.StatusCode,
+			Message:    strings.TrimSpace(string(respBody)),
+		}
+	}
+	if err := json.Unmarshal(respBody, resp); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
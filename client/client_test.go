@@ -0,0 +1,118 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/awm-relayer/api"
+	"github.com/ava-labs/awm-relayer/relayer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayMessage(t *testing.T) {
+	req := &api.RelayMessageRequest{
+		BlockchainID:    ids.GenerateTestID().String(),
+		TransactionHash: common.HexToHash("0x1234").Hex(),
+		EventIndex:      1,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, api.RelayAPIPath, r.URL.Path)
+		var received api.RelayMessageRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		require.Equal(t, *req, received)
+		require.NoError(t, json.NewEncoder(w).Encode(api.RelayMessageResponse{TransactionHash: "0xabcd"}))
+	}))
+	defer server.Close()
+
+	resp, err := NewClient(server.URL+"/", nil).RelayMessage(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, "0xabcd", resp.TransactionHash)
+}
+
+func TestGetMessageStatus(t *testing.T) {
+	sourceBlockchainID := ids.GenerateTestID()
+	destinationBlockchainID := ids.GenerateTestID()
+	messageID := ids.GenerateTestID()
+	statuses := []relayer.DeliveryStatus{
+		{
+			SourceBlockchainID:      sourceBlockchainID,
+			DestinationBlockchainID: destinationBlockchainID,
+			MessageProtocolAddress:  common.HexToAddress("0x0123"),
+			Delivered:               true,
+			TransactionHash:         "0xabcd",
+		},
+	}
+
+	testCases := []struct {
+		name                    string
+		destinationBlockchainID ids.ID
+		statusCode              int
+		expectedErr             *APIError
+	}{
+		{
+			name:       "all destinations",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:                    "one destination",
+			destinationBlockchainID: destinationBlockchainID,
+			statusCode:              http.StatusOK,
+		},
+		{
+			name:       "unavailable",
+			statusCode: http.StatusNotFound,
+			expectedErr: &APIError{
+				StatusCode: http.StatusNotFound,
+				Message:    "delivery status unavailable",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Equal(t, api.DeliveryStatusAPIPath, r.URL.Path)
+				query := r.URL.Query()
+				require.Equal(t, sourceBlockchainID.String(), query.Get("source-blockchain-id"))
+				require.Equal(t, messageID.String(), query.Get("message-id"))
+				if testCase.destinationBlockchainID == ids.Empty {
+					require.False(t, query.Has("destination-blockchain-id"))
+				} else {
+					require.Equal(t, testCase.destinationBlockchainID.String(), query.Get("destination-blockchain-id"))
+				}
+				if testCase.statusCode != http.StatusOK {
+					http.Error(w, "delivery status unavailable", testCase.statusCode)
+					return
+				}
+				require.NoError(t, json.NewEncoder(w).Encode(statuses))
+			}))
+			defer server.Close()
+
+			resp, err := NewClient(server.URL, nil).GetMessageStatus(
+				context.Background(),
+				&GetMessageStatusRequest{
+					SourceBlockchainID:      sourceBlockchainID,
+					MessageID:               messageID,
+					DestinationBlockchainID: testCase.destinationBlockchainID,
+				},
+			)
+			if testCase.expectedErr != nil {
+				var apiErr *APIError
+				require.ErrorAs(t, err, &apiErr)
+				require.Equal(t, testCase.expectedErr, apiErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, statuses, resp)
+		})
+	}
+}
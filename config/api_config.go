@@ -1,10 +1,18 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
+	"sort"
+
+	"go.uber.org/zap/zapcore"
 )
 
+// redactedValue replaces the values of query parameters and HTTP headers when an APIConfig is logged,
+// since they commonly carry API keys.
+const redactedValue = "<redacted>"
+
 // API configuration containing the base URL and query parameters
 type APIConfig struct {
 	BaseURL     string            `mapstructure:"base-url" json:"base-url"`
@@ -18,3 +26,52 @@ func (c *APIConfig) Validate() error {
 	}
 	return nil
 }
+
+// MarshalLogObject logs the base URL, and the names of the query parameters and HTTP headers with their
+// values redacted.
+func (c *APIConfig) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("base-url", c.BaseURL)
+	if err := enc.AddObject("query-parameters", redactedMap(c.QueryParams)); err != nil {
+		return err
+	}
+	return enc.AddObject("http-headers", redactedMap(c.HTTPHeaders))
+}
+
+// redactedMap logs the keys of a map in order, with their values redacted
+type redactedMap map[string]string
+
+func (m redactedMap) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		enc.AddString(key, redactedValue)
+	}
+	return nil
+}
+
+// validateFallbackRPCEndpoints validates the endpoints that requests to [primary] fail over to. Requests are
+// failed over by the HTTP transport, so all endpoints must be HTTP endpoints. [failoverOptionsSet] is true if
+// any option that only applies when failing over between endpoints is set.
+func validateFallbackRPCEndpoints(primary APIConfig, fallbacks []APIConfig, failoverOptionsSet bool) error {
+	if len(fallbacks) == 0 {
+		if failoverOptionsSet {
+			return errors.New(
+				"stale-head-timeout-seconds and endpoint-health-check-interval-seconds require fallback-rpc-endpoints",
+			)
+		}
+		return nil
+	}
+	for i, endpoint := range append([]APIConfig{primary}, fallbacks...) {
+		if err := endpoint.Validate(); err != nil {
+			return fmt.Errorf("invalid endpoint %d: %w", i, err)
+		}
+		u, _ := url.ParseRequestURI(endpoint.BaseURL)
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("rpc-endpoint and fallback-rpc-endpoints must be HTTP endpoints: %s", endpoint.BaseURL)
+		}
+	}
+	return nil
+}
@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"path/filepath"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -33,13 +35,50 @@ const (
 	defaultAPIPort             = uint16(8080)
 	defaultMetricsPort         = uint16(9090)
 	defaultIntervalSeconds     = uint64(10)
+
+	defaultDeduplicateSignatureRequests = true
+	defaultAggregationRetentionSeconds  = uint64(7 * 24 * 60 * 60)
+	defaultPostgresMaxOpenConnections   = 10
+	defaultSignatureCacheSize           = 1024
+	defaultSignatureCacheTTLSeconds     = uint64(5 * 60)
+	defaultShutdownTimeoutSeconds       = uint64(30)
 )
 
 var defaultLogLevel = logging.Info.String()
 
+// Supported log formats
+const (
+	LogFormatJSON    = "json"
+	LogFormatConsole = "console"
+
+	defaultLogFormat = LogFormatJSON
+)
+
+// Supported backends for the state stored in storage-location
+const (
+	StorageTypeJSON   = "json"
+	StorageTypeBadger = "badger"
+
+	defaultStorageType = StorageTypeJSON
+)
+
+// Supported handling of messages on a route paused via the API
+const (
+	// Messages are held until the route is resumed, and their source blocks are not checkpointed
+	PausedRouteModeBuffer = "buffer"
+	// Messages are not relayed, and their source blocks are checkpointed
+	PausedRouteModeSkip = "skip"
+
+	defaultPausedRouteMode = PausedRouteModeBuffer
+)
+
 const usageText = `
 Usage:
 awm-relayer --config-file path-to-config                Specifies the relayer config file and begin relaying messages.
+awm-relayer --config-file path-to-config --dry-run      Log the transactions that would be sent, without sending them.
+awm-relayer --config-file path-to-config --relay-file path-to-messages
+                                                        Relay the Warp messages in the file, then exit.
+awm-relayer keys --config-file path-to-config           Print the relayer IDs of the configured relayers and exit.
 awm-relayer --version                                   Display awm-relayer version and exit.
 awm-relayer --help                                      Display awm-relayer usage and exit.
 `
@@ -49,8 +88,12 @@ var errFailedToGetWarpQuorum = errors.New("failed to get warp quorum")
 // Top-level configuration
 type Config struct {
 	LogLevel               string                   `mapstructure:"log-level" json:"log-level"`
+	LogFormat              string                   `mapstructure:"log-format" json:"log-format"`
 	StorageLocation        string                   `mapstructure:"storage-location" json:"storage-location"`
+	StorageType            string                   `mapstructure:"storage-type" json:"storage-type"`
 	RedisURL               string                   `mapstructure:"redis-url" json:"redis-url"`
+	PostgresURL            string                   `mapstructure:"postgres-url" json:"postgres-url"`
+	RecordsStorage         *StorageConfig           `mapstructure:"records-storage" json:"records-storage"`
 	APIPort                uint16                   `mapstructure:"api-port" json:"api-port"`
 	MetricsPort            uint16                   `mapstructure:"metrics-port" json:"metrics-port"`
 	DBWriteIntervalSeconds uint64                   `mapstructure:"db-write-interval-seconds" json:"db-write-interval-seconds"` //nolint:lll
@@ -60,6 +103,45 @@ type Config struct {
 	DestinationBlockchains []*DestinationBlockchain `mapstructure:"destination-blockchains" json:"destination-blockchains"`
 	ProcessMissedBlocks    bool                     `mapstructure:"process-missed-blocks" json:"process-missed-blocks"`
 	DeciderURL             string                   `mapstructure:"decider-url" json:"decider-url"`
+	FeatureFlags           *FeatureFlagConfig       `mapstructure:"feature-flags" json:"feature-flags"`
+	Tracing                *TracingConfig           `mapstructure:"tracing" json:"tracing"`
+	Heartbeats             []*HeartbeatConfig       `mapstructure:"heartbeats" json:"heartbeats"`
+	LogSampling            *LogSamplingConfig       `mapstructure:"log-sampling" json:"log-sampling"`
+	Standby                *StandbyConfig           `mapstructure:"standby" json:"standby"`
+
+	DeduplicateSignatureRequests bool   `mapstructure:"deduplicate-signature-requests" json:"deduplicate-signature-requests"` //nolint:lll
+	PersistAggregations          bool   `mapstructure:"persist-aggregations" json:"persist-aggregations"`
+	PersistDeadLetters           bool   `mapstructure:"persist-dead-letters" json:"persist-dead-letters"`
+	EnableStateImport            bool   `mapstructure:"enable-state-import" json:"enable-state-import"`
+	AggregationRetentionSeconds  uint64 `mapstructure:"aggregation-retention-seconds" json:"aggregation-retention-seconds"` //nolint:lll
+	PostgresMaxOpenConnections   int    `mapstructure:"postgres-max-open-connections" json:"postgres-max-open-connections"` //nolint:lll
+	SignatureCacheSize           int    `mapstructure:"signature-cache-size" json:"signature-cache-size"`
+	SignatureCacheTTLSeconds     uint64 `mapstructure:"signature-cache-ttl-seconds" json:"signature-cache-ttl-seconds"`
+	// If non-zero, the IDs of delivered messages are remembered for this long, or until the source block is
+	// checkpointed, and are not relayed again if observed in that time.
+	DedupWindowSeconds uint64 `mapstructure:"dedup-window-seconds" json:"dedup-window-seconds"`
+	// If either is non-zero, writes to JSON file storage are buffered, and written to disk on this interval
+	// or once this many writes are buffered. Buffered writes are also written on shutdown.
+	StorageFlushIntervalMS  uint64 `mapstructure:"storage-flush-interval-ms" json:"storage-flush-interval-ms"`
+	StorageMaxPendingWrites uint64 `mapstructure:"storage-max-pending-writes" json:"storage-max-pending-writes"`
+	// If non-zero, the committed heights are written to the database, and buffered writes are written to the
+	// backing store, on this interval regardless of block processing and of the storage batching options.
+	CheckpointFlushIntervalSeconds uint64 `mapstructure:"checkpoint-flush-interval-seconds" json:"checkpoint-flush-interval-seconds"` //nolint:lll
+	// On SIGTERM or SIGINT, how long to wait for in-flight messages to be delivered and checkpointed
+	// before canceling them and exiting.
+	ShutdownTimeoutSeconds uint64 `mapstructure:"shutdown-timeout-seconds" json:"shutdown-timeout-seconds"`
+	// If non-zero, aggregating the signatures of a message via AppRequest fails after this long, reporting the
+	// validators that did not respond and the missing stake weight. Zero if only the number of attempts is bounded.
+	SignatureAggregationTimeoutSeconds uint64 `mapstructure:"signature-aggregation-timeout-seconds" json:"signature-aggregation-timeout-seconds"` //nolint:lll
+
+	// If set, messages are processed up to the point of delivery, and the delivery transactions are logged
+	// rather than sent. Processed blocks are not checkpointed.
+	DryRun bool `mapstructure:"dry-run" json:"dry-run"`
+	// If set, the Warp messages in this file are relayed, and the relayer exits. Source blockchains are
+	// not subscribed to, and no blocks are processed.
+	RelayFile string `mapstructure:"relay-file" json:"relay-file"`
+	// How messages are handled on routes paused via the API. One of "buffer" or "skip".
+	PausedRouteMode string `mapstructure:"paused-route-mode" json:"paused-route-mode"`
 
 	// convenience field to fetch a blockchain's subnet ID
 	blockchainIDToSubnetID map[ids.ID]ids.ID
@@ -80,6 +162,12 @@ func (c *Config) Validate() error {
 	if len(c.DestinationBlockchains) == 0 {
 		return errors.New("relayer not configured to relay to any subnets. A list of destination subnets must be provided in the configuration file") //nolint:lll
 	}
+	if _, err := c.GetLogFormat(); err != nil {
+		return err
+	}
+	if _, err := c.GetPausedRouteMode(); err != nil {
+		return err
+	}
 	if err := c.PChainAPI.Validate(); err != nil {
 		return err
 	}
@@ -89,43 +177,108 @@ func (c *Config) Validate() error {
 	if c.DBWriteIntervalSeconds == 0 || c.DBWriteIntervalSeconds > 600 {
 		return errors.New("db-write-interval-seconds must be between 1 and 600")
 	}
+	if c.RedisURL != "" && c.PostgresURL != "" {
+		return errors.New("only one of redis-url or postgres-url may be provided")
+	}
+	if err := validateStorageType(c.StorageType); err != nil {
+		return err
+	}
+	if c.PostgresURL != "" && c.PostgresMaxOpenConnections <= 0 {
+		return errors.New("postgres-max-open-connections must be positive")
+	}
+	if c.SignatureCacheSize < 0 {
+		return errors.New("signature-cache-size must not be negative")
+	}
+	if c.RecordsStorage != nil {
+		if err := c.RecordsStorage.Validate(); err != nil {
+			return fmt.Errorf("invalid records-storage: %w", err)
+		}
+		if c.RecordsStorage.PostgresURL != "" && c.PostgresMaxOpenConnections <= 0 {
+			return errors.New("postgres-max-open-connections must be positive")
+		}
+		// Separate JSON file stores in the same directory would overwrite each other's files
+		if c.RedisURL == "" && c.PostgresURL == "" && c.RecordsStorage.StorageLocation != "" &&
+			filepath.Clean(c.RecordsStorage.StorageLocation) == filepath.Clean(c.StorageLocation) {
+			return errors.New("records-storage storage-location must differ from storage-location")
+		}
+	}
 
 	blockchainIDToSubnetID := make(map[ids.ID]ids.ID)
 
 	// Validate the destination chains
 	destinationChains := set.NewSet[string](len(c.DestinationBlockchains))
-	for _, s := range c.DestinationBlockchains {
+	for i, s := range c.DestinationBlockchains {
 		if err := s.Validate(); err != nil {
-			return err
+			return fmt.Errorf("invalid destination-blockchains[%d] (blockchain-id '%s'): %w", i, s.BlockchainID, err)
 		}
 		if destinationChains.Contains(s.BlockchainID) {
-			return errors.New("configured destination subnets must have unique chain IDs")
+			return fmt.Errorf(
+				"configured destination subnets must have unique chain IDs. duplicate blockchain-id '%s'",
+				s.BlockchainID,
+			)
 		}
 		destinationChains.Add(s.BlockchainID)
 		blockchainIDToSubnetID[s.blockchainID] = s.subnetID
+		// Nonces are only shared with the other relayers via a shared database
+		if s.ShareNonces && c.RedisURL == "" && c.PostgresURL == "" {
+			return fmt.Errorf(
+				"invalid destination-blockchains[%d] (blockchain-id '%s'): %s",
+				i,
+				s.BlockchainID,
+				"share-nonces requires redis-url or postgres-url to be provided",
+			)
+		}
 	}
 
 	// Validate the source chains and store the source subnet and chain IDs for future use
 	sourceBlockchains := set.NewSet[string](len(c.SourceBlockchains))
-	for _, s := range c.SourceBlockchains {
+	for i, s := range c.SourceBlockchains {
 		// Validate configuration
 		if err := s.Validate(&destinationChains); err != nil {
-			return err
+			return fmt.Errorf("invalid source-blockchains[%d] (blockchain-id '%s'): %w", i, s.BlockchainID, err)
 		}
 		// Verify uniqueness
 		if sourceBlockchains.Contains(s.BlockchainID) {
-			return errors.New("configured source subnets must have unique chain IDs")
+			return fmt.Errorf(
+				"configured source subnets must have unique chain IDs. duplicate blockchain-id '%s'",
+				s.BlockchainID,
+			)
 		}
 		sourceBlockchains.Add(s.BlockchainID)
 		blockchainIDToSubnetID[s.blockchainID] = s.subnetID
 	}
 	c.blockchainIDToSubnetID = blockchainIDToSubnetID
 
+	for i, h := range c.Heartbeats {
+		if err := h.Validate(c.SourceBlockchains); err != nil {
+			return fmt.Errorf("invalid heartbeats[%d]: %w", i, err)
+		}
+	}
+
 	if len(c.DeciderURL) != 0 {
 		if _, err := url.ParseRequestURI(c.DeciderURL); err != nil {
 			return fmt.Errorf("Invalid decider URL: %w", err)
 		}
 	}
+	if c.FeatureFlags != nil {
+		if err := c.FeatureFlags.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.Tracing != nil {
+		if err := c.Tracing.Validate(); err != nil {
+			return err
+		}
+	}
+	if c.Standby != nil {
+		// The lease is only visible to the other relayers via a shared database
+		if c.RedisURL == "" && c.PostgresURL == "" {
+			return errors.New("standby requires redis-url or postgres-url to be provided")
+		}
+		if err := c.Standby.Validate(); err != nil {
+			return fmt.Errorf("invalid standby: %w", err)
+		}
+	}
 
 	return nil
 }
@@ -226,6 +379,58 @@ func (c *Config) GetOverwrittenOptions() []string {
 // Top-level config getters
 //
 
+// GetLogFormat returns the format of the relayer's log output. JSON is used if no format is configured.
+func (c *Config) GetLogFormat() (logging.Format, error) {
+	switch c.LogFormat {
+	case LogFormatJSON, "":
+		return logging.JSON, nil
+	case LogFormatConsole:
+		return logging.Plain, nil
+	default:
+		return logging.JSON, fmt.Errorf(
+			"invalid log-format '%s'. Must be one of %s or %s",
+			c.LogFormat,
+			LogFormatJSON,
+			LogFormatConsole,
+		)
+	}
+}
+
+// GetStorageFlushInterval returns the interval on which buffered writes to JSON file storage are written to disk,
+// or zero if writes are not flushed periodically.
+func (c *Config) GetStorageFlushInterval() time.Duration {
+	return time.Duration(c.StorageFlushIntervalMS) * time.Millisecond
+}
+
+// GetCheckpointFlushInterval returns the interval on which committed heights are forced to the database's
+// backing store, or zero if they are not flushed periodically.
+func (c *Config) GetCheckpointFlushInterval() time.Duration {
+	return time.Duration(c.CheckpointFlushIntervalSeconds) * time.Second
+}
+
+// GetSignatureAggregationTimeout returns the time allowed to aggregate the signatures of a message, or 0 if unbounded.
+func (c *Config) GetSignatureAggregationTimeout() time.Duration {
+	return time.Duration(c.SignatureAggregationTimeoutSeconds) * time.Second
+}
+
+// GetPausedRouteMode returns how messages are handled on routes paused via the API. Messages are buffered if no
+// mode is configured.
+func (c *Config) GetPausedRouteMode() (string, error) {
+	switch c.PausedRouteMode {
+	case PausedRouteModeBuffer, "":
+		return PausedRouteModeBuffer, nil
+	case PausedRouteModeSkip:
+		return PausedRouteModeSkip, nil
+	default:
+		return PausedRouteModeBuffer, fmt.Errorf(
+			"invalid paused-route-mode '%s'. Must be one of %s or %s",
+			c.PausedRouteMode,
+			PausedRouteModeBuffer,
+			PausedRouteModeSkip,
+		)
+	}
+}
+
 func (c *Config) GetWarpQuorum(blockchainID ids.ID) (WarpQuorum, error) {
 	for _, s := range c.DestinationBlockchains {
 		if blockchainID.String() == s.BlockchainID {
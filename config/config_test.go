@@ -4,13 +4,22 @@
 package config
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/awm-relayer/utils"
 	mock_ethclient "github.com/ava-labs/awm-relayer/vms/evm/mocks"
@@ -18,6 +27,7 @@ import (
 	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zapcore"
 )
 
 var (
@@ -219,6 +229,17 @@ func TestEitherKMSOrAccountPrivateKey(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "kms and additional private keys supplied",
+			dstCfg: func() DestinationBlockchain {
+				cfg := dstCfg
+				cfg.KMSKeyID = kmsKey1
+				cfg.KMSAWSRegion = awsRegion
+				cfg.AccountPrivateKeys = []string{"0x56289e99c94b6912bfc12adc093c9b51124f0dc54ac7a766b2bc5ccf558d8027"}
+				return cfg
+			},
+			valid: false,
+		},
 		{
 			name: "missing aws region",
 			dstCfg: func() DestinationBlockchain {
@@ -431,6 +452,211 @@ func TestValidateSourceBlockchain(t *testing.T) {
 			expectError:                   true,
 			expectedSupportedDestinations: []string{},
 		},
+		{
+			name: "valid historical block range",
+			sourceSubnet: func() SourceBlockchain {
+				cfg := validSourceCfg
+				cfg.ProcessHistoricalBlocksFromHeight = 100
+				cfg.ProcessHistoricalBlocksToHeight = 100
+				return cfg
+			},
+			destinationBlockchainIDs:      []string{testBlockchainID},
+			expectError:                   false,
+			expectedSupportedDestinations: []string{testBlockchainID},
+		},
+		{
+			name: "polling without ws endpoint",
+			sourceSubnet: func() SourceBlockchain {
+				cfg := validSourceCfg
+				cfg.WSEndpoint = APIConfig{}
+				cfg.SubscriptionMode = SubscriptionModePolling
+				return cfg
+			},
+			destinationBlockchainIDs:      []string{testBlockchainID},
+			expectError:                   false,
+			expectedSupportedDestinations: []string{testBlockchainID},
+		},
+		{
+			name: "websocket without ws endpoint",
+			sourceSubnet: func() SourceBlockchain {
+				cfg := validSourceCfg
+				cfg.WSEndpoint = APIConfig{}
+				cfg.SubscriptionMode = SubscriptionModeWebSocket
+				return cfg
+			},
+			destinationBlockchainIDs:      []string{testBlockchainID},
+			expectError:                   true,
+			expectedSupportedDestinations: []string{},
+		},
+		{
+			name: "invalid subscription mode",
+			sourceSubnet: func() SourceBlockchain {
+				cfg := validSourceCfg
+				cfg.SubscriptionMode = "push"
+				return cfg
+			},
+			destinationBlockchainIDs:      []string{testBlockchainID},
+			expectError:                   true,
+			expectedSupportedDestinations: []string{},
+		},
+		{
+			name: "historical block range without from height",
+			sourceSubnet: func() SourceBlockchain {
+				cfg := validSourceCfg
+				cfg.ProcessHistoricalBlocksToHeight = 100
+				return cfg
+			},
+			destinationBlockchainIDs:      []string{testBlockchainID},
+			expectError:                   true,
+			expectedSupportedDestinations: []string{},
+		},
+		{
+			name: "historical block range ends before it starts",
+			sourceSubnet: func() SourceBlockchain {
+				cfg := validSourceCfg
+				cfg.ProcessHistoricalBlocksFromHeight = 100
+				cfg.ProcessHistoricalBlocksToHeight = 99
+				return cfg
+			},
+			destinationBlockchainIDs:      []string{testBlockchainID},
+			expectError:                   true,
+			expectedSupportedDestinations: []string{},
+		},
+		{
+			name: "start block height",
+			sourceSubnet: func() SourceBlockchain {
+				cfg := validSourceCfg
+				cfg.StartBlockHeight = 100
+				return cfg
+			},
+			destinationBlockchainIDs:      []string{testBlockchainID},
+			expectError:                   false,
+			expectedSupportedDestinations: []string{testBlockchainID},
+		},
+		{
+			name: "start block height with historical from height",
+			sourceSubnet: func() SourceBlockchain {
+				cfg := validSourceCfg
+				cfg.StartBlockHeight = 100
+				cfg.ProcessHistoricalBlocksFromHeight = 100
+				return cfg
+			},
+			destinationBlockchainIDs:      []string{testBlockchainID},
+			expectError:                   true,
+			expectedSupportedDestinations: []string{},
+		},
+		{
+			name: "reconnect initial backoff exceeds max backoff",
+			sourceSubnet: func() SourceBlockchain {
+				cfg := validSourceCfg
+				cfg.ReconnectInitialBackoffMilliseconds = 2000
+				cfg.ReconnectMaxBackoffSeconds = 1
+				return cfg
+			},
+			destinationBlockchainIDs:      []string{testBlockchainID},
+			expectError:                   true,
+			expectedSupportedDestinations: []string{},
+		},
+		{
+			name: "block lag check interval without max block lag",
+			sourceSubnet: func() SourceBlockchain {
+				cfg := validSourceCfg
+				cfg.BlockLagCheckIntervalSeconds = 10
+				return cfg
+			},
+			destinationBlockchainIDs:      []string{testBlockchainID},
+			expectError:                   true,
+			expectedSupportedDestinations: []string{},
+		},
+		{
+			name: "fallback rpc endpoint",
+			sourceSubnet: func() SourceBlockchain {
+				cfg := validSourceCfg
+				cfg.FallbackRPCEndpoints = []APIConfig{{BaseURL: "http://127.0.0.1:9651/ext/bc/C/rpc"}}
+				cfg.StaleHeadTimeoutSeconds = 30
+				return cfg
+			},
+			destinationBlockchainIDs:      []string{testBlockchainID},
+			expectError:                   false,
+			expectedSupportedDestinations: []string{testBlockchainID},
+		},
+		{
+			name: "archival rpc endpoint",
+			sourceSubnet: func() SourceBlockchain {
+				cfg := validSourceCfg
+				cfg.ArchivalRPCEndpoint = APIConfig{BaseURL: "http://127.0.0.1:9653/ext/bc/C/rpc"}
+				return cfg
+			},
+			destinationBlockchainIDs:      []string{testBlockchainID},
+			expectError:                   false,
+			expectedSupportedDestinations: []string{testBlockchainID},
+		},
+		{
+			name: "signing subnet id",
+			sourceSubnet: func() SourceBlockchain {
+				cfg := validSourceCfg
+				cfg.SigningSubnetID = ids.GenerateTestID().String()
+				return cfg
+			},
+			destinationBlockchainIDs:      []string{testBlockchainID},
+			expectError:                   false,
+			expectedSupportedDestinations: []string{testBlockchainID},
+		},
+		{
+			name: "invalid signing subnet id",
+			sourceSubnet: func() SourceBlockchain {
+				cfg := validSourceCfg
+				cfg.SigningSubnetID = "not-a-subnet-id"
+				return cfg
+			},
+			destinationBlockchainIDs:      []string{testBlockchainID},
+			expectError:                   true,
+			expectedSupportedDestinations: []string{},
+		},
+		{
+			name: "invalid archival rpc endpoint",
+			sourceSubnet: func() SourceBlockchain {
+				cfg := validSourceCfg
+				cfg.ArchivalRPCEndpoint = APIConfig{BaseURL: "127.0.0.1:9653"}
+				return cfg
+			},
+			destinationBlockchainIDs:      []string{testBlockchainID},
+			expectError:                   true,
+			expectedSupportedDestinations: []string{},
+		},
+		{
+			name: "invalid fallback rpc endpoint",
+			sourceSubnet: func() SourceBlockchain {
+				cfg := validSourceCfg
+				cfg.FallbackRPCEndpoints = []APIConfig{{BaseURL: "ws://127.0.0.1:9651/ext/bc/C/ws"}}
+				return cfg
+			},
+			destinationBlockchainIDs:      []string{testBlockchainID},
+			expectError:                   true,
+			expectedSupportedDestinations: []string{},
+		},
+		{
+			name: "stale head timeout without fallback rpc endpoints",
+			sourceSubnet: func() SourceBlockchain {
+				cfg := validSourceCfg
+				cfg.StaleHeadTimeoutSeconds = 30
+				return cfg
+			},
+			destinationBlockchainIDs:      []string{testBlockchainID},
+			expectError:                   true,
+			expectedSupportedDestinations: []string{},
+		},
+		{
+			name: "p-chain source",
+			sourceSubnet: func() SourceBlockchain {
+				cfg := validSourceCfg
+				cfg.VM = P_CHAIN.String()
+				return cfg
+			},
+			destinationBlockchainIDs:      []string{testBlockchainID},
+			expectError:                   true,
+			expectedSupportedDestinations: []string{},
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -462,3 +688,680 @@ func TestValidateSourceBlockchain(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateDestinationBlockchain(t *testing.T) {
+	testCases := []struct {
+		name        string
+		dstCfg      func() DestinationBlockchain
+		expectError bool
+	}{
+		{
+			name:        "valid destination",
+			dstCfg:      func() DestinationBlockchain { return TestValidDestinationBlockchainConfig },
+			expectError: false,
+		},
+		{
+			name: "p-chain destination",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.VM = P_CHAIN.String()
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "valid shadow endpoint",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.ShadowEndpoint = APIConfig{BaseURL: "http://localhost:9650/ext/bc/C/rpc"}
+				cfg.ShadowOnly = true
+				return cfg
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid shadow endpoint",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.ShadowEndpoint = APIConfig{BaseURL: "not a url"}
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "valid smart account",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.SmartAccount = &SmartAccount{Address: testAddress}
+				return cfg
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid smart account address",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.SmartAccount = &SmartAccount{Address: "0x0000000000000000000000000000000000000000"}
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "valid broadcast endpoints",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.BroadcastEndpoints = []APIConfig{{BaseURL: "http://localhost:9650/ext/bc/C/rpc"}}
+				cfg.BroadcastToAllEndpoints = true
+				return cfg
+			},
+			expectError: false,
+		},
+		{
+			name: "broadcast without broadcast endpoints",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.BroadcastToAllEndpoints = true
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "valid fallback rpc endpoints",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.FallbackRPCEndpoints = []APIConfig{{BaseURL: "http://localhost:9652/ext/bc/C/rpc"}}
+				cfg.EndpointHealthCheckIntervalSeconds = 10
+				return cfg
+			},
+			expectError: false,
+		},
+		{
+			name: "valid low balance threshold",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.LowBalanceThresholdWei = "1000000000000000000"
+				return cfg
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid low balance threshold",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.LowBalanceThresholdWei = "1 AVAX"
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "health check interval without fallback rpc endpoints",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.EndpointHealthCheckIntervalSeconds = 10
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "valid gas limit multiplier",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.GasLimitMultiplier = 1.5
+				return cfg
+			},
+			expectError: false,
+		},
+		{
+			name: "gas limit multiplier below one",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.GasLimitMultiplier = 0.5
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "valid gas estimation",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.EstimateGas = true
+				cfg.GasEstimateBufferPercentage = 50
+				return cfg
+			},
+			expectError: false,
+		},
+		{
+			name: "gas estimate buffer without gas estimation",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.GasEstimateBufferPercentage = 50
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "valid additional private keys",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.AccountPrivateKeys = []string{"0x56289e99c94b6912bfc12adc093c9b51124f0dc54ac7a766b2bc5ccf558d8027"}
+				return cfg
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid additional private key",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.AccountPrivateKeys = []string{"0x1234"}
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "duplicate private keys",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.AccountPrivateKeys = []string{"0x" + cfg.AccountPrivateKey}
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "only additional private keys",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.AccountPrivateKeys = []string{cfg.AccountPrivateKey}
+				cfg.AccountPrivateKey = ""
+				return cfg
+			},
+			expectError: false,
+		},
+		{
+			name: "valid quorum percentage",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.QuorumPercentage = 80
+				return cfg
+			},
+			expectError: false,
+		},
+		{
+			name: "quorum percentage below minimum",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.QuorumPercentage = 20
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "quorum percentage above 100",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.QuorumPercentage = 101
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "shadow only without shadow endpoint",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.ShadowOnly = true
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "valid batching",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.BatchSize = 10
+				cfg.BatchTimeoutMilliseconds = 200
+				return cfg
+			},
+			expectError: false,
+		},
+		{
+			name: "batch timeout without batch size",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.BatchTimeoutMilliseconds = 200
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "valid inclusion deadline",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.InclusionDeadlineSeconds = 30
+				cfg.FeeBumpPercentage = 25
+				return cfg
+			},
+			expectError: false,
+		},
+		{
+			name: "fee bump percentage without inclusion deadline",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.FeeBumpPercentage = 25
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "fee bump percentage too low",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.InclusionDeadlineSeconds = 30
+				cfg.FeeBumpPercentage = 5
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "valid circuit breaker",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.CircuitBreakerFailureThreshold = 5
+				cfg.CircuitBreakerCooldownSeconds = 10
+				return cfg
+			},
+			expectError: false,
+		},
+		{
+			name: "circuit breaker cooldown without failure threshold",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.CircuitBreakerCooldownSeconds = 10
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "circuit breaker max cooldown below cooldown",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.CircuitBreakerFailureThreshold = 5
+				cfg.CircuitBreakerCooldownSeconds = 60
+				cfg.CircuitBreakerMaxCooldownSeconds = 30
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "valid max gas price",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.MaxGasPriceWei = 100_000_000_000
+				cfg.MaxPriorityFeePerGas = 2_000_000_000
+				return cfg
+			},
+			expectError: false,
+		},
+		{
+			name: "max priority fee per gas exceeds max gas price",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.MaxGasPriceWei = 1_000_000_000
+				cfg.MaxPriorityFeePerGas = 2_000_000_000
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "valid max sends per second",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.MaxSendsPerSecond = 0.5
+				return cfg
+			},
+			expectError: false,
+		},
+		{
+			name: "negative max sends per second",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.MaxSendsPerSecond = -1
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "tls insecure skip verify",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.TLS = &TLSConfig{InsecureSkipVerify: true}
+				return cfg
+			},
+			expectError: false,
+		},
+		{
+			name: "tls client cert without key",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.TLS = &TLSConfig{ClientCertPath: "client.crt"}
+				return cfg
+			},
+			expectError: true,
+		},
+		{
+			name: "tls missing ca cert",
+			dstCfg: func() DestinationBlockchain {
+				cfg := TestValidDestinationBlockchainConfig
+				cfg.TLS = &TLSConfig{CACertPath: filepath.Join(t.TempDir(), "ca.crt")}
+				return cfg
+			},
+			expectError: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dstCfg := testCase.dstCfg()
+			err := dstCfg.Validate()
+			if testCase.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTLSClientConfig(t *testing.T) {
+	// Generate a self-signed certificate, used as both the CA certificate and the client certificate
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "relayer"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	require.NoError(t, os.WriteFile(certPath, certPEM, 0o600))
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	require.NoError(t, os.WriteFile(keyPath, keyPEM, 0o600))
+
+	tlsConfig, err := (&TLSConfig{
+		CACertPath:     certPath,
+		ClientCertPath: certPath,
+		ClientKeyPath:  keyPath,
+	}).ClientConfig()
+	require.NoError(t, err)
+	require.Len(t, tlsConfig.Certificates, 1)
+	require.False(t, tlsConfig.InsecureSkipVerify)
+	cert, err := x509.ParseCertificate(certDER)
+	require.NoError(t, err)
+	_, err = cert.Verify(x509.VerifyOptions{Roots: tlsConfig.RootCAs})
+	require.NoError(t, err)
+
+	// The CA certificate file must contain a certificate
+	_, err = (&TLSConfig{CACertPath: keyPath}).ClientConfig()
+	require.Error(t, err)
+}
+
+func TestApplyQuorumPercentage(t *testing.T) {
+	chainQuorum := WarpQuorum{QuorumNumerator: 67, QuorumDenominator: 100}
+	testCases := []struct {
+		name             string
+		quorumPercentage uint64
+		expectedQuorum   WarpQuorum
+		expectError      bool
+	}{
+		{
+			name:             "unset",
+			quorumPercentage: 0,
+			expectedQuorum:   chainQuorum,
+		},
+		{
+			name:             "equal to chain quorum",
+			quorumPercentage: 67,
+			expectedQuorum:   chainQuorum,
+		},
+		{
+			name:             "above chain quorum",
+			quorumPercentage: 90,
+			expectedQuorum:   WarpQuorum{QuorumNumerator: 90, QuorumDenominator: 100},
+		},
+		{
+			name:             "below chain quorum",
+			quorumPercentage: 50,
+			expectError:      true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dstCfg := TestValidDestinationBlockchainConfig
+			dstCfg.QuorumPercentage = testCase.quorumPercentage
+			quorum, err := dstCfg.applyQuorumPercentage(chainQuorum)
+			if testCase.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testCase.expectedQuorum, quorum)
+		})
+	}
+}
+
+func TestValidateConfigErrorMessages(t *testing.T) {
+	testCases := []struct {
+		name          string
+		modify        func(cfg *Config)
+		expectedError []string
+	}{
+		{
+			name: "invalid destination private key",
+			modify: func(cfg *Config) {
+				cfg.DestinationBlockchains[0].AccountPrivateKey = "0x1234"
+			},
+			expectedError: []string{"destination-blockchains[0]", testBlockchainID, "account-private-key"},
+		},
+		{
+			name: "invalid source blockchain ID",
+			modify: func(cfg *Config) {
+				cfg.SourceBlockchains[0].BlockchainID = "not-an-id"
+			},
+			expectedError: []string{"source-blockchains[0]", "not-an-id"},
+		},
+		{
+			name: "unconfigured supported destination",
+			modify: func(cfg *Config) {
+				cfg.SourceBlockchains[0].SupportedDestinations = []*SupportedDestination{
+					{BlockchainID: "2TGBXcnwx5PqiXWiqxAKUaNSqDguXNh1mxnp82jui68hxJSZAx"},
+				}
+			},
+			expectedError: []string{"source-blockchains[0]", "2TGBXcnwx5PqiXWiqxAKUaNSqDguXNh1mxnp82jui68hxJSZAx"},
+		},
+		{
+			name: "invalid log format",
+			modify: func(cfg *Config) {
+				cfg.LogFormat = "xml"
+			},
+			expectedError: []string{"log-format", "xml"},
+		},
+		{
+			name: "invalid storage type",
+			modify: func(cfg *Config) {
+				cfg.StorageType = "leveldb"
+			},
+			expectedError: []string{"storage-type", "leveldb"},
+		},
+		{
+			name: "multiple records storage backends",
+			modify: func(cfg *Config) {
+				cfg.RecordsStorage = &StorageConfig{
+					RedisURL:    "redis://localhost:6379/0",
+					PostgresURL: "postgres://localhost:5432/relayer",
+				}
+			},
+			expectedError: []string{"records-storage", "exactly one of"},
+		},
+		{
+			name: "invalid records storage type",
+			modify: func(cfg *Config) {
+				cfg.RecordsStorage = &StorageConfig{
+					StorageLocation: "/tmp/records",
+					StorageType:     "leveldb",
+				}
+			},
+			expectedError: []string{"records-storage", "storage-type", "leveldb"},
+		},
+		{
+			name: "invalid paused route mode",
+			modify: func(cfg *Config) {
+				cfg.PausedRouteMode = "drop"
+			},
+			expectedError: []string{"paused-route-mode", "drop"},
+		},
+		{
+			name: "invalid message contract address",
+			modify: func(cfg *Config) {
+				cfg.SourceBlockchains[0].MessageContracts = map[string]MessageProtocolConfig{
+					"0x1234": {MessageFormat: TELEPORTER.String()},
+				}
+			},
+			expectedError: []string{"source-blockchains[0]", "message contract address", "0x1234"},
+		},
+		{
+			name: "invalid tracing exporter type",
+			modify: func(cfg *Config) {
+				cfg.Tracing = &TracingConfig{Endpoint: "localhost:4317", ExporterType: "udp"}
+			},
+			expectedError: []string{"exporter-type", "udp"},
+		},
+		{
+			name: "invalid tracing sample rate",
+			modify: func(cfg *Config) {
+				cfg.Tracing = &TracingConfig{Endpoint: "localhost:4317", SampleRate: 1.5}
+			},
+			expectedError: []string{"sample-rate"},
+		},
+		{
+			name: "heartbeat from unconfigured source",
+			modify: func(cfg *Config) {
+				cfg.Heartbeats = []*HeartbeatConfig{{
+					SourceBlockchainID:         testBlockchainID2,
+					DestinationBlockchainID:    testBlockchainID,
+					TeleporterMessengerAddress: testAddress,
+					AccountPrivateKey:          testPk1,
+				}}
+			},
+			expectedError: []string{"heartbeats[0]", "source blockchain"},
+		},
+		{
+			name: "heartbeat to unsupported destination",
+			modify: func(cfg *Config) {
+				cfg.Heartbeats = []*HeartbeatConfig{{
+					SourceBlockchainID:         testBlockchainID,
+					DestinationBlockchainID:    testBlockchainID2,
+					TeleporterMessengerAddress: testAddress,
+					AccountPrivateKey:          testPk1,
+				}}
+			},
+			expectedError: []string{"heartbeats[0]", "supported destination"},
+		},
+		{
+			name: "heartbeat via unconfigured messenger",
+			modify: func(cfg *Config) {
+				cfg.Heartbeats = []*HeartbeatConfig{{
+					SourceBlockchainID:         testBlockchainID,
+					DestinationBlockchainID:    testBlockchainID,
+					TeleporterMessengerAddress: "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+					AccountPrivateKey:          testPk1,
+				}}
+			},
+			expectedError: []string{"heartbeats[0]", "teleporter-messenger-address"},
+		},
+		{
+			name: "invalid heartbeat private key",
+			modify: func(cfg *Config) {
+				cfg.Heartbeats = []*HeartbeatConfig{{
+					SourceBlockchainID:         testBlockchainID,
+					DestinationBlockchainID:    testBlockchainID,
+					TeleporterMessengerAddress: testAddress,
+					AccountPrivateKey:          "0x1234",
+				}}
+			},
+			expectedError: []string{"heartbeats[0]", "account-private-key"},
+		},
+		{
+			name: "standby without shared database",
+			modify: func(cfg *Config) {
+				cfg.Standby = &StandbyConfig{InstanceID: "relayer-1"}
+			},
+			expectedError: []string{"standby", "redis-url"},
+		},
+		{
+			name: "share nonces without shared database",
+			modify: func(cfg *Config) {
+				cfg.DestinationBlockchains[0].ShareNonces = true
+			},
+			expectedError: []string{"destination-blockchains[0]", "share-nonces", "redis-url"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg := TestValidConfig
+			sourceBlockchain := *TestValidConfig.SourceBlockchains[0]
+			destinationBlockchain := *TestValidConfig.DestinationBlockchains[0]
+			cfg.SourceBlockchains = []*SourceBlockchain{&sourceBlockchain}
+			cfg.DestinationBlockchains = []*DestinationBlockchain{&destinationBlockchain}
+			testCase.modify(&cfg)
+
+			err := cfg.Validate()
+			require.Error(t, err)
+			for _, expected := range testCase.expectedError {
+				require.ErrorContains(t, err, expected)
+			}
+		})
+	}
+}
+
+func TestGetLogFormat(t *testing.T) {
+	testCases := []struct {
+		logFormat      string
+		expectedFormat logging.Format
+	}{
+		{logFormat: "", expectedFormat: logging.JSON},
+		{logFormat: LogFormatJSON, expectedFormat: logging.JSON},
+		{logFormat: LogFormatConsole, expectedFormat: logging.Plain},
+	}
+	for _, testCase := range testCases {
+		cfg := Config{LogFormat: testCase.logFormat}
+		format, err := cfg.GetLogFormat()
+		require.NoError(t, err)
+		require.Equal(t, testCase.expectedFormat, format)
+	}
+}
+
+func TestAPIConfigRedactsSecrets(t *testing.T) {
+	endpoint := APIConfig{
+		BaseURL:     "https://rpc.example.com/ext/bc/C/rpc",
+		QueryParams: map[string]string{"token": "query-secret"},
+		HTTPHeaders: map[string]string{"X-Api-Key": "header-secret", "Authorization": "Bearer header-secret"},
+	}
+	enc := zapcore.NewMapObjectEncoder()
+	require.NoError(t, endpoint.MarshalLogObject(enc))
+	require.Equal(t, map[string]interface{}{
+		"base-url": endpoint.BaseURL,
+		"query-parameters": map[string]interface{}{
+			"token": redactedValue,
+		},
+		"http-headers": map[string]interface{}{
+			"Authorization": redactedValue,
+			"X-Api-Key":     redactedValue,
+		},
+	}, enc.Fields)
+}
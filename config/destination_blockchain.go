@@ -2,14 +2,48 @@ package config
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"math/big"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/awm-relayer/utils"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+const (
+	defaultGasLimitMultiplier = 1.0
+	// Upper bound on the gas limit multiplier, to guard against misconfigured values draining the relayer account
+	maxGasLimitMultiplier = 5.0
+
+	defaultGasEstimateBufferPercentage = 20
+
+	// Set the max fee per gas to twice the estimated base fee by default
+	defaultBaseFeeFactor        = 2
+	defaultMaxPriorityFeePerGas = 2500000000 // 2.5 gwei
+
+	defaultMaxRetryBackoffSeconds = 10
+
+	defaultBatchTimeoutMilliseconds = 500
+
+	defaultDestinationEndpointHealthCheckIntervalSeconds = 30
+
+	defaultBalanceCheckIntervalSeconds = 60
+
+	defaultFeeBumpPercentage = 20
+	defaultMaxFeeBumps       = 3
+	// Nodes reject replacement transactions that do not increase the fees by at least this percentage
+	minFeeBumpPercentage = 10
+
+	defaultCircuitBreakerCooldownSeconds    = 30
+	defaultCircuitBreakerMaxCooldownSeconds = 300
+)
+
 // Destination blockchain configuration. Specifies how to connect to and issue
 // transactions on the destination blockchain.
 type DestinationBlockchain struct {
@@ -20,13 +54,148 @@ type DestinationBlockchain struct {
 	KMSKeyID          string    `mapstructure:"kms-key-id" json:"kms-key-id"`
 	KMSAWSRegion      string    `mapstructure:"kms-aws-region" json:"kms-aws-region"`
 	AccountPrivateKey string    `mapstructure:"account-private-key" json:"account-private-key"`
+	ShadowEndpoint    APIConfig `mapstructure:"shadow-endpoint" json:"shadow-endpoint"`
+	ShadowOnly        bool      `mapstructure:"shadow-only" json:"shadow-only"`
+
+	// Additional private keys used alongside account-private-key. Messages are sent from each key in turn.
+	AccountPrivateKeys []string `mapstructure:"account-private-keys" json:"account-private-keys"`
+
+	// If non-zero, transactions that have neither been included in a block nor are present in the
+	// mempool after this many seconds are re-submitted with the same nonce.
+	PendingTxTimeoutSeconds uint64 `mapstructure:"pending-tx-timeout-seconds" json:"pending-tx-timeout-seconds"`
+
+	// If non-zero, transactions that are still pending in the mempool after this many seconds are replaced
+	// with a transaction with the same nonce, and fees increased by FeeBumpPercentage percent. Each transaction
+	// is replaced at most MaxFeeBumps times.
+	InclusionDeadlineSeconds uint64 `mapstructure:"inclusion-deadline-seconds" json:"inclusion-deadline-seconds"`
+	FeeBumpPercentage        uint64 `mapstructure:"fee-bump-percentage" json:"fee-bump-percentage"`
+	MaxFeeBumps              uint64 `mapstructure:"max-fee-bumps" json:"max-fee-bumps"`
+
+	// If set, a gap between the locally tracked nonce and the pending nonce on the chain is filled with
+	// zero-value transfers to the sending account. Otherwise, the locally tracked nonce is reset to the pending nonce.
+	FillNonceGaps bool `mapstructure:"fill-nonce-gaps" json:"fill-nonce-gaps"`
+
+	// If non-zero, a source block is not checkpointed until each delivery transaction for its messages has
+	// this many blocks built on top of it. Deliveries reorged out before then are sent again.
+	DestinationConfirmations uint64 `mapstructure:"destination-confirmations" json:"destination-confirmations"`
+
+	// Additional endpoints to which signed transactions are submitted concurrently with rpc-endpoint,
+	// if broadcast-to-all-endpoints is set.
+	BroadcastEndpoints      []APIConfig `mapstructure:"broadcast-endpoints" json:"broadcast-endpoints"`
+	BroadcastToAllEndpoints bool        `mapstructure:"broadcast-to-all-endpoints" json:"broadcast-to-all-endpoints"`
+
+	SmartAccount *SmartAccount `mapstructure:"smart-account" json:"smart-account"`
+
+	// Factor applied to the gas limit of each transaction issued to this destination. Values above
+	// maxGasLimitMultiplier are clamped. Defaults to defaultGasLimitMultiplier if unset.
+	GasLimitMultiplier float64 `mapstructure:"gas-limit-multiplier" json:"gas-limit-multiplier"`
+
+	// If set, the gas limit of each transaction is estimated against the destination, and increased by
+	// GasEstimateBufferPercentage percent. The gas limit specified by the message serves as a floor.
+	EstimateGas                 bool   `mapstructure:"estimate-gas" json:"estimate-gas"`
+	GasEstimateBufferPercentage uint64 `mapstructure:"gas-estimate-buffer-percentage" json:"gas-estimate-buffer-percentage"`
+
+	// If set, each delivery is simulated via eth_call immediately before it is issued, and skipped if the
+	// destination has already received the message, for example from another relayer.
+	SimulateBeforeSend bool `mapstructure:"simulate-before-send" json:"simulate-before-send"`
+
+	// EIP-1559 fee parameters. The max fee per gas is set to base-fee-factor times the estimated base fee plus
+	// max-priority-fee-per-gas. If max-priority-fee-per-gas is set, the suggested tip is also capped at that value.
+	BaseFeeFactor        uint64 `mapstructure:"base-fee-factor" json:"base-fee-factor"`
+	MaxPriorityFeePerGas uint64 `mapstructure:"max-priority-fee-per-gas" json:"max-priority-fee-per-gas"`
+	// If non-zero, the maximum gas price, or max fee per gas, in wei of transactions sent to the destination.
+	// Sends are deferred while the destination's gas price exceeds it.
+	MaxGasPriceWei uint64 `mapstructure:"max-gas-price-wei" json:"max-gas-price-wei"`
+
+	// Number of times a failed transaction submission is retried, and the upper bound on the delay between attempts
+	MaxSendRetries         uint64 `mapstructure:"max-send-retries" json:"max-send-retries"`
+	MaxRetryBackoffSeconds uint64 `mapstructure:"max-retry-backoff-seconds" json:"max-retry-backoff-seconds"`
+
+	// If non-zero, sends to this destination are suspended for a cooldown period after this many consecutive
+	// failures. A single trial send is then attempted, and the cooldown is doubled, up to the max cooldown,
+	// each time the trial fails.
+	CircuitBreakerFailureThreshold   uint64 `mapstructure:"circuit-breaker-failure-threshold" json:"circuit-breaker-failure-threshold"`       //nolint:lll
+	CircuitBreakerCooldownSeconds    uint64 `mapstructure:"circuit-breaker-cooldown-seconds" json:"circuit-breaker-cooldown-seconds"`         //nolint:lll
+	CircuitBreakerMaxCooldownSeconds uint64 `mapstructure:"circuit-breaker-max-cooldown-seconds" json:"circuit-breaker-max-cooldown-seconds"` //nolint:lll
+
+	// If set, the nonces of transactions are allocated via the shared database, so that multiple relayers
+	// may use the same signing keys.
+	ShareNonces bool `mapstructure:"share-nonces" json:"share-nonces"`
+
+	// If non-zero, transactions are submitted to this destination at no more than this rate on average. Sends in
+	// excess of the rate wait for the rate limiter rather than failing.
+	MaxSendsPerSecond float64 `mapstructure:"max-sends-per-second" json:"max-sends-per-second"`
+
+	// If set, overrides the quorum fetched from the destination chain's Warp config. Must be at least the
+	// destination chain's quorum, since a signature with less stake would fail verification.
+	QuorumPercentage uint64 `mapstructure:"quorum-percentage" json:"quorum-percentage"`
+
+	// Maximum number of messages that may be in the process of being delivered to this destination at once,
+	// across all source blockchains. Zero if unlimited.
+	MaxConcurrentSends uint64 `mapstructure:"max-concurrent-sends" json:"max-concurrent-sends"`
+	// If set, messages waiting for one of the max-concurrent-sends slots are sent in the order they arrived,
+	// rather than in order of the priority assigned by their message protocol.
+	DisableSendPrioritization bool `mapstructure:"disable-send-prioritization" json:"disable-send-prioritization"`
+
+	// Maximum number of messages from processed source blocks that may be pending delivery to this destination,
+	// across all source blockchains. Once reached, new blocks are not processed from the sources that deliver to
+	// this destination until pending messages are delivered. Zero if unlimited.
+	MaxPendingMessages uint64 `mapstructure:"max-pending-messages" json:"max-pending-messages"`
+
+	// If greater than 1, up to this many messages to the same receiver are delivered in a single transaction.
+	// A partial batch is sent once its first message has waited batch-timeout-milliseconds.
+	BatchSize                uint64 `mapstructure:"batch-size" json:"batch-size"`
+	BatchTimeoutMilliseconds uint64 `mapstructure:"batch-timeout-milliseconds" json:"batch-timeout-milliseconds"`
+
+	// RPC endpoints to fail over to, in order of preference, should RPCEndpoint become unavailable.
+	// Transactions are signed once, and the same signed transaction is submitted to each endpoint in turn,
+	// so failing over does not affect the sender account nonces.
+	FallbackRPCEndpoints               []APIConfig `mapstructure:"fallback-rpc-endpoints" json:"fallback-rpc-endpoints"`                                 //nolint:lll
+	StaleHeadTimeoutSeconds            uint64      `mapstructure:"stale-head-timeout-seconds" json:"stale-head-timeout-seconds"`                         //nolint:lll
+	EndpointHealthCheckIntervalSeconds uint64      `mapstructure:"endpoint-health-check-interval-seconds" json:"endpoint-health-check-interval-seconds"` //nolint:lll
+
+	// The balance of each signing account is checked every BalanceCheckIntervalSeconds. If set, a warning is
+	// logged when an account's balance falls below LowBalanceThresholdWei, a base-10 integer string.
+	LowBalanceThresholdWei      string `mapstructure:"low-balance-threshold-wei" json:"low-balance-threshold-wei"`
+	BalanceCheckIntervalSeconds uint64 `mapstructure:"balance-check-interval-seconds" json:"balance-check-interval-seconds"`
+
+	// If provided, the connections to each of the destination blockchain's endpoints use this TLS configuration
+	TLS *TLSConfig `mapstructure:"tls" json:"tls"`
 
 	// Fetched from the chain after startup
 	warpQuorum WarpQuorum
 
 	// convenience fields to access parsed data after initialization
-	subnetID     ids.ID
-	blockchainID ids.ID
+	subnetID            ids.ID
+	blockchainID        ids.ID
+	lowBalanceThreshold *big.Int
+	tlsConfig           *tls.Config
+}
+
+// Smart account configuration. If provided, deliveries are submitted through the smart account's
+// execute entrypoint rather than directly from the signing account.
+type SmartAccount struct {
+	Address            string `mapstructure:"address" json:"address"`
+	ExecuteGasOverhead uint64 `mapstructure:"execute-gas-overhead" json:"execute-gas-overhead"`
+
+	// convenience fields to access parsed data after initialization
+	address common.Address
+}
+
+func (s *SmartAccount) Validate() error {
+	if !common.IsHexAddress(s.Address) {
+		return fmt.Errorf("invalid smart account address: %s", s.Address)
+	}
+	address := common.HexToAddress(s.Address)
+	if address == utils.ZeroAddress {
+		return fmt.Errorf("invalid smart account address: %s", s.Address)
+	}
+	s.address = address
+	return nil
+}
+
+func (s *SmartAccount) GetAddress() common.Address {
+	return s.address
 }
 
 // Validates the destination subnet configuration
@@ -34,23 +203,141 @@ func (s *DestinationBlockchain) Validate() error {
 	if err := s.RPCEndpoint.Validate(); err != nil {
 		return fmt.Errorf("invalid rpc-endpoint in destination subnet configuration: %w", err)
 	}
+	if s.TLS != nil {
+		if err := s.TLS.Validate(); err != nil {
+			return fmt.Errorf("invalid tls in destination subnet configuration: %w", err)
+		}
+		tlsConfig, err := s.TLS.ClientConfig()
+		if err != nil {
+			return fmt.Errorf("invalid tls in destination subnet configuration: %w", err)
+		}
+		s.tlsConfig = tlsConfig
+	}
+	// The shadow endpoint is optional. If provided, messages are additionally delivered to the shadow chain.
+	if s.ShadowEndpoint.BaseURL != "" {
+		if err := s.ShadowEndpoint.Validate(); err != nil {
+			return fmt.Errorf("invalid shadow-endpoint in destination subnet configuration: %w", err)
+		}
+	} else if s.ShadowOnly {
+		return errors.New("shadow-only requires a shadow-endpoint to be provided")
+	}
+	if err := validateFallbackRPCEndpoints(
+		s.RPCEndpoint,
+		s.FallbackRPCEndpoints,
+		s.StaleHeadTimeoutSeconds != 0 || s.EndpointHealthCheckIntervalSeconds != 0,
+	); err != nil {
+		return fmt.Errorf("invalid fallback-rpc-endpoints in destination subnet configuration: %w", err)
+	}
+	for i, endpoint := range s.BroadcastEndpoints {
+		if err := endpoint.Validate(); err != nil {
+			return fmt.Errorf("invalid broadcast-endpoints[%d] in destination subnet configuration: %w", i, err)
+		}
+	}
+	if s.BroadcastToAllEndpoints && len(s.BroadcastEndpoints) == 0 {
+		return errors.New("broadcast-to-all-endpoints requires at least one broadcast-endpoint to be provided")
+	}
+	if s.GasLimitMultiplier != 0 && s.GasLimitMultiplier < 1 {
+		return fmt.Errorf("invalid gas-limit-multiplier %f. must be at least 1", s.GasLimitMultiplier)
+	}
+	if s.GasEstimateBufferPercentage != 0 && !s.EstimateGas {
+		return errors.New("gas-estimate-buffer-percentage requires estimate-gas to be set")
+	}
+	if s.MaxGasPriceWei != 0 && s.MaxPriorityFeePerGas > s.MaxGasPriceWei {
+		return fmt.Errorf(
+			"invalid max-priority-fee-per-gas %d. must not exceed max-gas-price-wei %d",
+			s.MaxPriorityFeePerGas,
+			s.MaxGasPriceWei,
+		)
+	}
+	if s.MaxSendsPerSecond < 0 {
+		return fmt.Errorf("invalid max-sends-per-second %f. must not be negative", s.MaxSendsPerSecond)
+	}
+	if s.QuorumPercentage != 0 &&
+		(s.QuorumPercentage < warp.WarpQuorumNumeratorMinimum || s.QuorumPercentage > warp.WarpQuorumDenominator) {
+		return fmt.Errorf(
+			"invalid quorum-percentage %d. must be between %d and %d",
+			s.QuorumPercentage,
+			warp.WarpQuorumNumeratorMinimum,
+			warp.WarpQuorumDenominator,
+		)
+	}
+	if s.BatchTimeoutMilliseconds != 0 && !s.BatchingEnabled() {
+		return errors.New("batch-timeout-milliseconds requires batch-size to be greater than 1")
+	}
+	if (s.FeeBumpPercentage != 0 || s.MaxFeeBumps != 0) && s.InclusionDeadlineSeconds == 0 {
+		return errors.New("fee-bump-percentage and max-fee-bumps require inclusion-deadline-seconds")
+	}
+	if s.FeeBumpPercentage != 0 && s.FeeBumpPercentage < minFeeBumpPercentage {
+		return fmt.Errorf(
+			"invalid fee-bump-percentage %d. must be at least %d",
+			s.FeeBumpPercentage,
+			minFeeBumpPercentage,
+		)
+	}
+	if (s.CircuitBreakerCooldownSeconds != 0 || s.CircuitBreakerMaxCooldownSeconds != 0) &&
+		!s.CircuitBreakerEnabled() {
+		return errors.New(
+			"circuit-breaker-cooldown-seconds and circuit-breaker-max-cooldown-seconds require circuit-breaker-failure-threshold", //nolint:lll
+		)
+	}
+	if s.GetCircuitBreakerMaxCooldown() < s.GetCircuitBreakerCooldown() {
+		return fmt.Errorf(
+			"invalid circuit-breaker-max-cooldown-seconds %d. must be at least circuit-breaker-cooldown-seconds",
+			s.CircuitBreakerMaxCooldownSeconds,
+		)
+	}
+	s.lowBalanceThreshold = nil
+	if s.LowBalanceThresholdWei != "" {
+		threshold, ok := new(big.Int).SetString(s.LowBalanceThresholdWei, 10)
+		if !ok || threshold.Sign() < 0 {
+			return fmt.Errorf("invalid low-balance-threshold-wei: %s", s.LowBalanceThresholdWei)
+		}
+		s.lowBalanceThreshold = threshold
+	}
+	if s.SmartAccount != nil {
+		if err := s.SmartAccount.Validate(); err != nil {
+			return fmt.Errorf("invalid smart-account in destination subnet configuration: %w", err)
+		}
+	}
 	if s.KMSKeyID != "" {
 		if s.KMSAWSRegion == "" {
 			return errors.New("KMS key ID provided without an AWS region")
 		}
-		if s.AccountPrivateKey != "" {
+		if len(s.GetAccountPrivateKeys()) != 0 {
 			return errors.New("only one of account private key or KMS key ID can be provided")
 		}
 	} else {
-		if _, err := crypto.HexToECDSA(utils.SanitizeHexString(s.AccountPrivateKey)); err != nil {
-			return utils.ErrInvalidPrivateKeyHex
+		privateKeys := s.GetAccountPrivateKeys()
+		if len(privateKeys) == 0 {
+			return fmt.Errorf("one of account-private-key or kms-key-id must be provided: %w", utils.ErrInvalidPrivateKeyHex)
+		}
+		// Name the field each key was provided in, in the order returned by GetAccountPrivateKeys
+		var fields []string
+		if s.AccountPrivateKey != "" {
+			fields = append(fields, "account-private-key")
+		}
+		for i := range s.AccountPrivateKeys {
+			fields = append(fields, fmt.Sprintf("account-private-keys[%d]", i))
+		}
+		addresses := set.NewSet[common.Address](len(privateKeys))
+		for i, privateKey := range privateKeys {
+			pk, err := crypto.HexToECDSA(privateKey)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", fields[i], utils.ErrInvalidPrivateKeyHex)
+			}
+			// Each key maintains its own nonce sequence, so keys may not be repeated
+			address := crypto.PubkeyToAddress(pk.PublicKey)
+			if addresses.Contains(address) {
+				return fmt.Errorf("duplicate account private key for address %s", address)
+			}
+			addresses.Add(address)
 		}
 	}
 
 	// Validate the VM specific settings
 	vm := ParseVM(s.VM)
-	if vm == UNKNOWN_VM {
-		return fmt.Errorf("unsupported VM type for source subnet: %s", s.VM)
+	if vm == UNKNOWN_VM || vm == P_CHAIN {
+		return fmt.Errorf("unsupported VM type for destination subnet: %s", s.VM)
 	}
 
 	// Validate and store the subnet and blockchain IDs for future use
@@ -68,6 +355,25 @@ func (s *DestinationBlockchain) Validate() error {
 	return nil
 }
 
+// GetAccountPrivateKeys returns the sanitized private keys configured by account-private-key
+// and account-private-keys, in that order.
+func (s *DestinationBlockchain) GetAccountPrivateKeys() []string {
+	var privateKeys []string
+	if s.AccountPrivateKey != "" {
+		privateKeys = append(privateKeys, utils.SanitizeHexString(s.AccountPrivateKey))
+	}
+	for _, privateKey := range s.AccountPrivateKeys {
+		privateKeys = append(privateKeys, utils.SanitizeHexString(privateKey))
+	}
+	return privateKeys
+}
+
+// GetTLSConfig returns the TLS configuration of the connections to the destination blockchain's endpoints, or nil
+// if the default configuration is used.
+func (s *DestinationBlockchain) GetTLSConfig() *tls.Config {
+	return s.tlsConfig
+}
+
 func (s *DestinationBlockchain) GetSubnetID() ids.ID {
 	return s.subnetID
 }
@@ -76,21 +382,182 @@ func (s *DestinationBlockchain) GetBlockchainID() ids.ID {
 	return s.blockchainID
 }
 
+// Returns the factor to apply to the gas limit of each transaction issued to the destination blockchain,
+// clamped to maxGasLimitMultiplier.
+func (s *DestinationBlockchain) GetGasLimitMultiplier() float64 {
+	if s.GasLimitMultiplier == 0 {
+		return defaultGasLimitMultiplier
+	}
+	return min(s.GasLimitMultiplier, maxGasLimitMultiplier)
+}
+
+// Returns the percentage by which estimated gas limits are increased.
+func (s *DestinationBlockchain) GetGasEstimateBufferPercentage() uint64 {
+	if s.GasEstimateBufferPercentage == 0 {
+		return defaultGasEstimateBufferPercentage
+	}
+	return s.GasEstimateBufferPercentage
+}
+
+// Returns the factor by which the estimated base fee is multiplied to compute the max fee per gas.
+func (s *DestinationBlockchain) GetBaseFeeFactor() uint64 {
+	if s.BaseFeeFactor == 0 {
+		return defaultBaseFeeFactor
+	}
+	return s.BaseFeeFactor
+}
+
+// Returns the priority fee buffer added to the max fee per gas.
+func (s *DestinationBlockchain) GetMaxPriorityFeePerGas() uint64 {
+	if s.MaxPriorityFeePerGas == 0 {
+		return defaultMaxPriorityFeePerGas
+	}
+	return s.MaxPriorityFeePerGas
+}
+
+// Returns the maximum gas price in wei of transactions sent to the destination, or nil if it is not capped.
+func (s *DestinationBlockchain) GetMaxGasPrice() *big.Int {
+	if s.MaxGasPriceWei == 0 {
+		return nil
+	}
+	return new(big.Int).SetUint64(s.MaxGasPriceWei)
+}
+
+// Returns the upper bound on the delay between transaction submission attempts.
+func (s *DestinationBlockchain) GetMaxRetryBackoff() time.Duration {
+	if s.MaxRetryBackoffSeconds == 0 {
+		return defaultMaxRetryBackoffSeconds * time.Second
+	}
+	return time.Duration(s.MaxRetryBackoffSeconds) * time.Second
+}
+
+// Returns the percentage by which the fees of a transaction are increased each time it is replaced.
+func (s *DestinationBlockchain) GetFeeBumpPercentage() uint64 {
+	if s.FeeBumpPercentage == 0 {
+		return defaultFeeBumpPercentage
+	}
+	return s.FeeBumpPercentage
+}
+
+// Returns the maximum number of times a transaction is replaced with increased fees.
+func (s *DestinationBlockchain) GetMaxFeeBumps() uint64 {
+	if s.MaxFeeBumps == 0 {
+		return defaultMaxFeeBumps
+	}
+	return s.MaxFeeBumps
+}
+
+// Returns true if the rate at which transactions are submitted to the destination blockchain is limited.
+func (s *DestinationBlockchain) RateLimitEnabled() bool {
+	return s.MaxSendsPerSecond != 0
+}
+
+// Returns true if sends to the destination blockchain are suspended after consecutive failures.
+func (s *DestinationBlockchain) CircuitBreakerEnabled() bool {
+	return s.CircuitBreakerFailureThreshold != 0
+}
+
+// Returns how long sends are suspended after the circuit breaker first opens.
+func (s *DestinationBlockchain) GetCircuitBreakerCooldown() time.Duration {
+	if s.CircuitBreakerCooldownSeconds == 0 {
+		return defaultCircuitBreakerCooldownSeconds * time.Second
+	}
+	return time.Duration(s.CircuitBreakerCooldownSeconds) * time.Second
+}
+
+// Returns the upper bound on how long sends are suspended after repeated failed trial sends.
+func (s *DestinationBlockchain) GetCircuitBreakerMaxCooldown() time.Duration {
+	if s.CircuitBreakerMaxCooldownSeconds == 0 {
+		return max(defaultCircuitBreakerMaxCooldownSeconds*time.Second, s.GetCircuitBreakerCooldown())
+	}
+	return time.Duration(s.CircuitBreakerMaxCooldownSeconds) * time.Second
+}
+
+// Returns true if messages to the destination blockchain are delivered in batches.
+func (s *DestinationBlockchain) BatchingEnabled() bool {
+	return s.BatchSize > 1
+}
+
+// Returns the maximum time a message waits for its batch to fill before the batch is sent.
+func (s *DestinationBlockchain) GetBatchTimeout() time.Duration {
+	if s.BatchTimeoutMilliseconds == 0 {
+		return defaultBatchTimeoutMilliseconds * time.Millisecond
+	}
+	return time.Duration(s.BatchTimeoutMilliseconds) * time.Millisecond
+}
+
+// Returns true if the destination client fails over between multiple RPC endpoints.
+func (s *DestinationBlockchain) HasFallbackRPCEndpoints() bool {
+	return len(s.FallbackRPCEndpoints) != 0
+}
+
+// Returns the RPC endpoints of the destination blockchain in order of preference.
+func (s *DestinationBlockchain) GetRPCEndpoints() []APIConfig {
+	return append([]APIConfig{s.RPCEndpoint}, s.FallbackRPCEndpoints...)
+}
+
+// Returns the duration without a new block after which an RPC endpoint is considered stale, or 0 if
+// endpoints are not checked for staleness.
+func (s *DestinationBlockchain) GetStaleHeadTimeout() time.Duration {
+	return time.Duration(s.StaleHeadTimeoutSeconds) * time.Second
+}
+
+// Returns the interval at which the health of the RPC endpoints is checked.
+func (s *DestinationBlockchain) GetEndpointHealthCheckInterval() time.Duration {
+	if s.EndpointHealthCheckIntervalSeconds == 0 {
+		return defaultDestinationEndpointHealthCheckIntervalSeconds * time.Second
+	}
+	return time.Duration(s.EndpointHealthCheckIntervalSeconds) * time.Second
+}
+
+// Returns the balance below which a signing account is considered low on funds, or nil if unset.
+func (s *DestinationBlockchain) GetLowBalanceThreshold() *big.Int {
+	return s.lowBalanceThreshold
+}
+
+// Returns the interval at which the balance of each signing account is checked.
+func (s *DestinationBlockchain) GetBalanceCheckInterval() time.Duration {
+	if s.BalanceCheckIntervalSeconds == 0 {
+		return defaultBalanceCheckIntervalSeconds * time.Second
+	}
+	return time.Duration(s.BalanceCheckIntervalSeconds) * time.Second
+}
+
+// Returns true if a shadow endpoint is configured for the destination blockchain.
+func (s *DestinationBlockchain) HasShadowEndpoint() bool {
+	return s.ShadowEndpoint.BaseURL != ""
+}
+
 func (s *DestinationBlockchain) initializeWarpQuorum() error {
 	blockchainID, err := ids.FromString(s.BlockchainID)
 	if err != nil {
-		return fmt.Errorf("invalid blockchainID in configuration. error: %w", err)
+		return fmt.Errorf("invalid blockchain-id in configuration. error: %w", err)
 	}
 	subnetID, err := ids.FromString(s.SubnetID)
 	if err != nil {
 		return fmt.Errorf("invalid subnetID in configuration. error: %w", err)
 	}
 
+	// The Warp precompile config can only be fetched from EVM destinations. Other VMs use the default quorum,
+	// which may be overridden by quorum-percentage.
+	if ParseVM(s.VM) != EVM {
+		quorum, err := s.applyQuorumPercentage(WarpQuorum{
+			QuorumNumerator:   warp.WarpDefaultQuorumNumerator,
+			QuorumDenominator: warp.WarpQuorumDenominator,
+		})
+		if err != nil {
+			return err
+		}
+		s.warpQuorum = quorum
+		return nil
+	}
+
 	client, err := utils.NewEthClientWithConfig(
 		context.Background(),
 		s.RPCEndpoint.BaseURL,
 		s.RPCEndpoint.HTTPHeaders,
 		s.RPCEndpoint.QueryParams,
+		s.tlsConfig,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to dial destination blockchain %s: %w", blockchainID, err)
@@ -100,11 +567,35 @@ func (s *DestinationBlockchain) initializeWarpQuorum() error {
 	if err != nil {
 		return fmt.Errorf("failed to fetch warp quorum for subnet %s: %w", subnetID, err)
 	}
+	quorum, err = s.applyQuorumPercentage(quorum)
+	if err != nil {
+		return err
+	}
 
 	s.warpQuorum = quorum
 	return nil
 }
 
+// applyQuorumPercentage overrides the destination chain's quorum with the configured quorum percentage, if set.
+func (s *DestinationBlockchain) applyQuorumPercentage(quorum WarpQuorum) (WarpQuorum, error) {
+	if s.QuorumPercentage == 0 {
+		return quorum, nil
+	}
+	// Compare quorum-percentage/100 to the chain's numerator/denominator
+	if s.QuorumPercentage*quorum.QuorumDenominator < quorum.QuorumNumerator*warp.WarpQuorumDenominator {
+		return WarpQuorum{}, fmt.Errorf(
+			"quorum-percentage %d is less than the destination chain's quorum of %d/%d",
+			s.QuorumPercentage,
+			quorum.QuorumNumerator,
+			quorum.QuorumDenominator,
+		)
+	}
+	return WarpQuorum{
+		QuorumNumerator:   s.QuorumPercentage,
+		QuorumDenominator: warp.WarpQuorumDenominator,
+	}, nil
+}
+
 // Warp Quorum configuration, fetched from the chain config
 type WarpQuorum struct {
 	QuorumNumerator   uint64
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const defaultFeatureFlagCacheTTLSeconds = uint64(30)
+
+// Feature flag service configuration. If provided, the service is queried to determine whether
+// relaying is currently enabled for each source and destination blockchain pair.
+type FeatureFlagConfig struct {
+	URL             string            `mapstructure:"url" json:"url"`
+	HTTPHeaders     map[string]string `mapstructure:"http-headers" json:"http-headers"`
+	CacheTTLSeconds uint64            `mapstructure:"cache-ttl-seconds" json:"cache-ttl-seconds"`
+	FailClosed      bool              `mapstructure:"fail-closed" json:"fail-closed"`
+}
+
+func (c *FeatureFlagConfig) Validate() error {
+	if _, err := url.ParseRequestURI(c.URL); err != nil {
+		return fmt.Errorf("invalid feature flag URL: %w", err)
+	}
+	return nil
+}
+
+// GetCacheTTL returns how long feature flag lookups are cached, defaulting to 30 seconds
+func (c *FeatureFlagConfig) GetCacheTTL() time.Duration {
+	if c.CacheTTLSeconds == 0 {
+		return time.Duration(defaultFeatureFlagCacheTTLSeconds) * time.Second
+	}
+	return time.Duration(c.CacheTTLSeconds) * time.Second
+}
@@ -10,5 +10,7 @@ func BuildFlagSet() *pflag.FlagSet {
 	fs.String(ConfigFileKey, "", "Specifies the relayer config file")
 	fs.BoolP(VersionKey, "", false, "Display awm-relayer version")
 	fs.BoolP(HelpKey, "", false, "Display awm-relayer usage")
+	fs.Bool(DryRunKey, false, "Log the transactions that would be sent to destination blockchains without sending them")
+	fs.String(RelayFileKey, "", "Relay the Warp messages in the specified file, then exit")
 	return fs
 }
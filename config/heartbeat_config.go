@@ -0,0 +1,130 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/awm-relayer/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	defaultHeartbeatIntervalSeconds = 60
+	defaultHeartbeatDeadlineSeconds = 120
+)
+
+// A Teleporter message sent from a source blockchain to a destination blockchain every IntervalSeconds, to
+// monitor the route end to end. The message is sent from the account of AccountPrivateKey, which must be funded
+// on the source blockchain. If it is not delivered within DeadlineSeconds, the heartbeat is reported as failed.
+type HeartbeatConfig struct {
+	SourceBlockchainID         string `mapstructure:"source-blockchain-id" json:"source-blockchain-id"`
+	DestinationBlockchainID    string `mapstructure:"destination-blockchain-id" json:"destination-blockchain-id"`
+	TeleporterMessengerAddress string `mapstructure:"teleporter-messenger-address" json:"teleporter-messenger-address"` //nolint:lll
+	AccountPrivateKey          string `mapstructure:"account-private-key" json:"account-private-key"`
+	IntervalSeconds            uint64 `mapstructure:"interval-seconds" json:"interval-seconds"`
+	DeadlineSeconds            uint64 `mapstructure:"deadline-seconds" json:"deadline-seconds"`
+
+	// convenience fields to access parsed data after initialization
+	sourceBlockchainID         ids.ID
+	destinationBlockchainID    ids.ID
+	teleporterMessengerAddress common.Address
+}
+
+// Validates the heartbeat's options, and that it is sent over a route configured in [sourceBlockchains]
+// via one of the source's message contracts.
+func (h *HeartbeatConfig) Validate(sourceBlockchains []*SourceBlockchain) error {
+	sourceBlockchainID, err := utils.HexOrCB58ToID(h.SourceBlockchainID)
+	if err != nil {
+		return fmt.Errorf("invalid source-blockchain-id '%s': %w", h.SourceBlockchainID, err)
+	}
+	destinationBlockchainID, err := utils.HexOrCB58ToID(h.DestinationBlockchainID)
+	if err != nil {
+		return fmt.Errorf("invalid destination-blockchain-id '%s': %w", h.DestinationBlockchainID, err)
+	}
+	if !common.IsHexAddress(h.TeleporterMessengerAddress) {
+		return fmt.Errorf("invalid teleporter-messenger-address '%s'", h.TeleporterMessengerAddress)
+	}
+	teleporterMessengerAddress := common.HexToAddress(h.TeleporterMessengerAddress)
+	if _, err := crypto.HexToECDSA(h.GetAccountPrivateKey()); err != nil {
+		return fmt.Errorf("invalid account-private-key: %w", utils.ErrInvalidPrivateKeyHex)
+	}
+
+	var sourceBlockchain *SourceBlockchain
+	for _, s := range sourceBlockchains {
+		if s.GetBlockchainID() == sourceBlockchainID {
+			sourceBlockchain = s
+		}
+	}
+	if sourceBlockchain == nil {
+		return fmt.Errorf("source blockchain %s is not configured", sourceBlockchainID)
+	}
+	var supportedDestination bool
+	for _, dest := range sourceBlockchain.SupportedDestinations {
+		if dest.GetBlockchainID() == destinationBlockchainID {
+			supportedDestination = true
+		}
+	}
+	if !supportedDestination {
+		return fmt.Errorf(
+			"destination blockchain %s is not a supported destination of source blockchain %s",
+			destinationBlockchainID,
+			sourceBlockchainID,
+		)
+	}
+	var messageContract bool
+	for address := range sourceBlockchain.MessageContracts {
+		if common.HexToAddress(address) == teleporterMessengerAddress {
+			messageContract = true
+		}
+	}
+	if !messageContract {
+		return fmt.Errorf(
+			"teleporter-messenger-address %s is not a message contract of source blockchain %s",
+			teleporterMessengerAddress,
+			sourceBlockchainID,
+		)
+	}
+
+	h.sourceBlockchainID = sourceBlockchainID
+	h.destinationBlockchainID = destinationBlockchainID
+	h.teleporterMessengerAddress = teleporterMessengerAddress
+	return nil
+}
+
+func (h *HeartbeatConfig) GetSourceBlockchainID() ids.ID {
+	return h.sourceBlockchainID
+}
+
+func (h *HeartbeatConfig) GetDestinationBlockchainID() ids.ID {
+	return h.destinationBlockchainID
+}
+
+func (h *HeartbeatConfig) GetTeleporterMessengerAddress() common.Address {
+	return h.teleporterMessengerAddress
+}
+
+// GetAccountPrivateKey returns the sanitized private key of the account that sends the heartbeat
+func (h *HeartbeatConfig) GetAccountPrivateKey() string {
+	return utils.SanitizeHexString(h.AccountPrivateKey)
+}
+
+// GetInterval returns the interval at which heartbeat messages are sent
+func (h *HeartbeatConfig) GetInterval() time.Duration {
+	if h.IntervalSeconds == 0 {
+		return defaultHeartbeatIntervalSeconds * time.Second
+	}
+	return time.Duration(h.IntervalSeconds) * time.Second
+}
+
+// GetDeadline returns how long after it is sent a heartbeat message must be delivered
+func (h *HeartbeatConfig) GetDeadline() time.Duration {
+	if h.DeadlineSeconds == 0 {
+		return defaultHeartbeatDeadlineSeconds * time.Second
+	}
+	return time.Duration(h.DeadlineSeconds) * time.Second
+}
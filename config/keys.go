@@ -8,9 +8,12 @@ const (
 	ConfigFileKey = "config-file"
 	VersionKey    = "version"
 	HelpKey       = "help"
+	DryRunKey     = "dry-run"
+	RelayFileKey  = "relay-file"
 
 	// Top-level configuration keys
 	LogLevelKey               = "log-level"
+	LogFormatKey              = "log-format"
 	PChainAPIKey              = "p-chain-api"
 	InfoAPIKey                = "info-api"
 	APIPortKey                = "api-port"
@@ -19,8 +22,18 @@ const (
 	DestinationBlockchainsKey = "destination-blockchains"
 	AccountPrivateKeyKey      = "account-private-key"
 	StorageLocationKey        = "storage-location"
+	StorageTypeKey            = "storage-type"
 	RedisURLKey               = "redis-url"
+	PostgresURLKey            = "postgres-url"
 	ProcessMissedBlocksKey    = "process-missed-blocks"
 	ManualWarpMessagesKey     = "manual-warp-messages"
 	DBWriteIntervalSecondsKey = "db-write-interval-seconds"
+
+	DeduplicateSignatureRequestsKey = "deduplicate-signature-requests"
+	AggregationRetentionSecondsKey  = "aggregation-retention-seconds"
+	PostgresMaxOpenConnectionsKey   = "postgres-max-open-connections"
+	SignatureCacheSizeKey           = "signature-cache-size"
+	SignatureCacheTTLSecondsKey     = "signature-cache-ttl-seconds"
+	ShutdownTimeoutSecondsKey       = "shutdown-timeout-seconds"
+	PausedRouteModeKey              = "paused-route-mode"
 )
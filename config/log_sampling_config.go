@@ -0,0 +1,27 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// Log sampling configuration. Each non-zero rate limits the number of log entries emitted per second at that level
+// with the same message and route. Warnings and errors are never sampled.
+type LogSamplingConfig struct {
+	DebugPerSecond uint64 `mapstructure:"debug-per-second" json:"debug-per-second"`
+	InfoPerSecond  uint64 `mapstructure:"info-per-second" json:"info-per-second"`
+}
+
+// GetRates returns the maximum number of entries per second at each sampled log level
+func (c *LogSamplingConfig) GetRates() map[logging.Level]uint64 {
+	rates := make(map[logging.Level]uint64)
+	if c.DebugPerSecond != 0 {
+		rates[logging.Debug] = c.DebugPerSecond
+	}
+	if c.InfoPerSecond != 0 {
+		rates[logging.Info] = c.InfoPerSecond
+	}
+	return rates
+}
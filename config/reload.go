@@ -0,0 +1,105 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"reflect"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// ReloadChanges describes the differences between the running configuration and a reloaded configuration
+// that may be applied without restarting the relayer.
+type ReloadChanges struct {
+	// Source blockchains that were added, or whose configuration changed. Changed source blockchains
+	// should be restarted with the reloaded configuration.
+	AddedSources []*SourceBlockchain
+	// Source blockchains that are no longer configured
+	RemovedSources []ids.ID
+	// Destination blockchains whose signing keys changed
+	UpdatedSigners []*DestinationBlockchain
+	// Set if any other option changed. Such changes are not applied until the relayer is restarted.
+	RestartRequired bool
+}
+
+// GetReloadChanges returns the changes from [running] to [reloaded]. Both configurations must be validated.
+func GetReloadChanges(running *Config, reloaded *Config) ReloadChanges {
+	var changes ReloadChanges
+
+	runningSources := make(map[ids.ID]*SourceBlockchain, len(running.SourceBlockchains))
+	for _, s := range running.SourceBlockchains {
+		runningSources[s.GetBlockchainID()] = s
+	}
+	reloadedSources := make(map[ids.ID]*SourceBlockchain, len(reloaded.SourceBlockchains))
+	for _, s := range reloaded.SourceBlockchains {
+		reloadedSources[s.GetBlockchainID()] = s
+		if runningSource, ok := runningSources[s.GetBlockchainID()]; !ok || !reflect.DeepEqual(withoutTLSConfig(runningSource), withoutTLSConfig(s)) {
+			changes.AddedSources = append(changes.AddedSources, s)
+		}
+	}
+	for _, s := range running.SourceBlockchains {
+		if _, ok := reloadedSources[s.GetBlockchainID()]; !ok {
+			changes.RemovedSources = append(changes.RemovedSources, s.GetBlockchainID())
+		}
+	}
+
+	runningDestinations := make(map[ids.ID]*DestinationBlockchain, len(running.DestinationBlockchains))
+	for _, d := range running.DestinationBlockchains {
+		runningDestinations[d.GetBlockchainID()] = d
+	}
+	if len(running.DestinationBlockchains) != len(reloaded.DestinationBlockchains) {
+		changes.RestartRequired = true
+	}
+	for _, d := range reloaded.DestinationBlockchains {
+		runningDestination, ok := runningDestinations[d.GetBlockchainID()]
+		if !ok {
+			changes.RestartRequired = true
+			continue
+		}
+		if !reflect.DeepEqual(withoutSigners(runningDestination), withoutSigners(d)) {
+			changes.RestartRequired = true
+		}
+		if !reflect.DeepEqual(runningDestination.GetAccountPrivateKeys(), d.GetAccountPrivateKeys()) ||
+			runningDestination.KMSKeyID != d.KMSKeyID ||
+			runningDestination.KMSAWSRegion != d.KMSAWSRegion {
+			changes.UpdatedSigners = append(changes.UpdatedSigners, d)
+		}
+	}
+
+	if !reflect.DeepEqual(withoutBlockchains(running), withoutBlockchains(reloaded)) {
+		changes.RestartRequired = true
+	}
+	return changes
+}
+
+// withoutSigners returns a copy of [d] without its signing keys, or values fetched from the chain
+func withoutSigners(d *DestinationBlockchain) DestinationBlockchain {
+	c := *d
+	c.AccountPrivateKey = ""
+	c.AccountPrivateKeys = nil
+	c.KMSKeyID = ""
+	c.KMSAWSRegion = ""
+	c.warpQuorum = WarpQuorum{}
+	// Loaded from the TLS options, which are compared instead
+	c.tlsConfig = nil
+	return c
+}
+
+// withoutTLSConfig returns a copy of [s] without the TLS configuration loaded from its TLS options, which are
+// compared instead
+func withoutTLSConfig(s *SourceBlockchain) SourceBlockchain {
+	c := *s
+	c.tlsConfig = nil
+	return c
+}
+
+// withoutBlockchains returns a copy of [c] with only its global options
+func withoutBlockchains(c *Config) Config {
+	g := *c
+	g.SourceBlockchains = nil
+	g.DestinationBlockchains = nil
+	g.blockchainIDToSubnetID = nil
+	g.overwrittenOptions = nil
+	return g
+}
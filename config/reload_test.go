@@ -0,0 +1,107 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetReloadChanges(t *testing.T) {
+	// Returns a validated copy of the test config with a second source blockchain, modified by [f]
+	newConfig := func(f func(c *Config)) *Config {
+		c := TestValidConfig
+		source1 := *TestValidConfig.SourceBlockchains[0]
+		source2 := *TestValidConfig.SourceBlockchains[0]
+		source2.BlockchainID = testBlockchainID2
+		destination := *TestValidConfig.DestinationBlockchains[0]
+		c.SourceBlockchains = []*SourceBlockchain{&source1, &source2}
+		c.DestinationBlockchains = []*DestinationBlockchain{&destination}
+		f(&c)
+		require.NoError(t, c.Validate())
+		return &c
+	}
+	blockchainID2, err := ids.FromString(testBlockchainID2)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name                    string
+		running                 *Config
+		reloaded                *Config
+		expectedAddedSources    []ids.ID
+		expectedRemovedSources  []ids.ID
+		expectedUpdatedSigners  int
+		expectedRestartRequired bool
+	}{
+		{
+			name:     "unchanged",
+			running:  newConfig(func(*Config) {}),
+			reloaded: newConfig(func(*Config) {}),
+		},
+		{
+			name: "source added",
+			running: newConfig(func(c *Config) {
+				c.SourceBlockchains = c.SourceBlockchains[:1]
+			}),
+			reloaded:             newConfig(func(*Config) {}),
+			expectedAddedSources: []ids.ID{blockchainID2},
+		},
+		{
+			name:    "source removed",
+			running: newConfig(func(*Config) {}),
+			reloaded: newConfig(func(c *Config) {
+				c.SourceBlockchains = c.SourceBlockchains[:1]
+			}),
+			expectedRemovedSources: []ids.ID{blockchainID2},
+		},
+		{
+			name:    "source changed",
+			running: newConfig(func(*Config) {}),
+			reloaded: newConfig(func(c *Config) {
+				c.SourceBlockchains[1].MaxConcurrentAggregations = 2
+			}),
+			expectedAddedSources: []ids.ID{blockchainID2},
+		},
+		{
+			name:    "signing key changed",
+			running: newConfig(func(*Config) {}),
+			reloaded: newConfig(func(c *Config) {
+				c.DestinationBlockchains[0].AccountPrivateKeys = []string{testPk1}
+			}),
+			expectedUpdatedSigners: 1,
+		},
+		{
+			name:    "destination option changed",
+			running: newConfig(func(*Config) {}),
+			reloaded: newConfig(func(c *Config) {
+				c.DestinationBlockchains[0].MaxSendRetries = 3
+			}),
+			expectedRestartRequired: true,
+		},
+		{
+			name:    "global option changed",
+			running: newConfig(func(*Config) {}),
+			reloaded: newConfig(func(c *Config) {
+				c.LogLevel = "debug"
+			}),
+			expectedRestartRequired: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			changes := GetReloadChanges(testCase.running, testCase.reloaded)
+
+			var addedSources []ids.ID
+			for _, s := range changes.AddedSources {
+				addedSources = append(addedSources, s.GetBlockchainID())
+			}
+			require.Equal(t, testCase.expectedAddedSources, addedSources)
+			require.Equal(t, testCase.expectedRemovedSources, changes.RemovedSources)
+			require.Len(t, changes.UpdatedSigners, testCase.expectedUpdatedSigners)
+			require.Equal(t, testCase.expectedRestartRequired, changes.RestartRequired)
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/set"
@@ -9,6 +12,33 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
+const (
+	defaultReconnectInitialBackoffMilliseconds = 500
+	defaultReconnectMaxBackoffSeconds          = 30
+
+	defaultBlockLagCheckIntervalSeconds = 60
+
+	defaultEndpointHealthCheckIntervalSeconds = 30
+
+	defaultMaxMessageBytes = 1 << 20 // 1 MiB
+
+	defaultPollingIntervalMilliseconds = 1000
+	defaultPollingBatchSize            = 200
+)
+
+// Modes in which new blocks are received from a source blockchain
+const (
+	// Subscribe to new blocks via ws-endpoint, falling back to polling rpc-endpoint if the subscription
+	// cannot be established
+	SubscriptionModeAuto = "auto"
+	// Subscribe to new blocks via ws-endpoint
+	SubscriptionModeWebSocket = "websocket"
+	// Poll rpc-endpoint for new blocks
+	SubscriptionModePolling = "polling"
+
+	defaultSubscriptionMode = SubscriptionModeAuto
+)
+
 // Source blockchain configuration.
 // Specifies how to connect to and listen for messages on the source blockchain.
 // Specifies the message protocols supported by the relayer for this blockchain.
@@ -25,12 +55,77 @@ type SourceBlockchain struct {
 	ProcessHistoricalBlocksFromHeight uint64                           `mapstructure:"process-historical-blocks-from-height" json:"process-historical-blocks-from-height"` //nolint:lll
 	AllowedOriginSenderAddresses      []string                         `mapstructure:"allowed-origin-sender-addresses" json:"allowed-origin-sender-addresses"`             //nolint:lll
 	WarpAPIEndpoint                   APIConfig                        `mapstructure:"warp-api-endpoint" json:"warp-api-endpoint"`                                         //nolint:lll
+	MaxConcurrentAggregations         uint64                           `mapstructure:"max-concurrent-aggregations" json:"max-concurrent-aggregations"`                     //nolint:lll
+	MinSourceStake                    uint64                           `mapstructure:"min-source-stake" json:"min-source-stake"`                                           //nolint:lll
+	ProcessHistoricalBlocksToHeight   uint64                           `mapstructure:"process-historical-blocks-to-height" json:"process-historical-blocks-to-height"`     //nolint:lll
+
+	// Reconnection settings for the WebSocket subscription. MaxReconnectAttempts of 0 retries indefinitely.
+	ReconnectInitialBackoffMilliseconds uint64 `mapstructure:"reconnect-initial-backoff-milliseconds" json:"reconnect-initial-backoff-milliseconds"` //nolint:lll
+	ReconnectMaxBackoffSeconds          uint64 `mapstructure:"reconnect-max-backoff-seconds" json:"reconnect-max-backoff-seconds"`                   //nolint:lll
+	MaxReconnectAttempts                uint64 `mapstructure:"max-reconnect-attempts" json:"max-reconnect-attempts"`                                 //nolint:lll
+
+	// If non-zero, a warning is logged when the latest checkpointed block is more than this many blocks
+	// behind the chain head, as checked every BlockLagCheckIntervalSeconds.
+	MaxBlockLag                  uint64 `mapstructure:"max-block-lag" json:"max-block-lag"`
+	BlockLagCheckIntervalSeconds uint64 `mapstructure:"block-lag-check-interval-seconds" json:"block-lag-check-interval-seconds"` //nolint:lll
+
+	// RPC endpoints to fail over to, in order of preference, should RPCEndpoint become unavailable.
+	// An endpoint is unavailable if it fails to respond, or if its head has not advanced in
+	// StaleHeadTimeoutSeconds. The health of each endpoint is checked every EndpointHealthCheckIntervalSeconds.
+	FallbackRPCEndpoints               []APIConfig `mapstructure:"fallback-rpc-endpoints" json:"fallback-rpc-endpoints"`                                 //nolint:lll
+	StaleHeadTimeoutSeconds            uint64      `mapstructure:"stale-head-timeout-seconds" json:"stale-head-timeout-seconds"`                         //nolint:lll
+	EndpointHealthCheckIntervalSeconds uint64      `mapstructure:"endpoint-health-check-interval-seconds" json:"endpoint-health-check-interval-seconds"` //nolint:lll
+
+	// If non-zero, messages in blocks whose timestamp is more than this many seconds old when the block is processed
+	// are not relayed, such as when catching up after an outage. Zero if messages never expire.
+	MaxMessageAgeSeconds uint64 `mapstructure:"max-message-age-seconds" json:"max-message-age-seconds"`
+
+	// Messages whose payload is larger than this many bytes are not relayed. Defaults to defaultMaxMessageBytes.
+	MaxMessageBytes uint64 `mapstructure:"max-message-bytes" json:"max-message-bytes"`
+
+	// If non-zero, the height from which blocks are processed if the database contains no latest processed block.
+	// Unlike ProcessHistoricalBlocksFromHeight, it is ignored once a block has been processed.
+	StartBlockHeight uint64 `mapstructure:"start-block-height" json:"start-block-height"`
+
+	// How new blocks are received. In polling mode, rpc-endpoint is polled every PollingIntervalMilliseconds, and
+	// up to PollingBatchSize new blocks are processed per poll.
+	SubscriptionMode            string `mapstructure:"subscription-mode" json:"subscription-mode"`
+	PollingIntervalMilliseconds uint64 `mapstructure:"polling-interval-milliseconds" json:"polling-interval-milliseconds"` //nolint:lll
+	PollingBatchSize            uint64 `mapstructure:"polling-batch-size" json:"polling-batch-size"`
+
+	// If provided, the logs of blocks that were already produced when the relayer started, such as when catching up
+	// on missed blocks or processing a historical block range, are fetched from this endpoint rather than
+	// RPCEndpoint, so that RPCEndpoint need not be an archival node.
+	ArchivalRPCEndpoint APIConfig `mapstructure:"archival-rpc-endpoint" json:"archival-rpc-endpoint"`
+
+	// If set, messages from the same origin sender are delivered to each destination one at a time, in the order
+	// in which their blocks are received. Messages from different senders are still delivered concurrently.
+	PreserveOrderPerSender bool `mapstructure:"preserve-order-per-sender" json:"preserve-order-per-sender"`
+
+	// If non-zero, the hashes of this many of the most recently received blocks are tracked, so that a reorg of
+	// the source blockchain to that depth is detected, and the blocks that replaced the orphaned blocks are
+	// reprocessed.
+	ReorgDepth uint64 `mapstructure:"reorg-depth" json:"reorg-depth"`
+
+	// If either is non-zero, the messages in a block are only delivered once the block is buried by this many
+	// blocks, and its timestamp is this many seconds old, for sources with probabilistic finality.
+	FinalityDelayBlocks  uint64 `mapstructure:"finality-delay-blocks" json:"finality-delay-blocks"`
+	FinalityDelaySeconds uint64 `mapstructure:"finality-delay-seconds" json:"finality-delay-seconds"`
+
+	// If provided, signatures of the source blockchain's messages are collected from the validators of this subnet,
+	// rather than those of SubnetID, or of the destination subnet if the source is in the primary network.
+	SigningSubnetID string `mapstructure:"signing-subnet-id" json:"signing-subnet-id"`
+
+	// If provided, the connections to each of the source blockchain's endpoints use this TLS configuration
+	TLS *TLSConfig `mapstructure:"tls" json:"tls"`
 
 	// convenience fields to access parsed data after initialization
 	subnetID                     ids.ID
+	signingSubnetID              ids.ID
 	blockchainID                 ids.ID
 	allowedOriginSenderAddresses []common.Address
 	useAppRequestNetwork         bool
+	tlsConfig                    *tls.Config
 }
 
 // Validates the source subnet configuration, including verifying that the supported destinations are present in
@@ -40,8 +135,32 @@ func (s *SourceBlockchain) Validate(destinationBlockchainIDs *set.Set[string]) e
 	if err := s.RPCEndpoint.Validate(); err != nil {
 		return fmt.Errorf("invalid rpc-endpoint in source subnet configuration: %w", err)
 	}
-	if err := s.WSEndpoint.Validate(); err != nil {
-		return fmt.Errorf("invalid ws-endpoint in source subnet configuration: %w", err)
+	if s.TLS != nil {
+		if err := s.TLS.Validate(); err != nil {
+			return fmt.Errorf("invalid tls in source subnet configuration: %w", err)
+		}
+		tlsConfig, err := s.TLS.ClientConfig()
+		if err != nil {
+			return fmt.Errorf("invalid tls in source subnet configuration: %w", err)
+		}
+		s.tlsConfig = tlsConfig
+	}
+	subscriptionMode, err := s.GetSubscriptionMode()
+	if err != nil {
+		return err
+	}
+	// The WebSocket endpoint is optional if the relayer may poll for new blocks
+	if subscriptionMode == SubscriptionModeWebSocket || s.WSEndpoint.BaseURL != "" {
+		if err := s.WSEndpoint.Validate(); err != nil {
+			return fmt.Errorf("invalid ws-endpoint in source subnet configuration: %w", err)
+		}
+	}
+	if err := validateFallbackRPCEndpoints(
+		s.RPCEndpoint,
+		s.FallbackRPCEndpoints,
+		s.StaleHeadTimeoutSeconds != 0 || s.EndpointHealthCheckIntervalSeconds != 0,
+	); err != nil {
+		return fmt.Errorf("invalid fallback-rpc-endpoints in source subnet configuration: %w", err)
 	}
 	// The Warp API endpoint is optional. If omitted, signatures are fetched from validators via app request.
 	if s.WarpAPIEndpoint.BaseURL != "" {
@@ -52,6 +171,29 @@ func (s *SourceBlockchain) Validate(destinationBlockchainIDs *set.Set[string]) e
 		s.useAppRequestNetwork = true
 	}
 
+	if s.HasArchivalRPCEndpoint() {
+		if err := s.ArchivalRPCEndpoint.Validate(); err != nil {
+			return fmt.Errorf("invalid archival-rpc-endpoint in source subnet configuration: %w", err)
+		}
+	}
+
+	if s.StartBlockHeight != 0 && s.ProcessHistoricalBlocksFromHeight != 0 {
+		return errors.New("start-block-height may not be combined with process-historical-blocks-from-height")
+	}
+
+	if s.ProcessHistoricalBlocksToHeight != 0 {
+		if s.ProcessHistoricalBlocksFromHeight == 0 {
+			return errors.New("process-historical-blocks-to-height requires process-historical-blocks-from-height")
+		}
+		if s.ProcessHistoricalBlocksToHeight < s.ProcessHistoricalBlocksFromHeight {
+			return fmt.Errorf(
+				"process-historical-blocks-to-height %d is less than process-historical-blocks-from-height %d",
+				s.ProcessHistoricalBlocksToHeight,
+				s.ProcessHistoricalBlocksFromHeight,
+			)
+		}
+	}
+
 	// Validate the VM specific settings
 	switch ParseVM(s.VM) {
 	case EVM:
@@ -60,10 +202,33 @@ func (s *SourceBlockchain) Validate(destinationBlockchainIDs *set.Set[string]) e
 				return fmt.Errorf("invalid message contract address in EVM source subnet: %s", messageContractAddress)
 			}
 		}
+	case P_CHAIN:
+		// P-Chain originated messages may be unpacked, but no subscriber watches the P-Chain for them yet
+		return errors.New("relaying Warp messages from the P-Chain is not yet supported")
 	default:
 		return fmt.Errorf("unsupported VM type for source subnet: %s", s.VM)
 	}
 
+	if s.GetReconnectInitialBackoff() > s.GetReconnectMaxBackoff() {
+		return fmt.Errorf(
+			"reconnect-initial-backoff-milliseconds must not exceed reconnect-max-backoff-seconds: %d > %d",
+			s.GetReconnectInitialBackoff().Milliseconds(),
+			s.GetReconnectMaxBackoff().Milliseconds(),
+		)
+	}
+
+	if s.MaxMessageAgeSeconds != 0 && s.FinalityDelaySeconds >= s.MaxMessageAgeSeconds {
+		return fmt.Errorf(
+			"finality-delay-seconds %d must be less than max-message-age-seconds %d",
+			s.FinalityDelaySeconds,
+			s.MaxMessageAgeSeconds,
+		)
+	}
+
+	if s.BlockLagCheckIntervalSeconds != 0 && s.MaxBlockLag == 0 {
+		return errors.New("block-lag-check-interval-seconds requires max-block-lag to be set")
+	}
+
 	// Validate message settings correspond to a supported message protocol
 	for _, messageConfig := range s.MessageContracts {
 		protocol := ParseMessageProtocol(messageConfig.MessageFormat)
@@ -83,6 +248,13 @@ func (s *SourceBlockchain) Validate(destinationBlockchainIDs *set.Set[string]) e
 		return fmt.Errorf("invalid subnetID '%s' in configuration. error: %w", s.SubnetID, err)
 	}
 	s.subnetID = subnetID
+	if s.SigningSubnetID != "" {
+		signingSubnetID, err := utils.HexOrCB58ToID(s.SigningSubnetID)
+		if err != nil {
+			return fmt.Errorf("invalid signing-subnet-id '%s' in configuration. error: %w", s.SigningSubnetID, err)
+		}
+		s.signingSubnetID = signingSubnetID
+	}
 
 	// If the list of supported destinations is empty, populate with all of the configured destinations
 	if len(s.SupportedDestinations) == 0 {
@@ -95,7 +267,7 @@ func (s *SourceBlockchain) Validate(destinationBlockchainIDs *set.Set[string]) e
 	for _, dest := range s.SupportedDestinations {
 		blockchainID, err := utils.HexOrCB58ToID(dest.BlockchainID)
 		if err != nil {
-			return fmt.Errorf("invalid blockchainID in configuration. error: %w", err)
+			return fmt.Errorf("invalid blockchain-id '%s' in supported-destinations. error: %w", dest.BlockchainID, err)
 		}
 		if !destinationBlockchainIDs.Contains(dest.BlockchainID) {
 			return fmt.Errorf(
@@ -105,6 +277,11 @@ func (s *SourceBlockchain) Validate(destinationBlockchainIDs *set.Set[string]) e
 			)
 		}
 		dest.blockchainID = blockchainID
+		if dest.DeliverySLA != nil {
+			if err := dest.DeliverySLA.Validate(); err != nil {
+				return fmt.Errorf("invalid delivery-sla for destination blockchain %s: %w", dest.BlockchainID, err)
+			}
+		}
 		for _, addressStr := range dest.Addresses {
 			if !common.IsHexAddress(addressStr) {
 				return fmt.Errorf(
@@ -150,10 +327,26 @@ func (s *SourceBlockchain) GetSubnetID() ids.ID {
 	return s.subnetID
 }
 
+// Returns true if the source blockchain's messages are signed by a subnet other than the default signing subnet
+func (s *SourceBlockchain) HasSigningSubnetID() bool {
+	return s.SigningSubnetID != ""
+}
+
+// GetSigningSubnetID returns the configured signing subnet. Only valid if HasSigningSubnetID returns true.
+func (s *SourceBlockchain) GetSigningSubnetID() ids.ID {
+	return s.signingSubnetID
+}
+
 func (s *SourceBlockchain) GetBlockchainID() ids.ID {
 	return s.blockchainID
 }
 
+// Returns true if a bounded range of historical blocks is to be processed on startup, rather than
+// catching up from the latest processed block.
+func (s *SourceBlockchain) HasHistoricalBlockRange() bool {
+	return s.ProcessHistoricalBlocksToHeight != 0
+}
+
 func (s *SourceBlockchain) GetAllowedOriginSenderAddresses() []common.Address {
 	return s.allowedOriginSenderAddresses
 }
@@ -162,10 +355,122 @@ func (s *SourceBlockchain) UseAppRequestNetwork() bool {
 	return s.useAppRequestNetwork
 }
 
+// GetTLSConfig returns the TLS configuration of the connections to the source blockchain's endpoints, or nil if
+// the default configuration is used.
+func (s *SourceBlockchain) GetTLSConfig() *tls.Config {
+	return s.tlsConfig
+}
+
+// Returns the delay before the first attempt to reconnect the WebSocket subscription.
+func (s *SourceBlockchain) GetReconnectInitialBackoff() time.Duration {
+	if s.ReconnectInitialBackoffMilliseconds == 0 {
+		return defaultReconnectInitialBackoffMilliseconds * time.Millisecond
+	}
+	return time.Duration(s.ReconnectInitialBackoffMilliseconds) * time.Millisecond
+}
+
+// Returns true if historical blocks are processed via a separate archival RPC endpoint.
+func (s *SourceBlockchain) HasArchivalRPCEndpoint() bool {
+	return s.ArchivalRPCEndpoint.BaseURL != ""
+}
+
+// Returns true if the source client fails over between multiple RPC endpoints.
+func (s *SourceBlockchain) HasFallbackRPCEndpoints() bool {
+	return len(s.FallbackRPCEndpoints) != 0
+}
+
+// Returns the RPC endpoints of the source blockchain in order of preference.
+func (s *SourceBlockchain) GetRPCEndpoints() []APIConfig {
+	return append([]APIConfig{s.RPCEndpoint}, s.FallbackRPCEndpoints...)
+}
+
+// Returns the duration without a new block after which an RPC endpoint is considered stale, or 0 if
+// endpoints are not checked for staleness.
+func (s *SourceBlockchain) GetStaleHeadTimeout() time.Duration {
+	return time.Duration(s.StaleHeadTimeoutSeconds) * time.Second
+}
+
+// Returns the interval at which the health of the RPC endpoints is checked.
+func (s *SourceBlockchain) GetEndpointHealthCheckInterval() time.Duration {
+	if s.EndpointHealthCheckIntervalSeconds == 0 {
+		return defaultEndpointHealthCheckIntervalSeconds * time.Second
+	}
+	return time.Duration(s.EndpointHealthCheckIntervalSeconds) * time.Second
+}
+
+// Returns the age after which messages from the source blockchain are not relayed, or 0 if they never expire.
+func (s *SourceBlockchain) GetMaxMessageAge() time.Duration {
+	return time.Duration(s.MaxMessageAgeSeconds) * time.Second
+}
+
+// Returns the time to wait after the timestamp of a source block before delivering its messages.
+func (s *SourceBlockchain) GetFinalityDelay() time.Duration {
+	return time.Duration(s.FinalityDelaySeconds) * time.Second
+}
+
+// Returns the maximum payload size, in bytes, of the messages relayed from the source blockchain.
+func (s *SourceBlockchain) GetMaxMessageBytes() uint64 {
+	if s.MaxMessageBytes == 0 {
+		return defaultMaxMessageBytes
+	}
+	return s.MaxMessageBytes
+}
+
+// Returns the interval at which the block lag of the source blockchain is checked.
+func (s *SourceBlockchain) GetBlockLagCheckInterval() time.Duration {
+	if s.BlockLagCheckIntervalSeconds == 0 {
+		return defaultBlockLagCheckIntervalSeconds * time.Second
+	}
+	return time.Duration(s.BlockLagCheckIntervalSeconds) * time.Second
+}
+
+// Returns how new blocks are received from the source blockchain, defaulting to auto.
+func (s *SourceBlockchain) GetSubscriptionMode() (string, error) {
+	switch s.SubscriptionMode {
+	case SubscriptionModeAuto, "":
+		return SubscriptionModeAuto, nil
+	case SubscriptionModeWebSocket, SubscriptionModePolling:
+		return s.SubscriptionMode, nil
+	default:
+		return defaultSubscriptionMode, fmt.Errorf(
+			"invalid subscription-mode %s. must be one of %s, %s, or %s",
+			s.SubscriptionMode,
+			SubscriptionModeAuto,
+			SubscriptionModeWebSocket,
+			SubscriptionModePolling,
+		)
+	}
+}
+
+// Returns the interval at which the RPC endpoint is polled for new blocks in polling mode.
+func (s *SourceBlockchain) GetPollingInterval() time.Duration {
+	if s.PollingIntervalMilliseconds == 0 {
+		return defaultPollingIntervalMilliseconds * time.Millisecond
+	}
+	return time.Duration(s.PollingIntervalMilliseconds) * time.Millisecond
+}
+
+// Returns the maximum number of new blocks processed per poll in polling mode.
+func (s *SourceBlockchain) GetPollingBatchSize() uint64 {
+	if s.PollingBatchSize == 0 {
+		return defaultPollingBatchSize
+	}
+	return s.PollingBatchSize
+}
+
+// Returns the upper bound on the delay between attempts to reconnect the WebSocket subscription.
+func (s *SourceBlockchain) GetReconnectMaxBackoff() time.Duration {
+	if s.ReconnectMaxBackoffSeconds == 0 {
+		return defaultReconnectMaxBackoffSeconds * time.Second
+	}
+	return time.Duration(s.ReconnectMaxBackoffSeconds) * time.Second
+}
+
 // Specifies a supported destination blockchain and addresses for a source blockchain.
 type SupportedDestination struct {
-	BlockchainID string   `mapstructure:"blockchain-id" json:"blockchain-id"`
-	Addresses    []string `mapstructure:"addresses" json:"addresses"`
+	BlockchainID string       `mapstructure:"blockchain-id" json:"blockchain-id"`
+	Addresses    []string     `mapstructure:"addresses" json:"addresses"`
+	DeliverySLA  *DeliverySLA `mapstructure:"delivery-sla" json:"delivery-sla"`
 
 	// convenience fields to access parsed data after initialization
 	blockchainID ids.ID
@@ -180,6 +485,28 @@ func (s *SupportedDestination) GetAddresses() []common.Address {
 	return s.addresses
 }
 
+// Delivery SLA for messages relayed from a source blockchain to a destination blockchain.
+// The SLA is met if at least [TargetPercentage] percent of the messages relayed within the
+// trailing [WindowSeconds] were delivered within [MaxLatencySeconds].
+type DeliverySLA struct {
+	MaxLatencySeconds uint64  `mapstructure:"max-latency-seconds" json:"max-latency-seconds"`
+	TargetPercentage  float64 `mapstructure:"target-percentage" json:"target-percentage"`
+	WindowSeconds     uint64  `mapstructure:"window-seconds" json:"window-seconds"`
+}
+
+func (s *DeliverySLA) Validate() error {
+	if s.MaxLatencySeconds == 0 {
+		return fmt.Errorf("max-latency-seconds must be greater than 0")
+	}
+	if s.TargetPercentage <= 0 || s.TargetPercentage > 100 {
+		return fmt.Errorf("target-percentage must be in the range (0, 100]: %f", s.TargetPercentage)
+	}
+	if s.WindowSeconds == 0 {
+		return fmt.Errorf("window-seconds must be greater than 0")
+	}
+	return nil
+}
+
 // The generic configuration for a message protocol.
 type MessageProtocolConfig struct {
 	MessageFormat string                 `mapstructure:"message-format" json:"message-format"`
@@ -0,0 +1,61 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	defaultStandbyLeaseDurationSeconds = 30
+	defaultStandbyGracePeriodSeconds   = 60
+)
+
+// Warm standby configuration. Relayers sharing a database elect a leader for each route by holding a lease
+// stored in the database, which the leader renews as it relays messages. The other relayers act as standbys:
+// they process and sign messages, but only send them if they remain undelivered after GracePeriodSeconds.
+type StandbyConfig struct {
+	// Identifies this relayer to the others sharing the database. Defaults to the hostname.
+	InstanceID           string `mapstructure:"instance-id" json:"instance-id"`
+	LeaseDurationSeconds uint64 `mapstructure:"lease-duration-seconds" json:"lease-duration-seconds"`
+	GracePeriodSeconds   uint64 `mapstructure:"grace-period-seconds" json:"grace-period-seconds"`
+
+	// convenience fields to access parsed data after initialization
+	instanceID string
+}
+
+func (s *StandbyConfig) Validate() error {
+	s.instanceID = s.InstanceID
+	if s.instanceID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return fmt.Errorf("instance-id must be provided if the hostname is unavailable: %w", err)
+		}
+		s.instanceID = hostname
+	}
+	return nil
+}
+
+// GetInstanceID returns the ID identifying this relayer as the holder of a lease
+func (s *StandbyConfig) GetInstanceID() string {
+	return s.instanceID
+}
+
+// GetLeaseDuration returns how long a lease is held without being renewed before another relayer may acquire it
+func (s *StandbyConfig) GetLeaseDuration() time.Duration {
+	if s.LeaseDurationSeconds == 0 {
+		return defaultStandbyLeaseDurationSeconds * time.Second
+	}
+	return time.Duration(s.LeaseDurationSeconds) * time.Second
+}
+
+// GetGracePeriod returns how long a standby relayer waits for a message to be delivered before sending it
+func (s *StandbyConfig) GetGracePeriod() time.Duration {
+	if s.GracePeriodSeconds == 0 {
+		return defaultStandbyGracePeriodSeconds * time.Second
+	}
+	return time.Duration(s.GracePeriodSeconds) * time.Second
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Storage backend configuration for a subset of the relayer's state. Exactly one of
+// the file storage location, the Redis URL, or the Postgres URL must be provided. The storage
+// type selects the format of the files in the storage location.
+type StorageConfig struct {
+	StorageLocation string `mapstructure:"storage-location" json:"storage-location"`
+	StorageType     string `mapstructure:"storage-type" json:"storage-type"`
+	RedisURL        string `mapstructure:"redis-url" json:"redis-url"`
+	PostgresURL     string `mapstructure:"postgres-url" json:"postgres-url"`
+}
+
+func (c *StorageConfig) Validate() error {
+	numBackends := 0
+	for _, option := range []string{c.StorageLocation, c.RedisURL, c.PostgresURL} {
+		if option != "" {
+			numBackends++
+		}
+	}
+	if numBackends != 1 {
+		return errors.New("exactly one of storage-location, redis-url, or postgres-url must be provided")
+	}
+	if err := validateStorageType(c.StorageType); err != nil {
+		return err
+	}
+	if c.RedisURL != "" {
+		if _, err := url.Parse(c.RedisURL); err != nil {
+			return fmt.Errorf("invalid redis-url: %w", err)
+		}
+	}
+	if c.PostgresURL != "" {
+		if _, err := url.Parse(c.PostgresURL); err != nil {
+			return fmt.Errorf("invalid postgres-url: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateStorageType returns an error if [storageType] is not a supported storage type.
+// An empty storage type selects the default.
+func validateStorageType(storageType string) error {
+	switch storageType {
+	case StorageTypeJSON, StorageTypeBadger, "":
+		return nil
+	default:
+		return fmt.Errorf(
+			"invalid storage-type '%s'. Must be one of %s or %s",
+			storageType,
+			StorageTypeJSON,
+			StorageTypeBadger,
+		)
+	}
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// TLS configuration for the connections to a blockchain's RPC endpoints. Used to trust a private certificate
+// authority, and to authenticate the relayer to endpoints that require mutual TLS.
+type TLSConfig struct {
+	// PEM-encoded certificates of the certificate authorities trusted in addition to the system's
+	CACertPath string `mapstructure:"ca-cert-path" json:"ca-cert-path"`
+	// PEM-encoded client certificate and private key presented to endpoints that require mutual TLS
+	ClientCertPath string `mapstructure:"client-cert-path" json:"client-cert-path"`
+	ClientKeyPath  string `mapstructure:"client-key-path" json:"client-key-path"`
+	// If set, the endpoints' certificates are not verified. Only intended for testing.
+	InsecureSkipVerify bool `mapstructure:"insecure-skip-verify" json:"insecure-skip-verify"`
+}
+
+func (c *TLSConfig) Validate() error {
+	if (c.ClientCertPath == "") != (c.ClientKeyPath == "") {
+		return errors.New("client-cert-path and client-key-path must be provided together")
+	}
+	return nil
+}
+
+// ClientConfig loads the configured certificates, and returns the TLS configuration for the RPC clients
+func (c *TLSConfig) ClientConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.InsecureSkipVerify, //nolint:gosec
+	}
+	if c.CACertPath != "" {
+		caCerts, err := os.ReadFile(c.CACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca-cert-path: %w", err)
+		}
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caCerts) {
+			return nil, fmt.Errorf("no PEM-encoded certificates found in %s", c.CACertPath)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	if c.ClientCertPath != "" {
+		clientCert, err := tls.LoadX509KeyPair(c.ClientCertPath, c.ClientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/trace"
+)
+
+const (
+	defaultTracingExporterType = "grpc"
+	defaultTracingSampleRate   = 1.0
+)
+
+// OpenTelemetry tracing configuration. If provided, the relay of each Warp message is traced, and the
+// spans are exported via OTLP to the endpoint.
+type TracingConfig struct {
+	Endpoint     string            `mapstructure:"endpoint" json:"endpoint"`
+	ExporterType string            `mapstructure:"exporter-type" json:"exporter-type"`
+	Headers      map[string]string `mapstructure:"headers" json:"headers"`
+	Insecure     bool              `mapstructure:"insecure" json:"insecure"`
+	SampleRate   float64           `mapstructure:"sample-rate" json:"sample-rate"`
+}
+
+func (c *TracingConfig) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("tracing endpoint must be provided")
+	}
+	if _, err := c.GetExporterType(); err != nil {
+		return fmt.Errorf("invalid tracing exporter-type: %w", err)
+	}
+	if c.SampleRate < 0 || c.SampleRate > 1 {
+		return fmt.Errorf("tracing sample-rate must be between 0 and 1, got %f", c.SampleRate)
+	}
+	return nil
+}
+
+// GetExporterType returns the OTLP protocol used to export spans, defaulting to gRPC
+func (c *TracingConfig) GetExporterType() (trace.ExporterType, error) {
+	if c.ExporterType == "" {
+		return trace.ExporterTypeFromString(defaultTracingExporterType)
+	}
+	return trace.ExporterTypeFromString(c.ExporterType)
+}
+
+// GetSampleRate returns the fraction of Warp messages that are traced, defaulting to all of them
+func (c *TracingConfig) GetSampleRate() float64 {
+	if c.SampleRate == 0 {
+		return defaultTracingSampleRate
+	}
+	return c.SampleRate
+}
@@ -3,19 +3,60 @@
 
 package config
 
+import (
+	"fmt"
+	"sync"
+)
+
 // Supported VMs
 type VM int
 
 const (
 	UNKNOWN_VM VM = iota
 	EVM
+	// Warp messages that originate from the P-Chain itself, rather than from a subnet blockchain
+	P_CHAIN
+
+	// Values at or above firstRegisteredVM are assigned by RegisterVM
+	firstRegisteredVM
+)
+
+var (
+	registeredVMsLock sync.RWMutex
+	registeredVMs     = make(map[string]VM)
 )
 
+// RegisterVM adds a VM that may be configured by name, and returns its VM value. Intended to be called from
+// an init function by packages that provide a destination client for a VM not natively supported by the relayer.
+// Panics if [name] is empty or already registered.
+func RegisterVM(name string) VM {
+	registeredVMsLock.Lock()
+	defer registeredVMsLock.Unlock()
+	if name == "" {
+		panic("config: RegisterVM called with empty name")
+	}
+	if _, ok := registeredVMs[name]; ok || name == EVM.String() || name == P_CHAIN.String() {
+		panic(fmt.Sprintf("config: RegisterVM called twice for %s", name))
+	}
+	vm := firstRegisteredVM + VM(len(registeredVMs))
+	registeredVMs[name] = vm
+	return vm
+}
+
 func (vm VM) String() string {
 	switch vm {
 	case EVM:
 		return "evm"
+	case P_CHAIN:
+		return "p-chain"
 	default:
+		registeredVMsLock.RLock()
+		defer registeredVMsLock.RUnlock()
+		for name, registered := range registeredVMs {
+			if registered == vm {
+				return name
+			}
+		}
 		return "unknown"
 	}
 }
@@ -25,7 +66,14 @@ func ParseVM(vm string) VM {
 	switch vm {
 	case "evm":
 		return EVM
+	case "p-chain":
+		return P_CHAIN
 	default:
+		registeredVMsLock.RLock()
+		defer registeredVMsLock.RUnlock()
+		if registered, ok := registeredVMs[vm]; ok {
+			return registered
+		}
 		return UNKNOWN_VM
 	}
 }
@@ -37,15 +85,52 @@ const (
 	UNKNOWN_MESSAGE_PROTOCOL MessageProtocol = iota
 	TELEPORTER
 	OFF_CHAIN_REGISTRY
+	RAW
+
+	// Values at or above firstRegisteredMessageProtocol are assigned by RegisterMessageProtocol
+	firstRegisteredMessageProtocol
+)
+
+var (
+	registeredMessageProtocolsLock sync.RWMutex
+	registeredMessageProtocols     = make(map[string]MessageProtocol)
 )
 
+// RegisterMessageProtocol adds a message protocol that may be configured as a message format by name, and
+// returns its MessageProtocol value. Intended to be called from an init function by packages that provide
+// a message handler factory for a protocol not natively supported by the relayer.
+// Panics if [name] is empty or already registered.
+func RegisterMessageProtocol(name string) MessageProtocol {
+	registeredMessageProtocolsLock.Lock()
+	defer registeredMessageProtocolsLock.Unlock()
+	if name == "" {
+		panic("config: RegisterMessageProtocol called with empty name")
+	}
+	_, ok := registeredMessageProtocols[name]
+	if ok || name == TELEPORTER.String() || name == OFF_CHAIN_REGISTRY.String() || name == RAW.String() {
+		panic(fmt.Sprintf("config: RegisterMessageProtocol called twice for %s", name))
+	}
+	protocol := firstRegisteredMessageProtocol + MessageProtocol(len(registeredMessageProtocols))
+	registeredMessageProtocols[name] = protocol
+	return protocol
+}
+
 func (msg MessageProtocol) String() string {
 	switch msg {
 	case TELEPORTER:
 		return "teleporter"
 	case OFF_CHAIN_REGISTRY:
 		return "off-chain-registry"
+	case RAW:
+		return "raw"
 	default:
+		registeredMessageProtocolsLock.RLock()
+		defer registeredMessageProtocolsLock.RUnlock()
+		for name, registered := range registeredMessageProtocols {
+			if registered == msg {
+				return name
+			}
+		}
 		return "unknown"
 	}
 }
@@ -57,7 +142,14 @@ func ParseMessageProtocol(msg string) MessageProtocol {
 		return TELEPORTER
 	case "off-chain-registry":
 		return OFF_CHAIN_REGISTRY
+	case "raw":
+		return RAW
 	default:
+		registeredMessageProtocolsLock.RLock()
+		defer registeredMessageProtocolsLock.RUnlock()
+		if registered, ok := registeredMessageProtocols[msg]; ok {
+			return registered
+		}
 		return UNKNOWN_MESSAGE_PROTOCOL
 	}
 }
@@ -53,11 +53,20 @@ func BuildViper(fs *pflag.FlagSet) (*viper.Viper, error) {
 
 func SetDefaultConfigValues(v *viper.Viper) {
 	v.SetDefault(LogLevelKey, defaultLogLevel)
+	v.SetDefault(LogFormatKey, defaultLogFormat)
 	v.SetDefault(StorageLocationKey, defaultStorageLocation)
+	v.SetDefault(StorageTypeKey, defaultStorageType)
 	v.SetDefault(ProcessMissedBlocksKey, defaultProcessMissedBlocks)
 	v.SetDefault(APIPortKey, defaultAPIPort)
 	v.SetDefault(MetricsPortKey, defaultMetricsPort)
 	v.SetDefault(DBWriteIntervalSecondsKey, defaultIntervalSeconds)
+	v.SetDefault(DeduplicateSignatureRequestsKey, defaultDeduplicateSignatureRequests)
+	v.SetDefault(AggregationRetentionSecondsKey, defaultAggregationRetentionSeconds)
+	v.SetDefault(PostgresMaxOpenConnectionsKey, defaultPostgresMaxOpenConnections)
+	v.SetDefault(SignatureCacheSizeKey, defaultSignatureCacheSize)
+	v.SetDefault(SignatureCacheTTLSecondsKey, defaultSignatureCacheTTLSeconds)
+	v.SetDefault(ShutdownTimeoutSecondsKey, defaultShutdownTimeoutSeconds)
+	v.SetDefault(PausedRouteModeKey, defaultPausedRouteMode)
 }
 
 // BuildConfig constructs the relayer config using Viper.
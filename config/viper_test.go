@@ -20,10 +20,14 @@ func TestBuildConfig(t *testing.T) {
 	cfg, err := BuildConfig(v)
 	require.NoError(t, err)
 	require.Equal(t, defaultLogLevel, cfg.LogLevel)
+	require.Equal(t, defaultLogFormat, cfg.LogFormat)
 	require.Equal(t, defaultStorageLocation, cfg.StorageLocation)
 	require.Equal(t, defaultAPIPort, cfg.APIPort)
 	require.Equal(t, defaultMetricsPort, cfg.MetricsPort)
 	require.Equal(t, defaultIntervalSeconds, cfg.DBWriteIntervalSeconds)
+	require.Equal(t, defaultDeduplicateSignatureRequests, cfg.DeduplicateSignatureRequests)
+	require.Equal(t, defaultShutdownTimeoutSeconds, cfg.ShutdownTimeoutSeconds)
+	require.Equal(t, defaultPausedRouteMode, cfg.PausedRouteMode)
 	require.Equal(t, &APIConfig{
 		BaseURL: "https://api.avax-test.network",
 	}, cfg.PChainAPI)
@@ -63,3 +67,21 @@ func TestBuildConfig(t *testing.T) {
 		AccountPrivateKey: "7493...",
 	}, cfg.DestinationBlockchains[0])
 }
+
+func TestDryRunFlag(t *testing.T) {
+	cfgBytes, err := os.ReadFile("../sample-relayer-config.json")
+	require.NoError(t, err)
+	for _, dryRun := range []bool{false, true} {
+		fs := BuildFlagSet()
+		if dryRun {
+			require.NoError(t, fs.Parse([]string{"--dry-run"}))
+		}
+		v := viper.New()
+		require.NoError(t, v.BindPFlags(fs))
+		v.SetConfigType("json")
+		require.NoError(t, v.ReadConfig(bytes.NewBuffer(cfgBytes)))
+		cfg, err := BuildConfig(v)
+		require.NoError(t, err)
+		require.Equal(t, dryRun, cfg.DryRun)
+	}
+}
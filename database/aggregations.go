@@ -0,0 +1,121 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AggregationRecord describes how the aggregate signature of a delivered Warp message was formed.
+type AggregationRecord struct {
+	WarpMessageID           string `json:"warp-message-id"`
+	SourceBlockchainID      string `json:"source-blockchain-id"`
+	DestinationBlockchainID string `json:"destination-blockchain-id"`
+	SigningSubnetID         string `json:"signing-subnet-id"`
+	TransactionHash         string `json:"transaction-hash"`
+	// Hex-encoded aggregate BLS signature
+	Signature string `json:"signature"`
+	// Indices of the signing validators in the canonical validator set
+	Signers []int `json:"signers"`
+	// Node IDs and stake weight of each signing validator, in the same order as Signers.
+	// Empty if the signature was fetched via the Warp API, in which case the validator set is not known to the relayer.
+	SignerValidators []AggregationSigner `json:"signer-validators,omitempty"`
+	// Stake weight of the signing validators, and of the entire validator set.
+	// Zero if the signature was fetched via the Warp API, in which case the stake is not known to the relayer.
+	SignedWeight uint64 `json:"signed-weight"`
+	TotalWeight  uint64 `json:"total-weight"`
+	// Unix timestamp, in seconds, at which the message was delivered
+	Timestamp int64 `json:"timestamp"`
+}
+
+// AggregationSigner identifies a validator that contributed to an aggregate signature.
+type AggregationSigner struct {
+	// Node IDs registered with the validator's BLS public key
+	NodeIDs []string `json:"node-ids"`
+	Weight  uint64   `json:"weight"`
+}
+
+// Maps Warp message ID to the aggregation record for that message
+type aggregationRecords map[string]*AggregationRecord
+
+// AggregationStore persists aggregation records for each relayerID under the AggregationsKey.
+// Records older than the retention period are pruned on each write.
+type AggregationStore struct {
+	db        RelayerDatabase
+	retention time.Duration
+	lock      *sync.Mutex
+}
+
+// NewAggregationStore returns an AggregationStore backed by [db]. If [retention] is zero, records are kept
+// indefinitely.
+func NewAggregationStore(db RelayerDatabase, retention time.Duration) *AggregationStore {
+	return &AggregationStore{
+		db:        db,
+		retention: retention,
+		lock:      &sync.Mutex{},
+	}
+}
+
+// Put stores the record for [relayerID], and prunes expired records.
+func (s *AggregationStore) Put(relayerID common.Hash, record *AggregationRecord) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	records, err := s.getRecords(relayerID)
+	if err != nil {
+		return err
+	}
+	records[record.WarpMessageID] = record
+	if s.retention != 0 {
+		cutoff := time.Unix(record.Timestamp, 0).Add(-s.retention).Unix()
+		for messageID, r := range records {
+			if r.Timestamp < cutoff {
+				delete(records, messageID)
+			}
+		}
+	}
+
+	value, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	return s.db.Put(relayerID, AggregationsKey, value)
+}
+
+// Get returns the record for the Warp message with ID [warpMessageID] stored for [relayerID].
+// Returns ErrKeyNotFound if no such record exists.
+func (s *AggregationStore) Get(relayerID common.Hash, warpMessageID string) (*AggregationRecord, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	records, err := s.getRecords(relayerID)
+	if err != nil {
+		return nil, err
+	}
+	record, ok := records[warpMessageID]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return record, nil
+}
+
+// Helper to get the records stored for [relayerID]. Not thread-safe.
+func (s *AggregationStore) getRecords(relayerID common.Hash) (aggregationRecords, error) {
+	records := make(aggregationRecords)
+	value, err := s.db.Get(relayerID, AggregationsKey)
+	if IsKeyNotFoundError(err) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(value, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregationStore(t *testing.T) {
+	relayerIDs := createRelayerIDs([]ids.ID{ids.GenerateTestID()})
+	relayerID := relayerIDs[0].ID
+	db, err := NewJSONFileStorage(logging.NoLog{}, t.TempDir(), relayerIDs)
+	require.NoError(t, err)
+
+	store := NewAggregationStore(db, time.Hour)
+
+	// No records stored yet
+	_, err = store.Get(relayerID, "message1")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	start := time.Unix(1_000_000, 0)
+	record1 := &AggregationRecord{
+		WarpMessageID: "message1",
+		Signers:       []int{0, 2},
+		SignedWeight:  70,
+		TotalWeight:   100,
+		Timestamp:     start.Unix(),
+	}
+	require.NoError(t, store.Put(relayerID, record1))
+
+	res, err := store.Get(relayerID, "message1")
+	require.NoError(t, err)
+	require.Equal(t, record1, res)
+
+	// Storing a record after the retention period prunes the expired record
+	record2 := &AggregationRecord{
+		WarpMessageID: "message2",
+		Timestamp:     start.Add(2 * time.Hour).Unix(),
+	}
+	require.NoError(t, store.Put(relayerID, record2))
+
+	_, err = store.Get(relayerID, "message1")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	res, err = store.Get(relayerID, "message2")
+	require.NoError(t, err)
+	require.Equal(t, record2, res)
+}
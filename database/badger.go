@@ -0,0 +1,119 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+var _ RelayerDatabase = &BadgerStorage{}
+
+// badgerDirName is the subdirectory of the storage location in which the BadgerDB files are kept
+const badgerDirName = "badger"
+
+// BadgerStorage stores relayer state in an embedded BadgerDB database, keyed on the relayer ID and data key.
+// Unlike JSONFileStorage, each Put writes only the given key, in a single atomic transaction.
+type BadgerStorage struct {
+	logger logging.Logger
+	db     *badger.DB
+}
+
+// NewBadgerStorage opens the BadgerDB database in [dir], creating it if it does not exist.
+func NewBadgerStorage(logger logging.Logger, dir string) (*BadgerStorage, error) {
+	opts := badger.DefaultOptions(filepath.Join(filepath.Clean(dir), badgerDirName)).
+		WithLogger(&badgerLogger{logger: logger})
+	db, err := badger.Open(opts)
+	if err != nil {
+		logger.Error(
+			"Failed to open BadgerDB database",
+			zap.String("dir", dir),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &BadgerStorage{
+		logger: logger,
+		db:     db,
+	}, nil
+}
+
+func (s *BadgerStorage) Get(relayerID common.Hash, key DataKey) ([]byte, error) {
+	var value []byte
+	err := s.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(constructCompositeKey(relayerID, key)))
+		if err != nil {
+			return err
+		}
+		value, err = item.ValueCopy(nil)
+		return err
+	})
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return nil, ErrKeyNotFound
+	}
+	if err != nil {
+		s.logger.Debug(
+			"Error retrieving key from BadgerDB",
+			zap.String("relayerID", relayerID.Hex()),
+			zap.String("key", key.String()),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return value, nil
+}
+
+func (s *BadgerStorage) Put(relayerID common.Hash, key DataKey, value []byte) error {
+	err := s.db.Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte(constructCompositeKey(relayerID, key)), value)
+	})
+	if err != nil {
+		s.logger.Error(
+			"Error storing key in BadgerDB",
+			zap.String("relayerID", relayerID.Hex()),
+			zap.String("key", key.String()),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// Rewind is equivalent to Put, since BadgerStorage does not guard against moving a checkpoint backwards.
+func (s *BadgerStorage) Rewind(relayerID common.Hash, key DataKey, value []byte) error {
+	return s.Put(relayerID, key, value)
+}
+
+// Close closes the BadgerDB database. The storage must not be used afterwards.
+func (s *BadgerStorage) Close() error {
+	return s.db.Close()
+}
+
+// badgerLogger forwards BadgerDB's log messages to the relayer's logger.
+type badgerLogger struct {
+	logger logging.Logger
+}
+
+func (l *badgerLogger) Errorf(format string, args ...interface{}) {
+	l.logger.Error(strings.TrimSpace(fmt.Sprintf(format, args...)))
+}
+
+func (l *badgerLogger) Warningf(format string, args ...interface{}) {
+	l.logger.Warn(strings.TrimSpace(fmt.Sprintf(format, args...)))
+}
+
+func (l *badgerLogger) Infof(format string, args ...interface{}) {
+	l.logger.Debug(strings.TrimSpace(fmt.Sprintf(format, args...)))
+}
+
+func (l *badgerLogger) Debugf(format string, args ...interface{}) {
+	l.logger.Verbo(strings.TrimSpace(fmt.Sprintf(format, args...)))
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBadgerStorage(t *testing.T) {
+	dir := t.TempDir()
+	relayerIDs := createRelayerIDs([]ids.ID{ids.GenerateTestID(), ids.GenerateTestID()})
+
+	storage, err := NewBadgerStorage(logging.NoLog{}, dir)
+	require.NoError(t, err)
+
+	// Keys that have not been written are not found
+	_, err = storage.Get(relayerIDs[0].ID, LatestProcessedBlockKey)
+	require.True(t, IsKeyNotFoundError(err))
+
+	require.NoError(t, storage.Put(relayerIDs[0].ID, LatestProcessedBlockKey, []byte("100")))
+	require.NoError(t, storage.Put(relayerIDs[0].ID, AggregationsKey, []byte("{}")))
+	require.NoError(t, storage.Put(relayerIDs[1].ID, LatestProcessedBlockKey, []byte("200")))
+	// Values are overwritten
+	require.NoError(t, storage.Put(relayerIDs[0].ID, LatestProcessedBlockKey, []byte("101")))
+
+	value, err := storage.Get(relayerIDs[0].ID, LatestProcessedBlockKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("101"), value)
+	_, err = storage.Get(relayerIDs[1].ID, AggregationsKey)
+	require.True(t, IsKeyNotFoundError(err))
+
+	// Values persist across reopening the database
+	require.NoError(t, Close(storage))
+	storage, err = NewBadgerStorage(logging.NoLog{}, dir)
+	require.NoError(t, err)
+	defer storage.Close()
+
+	for _, testCase := range []struct {
+		relayerID common.Hash
+		key       DataKey
+		expected  []byte
+	}{
+		{relayerID: relayerIDs[0].ID, key: LatestProcessedBlockKey, expected: []byte("101")},
+		{relayerID: relayerIDs[0].ID, key: AggregationsKey, expected: []byte("{}")},
+		{relayerID: relayerIDs[1].ID, key: LatestProcessedBlockKey, expected: []byte("200")},
+	} {
+		value, err := storage.Get(testCase.relayerID, testCase.key)
+		require.NoError(t, err)
+		require.Equal(t, testCase.expected, value)
+	}
+}
@@ -6,6 +6,9 @@
 package database
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/awm-relayer/config"
 	"github.com/ethereum/go-ethereum/common"
@@ -21,6 +24,11 @@ var (
 
 const (
 	LatestProcessedBlockKey DataKey = iota
+	AggregationsKey
+	DeliveredMessagesKey
+	LeaseKey
+	DeadLettersKey
+	NonceKey
 )
 
 type DataKey int
@@ -29,6 +37,16 @@ func (k DataKey) String() string {
 	switch k {
 	case LatestProcessedBlockKey:
 		return "latestProcessedBlock"
+	case AggregationsKey:
+		return "aggregations"
+	case DeliveredMessagesKey:
+		return "deliveredMessages"
+	case LeaseKey:
+		return "lease"
+	case DeadLettersKey:
+		return "deadLetters"
+	case NonceKey:
+		return "nonce"
 	}
 	return "unknown"
 }
@@ -37,12 +55,126 @@ func (k DataKey) String() string {
 // Implementations should be thread-safe.
 type RelayerDatabase interface {
 	Get(relayerID common.Hash, key DataKey) ([]byte, error)
+	// Put stores [value] under [key]. Backends that may be shared by multiple relayers only ever advance
+	// the LatestProcessedBlockKey, so that a relayer that lags behind the others does not move it backwards.
 	Put(relayerID common.Hash, key DataKey, value []byte) error
+	// Rewind stores [value] under [key] unconditionally, so that a checkpoint may be moved backwards,
+	// for example to reprocess the blocks orphaned by a reorg of the source blockchain.
+	Rewind(relayerID common.Hash, key DataKey, value []byte) error
+}
+
+// relayerIDRegistrar is implemented by databases that must be configured with each relayer ID before it is used.
+type relayerIDRegistrar interface {
+	RegisterRelayerIDs(relayerIDs []RelayerID) error
+}
+
+// RegisterRelayerIDs configures [db] to store the state of [relayerIDs], which may not have been
+// known when the database was created. This is a no-op for backends that accept any relayer ID.
+func RegisterRelayerIDs(db RelayerDatabase, relayerIDs []RelayerID) error {
+	if registrar, ok := db.(relayerIDRegistrar); ok {
+		return registrar.RegisterRelayerIDs(relayerIDs)
+	}
+	return nil
+}
+
+// flusher is implemented by databases that buffer writes in memory.
+type flusher interface {
+	Flush() error
+}
+
+// Flush writes any writes buffered by [db] to its backing store. This is a no-op for backends that
+// do not buffer writes.
+func Flush(db RelayerDatabase) error {
+	if f, ok := db.(flusher); ok {
+		return f.Flush()
+	}
+	return nil
 }
 
+// closer is implemented by databases that hold resources that must be released before exiting.
+type closer interface {
+	Close() error
+}
+
+// Close releases the resources held by [db]. This is a no-op for backends that do not hold any.
+// [db] must not be used afterwards.
+func Close(db RelayerDatabase) error {
+	if c, ok := db.(closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// NewDatabase creates the database for the relayer's state. Checkpoints are kept in the backend
+// configured by the top-level storage options. If records storage is configured, delivery records
+// are kept in a separate backend. Each backend is verified to be reachable before returning.
 func NewDatabase(logger logging.Logger, cfg *config.Config) (RelayerDatabase, error) {
-	if cfg.RedisURL != "" {
-		db, err := NewRedisDatabase(logger, cfg.RedisURL, GetConfigRelayerIDs(cfg))
+	relayerIDs := GetConfigRelayerIDs(cfg)
+	db, err := newBackend(
+		logger,
+		cfg.RedisURL,
+		cfg.PostgresURL,
+		cfg.PostgresMaxOpenConnections,
+		cfg.StorageLocation,
+		cfg.StorageType,
+		cfg.GetStorageFlushInterval(),
+		int(cfg.StorageMaxPendingWrites),
+		relayerIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.RecordsStorage == nil {
+		return db, nil
+	}
+
+	recordsDB, err := newBackend(
+		logger,
+		cfg.RecordsStorage.RedisURL,
+		cfg.RecordsStorage.PostgresURL,
+		cfg.PostgresMaxOpenConnections,
+		cfg.RecordsStorage.StorageLocation,
+		cfg.RecordsStorage.StorageType,
+		cfg.GetStorageFlushInterval(),
+		int(cfg.StorageMaxPendingWrites),
+		relayerIDs,
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to create records database",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return NewNamespacedDatabase(
+		db,
+		map[DataKey]RelayerDatabase{
+			AggregationsKey: recordsDB,
+			DeadLettersKey:  recordsDB,
+		},
+	), nil
+}
+
+// newBackend creates a Redis database if [redisURL] is provided, a Postgres database if [postgresURL] is provided,
+// and a database of [storageType] in [storageLocation] otherwise. Writes to a JSON file database are batched
+// according to [flushInterval] and [maxPendingWrites].
+func newBackend(
+	logger logging.Logger,
+	redisURL string,
+	postgresURL string,
+	postgresMaxOpenConnections int,
+	storageLocation string,
+	storageType string,
+	flushInterval time.Duration,
+	maxPendingWrites int,
+	relayerIDs []RelayerID,
+) (RelayerDatabase, error) {
+	var (
+		db  RelayerDatabase
+		err error
+	)
+	if redisURL != "" {
+		db, err = NewRedisDatabase(logger, redisURL, relayerIDs)
 		if err != nil {
 			logger.Error(
 				"Failed to create Redis database",
@@ -50,9 +182,26 @@ func NewDatabase(logger logging.Logger, cfg *config.Config) (RelayerDatabase, er
 			)
 			return nil, err
 		}
-		return db, nil
+	} else if postgresURL != "" {
+		db, err = NewPostgresDatabase(logger, postgresURL, postgresMaxOpenConnections)
+		if err != nil {
+			logger.Error(
+				"Failed to create Postgres database",
+				zap.Error(err),
+			)
+			return nil, err
+		}
+	} else if storageType == config.StorageTypeBadger {
+		db, err = NewBadgerStorage(logger, storageLocation)
+		if err != nil {
+			logger.Error(
+				"Failed to create BadgerDB database",
+				zap.Error(err),
+			)
+			return nil, err
+		}
 	} else {
-		db, err := NewJSONFileStorage(logger, cfg.StorageLocation, GetConfigRelayerIDs(cfg))
+		db, err = NewBatchedJSONFileStorage(logger, storageLocation, relayerIDs, flushInterval, maxPendingWrites)
 		if err != nil {
 			logger.Error(
 				"Failed to create JSON database",
@@ -60,6 +209,26 @@ func NewDatabase(logger logging.Logger, cfg *config.Config) (RelayerDatabase, er
 			)
 			return nil, err
 		}
-		return db, nil
 	}
+	if err := verifyBackend(db, relayerIDs); err != nil {
+		logger.Error(
+			"Failed to read from database",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return db, nil
+}
+
+// verifyBackend performs a read against the database to surface connectivity
+// or permission errors at startup rather than on the first checkpoint.
+func verifyBackend(db RelayerDatabase, relayerIDs []RelayerID) error {
+	if len(relayerIDs) == 0 {
+		return nil
+	}
+	_, err := db.Get(relayerIDs[0].ID, LatestProcessedBlockKey)
+	if err != nil && !IsKeyNotFoundError(err) {
+		return fmt.Errorf("%w: %w", ErrDatabaseMisconfiguration, err)
+	}
+	return nil
 }
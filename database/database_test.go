@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/awm-relayer/config"
 	"github.com/ethereum/go-ethereum/common"
@@ -228,3 +230,125 @@ func TestGetConfigRelayerKeys(t *testing.T) {
 		)
 	}
 }
+
+func TestGetConfigRelayerKeysHubAndSpoke(t *testing.T) {
+	hubID := ids.GenerateTestID().String()
+	spokeIDs := []string{ids.GenerateTestID().String(), ids.GenerateTestID().String()}
+
+	allowedDestinations := set.Of(append([]string{hubID}, spokeIDs...)...)
+	var sourceBlockchains []*config.SourceBlockchain
+	// The hub relays to each spoke, and each spoke only relays to the hub
+	hubCfg := config.TestValidSourceBlockchainConfig
+	hubCfg.BlockchainID = hubID
+	for _, spokeID := range spokeIDs {
+		hubCfg.SupportedDestinations = append(hubCfg.SupportedDestinations, &config.SupportedDestination{
+			BlockchainID: spokeID,
+		})
+	}
+	require.NoError(t, hubCfg.Validate(&allowedDestinations))
+	sourceBlockchains = append(sourceBlockchains, &hubCfg)
+	for _, spokeID := range spokeIDs {
+		spokeCfg := config.TestValidSourceBlockchainConfig
+		spokeCfg.BlockchainID = spokeID
+		spokeCfg.SupportedDestinations = []*config.SupportedDestination{{BlockchainID: hubID}}
+		require.NoError(t, spokeCfg.Validate(&allowedDestinations))
+		sourceBlockchains = append(sourceBlockchains, &spokeCfg)
+	}
+
+	relayerIDs := GetConfigRelayerIDs(&config.Config{SourceBlockchains: sourceBlockchains})
+
+	var routes [][2]string
+	for _, relayerID := range relayerIDs {
+		routes = append(routes, [2]string{
+			relayerID.SourceBlockchainID.String(),
+			relayerID.DestinationBlockchainID.String(),
+		})
+	}
+	require.ElementsMatch(t, [][2]string{
+		{hubID, spokeIDs[0]},
+		{hubID, spokeIDs[1]},
+		{spokeIDs[0], hubID},
+		{spokeIDs[1], hubID},
+	}, routes)
+}
+
+// Test that each backend moves a checkpoint backwards on Rewind, including those that only advance it on Put.
+func TestRewind(t *testing.T) {
+	relayerIDs := createRelayerIDs([]ids.ID{ids.GenerateTestID()})
+	relayerID := relayerIDs[0].ID
+	testCases := []struct {
+		name  string
+		newDB func(t *testing.T) RelayerDatabase
+	}{
+		{
+			name: "json",
+			newDB: func(t *testing.T) RelayerDatabase {
+				db, err := NewJSONFileStorage(logging.NoLog{}, t.TempDir(), relayerIDs)
+				require.NoError(t, err)
+				return db
+			},
+		},
+		{
+			name: "badger",
+			newDB: func(t *testing.T) RelayerDatabase {
+				db, err := NewBadgerStorage(logging.NoLog{}, t.TempDir())
+				require.NoError(t, err)
+				t.Cleanup(func() { db.Close() })
+				return db
+			},
+		},
+		{
+			name: "redis",
+			newDB: func(t *testing.T) RelayerDatabase {
+				return newTestRedisDatabase(t, miniredis.RunT(t))
+			},
+		},
+		{
+			name: "namespaced",
+			newDB: func(t *testing.T) RelayerDatabase {
+				recordsDB, err := NewJSONFileStorage(logging.NoLog{}, t.TempDir(), relayerIDs)
+				require.NoError(t, err)
+				return NewNamespacedDatabase(
+					newTestRedisDatabase(t, miniredis.RunT(t)),
+					map[DataKey]RelayerDatabase{AggregationsKey: recordsDB},
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			db := testCase.newDB(t)
+			require.NoError(t, db.Put(relayerID, LatestProcessedBlockKey, []byte("100")))
+			require.NoError(t, db.Rewind(relayerID, LatestProcessedBlockKey, []byte("90")))
+			value, err := db.Get(relayerID, LatestProcessedBlockKey)
+			require.NoError(t, err)
+			require.Equal(t, []byte("90"), value)
+
+			// The checkpoint is advanced from the rewound height
+			require.NoError(t, db.Put(relayerID, LatestProcessedBlockKey, []byte("95")))
+			value, err = db.Get(relayerID, LatestProcessedBlockKey)
+			require.NoError(t, err)
+			require.Equal(t, []byte("95"), value)
+		})
+	}
+}
+
+func TestNewDatabaseRecordsStorageType(t *testing.T) {
+	cfg := &config.Config{
+		StorageLocation: t.TempDir(),
+		StorageType:     config.StorageTypeJSON,
+		RecordsStorage: &config.StorageConfig{
+			StorageLocation: t.TempDir(),
+			StorageType:     config.StorageTypeBadger,
+		},
+	}
+	db, err := NewDatabase(logging.NoLog{}, cfg)
+	require.NoError(t, err)
+	defer Close(db)
+
+	namespaced, ok := db.(*NamespacedDatabase)
+	require.True(t, ok)
+	require.IsType(t, &JSONFileStorage{}, namespaced.defaultBackend)
+	require.IsType(t, &BadgerStorage{}, namespaced.backends[AggregationsKey])
+	require.IsType(t, &BadgerStorage{}, namespaced.backends[DeadLettersKey])
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DeadLetter records a Warp message that the relayer failed to deliver, so that it may be retried.
+type DeadLetter struct {
+	// ID of the Warp message, as emitted by the source blockchain
+	WarpMessageID           string `json:"warp-message-id"`
+	SourceBlockchainID      string `json:"source-blockchain-id"`
+	DestinationBlockchainID string `json:"destination-blockchain-id"`
+	// Address of the contract that sent the Warp message, used to route it to a message handler
+	SourceAddress string `json:"source-address"`
+	// Hex-encoded unsigned Warp message
+	UnsignedMessage string `json:"unsigned-message"`
+	// The error that caused the final delivery attempt to fail
+	Reason string `json:"reason"`
+	// Unix timestamp, in seconds, of the final delivery attempt
+	Timestamp int64 `json:"timestamp"`
+}
+
+// Maps Warp message ID to the dead letter for that message
+type deadLetters map[string]*DeadLetter
+
+// DeadLetterStore persists the messages that failed to be delivered for each relayerID under the DeadLettersKey.
+// A message is recorded once, with the reason for its most recent failure.
+type DeadLetterStore struct {
+	db   RelayerDatabase
+	lock *sync.Mutex
+}
+
+// NewDeadLetterStore returns a DeadLetterStore backed by [db].
+func NewDeadLetterStore(db RelayerDatabase) *DeadLetterStore {
+	return &DeadLetterStore{
+		db:   db,
+		lock: &sync.Mutex{},
+	}
+}
+
+// Put stores the dead letter for [relayerID], replacing any stored for the same Warp message.
+func (s *DeadLetterStore) Put(relayerID common.Hash, deadLetter *DeadLetter) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	letters, err := s.getDeadLetters(relayerID)
+	if err != nil {
+		return err
+	}
+	letters[deadLetter.WarpMessageID] = deadLetter
+	return s.putDeadLetters(relayerID, letters)
+}
+
+// Get returns the dead letter for the Warp message with ID [warpMessageID] stored for [relayerID].
+// Returns ErrKeyNotFound if no such dead letter exists.
+func (s *DeadLetterStore) Get(relayerID common.Hash, warpMessageID string) (*DeadLetter, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	letters, err := s.getDeadLetters(relayerID)
+	if err != nil {
+		return nil, err
+	}
+	deadLetter, ok := letters[warpMessageID]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return deadLetter, nil
+}
+
+// List returns the dead letters stored for [relayerID], oldest first.
+func (s *DeadLetterStore) List(relayerID common.Hash) ([]*DeadLetter, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	letters, err := s.getDeadLetters(relayerID)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*DeadLetter, 0, len(letters))
+	for _, deadLetter := range letters {
+		list = append(list, deadLetter)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Timestamp != list[j].Timestamp {
+			return list[i].Timestamp < list[j].Timestamp
+		}
+		return list[i].WarpMessageID < list[j].WarpMessageID
+	})
+	return list, nil
+}
+
+// Remove deletes the dead letter for the Warp message with ID [warpMessageID] stored for [relayerID], if any.
+func (s *DeadLetterStore) Remove(relayerID common.Hash, warpMessageID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	letters, err := s.getDeadLetters(relayerID)
+	if err != nil {
+		return err
+	}
+	if _, ok := letters[warpMessageID]; !ok {
+		return nil
+	}
+	delete(letters, warpMessageID)
+	return s.putDeadLetters(relayerID, letters)
+}
+
+// Helper to get the dead letters stored for [relayerID]. Not thread-safe.
+func (s *DeadLetterStore) getDeadLetters(relayerID common.Hash) (deadLetters, error) {
+	letters := make(deadLetters)
+	value, err := s.db.Get(relayerID, DeadLettersKey)
+	if IsKeyNotFoundError(err) {
+		return letters, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(value, &letters); err != nil {
+		return nil, err
+	}
+	return letters, nil
+}
+
+// Helper to write the dead letters for [relayerID]. Not thread-safe.
+func (s *DeadLetterStore) putDeadLetters(relayerID common.Hash, letters deadLetters) error {
+	value, err := json.Marshal(letters)
+	if err != nil {
+		return err
+	}
+	return s.db.Put(relayerID, DeadLettersKey, value)
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterStore(t *testing.T) {
+	relayerIDs := createRelayerIDs([]ids.ID{ids.GenerateTestID()})
+	relayerID := relayerIDs[0].ID
+	db, err := NewJSONFileStorage(logging.NoLog{}, t.TempDir(), relayerIDs)
+	require.NoError(t, err)
+
+	store := NewDeadLetterStore(db)
+
+	// No dead letters stored yet
+	list, err := store.List(relayerID)
+	require.NoError(t, err)
+	require.Empty(t, list)
+	_, err = store.Get(relayerID, "message1")
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	deadLetter1 := &DeadLetter{
+		WarpMessageID:   "message1",
+		UnsignedMessage: "0x01",
+		Reason:          "failed to send warp message",
+		Timestamp:       2,
+	}
+	deadLetter2 := &DeadLetter{
+		WarpMessageID: "message2",
+		Reason:        "failed to collect signatures",
+		Timestamp:     1,
+	}
+	require.NoError(t, store.Put(relayerID, deadLetter1))
+	require.NoError(t, store.Put(relayerID, deadLetter2))
+
+	res, err := store.Get(relayerID, "message1")
+	require.NoError(t, err)
+	require.Equal(t, deadLetter1, res)
+	list, err = store.List(relayerID)
+	require.NoError(t, err)
+	require.Equal(t, []*DeadLetter{deadLetter2, deadLetter1}, list)
+
+	// A later failure of the same message replaces its dead letter
+	deadLetter1Retried := &DeadLetter{
+		WarpMessageID: "message1",
+		Reason:        "timed out",
+		Timestamp:     3,
+	}
+	require.NoError(t, store.Put(relayerID, deadLetter1Retried))
+	list, err = store.List(relayerID)
+	require.NoError(t, err)
+	require.Equal(t, []*DeadLetter{deadLetter2, deadLetter1Retried}, list)
+
+	// Removing a message that is not stored is a no-op
+	require.NoError(t, store.Remove(relayerID, "message3"))
+	require.NoError(t, store.Remove(relayerID, "message2"))
+	list, err = store.List(relayerID)
+	require.NoError(t, err)
+	require.Equal(t, []*DeadLetter{deadLetter1Retried}, list)
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// maxPendingDeliveredMessages is the number of messages added to a DeliveredMessageStore before the set is
+// written to the database.
+const maxPendingDeliveredMessages = 100
+
+type deliveredMessage struct {
+	// Height of the source block containing the message
+	Height uint64 `json:"height"`
+	// Unix timestamp, in seconds, at which the message was delivered
+	Timestamp int64 `json:"timestamp"`
+}
+
+// DeliveredMessageStore tracks the IDs of messages recently delivered by a single relayerID, so that they are
+// not re-relayed if the source block is reprocessed before it is checkpointed, for example after a restart.
+// The set is held in memory, and persisted under the DeliveredMessagesKey. Since the whole set is written at once,
+// added messages are written in batches: once maxPendingDeliveredMessages are pending, when entries are pruned,
+// or on Flush. Pending messages are lost if the relayer exits without flushing, in which case their delivery is
+// checked on the destination blockchain instead.
+type DeliveredMessageStore struct {
+	db        RelayerDatabase
+	relayerID common.Hash
+	window    time.Duration
+	lock      *sync.Mutex
+	messages  map[ids.ID]deliveredMessage
+	// Number of messages added since the set was last written
+	pendingAdds int
+}
+
+// NewDeliveredMessageStore returns a DeliveredMessageStore for [relayerID] backed by [db], loading any
+// previously persisted entries. Entries expire after [window].
+func NewDeliveredMessageStore(
+	db RelayerDatabase,
+	relayerID common.Hash,
+	window time.Duration,
+) (*DeliveredMessageStore, error) {
+	messages := make(map[ids.ID]deliveredMessage)
+	value, err := db.Get(relayerID, DeliveredMessagesKey)
+	if err != nil && !IsKeyNotFoundError(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(value, &messages); err != nil {
+			return nil, err
+		}
+	}
+	return &DeliveredMessageStore{
+		db:        db,
+		relayerID: relayerID,
+		window:    window,
+		lock:      &sync.Mutex{},
+		messages:  messages,
+	}, nil
+}
+
+// Contains returns true if the message with ID [messageID] was delivered within the window.
+func (s *DeliveredMessageStore) Contains(messageID ids.ID) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	message, ok := s.messages[messageID]
+	return ok && !s.expired(message, time.Now())
+}
+
+// Add records the delivery of the message with ID [messageID], contained in the source block at [height].
+// The set is only written to the database once maxPendingDeliveredMessages have been added since it was last written.
+func (s *DeliveredMessageStore) Add(messageID ids.ID, height uint64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.messages[messageID] = deliveredMessage{
+		Height:    height,
+		Timestamp: time.Now().Unix(),
+	}
+	s.pendingAdds++
+	if s.pendingAdds < maxPendingDeliveredMessages {
+		return nil
+	}
+	return s.write()
+}
+
+// Flush writes the set to the database if messages have been added since it was last written.
+func (s *DeliveredMessageStore) Flush() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.pendingAdds == 0 {
+		return nil
+	}
+	return s.write()
+}
+
+// Prune removes expired entries, and entries for messages contained in source blocks at or below
+// [checkpointHeight], since those blocks will not be reprocessed. If any are removed, the set is written to the
+// database, including the messages pending since it was last written.
+func (s *DeliveredMessageStore) Prune(checkpointHeight uint64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+	pruned := false
+	for messageID, message := range s.messages {
+		if message.Height <= checkpointHeight || s.expired(message, now) {
+			delete(s.messages, messageID)
+			pruned = true
+		}
+	}
+	if !pruned {
+		return nil
+	}
+	return s.write()
+}
+
+// Helper to check if [message] has expired as of [now]. Not thread-safe.
+func (s *DeliveredMessageStore) expired(message deliveredMessage, now time.Time) bool {
+	return now.After(time.Unix(message.Timestamp, 0).Add(s.window))
+}
+
+// Helper to persist the in-memory set. Not thread-safe.
+func (s *DeliveredMessageStore) write() error {
+	value, err := json.Marshal(s.messages)
+	if err != nil {
+		return err
+	}
+	if err := s.db.Put(s.relayerID, DeliveredMessagesKey, value); err != nil {
+		return err
+	}
+	s.pendingAdds = 0
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeliveredMessageStore(t *testing.T) {
+	relayerIDs := createRelayerIDs([]ids.ID{ids.GenerateTestID()})
+	relayerID := relayerIDs[0].ID
+	db, err := NewJSONFileStorage(logging.NoLog{}, t.TempDir(), relayerIDs)
+	require.NoError(t, err)
+
+	store, err := NewDeliveredMessageStore(db, relayerID, time.Hour)
+	require.NoError(t, err)
+
+	message1 := ids.GenerateTestID()
+	message2 := ids.GenerateTestID()
+	require.False(t, store.Contains(message1))
+
+	require.NoError(t, store.Add(message1, 10))
+	require.NoError(t, store.Add(message2, 11))
+	require.True(t, store.Contains(message1))
+	require.True(t, store.Contains(message2))
+
+	// Added messages are not written until flushed
+	reloaded, err := NewDeliveredMessageStore(db, relayerID, time.Hour)
+	require.NoError(t, err)
+	require.False(t, reloaded.Contains(message1))
+	require.NoError(t, store.Flush())
+
+	// Delivered messages are loaded from the database
+	store, err = NewDeliveredMessageStore(db, relayerID, time.Hour)
+	require.NoError(t, err)
+	require.True(t, store.Contains(message1))
+	require.True(t, store.Contains(message2))
+
+	// Checkpointing a height drops messages in blocks at or below it
+	require.NoError(t, store.Prune(10))
+	require.False(t, store.Contains(message1))
+	require.True(t, store.Contains(message2))
+
+	store, err = NewDeliveredMessageStore(db, relayerID, time.Hour)
+	require.NoError(t, err)
+	require.False(t, store.Contains(message1))
+	require.True(t, store.Contains(message2))
+
+	// Messages outside the window are not reported as delivered
+	store.window = -time.Second
+	require.False(t, store.Contains(message2))
+	require.NoError(t, store.Prune(0))
+	require.Empty(t, store.messages)
+}
+
+func TestDeliveredMessageStoreBatching(t *testing.T) {
+	relayerIDs := createRelayerIDs([]ids.ID{ids.GenerateTestID()})
+	relayerID := relayerIDs[0].ID
+	db, err := NewJSONFileStorage(logging.NoLog{}, t.TempDir(), relayerIDs)
+	require.NoError(t, err)
+	store, err := NewDeliveredMessageStore(db, relayerID, time.Hour)
+	require.NoError(t, err)
+
+	// The set is written once maxPendingDeliveredMessages have been added
+	messageIDs := make([]ids.ID, maxPendingDeliveredMessages+1)
+	for i := range messageIDs {
+		messageIDs[i] = ids.GenerateTestID()
+		require.NoError(t, store.Add(messageIDs[i], uint64(i+1)))
+	}
+	reloaded, err := NewDeliveredMessageStore(db, relayerID, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, reloaded.messages, maxPendingDeliveredMessages)
+	require.Equal(t, 1, store.pendingAdds)
+
+	// Pruning writes the pending messages along with the removed entries
+	require.NoError(t, store.Prune(1))
+	require.Zero(t, store.pendingAdds)
+	reloaded, err = NewDeliveredMessageStore(db, relayerID, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, reloaded.messages, maxPendingDeliveredMessages)
+	require.False(t, reloaded.Contains(messageIDs[0]))
+	require.True(t, reloaded.Contains(messageIDs[maxPendingDeliveredMessages]))
+
+	// Flushing without pending messages does not write the set
+	require.NoError(t, db.Put(relayerID, DeliveredMessagesKey, []byte("{}")))
+	require.NoError(t, store.Flush())
+	reloaded, err = NewDeliveredMessageStore(db, relayerID, time.Hour)
+	require.NoError(t, err)
+	require.Empty(t, reloaded.messages)
+}
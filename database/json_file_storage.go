@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ethereum/go-ethereum/common"
@@ -26,20 +27,49 @@ type JSONFileStorage struct {
 	dir string
 
 	// Each network has its own mutex
-	// The RelayerIDs used to index the JSONFileStorage are created at initialization
-	// and are not modified afterwards, so we don't need to lock the map itself.
+	// The RelayerIDs used to index the JSONFileStorage are created at initialization,
+	// and may be added to by RegisterRelayerIDs. lock guards the maps themselves.
+	lock         sync.RWMutex
 	mutexes      map[common.Hash]*sync.RWMutex
 	logger       logging.Logger
 	currentState map[common.Hash]chainState
+
+	// Writes are batched if either of flushInterval or maxPendingWrites is non-zero. Otherwise, each Put
+	// is written to disk immediately. pendingLock guards dirty and pendingWrites.
+	flushInterval    time.Duration
+	maxPendingWrites int
+	pendingLock      sync.Mutex
+	dirty            map[common.Hash]struct{}
+	pendingWrites    int
 }
 
-// NewJSONFileStorage creates a new JSONFileStorage instance
+// NewJSONFileStorage creates a new JSONFileStorage instance that writes each Put to disk immediately
 func NewJSONFileStorage(logger logging.Logger, dir string, relayerIDs []RelayerID) (*JSONFileStorage, error) {
+	return NewBatchedJSONFileStorage(logger, dir, relayerIDs, 0, 0)
+}
+
+// NewBatchedJSONFileStorage creates a new JSONFileStorage instance that buffers writes in memory, and
+// writes them to disk every [flushInterval], or once [maxPendingWrites] writes are buffered, whichever
+// comes first. Either may be zero to disable that trigger. If both are zero, writes are not buffered.
+// Buffered writes are lost if the process exits without calling Flush.
+func NewBatchedJSONFileStorage(
+	logger logging.Logger,
+	dir string,
+	relayerIDs []RelayerID,
+	flushInterval time.Duration,
+	maxPendingWrites int,
+) (*JSONFileStorage, error) {
 	storage := &JSONFileStorage{
-		dir:          filepath.Clean(dir),
-		mutexes:      make(map[common.Hash]*sync.RWMutex),
-		logger:       logger,
-		currentState: make(map[common.Hash]chainState),
+		dir:              filepath.Clean(dir),
+		mutexes:          make(map[common.Hash]*sync.RWMutex),
+		logger:           logger,
+		currentState:     make(map[common.Hash]chainState),
+		flushInterval:    flushInterval,
+		maxPendingWrites: maxPendingWrites,
+		dirty:            make(map[common.Hash]struct{}),
+	}
+	if flushInterval != 0 {
+		go storage.flushPeriodically()
 	}
 
 	for _, relayerID := range relayerIDs {
@@ -79,9 +109,39 @@ func NewJSONFileStorage(logger logging.Logger, dir string, relayerIDs []RelayerI
 	return storage, nil
 }
 
-// Get the latest chain state from the JSON database, and retrieve the value from the key
-func (s *JSONFileStorage) Get(relayerID common.Hash, dataKey DataKey) ([]byte, error) {
+// RegisterRelayerIDs configures the storage for relayer IDs that were not known at initialization,
+// reading any existing state from disk. Relayer IDs that are already configured are ignored.
+func (s *JSONFileStorage) RegisterRelayerIDs(relayerIDs []RelayerID) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, relayerID := range relayerIDs {
+		key := relayerID.ID
+		if _, ok := s.mutexes[key]; ok {
+			continue
+		}
+		currentState, fileExists, err := s.getCurrentState(key)
+		if err != nil {
+			return err
+		}
+		if !fileExists {
+			currentState = make(chainState)
+		}
+		s.currentState[key] = currentState
+		s.mutexes[key] = &sync.RWMutex{}
+	}
+	return nil
+}
+
+func (s *JSONFileStorage) getMutex(relayerID common.Hash) (*sync.RWMutex, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
 	mutex, ok := s.mutexes[relayerID]
+	return mutex, ok
+}
+
+// Get the value of the key from the chain state, including writes not yet flushed to disk
+func (s *JSONFileStorage) Get(relayerID common.Hash, dataKey DataKey) ([]byte, error) {
+	mutex, ok := s.getMutex(relayerID)
 	if !ok {
 		return nil, errors.Wrap(
 			ErrDatabaseMisconfiguration,
@@ -91,11 +151,11 @@ func (s *JSONFileStorage) Get(relayerID common.Hash, dataKey DataKey) ([]byte, e
 
 	mutex.RLock()
 	defer mutex.RUnlock()
-	currentState, fileExists, err := s.getCurrentState(relayerID)
-	if err != nil {
-		return nil, err
-	}
-	if !fileExists {
+	s.lock.RLock()
+	currentState := s.currentState[relayerID]
+	s.lock.RUnlock()
+	// Every Put sets a key, so the state is only empty if nothing has been written for the relayer ID
+	if len(currentState) == 0 {
 		return nil, ErrRelayerIDNotFound
 	}
 
@@ -124,8 +184,9 @@ func (s *JSONFileStorage) getCurrentState(relayerID common.Hash) (chainState, bo
 
 // Put the value into the JSON database. Read the current chain state and overwrite the key, if it exists
 // If the file corresponding to {relayerID} does not exist, then it will be created
+// If writes are batched, the file is written on the next flush.
 func (s *JSONFileStorage) Put(relayerID common.Hash, dataKey DataKey, value []byte) error {
-	mutex, ok := s.mutexes[relayerID]
+	mutex, ok := s.getMutex(relayerID)
 	if !ok {
 		return errors.Wrap(
 			ErrDatabaseMisconfiguration,
@@ -134,11 +195,85 @@ func (s *JSONFileStorage) Put(relayerID common.Hash, dataKey DataKey, value []by
 	}
 
 	mutex.Lock()
-	defer mutex.Unlock()
+	s.lock.RLock()
+	currentState := s.currentState[relayerID]
+	s.lock.RUnlock()
 
 	// Update the in-memory state and write to disk
-	s.currentState[relayerID][dataKey.String()] = string(value)
-	return s.write(relayerID, s.currentState[relayerID])
+	currentState[dataKey.String()] = string(value)
+	if !s.batched() {
+		defer mutex.Unlock()
+		return s.write(relayerID, currentState)
+	}
+	mutex.Unlock()
+
+	// Flush without holding the relayer ID's mutex, since Flush acquires it
+	if s.addPendingWrite(relayerID) {
+		return s.Flush()
+	}
+	return nil
+}
+
+// Rewind is equivalent to Put, since JSONFileStorage does not guard against moving a checkpoint backwards.
+func (s *JSONFileStorage) Rewind(relayerID common.Hash, dataKey DataKey, value []byte) error {
+	return s.Put(relayerID, dataKey, value)
+}
+
+func (s *JSONFileStorage) batched() bool {
+	return s.flushInterval != 0 || s.maxPendingWrites != 0
+}
+
+// addPendingWrite marks the state of [relayerID] as not yet written to disk. Returns true if the maximum
+// number of pending writes has been reached.
+func (s *JSONFileStorage) addPendingWrite(relayerID common.Hash) bool {
+	s.pendingLock.Lock()
+	defer s.pendingLock.Unlock()
+	s.dirty[relayerID] = struct{}{}
+	s.pendingWrites++
+	return s.maxPendingWrites != 0 && s.pendingWrites >= s.maxPendingWrites
+}
+
+// Flush writes the state of each relayer ID with pending writes to disk. The state of relayer IDs that
+// fail to be written remains pending, and is retried on the next flush.
+func (s *JSONFileStorage) Flush() error {
+	s.pendingLock.Lock()
+	dirty := s.dirty
+	s.dirty = make(map[common.Hash]struct{})
+	s.pendingWrites = 0
+	s.pendingLock.Unlock()
+
+	var flushErr error
+	for relayerID := range dirty {
+		mutex, _ := s.getMutex(relayerID)
+		// Hold the write lock so that concurrent flushes of the same relayer ID do not interleave
+		mutex.Lock()
+		s.lock.RLock()
+		currentState := s.currentState[relayerID]
+		s.lock.RUnlock()
+		err := s.write(relayerID, currentState)
+		mutex.Unlock()
+		if err != nil {
+			s.logger.Error(
+				"failed to flush state",
+				zap.String("relayerID", relayerID.String()),
+				zap.Error(err),
+			)
+			s.pendingLock.Lock()
+			s.dirty[relayerID] = struct{}{}
+			s.pendingLock.Unlock()
+			flushErr = err
+		}
+	}
+	return flushErr
+}
+
+func (s *JSONFileStorage) flushPeriodically() {
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		// Errors are logged, and the writes retried on the next tick
+		_ = s.Flush()
+	}
 }
 
 func (s *JSONFileStorage) getFileName(relayerID common.Hash) string {
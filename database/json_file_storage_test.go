@@ -10,11 +10,13 @@ import (
 	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func createRelayerIDs(blockchainIDs []ids.ID) []RelayerID {
@@ -126,6 +128,32 @@ func TestConcurrentWriteReadMultipleChains(t *testing.T) {
 	}
 }
 
+// Test that relayer IDs registered after initialization can be written, and that their existing
+// state is read from disk.
+func TestRegisterRelayerIDs(t *testing.T) {
+	relayerIDs := createRelayerIDs([]ids.ID{ids.GenerateTestID()})
+	storageDir := t.TempDir()
+
+	previous, err := NewJSONFileStorage(logging.NoLog{}, storageDir, relayerIDs)
+	require.NoError(t, err)
+	require.NoError(t, previous.Put(relayerIDs[0].ID, LatestProcessedBlockKey, []byte("100")))
+
+	jsonStorage, err := NewJSONFileStorage(logging.NoLog{}, storageDir, nil)
+	require.NoError(t, err)
+	err = jsonStorage.Put(relayerIDs[0].ID, LatestProcessedBlockKey, []byte("101"))
+	require.ErrorIs(t, err, ErrDatabaseMisconfiguration)
+
+	require.NoError(t, RegisterRelayerIDs(jsonStorage, relayerIDs))
+	val, err := jsonStorage.Get(relayerIDs[0].ID, LatestProcessedBlockKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("100"), val)
+
+	require.NoError(t, jsonStorage.Put(relayerIDs[0].ID, LatestProcessedBlockKey, []byte("101")))
+	val, err = jsonStorage.Get(relayerIDs[0].ID, LatestProcessedBlockKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("101"), val)
+}
+
 func setupJsonStorage(t *testing.T, relayerIDs []RelayerID) *JSONFileStorage {
 	logger := logging.NewLogger(
 		"awm-relayer-test",
@@ -151,3 +179,47 @@ func testWrite(storage *JSONFileStorage, relayerID RelayerID, height uint64) {
 		return
 	}
 }
+
+func TestBatchedWrites(t *testing.T) {
+	relayerIDs := createRelayerIDs([]ids.ID{ids.GenerateTestID()})
+	relayerID := relayerIDs[0].ID
+	storageDir := t.TempDir()
+
+	// Flushes only once the maximum number of pending writes is reached
+	jsonStorage, err := NewBatchedJSONFileStorage(logging.NoLog{}, storageDir, relayerIDs, 0, 3)
+	require.NoError(t, err)
+	readFromDisk := func() ([]byte, error) {
+		storage, err := NewJSONFileStorage(logging.NoLog{}, storageDir, relayerIDs)
+		require.NoError(t, err)
+		return storage.Get(relayerID, LatestProcessedBlockKey)
+	}
+
+	require.NoError(t, jsonStorage.Put(relayerID, LatestProcessedBlockKey, []byte("100")))
+	require.NoError(t, jsonStorage.Put(relayerID, LatestProcessedBlockKey, []byte("101")))
+	// Reads see pending writes
+	val, err := jsonStorage.Get(relayerID, LatestProcessedBlockKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("101"), val)
+	_, err = readFromDisk()
+	require.ErrorIs(t, err, ErrRelayerIDNotFound)
+
+	require.NoError(t, jsonStorage.Put(relayerID, LatestProcessedBlockKey, []byte("102")))
+	val, err = readFromDisk()
+	require.NoError(t, err)
+	require.Equal(t, []byte("102"), val)
+
+	require.NoError(t, jsonStorage.Put(relayerID, LatestProcessedBlockKey, []byte("103")))
+	require.NoError(t, Flush(jsonStorage))
+	val, err = readFromDisk()
+	require.NoError(t, err)
+	require.Equal(t, []byte("103"), val)
+
+	// Flushes periodically
+	jsonStorage, err = NewBatchedJSONFileStorage(logging.NoLog{}, storageDir, relayerIDs, 10*time.Millisecond, 0)
+	require.NoError(t, err)
+	require.NoError(t, jsonStorage.Put(relayerID, LatestProcessedBlockKey, []byte("104")))
+	require.Eventually(t, func() bool {
+		val, err := readFromDisk()
+		return err == nil && string(val) == "104"
+	}, time.Second, 10*time.Millisecond)
+}
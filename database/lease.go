@@ -0,0 +1,120 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type leaseRecord struct {
+	// Identifies the relayer holding the lease
+	Holder string `json:"holder"`
+	// Unix timestamp, in milliseconds, after which the lease may be acquired by another relayer
+	Expiry int64 `json:"expiry"`
+}
+
+func (r leaseRecord) expired(now time.Time) bool {
+	return !now.Before(time.UnixMilli(r.Expiry))
+}
+
+// leaseAcquirer is implemented by databases that may be shared by multiple relayers, and that can atomically
+// acquire a lease.
+type leaseAcquirer interface {
+	// acquireLease stores [next] under the LeaseKey of [relayerID], unless the stored lease is held by
+	// another holder and has not expired as of [now]. Returns the lease stored once the operation completes.
+	acquireLease(relayerID common.Hash, next leaseRecord, now time.Time) (leaseRecord, error)
+}
+
+// Lease elects a leader among the relayers sharing a RelayerDatabase, for a single relayerID. The lease is
+// persisted under the LeaseKey, and is held by one relayer until it expires without being renewed.
+// If the database supports it, the lease is acquired atomically, so that at most one relayer holds it at a time.
+// Otherwise, the lease is read and then written, which is only safe if the database is not shared.
+type Lease struct {
+	db        RelayerDatabase
+	acquirer  leaseAcquirer
+	relayerID common.Hash
+	holder    string
+	duration  time.Duration
+	lock      *sync.Mutex
+	// The most recently read or written lease. The database is not queried again until it is due
+	// to be renewed, if held by this relayer, or until it expires otherwise.
+	current leaseRecord
+}
+
+// NewLease returns a Lease for [relayerID] backed by [db], acquired on behalf of [holder] for [duration] at a time.
+// If [db] is a NamespacedDatabase, the lease is stored in the backend configured for the LeaseKey.
+func NewLease(db RelayerDatabase, relayerID common.Hash, holder string, duration time.Duration) *Lease {
+	backend := db
+	if namespaced, ok := db.(*NamespacedDatabase); ok {
+		backend = namespaced.backend(LeaseKey)
+	}
+	acquirer, _ := backend.(leaseAcquirer)
+	return &Lease{
+		db:        db,
+		acquirer:  acquirer,
+		relayerID: relayerID,
+		holder:    holder,
+		duration:  duration,
+		lock:      &sync.Mutex{},
+	}
+}
+
+// TryAcquire acquires or renews the lease, unless it is held by another relayer. Returns true if the lease
+// is held by this relayer.
+func (l *Lease) TryAcquire() (bool, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := time.Now()
+	if l.current.Holder == l.holder {
+		// Renew once half of the lease duration has elapsed
+		if now.Before(time.UnixMilli(l.current.Expiry).Add(-l.duration / 2)) {
+			return true, nil
+		}
+	} else if l.current.Holder != "" && !l.current.expired(now) {
+		return false, nil
+	}
+
+	next := leaseRecord{
+		Holder: l.holder,
+		Expiry: now.Add(l.duration).UnixMilli(),
+	}
+	if l.acquirer != nil {
+		current, err := l.acquirer.acquireLease(l.relayerID, next, now)
+		if err != nil {
+			return false, err
+		}
+		l.current = current
+		return current.Holder == l.holder, nil
+	}
+
+	var current leaseRecord
+	value, err := l.db.Get(l.relayerID, LeaseKey)
+	if err != nil && !IsKeyNotFoundError(err) {
+		return false, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(value, &current); err != nil {
+			return false, err
+		}
+	}
+	if current.Holder != l.holder && current.Holder != "" && !current.expired(now) {
+		l.current = current
+		return false, nil
+	}
+
+	value, err = json.Marshal(next)
+	if err != nil {
+		return false, err
+	}
+	if err := l.db.Put(l.relayerID, LeaseKey, value); err != nil {
+		return false, err
+	}
+	l.current = next
+	return true, nil
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLease(t *testing.T) {
+	relayerIDs := createRelayerIDs([]ids.ID{ids.GenerateTestID()})
+	testCases := []struct {
+		name  string
+		newDB func(t *testing.T) RelayerDatabase
+	}{
+		{
+			name: "json",
+			newDB: func(t *testing.T) RelayerDatabase {
+				db, err := NewJSONFileStorage(logging.NoLog{}, t.TempDir(), relayerIDs)
+				require.NoError(t, err)
+				return db
+			},
+		},
+		{
+			name: "redis",
+			newDB: func(t *testing.T) RelayerDatabase {
+				return newTestRedisDatabase(t, miniredis.RunT(t))
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testLease(t, testCase.newDB(t), relayerIDs[0].ID)
+		})
+	}
+}
+
+func testLease(t *testing.T, db RelayerDatabase, relayerID common.Hash) {
+	primary := NewLease(db, relayerID, "primary", 50*time.Millisecond)
+	standby := NewLease(db, relayerID, "standby", 50*time.Millisecond)
+
+	// The first relayer to try acquires the lease
+	leader, err := primary.TryAcquire()
+	require.NoError(t, err)
+	require.True(t, leader)
+	leader, err = standby.TryAcquire()
+	require.NoError(t, err)
+	require.False(t, leader)
+
+	// The lease is renewed by the leader before it expires
+	time.Sleep(30 * time.Millisecond)
+	leader, err = primary.TryAcquire()
+	require.NoError(t, err)
+	require.True(t, leader)
+	time.Sleep(30 * time.Millisecond)
+	leader, err = standby.TryAcquire()
+	require.NoError(t, err)
+	require.False(t, leader)
+
+	// Once the leader stops renewing the lease, it is acquired by the standby
+	time.Sleep(60 * time.Millisecond)
+	leader, err = standby.TryAcquire()
+	require.NoError(t, err)
+	require.True(t, leader)
+	leader, err = primary.TryAcquire()
+	require.NoError(t, err)
+	require.False(t, leader)
+}
+
+// Test that a lease in a shared database is acquired by exactly one of the relayers trying to acquire it at once.
+func TestLeaseConcurrentAcquire(t *testing.T) {
+	server := miniredis.RunT(t)
+	relayerID := common.Hash(ids.GenerateTestID())
+
+	const numRelayers = 10
+	var (
+		wg      sync.WaitGroup
+		leaders = make(chan string, numRelayers)
+	)
+	for i := 0; i < numRelayers; i++ {
+		holder := fmt.Sprintf("relayer-%d", i)
+		lease := NewLease(newTestRedisDatabase(t, server), relayerID, holder, time.Minute)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			leader, err := lease.TryAcquire()
+			require.NoError(t, err)
+			if leader {
+				leaders <- holder
+			}
+		}()
+	}
+	wg.Wait()
+	close(leaders)
+	require.Len(t, leaders, 1)
+
+	// The lease is stored in the shared database, and is not acquired by the other relayers
+	holder := <-leaders
+	lease := NewLease(newTestRedisDatabase(t, server), relayerID, holder+"-other", time.Minute)
+	leader, err := lease.TryAcquire()
+	require.NoError(t, err)
+	require.False(t, leader)
+}
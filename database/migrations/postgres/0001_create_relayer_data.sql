@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS relayer_data (
+    relayer_id TEXT NOT NULL,
+    data_key TEXT NOT NULL,
+    value BYTEA NOT NULL,
+    PRIMARY KEY (relayer_id, data_key)
+);
@@ -67,3 +67,17 @@ func (mr *MockRelayerDatabaseMockRecorder) Put(relayerID, key, value any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockRelayerDatabase)(nil).Put), relayerID, key, value)
 }
+
+// Rewind mocks base method.
+func (m *MockRelayerDatabase) Rewind(relayerID common.Hash, key database.DataKey, value []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rewind", relayerID, key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rewind indicates an expected call of Rewind.
+func (mr *MockRelayerDatabaseMockRecorder) Rewind(relayerID, key, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rewind", reflect.TypeOf((*MockRelayerDatabase)(nil).Rewind), relayerID, key, value)
+}
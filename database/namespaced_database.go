@@ -0,0 +1,92 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ RelayerDatabase = &NamespacedDatabase{}
+
+// NamespacedDatabase dispatches each key to the backend configured for its namespace,
+// so that state with different access patterns may be kept in different stores.
+// Keys without a configured backend are stored in the default backend.
+type NamespacedDatabase struct {
+	defaultBackend RelayerDatabase
+	backends       map[DataKey]RelayerDatabase
+}
+
+func NewNamespacedDatabase(
+	defaultBackend RelayerDatabase,
+	backends map[DataKey]RelayerDatabase,
+) *NamespacedDatabase {
+	return &NamespacedDatabase{
+		defaultBackend: defaultBackend,
+		backends:       backends,
+	}
+}
+
+func (n *NamespacedDatabase) Get(relayerID common.Hash, key DataKey) ([]byte, error) {
+	return n.backend(key).Get(relayerID, key)
+}
+
+func (n *NamespacedDatabase) Put(relayerID common.Hash, key DataKey, value []byte) error {
+	return n.backend(key).Put(relayerID, key, value)
+}
+
+func (n *NamespacedDatabase) Rewind(relayerID common.Hash, key DataKey, value []byte) error {
+	return n.backend(key).Rewind(relayerID, key, value)
+}
+
+// RegisterRelayerIDs registers [relayerIDs] with each of the backends.
+func (n *NamespacedDatabase) RegisterRelayerIDs(relayerIDs []RelayerID) error {
+	if err := RegisterRelayerIDs(n.defaultBackend, relayerIDs); err != nil {
+		return err
+	}
+	for _, db := range n.backends {
+		if err := RegisterRelayerIDs(db, relayerIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush flushes the buffered writes of each of the backends.
+func (n *NamespacedDatabase) Flush() error {
+	if err := Flush(n.defaultBackend); err != nil {
+		return err
+	}
+	for _, db := range n.backends {
+		if err := Flush(db); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes each of the backends once, since a backend may be configured for more than one key.
+func (n *NamespacedDatabase) Close() error {
+	backends := []RelayerDatabase{n.defaultBackend}
+	for _, db := range n.backends {
+		backends = append(backends, db)
+	}
+	closed := make(map[RelayerDatabase]struct{}, len(backends))
+	for _, db := range backends {
+		if _, ok := closed[db]; ok {
+			continue
+		}
+		closed[db] = struct{}{}
+		if err := Close(db); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *NamespacedDatabase) backend(key DataKey) RelayerDatabase {
+	if db, ok := n.backends[key]; ok {
+		return db
+	}
+	return n.defaultBackend
+}
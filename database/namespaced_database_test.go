@@ -0,0 +1,49 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespacedDatabase(t *testing.T) {
+	relayerIDs := createRelayerIDs([]ids.ID{ids.GenerateTestID()})
+	relayerID := relayerIDs[0].ID
+
+	checkpointsDB, err := NewJSONFileStorage(logging.NoLog{}, t.TempDir(), relayerIDs)
+	require.NoError(t, err)
+	recordsDB, err := NewJSONFileStorage(logging.NoLog{}, t.TempDir(), relayerIDs)
+	require.NoError(t, err)
+
+	db := NewNamespacedDatabase(
+		checkpointsDB,
+		map[DataKey]RelayerDatabase{
+			AggregationsKey: recordsDB,
+		},
+	)
+	require.NoError(t, db.Put(relayerID, LatestProcessedBlockKey, []byte("100")))
+	require.NoError(t, db.Put(relayerID, AggregationsKey, []byte("{}")))
+
+	// Each key is only written to the backend for its namespace
+	val, err := checkpointsDB.Get(relayerID, LatestProcessedBlockKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("100"), val)
+	_, err = checkpointsDB.Get(relayerID, AggregationsKey)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	val, err = recordsDB.Get(relayerID, AggregationsKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("{}"), val)
+	_, err = recordsDB.Get(relayerID, LatestProcessedBlockKey)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	// Reads are dispatched the same way
+	val, err = db.Get(relayerID, AggregationsKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("{}"), val)
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var ErrNonceAllocationNotSupported = errors.New("database does not support shared nonce allocation")
+
+// nonceAllocator is implemented by databases that may be shared by multiple relayers, and that can atomically
+// allocate the nonces of an account. [accountID] identifies the account in place of a relayer ID.
+type nonceAllocator interface {
+	// allocateNonce returns the greater of [minNonce] and the next unallocated nonce, and records the nonce
+	// following the returned one as the next unallocated nonce.
+	allocateNonce(accountID common.Hash, minNonce uint64) (uint64, error)
+	// releaseNonce returns [nonce] to the allocator, if no greater nonce has been allocated since.
+	releaseNonce(accountID common.Hash, nonce uint64) error
+}
+
+// NonceAllocator hands out monotonically increasing nonces for the sender accounts of destination blockchains,
+// so that relayers sharing a signing key do not issue transactions with the same nonce. The next nonce of each
+// account is stored under the NonceKey of an ID derived from the destination blockchain ID and the address,
+// and is atomically incremented by the database on each allocation.
+type NonceAllocator struct {
+	db nonceAllocator
+}
+
+// NewNonceAllocator returns a NonceAllocator backed by [db], or ErrNonceAllocationNotSupported if [db] cannot
+// atomically allocate nonces. Only databases that may be shared by multiple relayers support nonce allocation.
+// If [db] is a NamespacedDatabase, nonces are allocated by the backend configured for the NonceKey.
+func NewNonceAllocator(db RelayerDatabase) (*NonceAllocator, error) {
+	if namespaced, ok := db.(*NamespacedDatabase); ok {
+		db = namespaced.backend(NonceKey)
+	}
+	allocator, ok := db.(nonceAllocator)
+	if !ok {
+		return nil, ErrNonceAllocationNotSupported
+	}
+	return &NonceAllocator{
+		db: allocator,
+	}, nil
+}
+
+// AllocateNonce returns the next nonce of [address] on [destinationBlockchainID]. The nonce is at least
+// [minNonce], which should be the nonce of the account known to the caller.
+func (a *NonceAllocator) AllocateNonce(
+	destinationBlockchainID ids.ID,
+	address common.Address,
+	minNonce uint64,
+) (uint64, error) {
+	return a.db.allocateNonce(nonceAccountID(destinationBlockchainID, address), minNonce)
+}
+
+// ReleaseNonce returns [nonce] of [address] on [destinationBlockchainID], which was allocated but not used,
+// so that it is allocated again. If a greater nonce has been allocated since, this is a no-op.
+func (a *NonceAllocator) ReleaseNonce(destinationBlockchainID ids.ID, address common.Address, nonce uint64) error {
+	return a.db.releaseNonce(nonceAccountID(destinationBlockchainID, address), nonce)
+}
+
+// nonceAccountID returns the ID under which the next nonce of [address] on [destinationBlockchainID] is stored.
+func nonceAccountID(destinationBlockchainID ids.ID, address common.Address) common.Hash {
+	return crypto.Keccak256Hash(destinationBlockchainID[:], address[:])
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// testNonceDatabase allocates nonces from an in-memory map, as the shared databases do
+type testNonceDatabase struct {
+	RelayerDatabase
+	next map[common.Hash]uint64
+}
+
+func (d *testNonceDatabase) allocateNonce(accountID common.Hash, minNonce uint64) (uint64, error) {
+	nonce := max(d.next[accountID], minNonce)
+	d.next[accountID] = nonce + 1
+	return nonce, nil
+}
+
+func (d *testNonceDatabase) releaseNonce(accountID common.Hash, nonce uint64) error {
+	if d.next[accountID] == nonce+1 {
+		d.next[accountID] = nonce
+	}
+	return nil
+}
+
+func TestNonceAllocator(t *testing.T) {
+	relayerIDs := createRelayerIDs([]ids.ID{ids.GenerateTestID()})
+	jsonDB, err := NewJSONFileStorage(logging.NoLog{}, t.TempDir(), relayerIDs)
+	require.NoError(t, err)
+
+	// JSON file storage is not shared by multiple relayers
+	_, err = NewNonceAllocator(jsonDB)
+	require.ErrorIs(t, err, ErrNonceAllocationNotSupported)
+
+	// Nonces are allocated by the backend configured for the NonceKey
+	nonceDB := &testNonceDatabase{RelayerDatabase: jsonDB, next: make(map[common.Hash]uint64)}
+	allocator, err := NewNonceAllocator(NewNamespacedDatabase(jsonDB, map[DataKey]RelayerDatabase{NonceKey: nonceDB}))
+	require.NoError(t, err)
+
+	blockchainID := ids.GenerateTestID()
+	address1 := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+	address2 := common.HexToAddress("0xabcdef0123456789abcdef0123456789abcdef01")
+	allocate := func(address common.Address, minNonce uint64) uint64 {
+		nonce, err := allocator.AllocateNonce(blockchainID, address, minNonce)
+		require.NoError(t, err)
+		return nonce
+	}
+
+	require.Equal(t, uint64(5), allocate(address1, 5))
+	require.Equal(t, uint64(6), allocate(address1, 5))
+	// Each account has its own nonces
+	require.Equal(t, uint64(0), allocate(address2, 0))
+	// A minimum nonce ahead of the allocated nonces skips ahead
+	require.Equal(t, uint64(10), allocate(address1, 10))
+
+	// The most recently allocated nonce is allocated again once released
+	require.NoError(t, allocator.ReleaseNonce(blockchainID, address1, 10))
+	require.Equal(t, uint64(10), allocate(address1, 0))
+	// Other nonces are not
+	require.NoError(t, allocator.ReleaseNonce(blockchainID, address1, 6))
+	require.Equal(t, uint64(11), allocate(address1, 0))
+
+	// Nonces are not allocated by JSON file storage configured as the default backend
+	_, err = NewNonceAllocator(NewNamespacedDatabase(jsonDB, map[DataKey]RelayerDatabase{AggregationsKey: nonceDB}))
+	require.ErrorIs(t, err, ErrNonceAllocationNotSupported)
+}
@@ -0,0 +1,263 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"sort"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	// Registers the Postgres driver with database/sql
+	_ "github.com/lib/pq"
+)
+
+// The name under which the Postgres driver is registered with database/sql
+const postgresDriverName = "postgres"
+
+//go:embed migrations/postgres/*.sql
+var postgresMigrations embed.FS
+
+var _ RelayerDatabase = &PostgresDatabase{}
+
+// Checkpoints are only ever advanced, so that relayer instances sharing the database do not move a
+// checkpoint backwards. Other keys are overwritten.
+const postgresPutQuery = `
+INSERT INTO relayer_data (relayer_id, data_key, value) VALUES ($1, $2, $3)
+ON CONFLICT (relayer_id, data_key) DO UPDATE SET value = EXCLUDED.value
+WHERE relayer_data.data_key <> $4
+	OR convert_from(relayer_data.value, 'UTF8')::numeric < convert_from(EXCLUDED.value, 'UTF8')::numeric`
+
+const postgresRewindQuery = `
+INSERT INTO relayer_data (relayer_id, data_key, value) VALUES ($1, $2, $3)
+ON CONFLICT (relayer_id, data_key) DO UPDATE SET value = EXCLUDED.value`
+
+// The lease is only replaced if it is held by the same holder, or has expired. No row is returned otherwise.
+const postgresAcquireLeaseQuery = `
+INSERT INTO relayer_data (relayer_id, data_key, value) VALUES ($1, $2, $3)
+ON CONFLICT (relayer_id, data_key) DO UPDATE SET value = EXCLUDED.value
+WHERE convert_from(relayer_data.value, 'UTF8')::jsonb->>'holder' IN ($4, '')
+	OR (convert_from(relayer_data.value, 'UTF8')::jsonb->>'expiry')::bigint <= $5
+RETURNING value`
+
+// The stored value is the next nonce to allocate. The allocated nonce is the greater of it and the minimum nonce.
+const postgresAllocateNonceQuery = `
+INSERT INTO relayer_data (relayer_id, data_key, value) VALUES ($1, $2, convert_to(($3::bigint + 1)::text, 'UTF8'))
+ON CONFLICT (relayer_id, data_key) DO UPDATE SET value = convert_to(
+	(GREATEST(convert_from(relayer_data.value, 'UTF8')::bigint, $3::bigint) + 1)::text,
+	'UTF8'
+)
+RETURNING convert_from(value, 'UTF8')::bigint - 1`
+
+// A released nonce is only allocated again if no greater nonce has been allocated since.
+const postgresReleaseNonceQuery = `
+UPDATE relayer_data SET value = convert_to($3::bigint::text, 'UTF8')
+WHERE relayer_id = $1 AND data_key = $2 AND convert_from(value, 'UTF8')::bigint = $3::bigint + 1`
+
+const postgresGetQuery = `SELECT value FROM relayer_data WHERE relayer_id = $1 AND data_key = $2`
+
+// PostgresDatabase stores relayer state in the relayer_data table of a Postgres database,
+// keyed on the relayer ID and data key.
+type PostgresDatabase struct {
+	logger logging.Logger
+	db     *sql.DB
+}
+
+// NewPostgresDatabase connects to the Postgres database at [postgresURL], with a connection pool of at most
+// [maxOpenConnections] connections, and applies the embedded migrations.
+func NewPostgresDatabase(
+	logger logging.Logger,
+	postgresURL string,
+	maxOpenConnections int,
+) (*PostgresDatabase, error) {
+	db, err := sql.Open(postgresDriverName, postgresURL)
+	if err != nil {
+		logger.Error(
+			"Failed to open Postgres database",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	db.SetMaxOpenConns(maxOpenConnections)
+	db.SetMaxIdleConns(maxOpenConnections)
+	return newPostgresDatabase(logger, db)
+}
+
+func newPostgresDatabase(logger logging.Logger, db *sql.DB) (*PostgresDatabase, error) {
+	if err := applyPostgresMigrations(context.Background(), db); err != nil {
+		logger.Error(
+			"Failed to apply Postgres migrations",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &PostgresDatabase{
+		logger: logger,
+		db:     db,
+	}, nil
+}
+
+// applyPostgresMigrations executes the embedded migrations in lexical order within a single transaction.
+// Migrations must be idempotent, since they are applied on every startup.
+func applyPostgresMigrations(ctx context.Context, db *sql.DB) error {
+	names, err := fs.Glob(postgresMigrations, "migrations/postgres/*.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		migration, err := postgresMigrations.ReadFile(name)
+		if err != nil {
+			return errors.Join(err, tx.Rollback())
+		}
+		if _, err := tx.ExecContext(ctx, string(migration)); err != nil {
+			return errors.Join(err, tx.Rollback())
+		}
+	}
+	return tx.Commit()
+}
+
+func (p *PostgresDatabase) Get(relayerID common.Hash, key DataKey) ([]byte, error) {
+	var value []byte
+	err := p.db.QueryRowContext(context.Background(), postgresGetQuery, relayerID.Hex(), key.String()).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrKeyNotFound
+	}
+	if err != nil {
+		p.logger.Debug(
+			"Error retrieving key from Postgres",
+			zap.String("relayerID", relayerID.Hex()),
+			zap.String("key", key.String()),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return value, nil
+}
+
+func (p *PostgresDatabase) Put(relayerID common.Hash, key DataKey, value []byte) error {
+	_, err := p.db.ExecContext(
+		context.Background(),
+		postgresPutQuery,
+		relayerID.Hex(),
+		key.String(),
+		value,
+		LatestProcessedBlockKey.String(),
+	)
+	if err != nil {
+		p.logger.Error(
+			"Error storing key in Postgres",
+			zap.String("relayerID", relayerID.Hex()),
+			zap.String("key", key.String()),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// Rewind stores the value without the guard applied to checkpoints by Put.
+func (p *PostgresDatabase) Rewind(relayerID common.Hash, key DataKey, value []byte) error {
+	_, err := p.db.ExecContext(context.Background(), postgresRewindQuery, relayerID.Hex(), key.String(), value)
+	if err != nil {
+		p.logger.Error(
+			"Error rewinding key in Postgres",
+			zap.String("relayerID", relayerID.Hex()),
+			zap.String("key", key.String()),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
+
+func (p *PostgresDatabase) acquireLease(
+	relayerID common.Hash,
+	next leaseRecord,
+	now time.Time,
+) (leaseRecord, error) {
+	value, err := json.Marshal(next)
+	if err != nil {
+		return leaseRecord{}, err
+	}
+	var stored []byte
+	err = p.db.QueryRowContext(
+		context.Background(),
+		postgresAcquireLeaseQuery,
+		relayerID.Hex(),
+		LeaseKey.String(),
+		value,
+		next.Holder,
+		now.UnixMilli(),
+	).Scan(&stored)
+	if errors.Is(err, sql.ErrNoRows) {
+		// The lease is held by another holder
+		stored, err = p.Get(relayerID, LeaseKey)
+	}
+	if err != nil {
+		p.logger.Error(
+			"Error acquiring lease in Postgres",
+			zap.String("relayerID", relayerID.Hex()),
+			zap.Error(err),
+		)
+		return leaseRecord{}, err
+	}
+	var current leaseRecord
+	if err := json.Unmarshal(stored, &current); err != nil {
+		return leaseRecord{}, err
+	}
+	return current, nil
+}
+
+func (p *PostgresDatabase) allocateNonce(accountID common.Hash, minNonce uint64) (uint64, error) {
+	var nonce int64
+	err := p.db.QueryRowContext(
+		context.Background(),
+		postgresAllocateNonceQuery,
+		accountID.Hex(),
+		NonceKey.String(),
+		int64(minNonce),
+	).Scan(&nonce)
+	if err != nil {
+		p.logger.Error(
+			"Error allocating nonce in Postgres",
+			zap.String("accountID", accountID.Hex()),
+			zap.Error(err),
+		)
+		return 0, err
+	}
+	return uint64(nonce), nil
+}
+
+func (p *PostgresDatabase) releaseNonce(accountID common.Hash, nonce uint64) error {
+	_, err := p.db.ExecContext(
+		context.Background(),
+		postgresReleaseNonceQuery,
+		accountID.Hex(),
+		NonceKey.String(),
+		int64(nonce),
+	)
+	if err != nil {
+		p.logger.Error(
+			"Error releasing nonce in Postgres",
+			zap.String("accountID", accountID.Hex()),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// If set, TestPostgresDatabaseSemantics runs against the Postgres database at this URL
+const postgresTestURLEnvVar = "AWM_RELAYER_TEST_POSTGRES_URL"
+
+// newMockPostgresDatabase returns a PostgresDatabase backed by a mock connection, on which the migrations have
+// been applied.
+func newMockPostgresDatabase(t *testing.T) (*PostgresDatabase, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, mock.ExpectationsWereMet())
+		db.Close()
+	})
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS relayer_data")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	postgresDB, err := newPostgresDatabase(logging.NoLog{}, db)
+	require.NoError(t, err)
+	return postgresDB, mock
+}
+
+func TestPostgresMigrations(t *testing.T) {
+	testCases := []struct {
+		name         string
+		migrationErr error
+	}{
+		{
+			name: "applied",
+		},
+		{
+			name:         "rolled back on failure",
+			migrationErr: errors.New("permission denied"),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			// Each migration is applied in a single transaction
+			mock.ExpectBegin()
+			exec := mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS relayer_data"))
+			if testCase.migrationErr != nil {
+				exec.WillReturnError(testCase.migrationErr)
+				mock.ExpectRollback()
+			} else {
+				exec.WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			}
+
+			_, err = newPostgresDatabase(logging.NoLog{}, db)
+			require.ErrorIs(t, err, testCase.migrationErr)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresGet(t *testing.T) {
+	relayerID := common.Hash(ids.GenerateTestID())
+	testCases := []struct {
+		name          string
+		rows          *sqlmock.Rows
+		queryErr      error
+		expectedValue []byte
+		expectedErr   error
+	}{
+		{
+			name:          "found",
+			rows:          sqlmock.NewRows([]string{"value"}).AddRow([]byte("100")),
+			expectedValue: []byte("100"),
+		},
+		{
+			name:        "not found",
+			rows:        sqlmock.NewRows([]string{"value"}),
+			expectedErr: ErrKeyNotFound,
+		},
+		{
+			name:        "query error",
+			queryErr:    sql.ErrConnDone,
+			expectedErr: sql.ErrConnDone,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			postgresDB, mock := newMockPostgresDatabase(t)
+			query := mock.ExpectQuery(regexp.QuoteMeta(postgresGetQuery)).
+				WithArgs(relayerID.Hex(), LatestProcessedBlockKey.String())
+			if testCase.queryErr != nil {
+				query.WillReturnError(testCase.queryErr)
+			} else {
+				query.WillReturnRows(testCase.rows)
+			}
+
+			value, err := postgresDB.Get(relayerID, LatestProcessedBlockKey)
+			require.ErrorIs(t, err, testCase.expectedErr)
+			require.Equal(t, testCase.expectedErr == ErrKeyNotFound, IsKeyNotFoundError(err))
+			require.Equal(t, testCase.expectedValue, value)
+		})
+	}
+}
+
+func TestPostgresPut(t *testing.T) {
+	relayerID := common.Hash(ids.GenerateTestID())
+	postgresDB, mock := newMockPostgresDatabase(t)
+
+	// Each key is upserted with the checkpoint key, so that only checkpoints are guarded against decreasing
+	mock.ExpectExec(regexp.QuoteMeta(postgresPutQuery)).
+		WithArgs(relayerID.Hex(), LatestProcessedBlockKey.String(), []byte("100"), LatestProcessedBlockKey.String()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(postgresPutQuery)).
+		WithArgs(relayerID.Hex(), AggregationsKey.String(), []byte("{}"), LatestProcessedBlockKey.String()).
+		WillReturnError(sql.ErrConnDone)
+
+	require.NoError(t, postgresDB.Put(relayerID, LatestProcessedBlockKey, []byte("100")))
+	require.ErrorIs(t, postgresDB.Put(relayerID, AggregationsKey, []byte("{}")), sql.ErrConnDone)
+}
+
+func TestPostgresRewind(t *testing.T) {
+	relayerID := common.Hash(ids.GenerateTestID())
+	postgresDB, mock := newMockPostgresDatabase(t)
+
+	// Rewinds are upserted without the guard against decreasing checkpoints
+	require.NotContains(t, postgresRewindQuery, "WHERE")
+	mock.ExpectExec(regexp.QuoteMeta(postgresRewindQuery)).
+		WithArgs(relayerID.Hex(), LatestProcessedBlockKey.String(), []byte("90")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, postgresDB.Rewind(relayerID, LatestProcessedBlockKey, []byte("90")))
+}
+
+func TestPostgresAcquireLease(t *testing.T) {
+	relayerID := common.Hash(ids.GenerateTestID())
+	now := time.UnixMilli(1_000_000)
+	held := leaseRecord{Holder: "primary", Expiry: now.Add(time.Minute).UnixMilli()}
+	heldValue, err := json.Marshal(held)
+	require.NoError(t, err)
+	next := leaseRecord{Holder: "standby", Expiry: now.Add(time.Minute).UnixMilli()}
+	nextValue, err := json.Marshal(next)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		acquired bool
+		expected leaseRecord
+	}{
+		{
+			name:     "acquired",
+			acquired: true,
+			expected: next,
+		},
+		{
+			name:     "held by another relayer",
+			expected: held,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			postgresDB, mock := newMockPostgresDatabase(t)
+			query := mock.ExpectQuery(regexp.QuoteMeta(postgresAcquireLeaseQuery)).
+				WithArgs(relayerID.Hex(), LeaseKey.String(), nextValue, next.Holder, now.UnixMilli())
+			if testCase.acquired {
+				query.WillReturnRows(sqlmock.NewRows([]string{"value"}).AddRow(nextValue))
+			} else {
+				// The conditional upsert returns no row, and the lease is read instead
+				query.WillReturnRows(sqlmock.NewRows([]string{"value"}))
+				mock.ExpectQuery(regexp.QuoteMeta(postgresGetQuery)).
+					WithArgs(relayerID.Hex(), LeaseKey.String()).
+					WillReturnRows(sqlmock.NewRows([]string{"value"}).AddRow(heldValue))
+			}
+
+			current, err := postgresDB.acquireLease(relayerID, next, now)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, current)
+		})
+	}
+}
+
+// TestPostgresDatabaseSemantics checks the behavior of the queries against a live Postgres database, and is
+// skipped unless one is provided via the AWM_RELAYER_TEST_POSTGRES_URL environment variable.
+func TestPostgresDatabaseSemantics(t *testing.T) {
+	postgresURL := os.Getenv(postgresTestURLEnvVar)
+	if postgresURL == "" {
+		t.Skipf("%s not set", postgresTestURLEnvVar)
+	}
+
+	postgresDB, err := NewPostgresDatabase(logging.NoLog{}, postgresURL, 2)
+	require.NoError(t, err)
+	// Migrations may be applied repeatedly
+	_, err = NewPostgresDatabase(logging.NoLog{}, postgresURL, 2)
+	require.NoError(t, err)
+
+	relayerID := common.Hash(ids.GenerateTestID())
+	_, err = postgresDB.Get(relayerID, LatestProcessedBlockKey)
+	require.True(t, IsKeyNotFoundError(err))
+
+	// Checkpoints are only advanced
+	for _, height := range []string{"100", "99", "1000", "101"} {
+		require.NoError(t, postgresDB.Put(relayerID, LatestProcessedBlockKey, []byte(height)))
+	}
+	value, err := postgresDB.Get(relayerID, LatestProcessedBlockKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("1000"), value)
+
+	// Checkpoints are moved backwards by Rewind, and advanced from there
+	require.NoError(t, postgresDB.Rewind(relayerID, LatestProcessedBlockKey, []byte("90")))
+	value, err = postgresDB.Get(relayerID, LatestProcessedBlockKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("90"), value)
+	require.NoError(t, postgresDB.Put(relayerID, LatestProcessedBlockKey, []byte("95")))
+	value, err = postgresDB.Get(relayerID, LatestProcessedBlockKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("95"), value)
+
+	// Other keys are overwritten
+	require.NoError(t, postgresDB.Put(relayerID, AggregationsKey, []byte("b")))
+	require.NoError(t, postgresDB.Put(relayerID, AggregationsKey, []byte("a")))
+	value, err = postgresDB.Get(relayerID, AggregationsKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("a"), value)
+
+	// Nonces are allocated from the greater of the stored and minimum nonces, and only the most recently
+	// allocated nonce may be released
+	accountID := common.Hash(ids.GenerateTestID())
+	for _, step := range []struct {
+		minNonce uint64
+		release  bool
+		expected uint64
+	}{
+		{minNonce: 5, expected: 5},
+		{minNonce: 0, expected: 6},
+		{minNonce: 0, release: true, expected: 7},
+		{minNonce: 0, expected: 7},
+		{minNonce: 10, expected: 10},
+	} {
+		nonce, err := postgresDB.allocateNonce(accountID, step.minNonce)
+		require.NoError(t, err)
+		require.Equal(t, step.expected, nonce)
+		if step.release {
+			require.NoError(t, postgresDB.releaseNonce(accountID, nonce))
+		}
+	}
+	// Releasing a nonce other than the most recently allocated one is a no-op
+	require.NoError(t, postgresDB.releaseNonce(accountID, 5))
+	nonce, err := postgresDB.allocateNonce(accountID, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), nonce)
+}
@@ -5,7 +5,10 @@ package database
 
 import (
 	"context"
+	"encoding/json"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ethereum/go-ethereum/common"
@@ -15,6 +18,50 @@ import (
 
 var _ RelayerDatabase = &RedisDatabase{}
 
+// setIfGreaterScript atomically stores the value only if it is greater than the currently stored value.
+// Multiple relayer replicas may share checkpoint state in the same Redis instance, and a replica that
+// lags behind the others must not move a checkpoint backwards.
+var setIfGreaterScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if current and tonumber(current) >= tonumber(ARGV[1]) then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[1])
+return 1
+`)
+
+// allocateNonceScript atomically returns the greater of the stored next nonce and the minimum nonce, and stores
+// the nonce following it as the next nonce.
+var allocateNonceScript = redis.NewScript(`
+local nonce = math.max(tonumber(redis.call("GET", KEYS[1]) or "0"), tonumber(ARGV[1]))
+redis.call("SET", KEYS[1], string.format("%d", nonce + 1))
+return nonce
+`)
+
+// releaseNonceScript atomically stores the released nonce as the next nonce, if it is the most recently
+// allocated nonce.
+var releaseNonceScript = redis.NewScript(`
+if tonumber(redis.call("GET", KEYS[1]) or "0") ~= tonumber(ARGV[1]) + 1 then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[1])
+return 1
+`)
+
+// acquireLeaseScript atomically stores the lease in ARGV[3], held by ARGV[1], unless the stored lease is held by
+// another holder and expires after the time ARGV[2], in Unix milliseconds. Returns the lease stored afterwards.
+var acquireLeaseScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if current then
+	local lease = cjson.decode(current)
+	if lease.holder ~= ARGV[1] and lease.holder ~= "" and tonumber(lease.expiry) > tonumber(ARGV[2]) then
+		return current
+	end
+end
+redis.call("SET", KEYS[1], ARGV[3])
+return ARGV[3]
+`)
+
 type RedisDatabase struct {
 	logger logging.Logger
 	client *redis.Client
@@ -61,8 +108,13 @@ func (r *RedisDatabase) Put(relayerID common.Hash, key DataKey, value []byte) er
 	ctx := context.Background()
 	compositeKey := constructCompositeKey(relayerID, key)
 
-	// Persistently store the value in Redis
-	err := r.client.Set(ctx, compositeKey, value, 0).Err()
+	// Persistently store the value in Redis. Checkpoints are only ever advanced.
+	var err error
+	if key == LatestProcessedBlockKey {
+		err = setIfGreaterScript.Run(ctx, r.client, []string{compositeKey}, string(value)).Err()
+	} else {
+		err = r.client.Set(ctx, compositeKey, value, 0).Err()
+	}
 	if err != nil {
 		r.logger.Error("Error storing key in Redis",
 			zap.String("key", compositeKey),
@@ -72,6 +124,79 @@ func (r *RedisDatabase) Put(relayerID common.Hash, key DataKey, value []byte) er
 	return nil
 }
 
+// Rewind stores the value without the guard applied to checkpoints by Put.
+func (r *RedisDatabase) Rewind(relayerID common.Hash, key DataKey, value []byte) error {
+	compositeKey := constructCompositeKey(relayerID, key)
+	if err := r.client.Set(context.Background(), compositeKey, value, 0).Err(); err != nil {
+		r.logger.Error("Error rewinding key in Redis",
+			zap.String("key", compositeKey),
+			zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (r *RedisDatabase) acquireLease(relayerID common.Hash, next leaseRecord, now time.Time) (leaseRecord, error) {
+	compositeKey := constructCompositeKey(relayerID, LeaseKey)
+	value, err := json.Marshal(next)
+	if err != nil {
+		return leaseRecord{}, err
+	}
+	stored, err := acquireLeaseScript.Run(
+		context.Background(),
+		r.client,
+		[]string{compositeKey},
+		next.Holder,
+		strconv.FormatInt(now.UnixMilli(), 10),
+		string(value),
+	).Text()
+	if err != nil {
+		r.logger.Error("Error acquiring lease in Redis",
+			zap.String("key", compositeKey),
+			zap.Error(err))
+		return leaseRecord{}, err
+	}
+	var current leaseRecord
+	if err := json.Unmarshal([]byte(stored), &current); err != nil {
+		return leaseRecord{}, err
+	}
+	return current, nil
+}
+
+func (r *RedisDatabase) allocateNonce(accountID common.Hash, minNonce uint64) (uint64, error) {
+	compositeKey := constructCompositeKey(accountID, NonceKey)
+	nonce, err := allocateNonceScript.Run(
+		context.Background(),
+		r.client,
+		[]string{compositeKey},
+		strconv.FormatUint(minNonce, 10),
+	).Uint64()
+	if err != nil {
+		r.logger.Error("Error allocating nonce in Redis",
+			zap.String("key", compositeKey),
+			zap.Error(err))
+		return 0, err
+	}
+	return nonce, nil
+}
+
+func (r *RedisDatabase) releaseNonce(accountID common.Hash, nonce uint64) error {
+	compositeKey := constructCompositeKey(accountID, NonceKey)
+	err := releaseNonceScript.Run(
+		context.Background(),
+		r.client,
+		[]string{compositeKey},
+		strconv.FormatUint(nonce, 10),
+	).Err()
+	if err != nil {
+		r.logger.Error("Error releasing nonce in Redis",
+			zap.String("key", compositeKey),
+			zap.Error(err))
+		return err
+	}
+	return nil
+}
+
 func constructCompositeKey(relayerID common.Hash, key DataKey) string {
 	const keyDelimiter = "-"
 	return strings.Join([]string{relayerID.Hex(), key.String()}, keyDelimiter)
@@ -0,0 +1,169 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRedisDatabase returns a RedisDatabase connected to [server]
+func newTestRedisDatabase(t *testing.T, server *miniredis.Miniredis) *RedisDatabase {
+	db, err := NewRedisDatabase(logging.NoLog{}, "redis://"+server.Addr(), nil)
+	require.NoError(t, err)
+	return db
+}
+
+func TestRedisDatabaseGetPut(t *testing.T) {
+	server := miniredis.RunT(t)
+	db := newTestRedisDatabase(t, server)
+	relayerID := common.Hash(ids.GenerateTestID())
+
+	_, err := db.Get(relayerID, LatestProcessedBlockKey)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	require.True(t, IsKeyNotFoundError(err))
+
+	// Values are stored under the relayer ID and data key
+	require.NoError(t, db.Put(relayerID, AggregationsKey, []byte("b")))
+	stored, err := server.Get(relayerID.Hex() + "-" + AggregationsKey.String())
+	require.NoError(t, err)
+	require.Equal(t, "b", stored)
+
+	// Other keys are overwritten
+	require.NoError(t, db.Put(relayerID, AggregationsKey, []byte("a")))
+	value, err := db.Get(relayerID, AggregationsKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("a"), value)
+
+	// Errors other than a missing key are not reported as such
+	server.Close()
+	_, err = db.Get(relayerID, AggregationsKey)
+	require.Error(t, err)
+	require.False(t, IsKeyNotFoundError(err))
+}
+
+func TestRedisDatabaseCheckpoints(t *testing.T) {
+	testCases := []struct {
+		name     string
+		heights  []uint64
+		expected uint64
+	}{
+		{
+			name:     "increasing",
+			heights:  []uint64{100, 101, 102},
+			expected: 102,
+		},
+		{
+			name:     "decreasing",
+			heights:  []uint64{100, 99},
+			expected: 100,
+		},
+		{
+			// Heights are compared numerically, rather than as strings
+			name:     "more digits",
+			heights:  []uint64{99, 1000, 101},
+			expected: 1000,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			db := newTestRedisDatabase(t, miniredis.RunT(t))
+			relayerID := common.Hash(ids.GenerateTestID())
+			for _, height := range testCase.heights {
+				require.NoError(t, db.Put(relayerID, LatestProcessedBlockKey, []byte(strconv.FormatUint(height, 10))))
+			}
+			value, err := db.Get(relayerID, LatestProcessedBlockKey)
+			require.NoError(t, err)
+			require.Equal(t, strconv.FormatUint(testCase.expected, 10), string(value))
+		})
+	}
+}
+
+// Test that replicas sharing the database never move a checkpoint backwards, regardless of the order in which
+// their writes are applied.
+func TestRedisDatabaseConcurrentCheckpoints(t *testing.T) {
+	server := miniredis.RunT(t)
+	relayerID := common.Hash(ids.GenerateTestID())
+
+	const (
+		numReplicas = 4
+		numHeights  = 50
+	)
+	var wg sync.WaitGroup
+	for i := 0; i < numReplicas; i++ {
+		db := newTestRedisDatabase(t, server)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for height := 1; height <= numHeights; height++ {
+				require.NoError(t, db.Put(relayerID, LatestProcessedBlockKey, []byte(strconv.Itoa(height))))
+			}
+		}()
+	}
+	wg.Wait()
+
+	value, err := newTestRedisDatabase(t, server).Get(relayerID, LatestProcessedBlockKey)
+	require.NoError(t, err)
+	require.Equal(t, strconv.Itoa(numHeights), string(value))
+}
+
+func TestRedisDatabaseNonces(t *testing.T) {
+	server := miniredis.RunT(t)
+	db := newTestRedisDatabase(t, server)
+	accountID := common.Hash(ids.GenerateTestID())
+
+	// Nonces are allocated from the greater of the stored and minimum nonces, and only the most recently
+	// allocated nonce may be released
+	for _, step := range []struct {
+		minNonce uint64
+		release  bool
+		expected uint64
+	}{
+		{minNonce: 5, expected: 5},
+		{minNonce: 0, expected: 6},
+		{minNonce: 0, release: true, expected: 7},
+		{minNonce: 0, expected: 7},
+		{minNonce: 10, expected: 10},
+	} {
+		nonce, err := db.allocateNonce(accountID, step.minNonce)
+		require.NoError(t, err)
+		require.Equal(t, step.expected, nonce)
+		if step.release {
+			require.NoError(t, db.releaseNonce(accountID, nonce))
+		}
+	}
+	require.NoError(t, db.releaseNonce(accountID, 5))
+	nonce, err := db.allocateNonce(accountID, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), nonce)
+
+	// Concurrent allocations by replicas sharing the database are distinct
+	const numAllocations = 20
+	var (
+		wg     sync.WaitGroup
+		lock   sync.Mutex
+		nonces = make(map[uint64]struct{})
+	)
+	for i := 0; i < numAllocations; i++ {
+		replica := newTestRedisDatabase(t, server)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonce, err := replica.allocateNonce(accountID, 0)
+			require.NoError(t, err)
+			lock.Lock()
+			defer lock.Unlock()
+			nonces[nonce] = struct{}{}
+		}()
+	}
+	wg.Wait()
+	require.Len(t, nonces, numAllocations)
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrUnsupportedStateKey is returned when importing a value for a key that is not part of the relayer state
+var ErrUnsupportedStateKey = errors.New("unsupported state key")
+
+// stateKeys are the keys of the relayer state that is exported and imported. Leases are not included, since
+// they are held by a single running relayer, and nonces are not stored per relayer ID.
+var stateKeys = []DataKey{
+	LatestProcessedBlockKey,
+	AggregationsKey,
+	DeliveredMessagesKey,
+	DeadLettersKey,
+}
+
+// StateEntry is a value stored in the relayer database, used to migrate the relayer's state between backends.
+// Values are exported as stored, so may be imported into any backend.
+type StateEntry struct {
+	RelayerID common.Hash   `json:"relayer-id"`
+	Key       string        `json:"key"`
+	Value     hexutil.Bytes `json:"value"`
+}
+
+// ExportState returns each of the values stored in [db] for [relayerIDs]. Keys without a stored value are omitted.
+func ExportState(db RelayerDatabase, relayerIDs []RelayerID) ([]*StateEntry, error) {
+	entries := []*StateEntry{}
+	for _, relayerID := range relayerIDs {
+		for _, key := range stateKeys {
+			value, err := db.Get(relayerID.ID, key)
+			if IsKeyNotFoundError(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s of relayer %s: %w", key, relayerID.ID, err)
+			}
+			entries = append(entries, &StateEntry{
+				RelayerID: relayerID.ID,
+				Key:       key.String(),
+				Value:     value,
+			})
+		}
+	}
+	return entries, nil
+}
+
+// ImportState writes [entries] to [db], replacing the values already stored for their relayer IDs and keys.
+// The entries are validated before any is written, and buffered writes are flushed once all are written.
+func ImportState(db RelayerDatabase, entries []*StateEntry) error {
+	keys := make([]DataKey, len(entries))
+	for i, entry := range entries {
+		key, ok := parseStateKey(entry.Key)
+		if !ok {
+			return fmt.Errorf("%w %q for relayer %s", ErrUnsupportedStateKey, entry.Key, entry.RelayerID)
+		}
+		keys[i] = key
+	}
+	for i, entry := range entries {
+		if err := db.Put(entry.RelayerID, keys[i], entry.Value); err != nil {
+			return fmt.Errorf("failed to write %s of relayer %s: %w", keys[i], entry.RelayerID, err)
+		}
+	}
+	return Flush(db)
+}
+
+func parseStateKey(key string) (DataKey, bool) {
+	for _, stateKey := range stateKeys {
+		if stateKey.String() == key {
+			return stateKey, true
+		}
+	}
+	return 0, false
+}
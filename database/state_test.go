@@ -0,0 +1,63 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportState(t *testing.T) {
+	relayerIDs := createRelayerIDs([]ids.ID{ids.GenerateTestID(), ids.GenerateTestID()})
+	relayerID1 := relayerIDs[0].ID
+	relayerID2 := relayerIDs[1].ID
+
+	sourceDB, err := NewJSONFileStorage(logging.NoLog{}, t.TempDir(), relayerIDs)
+	require.NoError(t, err)
+	require.NoError(t, sourceDB.Put(relayerID1, LatestProcessedBlockKey, []byte("100")))
+	require.NoError(t, sourceDB.Put(relayerID1, DeadLettersKey, []byte("[]")))
+	require.NoError(t, sourceDB.Put(relayerID2, LatestProcessedBlockKey, []byte("200")))
+	// Leases are held by the running relayer, so are not exported
+	require.NoError(t, sourceDB.Put(relayerID2, LeaseKey, []byte("{}")))
+
+	entries, err := ExportState(sourceDB, relayerIDs)
+	require.NoError(t, err)
+	require.ElementsMatch(
+		t,
+		[]*StateEntry{
+			{RelayerID: relayerID1, Key: LatestProcessedBlockKey.String(), Value: []byte("100")},
+			{RelayerID: relayerID1, Key: DeadLettersKey.String(), Value: []byte("[]")},
+			{RelayerID: relayerID2, Key: LatestProcessedBlockKey.String(), Value: []byte("200")},
+		},
+		entries,
+	)
+
+	// Imported values replace those already stored
+	targetDB, err := NewJSONFileStorage(logging.NoLog{}, t.TempDir(), relayerIDs)
+	require.NoError(t, err)
+	require.NoError(t, targetDB.Put(relayerID2, LatestProcessedBlockKey, []byte("50")))
+	require.NoError(t, ImportState(targetDB, entries))
+	for _, entry := range entries {
+		key, ok := parseStateKey(entry.Key)
+		require.True(t, ok)
+		value, err := targetDB.Get(entry.RelayerID, key)
+		require.NoError(t, err)
+		require.Equal(t, []byte(entry.Value), value)
+	}
+	_, err = targetDB.Get(relayerID2, LeaseKey)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	// Nothing is written if any entry is invalid
+	err = ImportState(targetDB, []*StateEntry{
+		{RelayerID: relayerID1, Key: LatestProcessedBlockKey.String(), Value: []byte("300")},
+		{RelayerID: relayerID1, Key: LeaseKey.String(), Value: []byte("{}")},
+	})
+	require.ErrorIs(t, err, ErrUnsupportedStateKey)
+	value, err := targetDB.Get(relayerID1, LatestProcessedBlockKey)
+	require.NoError(t, err)
+	require.Equal(t, []byte("100"), value)
+}
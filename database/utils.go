@@ -21,28 +21,34 @@ func IsKeyNotFoundError(err error) bool {
 //   - In this case, we return the maximum of the latest processed block and the
 //     configured processHistoricalBlocksFromHeight.
 //
-// 2) The database has been configured for the chain, but does not contain the latest processed block data
-//   - In this case, we return the configured processHistoricalBlocksFromHeight
-//
-// 3) The database does not contain any information for the chain.
-//   - In this case, we return the configured processHistoricalBlocksFromHeight if it is set, otherwise
-//     we return the chain head.
+// 2) The database does not contain the latest processed block data for the chain
+//   - In this case, we return the configured processHistoricalBlocksFromHeight if it is set, otherwise the
+//     configured startBlockHeight if it is set, otherwise the chain head.
 func CalculateStartingBlockHeight(
 	logger logging.Logger,
 	db RelayerDatabase,
 	relayerID RelayerID,
 	processHistoricalBlocksFromHeight uint64,
+	startBlockHeight uint64,
 	currentHeight uint64,
 ) (uint64, error) {
 	latestProcessedBlock, err := GetLatestProcessedBlockHeight(db, relayerID)
 	if IsKeyNotFoundError(err) {
 		// The database does not contain the latest processed block data for the chain,
-		// use the configured process-historical-blocks-from-height instead.
-		// If process-historical-blocks-from-height was not configured, start from the chain head.
-		if processHistoricalBlocksFromHeight == 0 {
-			return currentHeight, nil
+		// use the configured process-historical-blocks-from-height or start-block-height instead.
+		// If neither was configured, start from the chain head.
+		if processHistoricalBlocksFromHeight != 0 {
+			return processHistoricalBlocksFromHeight, nil
+		}
+		if startBlockHeight != 0 {
+			logger.Info(
+				"Processing blocks from the configured start block height",
+				zap.String("relayerID", relayerID.ID.String()),
+				zap.Uint64("startBlockHeight", startBlockHeight),
+			)
+			return startBlockHeight, nil
 		}
-		return processHistoricalBlocksFromHeight, nil
+		return currentHeight, nil
 	} else if err != nil {
 		// Otherwise, we've encountered an unknown database error
 		logger.Error(
@@ -15,6 +15,7 @@ func TestCalculateStartingBlockHeight(t *testing.T) {
 	testCases := []struct {
 		name          string
 		cfgBlock      uint64
+		startBlock    uint64
 		dbBlock       uint64
 		dbError       error
 		expectedBlock uint64
@@ -65,6 +66,19 @@ func TestCalculateStartingBlockHeight(t *testing.T) {
 			expectedBlock: currentBlock,
 			expectedError: nil,
 		},
+		{
+			name:          "start block, no value in db",
+			startBlock:    100,
+			dbError:       ErrKeyNotFound,
+			expectedBlock: 100,
+		},
+		{
+			// The start block only applies if there is no checkpoint, even if it is later than the checkpoint
+			name:          "start block ignored with value in db",
+			startBlock:    150,
+			dbBlock:       100,
+			expectedBlock: 100,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -73,7 +87,14 @@ func TestCalculateStartingBlockHeight(t *testing.T) {
 			return []byte(strconv.FormatUint(testCase.dbBlock, 10)), testCase.dbError
 		}
 
-		ret, err := CalculateStartingBlockHeight(logging.NoLog{}, db, RelayerID{}, testCase.cfgBlock, currentBlock)
+		ret, err := CalculateStartingBlockHeight(
+			logging.NoLog{},
+			db,
+			RelayerID{},
+			testCase.cfgBlock,
+			testCase.startBlock,
+			currentBlock,
+		)
 		if testCase.expectedError == nil {
 			require.NoError(t, err, fmt.Sprintf("test failed: %s", testCase.name))
 			require.Equal(t, testCase.expectedBlock, ret, fmt.Sprintf("test failed: %s", testCase.name))
@@ -95,3 +116,7 @@ func (m *mockDB) Get(relayerID common.Hash, key DataKey) ([]byte, error) {
 func (m *mockDB) Put(relayerID common.Hash, key DataKey, value []byte) error {
 	return nil
 }
+
+func (m *mockDB) Rewind(relayerID common.Hash, key DataKey, value []byte) error {
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/database"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/pflag"
+)
+
+// keysCommand is the subcommand that prints the relayer IDs under which the relayer stores its state
+const keysCommand = "keys"
+
+const keysUsageText = `
+Usage:
+awm-relayer keys source-blockchain-id destination-blockchain-id origin-sender-address destination-address
+    Print the relayer ID of the source and destination blockchains and addresses.
+    Use the zero address to match any address.
+awm-relayer keys --config-file path-to-config
+    Print the relayer IDs of all relayers configured in the config file.
+`
+
+// runKeysCommand prints relayer IDs to [out], as calculated by the running relayer. [args] are the
+// arguments following the subcommand.
+func runKeysCommand(out io.Writer, args []string) error {
+	fs := pflag.NewFlagSet("awm-relayer keys", pflag.ContinueOnError)
+	fs.String(config.ConfigFileKey, "", "Specifies the relayer config file")
+	if err := fs.Parse(args); err != nil {
+		fmt.Print(keysUsageText)
+		return fmt.Errorf("couldn't parse flags: %w", err)
+	}
+
+	if fs.Changed(config.ConfigFileKey) {
+		v, err := config.BuildViper(fs)
+		if err != nil {
+			return fmt.Errorf("couldn't configure flags: %w", err)
+		}
+		cfg, err := config.BuildConfig(v)
+		if err != nil {
+			return fmt.Errorf("couldn't build config: %w", err)
+		}
+		// Relayer IDs are calculated from the source blockchains alone, so the rest of the configuration,
+		// such as the destination signing keys, is not validated.
+		destinationBlockchainIDs := set.NewSet[string](len(cfg.DestinationBlockchains))
+		for _, destinationBlockchain := range cfg.DestinationBlockchains {
+			destinationBlockchainIDs.Add(destinationBlockchain.BlockchainID)
+		}
+		for i, sourceBlockchain := range cfg.SourceBlockchains {
+			if err := sourceBlockchain.Validate(&destinationBlockchainIDs); err != nil {
+				return fmt.Errorf(
+					"invalid source-blockchains[%d] (blockchain-id '%s'): %w",
+					i,
+					sourceBlockchain.BlockchainID,
+					err,
+				)
+			}
+		}
+		for _, relayerID := range database.GetConfigRelayerIDs(&cfg) {
+			fmt.Fprintf(
+				out,
+				"%s source=%s destination=%s origin-sender=%s destination-address=%s\n",
+				relayerID.ID.Hex(),
+				relayerID.SourceBlockchainID,
+				relayerID.DestinationBlockchainID,
+				relayerID.OriginSenderAddress,
+				relayerID.DestinationAddress,
+			)
+		}
+		return nil
+	}
+
+	if fs.NArg() != 4 {
+		fmt.Print(keysUsageText)
+		return fmt.Errorf("expected 4 arguments, got %d", fs.NArg())
+	}
+	sourceBlockchainID, err := ids.FromString(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("invalid source blockchain ID '%s': %w", fs.Arg(0), err)
+	}
+	destinationBlockchainID, err := ids.FromString(fs.Arg(1))
+	if err != nil {
+		return fmt.Errorf("invalid destination blockchain ID '%s': %w", fs.Arg(1), err)
+	}
+	if !common.IsHexAddress(fs.Arg(2)) {
+		return fmt.Errorf("invalid origin sender address '%s'", fs.Arg(2))
+	}
+	if !common.IsHexAddress(fs.Arg(3)) {
+		return fmt.Errorf("invalid destination address '%s'", fs.Arg(3))
+	}
+	relayerID := database.CalculateRelayerID(
+		sourceBlockchainID,
+		destinationBlockchainID,
+		common.HexToAddress(fs.Arg(2)),
+		common.HexToAddress(fs.Arg(3)),
+	)
+	fmt.Fprintln(out, relayerID.Hex())
+	return nil
+}
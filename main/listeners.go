@@ -0,0 +1,125 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/relayer"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"go.uber.org/atomic"
+)
+
+// sourceListeners runs a Listener for each source blockchain. Each Listener may be started and stopped
+// independently of the others, so that source blockchains can be added and removed when the configuration
+// is reloaded.
+type sourceListeners struct {
+	logger              logging.Logger
+	metrics             *relayer.ListenerMetrics
+	messageCoordinator  *relayer.MessageCoordinator
+	processMissedBlocks bool
+	// Receives the error of the first Listener to exit other than by being stopped
+	errChan chan error
+
+	lock    sync.Mutex
+	health  map[ids.ID]*atomic.Bool
+	cancels map[ids.ID]context.CancelFunc
+}
+
+func newSourceListeners(
+	logger logging.Logger,
+	metrics *relayer.ListenerMetrics,
+	messageCoordinator *relayer.MessageCoordinator,
+	processMissedBlocks bool,
+) *sourceListeners {
+	return &sourceListeners{
+		logger:              logger,
+		metrics:             metrics,
+		messageCoordinator:  messageCoordinator,
+		processMissedBlocks: processMissedBlocks,
+		errChan:             make(chan error, 1),
+		health:              make(map[ids.ID]*atomic.Bool),
+		cancels:             make(map[ids.ID]context.CancelFunc),
+	}
+}
+
+// Start runs a Listener for [sourceBlockchain], starting from [minHeight]. Any Listener already
+// running for the source blockchain is stopped.
+func (l *sourceListeners) Start(sourceBlockchain *config.SourceBlockchain, ethClient ethclient.Client, minHeight uint64) {
+	blockchainID := sourceBlockchain.GetBlockchainID()
+	ctx, cancel := context.WithCancel(context.Background())
+	health := atomic.NewBool(true)
+
+	l.lock.Lock()
+	if previousCancel, ok := l.cancels[blockchainID]; ok {
+		previousCancel()
+	}
+	l.cancels[blockchainID] = cancel
+	l.health[blockchainID] = health
+	l.lock.Unlock()
+
+	go func() {
+		// RunListener runs until it errors or the context is canceled
+		err := relayer.RunListener(
+			ctx,
+			l.logger,
+			l.metrics,
+			*sourceBlockchain,
+			ethClient,
+			health,
+			l.processMissedBlocks,
+			minHeight,
+			l.messageCoordinator,
+		)
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+		select {
+		case l.errChan <- err:
+		default:
+		}
+	}()
+}
+
+// Stop stops the Listener for the source blockchain [blockchainID], if one is running.
+func (l *sourceListeners) Stop(blockchainID ids.ID) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if cancel, ok := l.cancels[blockchainID]; ok {
+		cancel()
+	}
+	delete(l.cancels, blockchainID)
+	delete(l.health, blockchainID)
+}
+
+// StopAll stops all running Listeners
+func (l *sourceListeners) StopAll() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for blockchainID, cancel := range l.cancels {
+		cancel()
+		delete(l.cancels, blockchainID)
+		delete(l.health, blockchainID)
+	}
+}
+
+// Health returns the health of each running Listener
+func (l *sourceListeners) Health() map[ids.ID]*atomic.Bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	health := make(map[ids.ID]*atomic.Bool, len(l.health))
+	for blockchainID, h := range l.health {
+		health[blockchainID] = h
+	}
+	return health
+}
+
+// Wait blocks until a Listener exits with an error, and returns that error.
+func (l *sourceListeners) Wait() error {
+	return <-l.errChan
+}
@@ -9,31 +9,38 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
 	"runtime"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/awm-relayer/api"
 	"github.com/ava-labs/awm-relayer/config"
 	"github.com/ava-labs/awm-relayer/database"
 	"github.com/ava-labs/awm-relayer/messages"
-	offchainregistry "github.com/ava-labs/awm-relayer/messages/off-chain-registry"
+	// Register the built-in message protocols
+	_ "github.com/ava-labs/awm-relayer/messages/off-chain-registry"
+	_ "github.com/ava-labs/awm-relayer/messages/raw"
 	"github.com/ava-labs/awm-relayer/messages/teleporter"
 	"github.com/ava-labs/awm-relayer/peers"
+	"github.com/ava-labs/awm-relayer/peers/validators"
 	"github.com/ava-labs/awm-relayer/relayer"
 	"github.com/ava-labs/awm-relayer/utils"
 	"github.com/ava-labs/awm-relayer/vms"
+	"github.com/ava-labs/awm-relayer/vms/evm"
 	"github.com/ava-labs/subnet-evm/ethclient"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"go.uber.org/atomic"
 	"go.uber.org/zap"
-	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -41,6 +48,21 @@ import (
 var version = "v0.0.0-dev"
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == keysCommand {
+		if err := runKeysCommand(os.Stdout, os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if len(os.Args) > 1 && os.Args[1] == preflightCommand {
+		if err := runPreflightCommand(os.Stdout, os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	fs := config.BuildFlagSet()
 	if err := fs.Parse(os.Args[1:]); err != nil {
 		config.DisplayUsageText()
@@ -84,15 +106,21 @@ func main() {
 	if err != nil {
 		panic(fmt.Errorf("error with log level: %w", err))
 	}
+	logFormat, err := cfg.GetLogFormat()
+	if err != nil {
+		panic(fmt.Errorf("error with log format: %w", err))
+	}
 
-	logger := logging.NewLogger(
-		"awm-relayer",
-		logging.NewWrappedCore(
-			logLevel,
-			os.Stdout,
-			logging.JSON.ConsoleEncoder(),
-		),
+	logCore := logging.NewWrappedCore(
+		logLevel,
+		os.Stdout,
+		logFormat.ConsoleEncoder(),
 	)
+	// Limit the rate of routine logs, such as those logged for each relayed message
+	if cfg.LogSampling != nil {
+		logCore.Core = utils.NewSamplingCore(logCore.Core, cfg.LogSampling.GetRates())
+	}
+	logger := logging.NewLogger("awm-relayer", logCore)
 
 	logger.Info("Initializing awm-relayer")
 	overwrittenLog := ""
@@ -101,26 +129,69 @@ func main() {
 	}
 	logger.Info(fmt.Sprintf("Set config options.%s", overwrittenLog))
 
-	// Initialize all destination clients
+	// Initialize metrics gathered through prometheus
+	gatherer, registerer, err := initializeMetrics()
+	if err != nil {
+		logger.Fatal("Failed to set up prometheus metrics", zap.Error(err))
+		panic(err)
+	}
+
+	destinationClientMetrics, err := evm.NewDestinationClientMetrics(registerer)
+	if err != nil {
+		logger.Fatal("Failed to create destination client metrics", zap.Error(err))
+		panic(err)
+	}
+
+	// Initialize the database
+	db, err := database.NewDatabase(logger, &cfg)
+	if err != nil {
+		logger.Fatal("Failed to create database", zap.Error(err))
+		panic(err)
+	}
+
+	// Nonces are allocated via the database for destinations whose signing keys are shared with other relayers
+	var nonceAllocator vms.NonceAllocator
+	if cfg.RedisURL != "" || cfg.PostgresURL != "" {
+		nonceAllocator, err = database.NewNonceAllocator(db)
+		if err != nil {
+			logger.Fatal("Failed to create nonce allocator", zap.Error(err))
+			panic(err)
+		}
+	}
+
+	// Initialize all destination clients. Their background goroutines are stopped once the relayer has shut down.
 	logger.Info("Initializing destination clients")
-	destinationClients, err := vms.CreateDestinationClients(logger, cfg)
+	clientsCtx, stopClients := context.WithCancel(context.Background())
+	defer stopClients()
+	destinationClients, err := vms.CreateDestinationClients(
+		clientsCtx,
+		logger,
+		destinationClientMetrics,
+		cfg,
+		nonceAllocator,
+	)
 	if err != nil {
 		logger.Fatal("Failed to create destination clients", zap.Error(err))
 		panic(err)
 	}
 
+	// Initialize shadow clients for destinations with a shadow endpoint
+	shadowClients, err := vms.CreateShadowDestinationClients(clientsCtx, logger, cfg)
+	if err != nil {
+		logger.Fatal("Failed to create shadow destination clients", zap.Error(err))
+		panic(err)
+	}
+
 	// Initialize all source clients
 	logger.Info("Initializing source clients")
-	sourceClients, err := createSourceClients(context.Background(), logger, &cfg)
+	sourceClientMetrics, err := evm.NewSourceClientMetrics(registerer)
 	if err != nil {
-		logger.Fatal("Failed to create source clients", zap.Error(err))
+		logger.Fatal("Failed to create source client metrics", zap.Error(err))
 		panic(err)
 	}
-
-	// Initialize metrics gathered through prometheus
-	gatherer, registerer, err := initializeMetrics()
+	sourceClients, err := createSourceClients(context.Background(), logger, sourceClientMetrics, &cfg)
 	if err != nil {
-		logger.Fatal("Failed to set up prometheus metrics", zap.Error(err))
+		logger.Fatal("Failed to create source clients", zap.Error(err))
 		panic(err)
 	}
 
@@ -128,14 +199,20 @@ func main() {
 	logger.Info("Initializing app request network")
 	// The app request network generates P2P networking logs that are verbose at the info level.
 	// Unless the log level is debug or lower, set the network log level to error to avoid spamming the logs.
-	// We do not collect metrics for the network.
+	// We do not collect metrics for the underlying p2p network.
 	networkLogLevel := logging.Error
 	if logLevel <= logging.Debug {
 		networkLogLevel = logLevel
 	}
+	networkMetrics, err := peers.NewAppRequestNetworkMetrics(registerer)
+	if err != nil {
+		logger.Fatal("Failed to create app request network metrics", zap.Error(err))
+		panic(err)
+	}
 	network, err := peers.NewNetwork(
 		networkLogLevel,
 		prometheus.DefaultRegisterer,
+		networkMetrics,
 		&cfg,
 	)
 	if err != nil {
@@ -164,19 +241,10 @@ func main() {
 		panic(err)
 	}
 
-	// Initialize the database
-	db, err := database.NewDatabase(logger, &cfg)
-	if err != nil {
-		logger.Fatal("Failed to create database", zap.Error(err))
-		panic(err)
-	}
-
 	// Initialize the global write ticker
 	ticker := utils.NewTicker(cfg.DBWriteIntervalSeconds)
 	go ticker.Run()
 
-	relayerHealth := createHealthTrackers(&cfg)
-
 	deciderConnection, err := createDeciderConnection(cfg.DeciderURL)
 	if err != nil {
 		logger.Fatal(
@@ -190,12 +258,20 @@ func main() {
 		logger,
 		&cfg,
 		deciderConnection,
+		sourceClients,
 	)
 	if err != nil {
 		logger.Fatal("Failed to create message handler factories", zap.Error(err))
 		panic(err)
 	}
 
+	sendQueues := createSendQueues(&cfg)
+	pendingMessages := createPendingMessages(&cfg, relayerMetrics)
+	signatureCache := peers.NewSignatureCache(
+		networkMetrics,
+		cfg.SignatureCacheSize,
+		time.Duration(cfg.SignatureCacheTTLSeconds)*time.Second,
+	)
 	applicationRelayers, minHeights, err := createApplicationRelayers(
 		context.Background(),
 		logger,
@@ -207,22 +283,111 @@ func main() {
 		&cfg,
 		sourceClients,
 		destinationClients,
+		shadowClients,
+		sendQueues,
+		pendingMessages,
+		signatureCache,
 	)
 	if err != nil {
 		logger.Fatal("Failed to create application relayers", zap.Error(err))
 		panic(err)
 	}
+	messageCoordinatorMetrics, err := relayer.NewMessageCoordinatorMetrics(registerer)
+	if err != nil {
+		logger.Fatal("Failed to create message coordinator metrics", zap.Error(err))
+		panic(err)
+	}
+	validatorClient := validators.NewCanonicalValidatorClient(logger, cfg.PChainAPI)
+	// Check the stake of sources with a configured minimum before relaying any of their messages
+	sourceStakeMonitor := relayer.NewSourceStakeMonitor(
+		logger,
+		messageCoordinatorMetrics,
+		validatorClient,
+		&cfg,
+	)
+	if sourceStakeMonitor != nil {
+		sourceStakeMonitor.CheckStake()
+		go sourceStakeMonitor.Run(context.Background())
+	}
+
+	tracer, err := createTracer(&cfg)
+	if err != nil {
+		logger.Fatal("Failed to create tracer", zap.Error(err))
+		panic(err)
+	}
+	if tracer != nil {
+		// Export the spans of the messages relayed before exiting
+		defer tracer.Close()
+	}
+
+	var deadLetters *database.DeadLetterStore
+	if cfg.PersistDeadLetters {
+		deadLetters = database.NewDeadLetterStore(db)
+	}
 	messageCoordinator := relayer.NewMessageCoordinator(
 		logger,
+		messageCoordinatorMetrics,
 		messageHandlerFactories,
 		applicationRelayers,
 		sourceClients,
+		sourceStakeMonitor,
+		tracer,
+		deadLetters,
 	)
 
+	// Relay the messages in the relay file in place of subscribing to the source blockchains
+	if cfg.RelayFile != "" {
+		if failed := relayMessagesFromFile(logger, &cfg, messageCoordinator); failed > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Warn when the relayer falls behind sources with a configured maximum block lag
+	blockLagMonitor := relayer.NewBlockLagMonitor(logger, messageCoordinatorMetrics, messageCoordinator, &cfg)
+	if blockLagMonitor != nil {
+		go blockLagMonitor.Run(context.Background())
+	}
+
+	// Monitor the configured routes end to end by periodically sending heartbeat messages over them
+	heartbeatMonitor, err := relayer.NewHeartbeatMonitor(logger, messageCoordinatorMetrics, messageCoordinator, &cfg)
+	if err != nil {
+		logger.Fatal("Failed to create heartbeat monitor", zap.Error(err))
+		panic(err)
+	}
+	if heartbeatMonitor != nil {
+		go heartbeatMonitor.Run(context.Background())
+	}
+
+	listenerMetrics, err := relayer.NewListenerMetrics(registerer)
+	if err != nil {
+		logger.Fatal("Failed to create listener metrics", zap.Error(err))
+		panic(err)
+	}
+
 	// Each Listener goroutine will have an atomic bool that it can set to false to indicate an unrecoverable error
-	api.HandleHealthCheck(logger, relayerHealth)
+	listeners := newSourceListeners(logger, listenerMetrics, messageCoordinator, cfg.ProcessMissedBlocks)
+	api.HandleHealthCheck(logger, listeners.Health, sourceStakeMonitor.Health(), validatorClient)
 	api.HandleRelay(logger, messageCoordinator)
 	api.HandleRelayMessage(logger, messageCoordinator)
+	api.HandleRoutes(logger, messageCoordinator)
+	api.HandleDeliveryStatus(logger, messageCoordinator)
+	api.HandleCheckpoints(logger, messageCoordinator)
+	api.HandleStateExport(logger, db, database.GetConfigRelayerIDs(&cfg))
+	if cfg.EnableStateImport {
+		api.HandleStateImport(logger, db)
+	}
+	api.HandleValidators(logger, network, &cfg)
+	if cfg.PersistAggregations {
+		api.HandleAggregations(
+			logger,
+			database.NewAggregationStore(db, time.Duration(cfg.AggregationRetentionSeconds)*time.Second),
+			database.GetConfigRelayerIDs(&cfg),
+		)
+	}
+	if deadLetters != nil {
+		api.HandleDeadLetters(logger, messageCoordinator, deadLetters, database.GetConfigRelayerIDs(&cfg))
+	}
 
 	// start the health check server
 	go func() {
@@ -230,99 +395,208 @@ func main() {
 	}()
 
 	// Create listeners for each of the subnets configured as a source
-	errGroup, ctx := errgroup.WithContext(context.Background())
-	for _, s := range cfg.SourceBlockchains {
-		sourceBlockchain := s
-
-		// errgroup will cancel the context when the first goroutine returns an error
-		errGroup.Go(func() error {
-			// runListener runs until it errors or the context is canceled by another goroutine
-			return relayer.RunListener(
-				ctx,
-				logger,
-				*sourceBlockchain,
-				sourceClients[sourceBlockchain.GetBlockchainID()],
-				relayerHealth[sourceBlockchain.GetBlockchainID()],
-				cfg.ProcessMissedBlocks,
-				minHeights[sourceBlockchain.GetBlockchainID()],
-				messageCoordinator,
-			)
-		})
+	for _, sourceBlockchain := range cfg.SourceBlockchains {
+		listeners.Start(
+			sourceBlockchain,
+			sourceClients[sourceBlockchain.GetBlockchainID()],
+			minHeights[sourceBlockchain.GetBlockchainID()],
+		)
+	}
+
+	// Apply changes to the configuration file on SIGHUP
+	reloader := &configReloader{
+		logger:              logger,
+		v:                   v,
+		cfg:                 &cfg,
+		running:             cfg,
+		listeners:           listeners,
+		messageCoordinator:  messageCoordinator,
+		sourceClientMetrics: sourceClientMetrics,
+		destinationClients:  destinationClients,
+		shadowClients:       shadowClients,
+		sendQueues:          sendQueues,
+		pendingMessages:     pendingMessages,
+		signatureCache:      signatureCache,
+		relayerMetrics:      relayerMetrics,
+		db:                  db,
+		ticker:              ticker,
+		network:             network,
+		messageCreator:      messageCreator,
+		deciderConnection:   deciderConnection,
+	}
+	shutdownCtx, stopSignals := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+	go reloader.Run(shutdownCtx)
+
+	// Bound the progress lost should the relayer exit unexpectedly
+	if interval := cfg.GetCheckpointFlushInterval(); interval != 0 {
+		go flushCheckpointsPeriodically(shutdownCtx, logger, messageCoordinator, db, interval)
+	}
+
+	// Flush Teleporter receipts that have been queued on idle routes
+	for _, sourceBlockchain := range cfg.SourceBlockchains {
+		receiptFlushers, err := teleporter.NewReceiptFlushers(logger, sourceBlockchain, destinationClients)
+		if err != nil {
+			logger.Fatal("Failed to create receipt flushers", zap.Error(err))
+			panic(err)
+		}
+		for _, receiptFlusher := range receiptFlushers {
+			go receiptFlusher.Run(shutdownCtx)
+		}
+	}
+
+	// Run until the first Listener errors, or the relayer is signaled to shut down
+	listenerErr := make(chan error, 1)
+	go func() {
+		listenerErr <- listeners.Wait()
+	}()
+	select {
+	case err := <-listenerErr:
+		logger.Error("Listener exited with error. Shutting down.", zap.Error(err))
+	case <-shutdownCtx.Done():
+		logger.Info("Received shutdown signal. Draining in-flight messages.")
+	}
+	stopSignals()
+
+	// Stop accepting new blocks, and wait for the messages already being relayed to be delivered and checkpointed
+	listeners.StopAll()
+	drained, abandoned := messageCoordinator.Shutdown(time.Duration(cfg.ShutdownTimeoutSeconds) * time.Second)
+	if err := database.Flush(db); err != nil {
+		logger.Error("Failed to flush database", zap.Error(err))
 	}
-	err = errGroup.Wait()
-	logger.Error("Relayer exiting.", zap.Error(err))
+	if err := database.Close(db); err != nil {
+		logger.Error("Failed to close database", zap.Error(err))
+	}
+	logger.Info(
+		"Relayer exiting.",
+		zap.Int64("drainedMessages", drained),
+		zap.Int64("abandonedMessages", abandoned),
+	)
 }
 
 func createMessageHandlerFactories(
 	logger logging.Logger,
 	globalConfig *config.Config,
 	deciderConnection *grpc.ClientConn,
+	sourceClients map[ids.ID]ethclient.Client,
 ) (map[ids.ID]map[common.Address]messages.MessageHandlerFactory, error) {
+	featureFlags := createFeatureFlagClient(logger, globalConfig)
 	messageHandlerFactories := make(map[ids.ID]map[common.Address]messages.MessageHandlerFactory)
 	for _, sourceBlockchain := range globalConfig.SourceBlockchains {
-		messageHandlerFactoriesForSource := make(map[common.Address]messages.MessageHandlerFactory)
-		// Create message handler factories for each supported message protocol
-		for addressStr, cfg := range sourceBlockchain.MessageContracts {
-			address := common.HexToAddress(addressStr)
-			format := cfg.MessageFormat
-			var (
-				m   messages.MessageHandlerFactory
-				err error
-			)
-			switch config.ParseMessageProtocol(format) {
-			case config.TELEPORTER:
-				m, err = teleporter.NewMessageHandlerFactory(
-					logger,
-					address,
-					cfg,
-					deciderConnection,
-				)
-			case config.OFF_CHAIN_REGISTRY:
-				m, err = offchainregistry.NewMessageHandlerFactory(
-					logger,
-					cfg,
-				)
-			default:
-				m, err = nil, fmt.Errorf("invalid message format %s", format)
-			}
-			if err != nil {
-				logger.Error("Failed to create message handler factory", zap.Error(err))
-				return nil, err
-			}
-			messageHandlerFactoriesForSource[address] = m
+		messageHandlerFactoriesForSource, err := createMessageHandlerFactoriesForSourceChain(
+			logger,
+			sourceBlockchain,
+			deciderConnection,
+			sourceClients[sourceBlockchain.GetBlockchainID()],
+			featureFlags,
+		)
+		if err != nil {
+			return nil, err
 		}
 		messageHandlerFactories[sourceBlockchain.GetBlockchainID()] = messageHandlerFactoriesForSource
 	}
 	return messageHandlerFactories, nil
 }
 
+// createTracer returns the tracer that exports the spans of each relayed Warp message,
+// or nil if tracing is not configured
+func createTracer(globalConfig *config.Config) (trace.Tracer, error) {
+	if globalConfig.Tracing == nil {
+		return nil, nil
+	}
+	exporterType, err := globalConfig.Tracing.GetExporterType()
+	if err != nil {
+		return nil, err
+	}
+	return trace.New(trace.Config{
+		ExporterConfig: trace.ExporterConfig{
+			Type:     exporterType,
+			Endpoint: globalConfig.Tracing.Endpoint,
+			Headers:  globalConfig.Tracing.Headers,
+			Insecure: globalConfig.Tracing.Insecure,
+		},
+		Enabled:         true,
+		TraceSampleRate: globalConfig.Tracing.GetSampleRate(),
+		AppName:         "awm-relayer",
+		Version:         version,
+	})
+}
+
+// createFeatureFlagClient returns the feature flag client, or nil if no feature flags are configured
+func createFeatureFlagClient(logger logging.Logger, globalConfig *config.Config) *messages.FeatureFlagClient {
+	if globalConfig.FeatureFlags == nil {
+		return nil
+	}
+	return messages.NewFeatureFlagClient(logger, globalConfig.FeatureFlags)
+}
+
+// createMessageHandlerFactoriesForSourceChain creates a message handler factory for each supported
+// message protocol of a given source blockchain.
+func createMessageHandlerFactoriesForSourceChain(
+	logger logging.Logger,
+	sourceBlockchain *config.SourceBlockchain,
+	deciderConnection *grpc.ClientConn,
+	sourceClient ethclient.Client,
+	featureFlags *messages.FeatureFlagClient,
+) (map[common.Address]messages.MessageHandlerFactory, error) {
+	messageHandlerFactories := make(map[common.Address]messages.MessageHandlerFactory)
+	for addressStr, cfg := range sourceBlockchain.MessageContracts {
+		address := common.HexToAddress(addressStr)
+		// Message protocols register their factories when their package is imported
+		m, err := messages.NewManager(messages.ManagerFactoryParams{
+			Logger:            logger,
+			Address:           address,
+			Config:            cfg,
+			DeciderConnection: deciderConnection,
+			SourceClient:      sourceClient,
+			FeatureFlags:      featureFlags,
+		})
+		if err != nil {
+			logger.Error("Failed to create message handler factory", zap.Error(err))
+			return nil, err
+		}
+		messageHandlerFactories[address] = m
+	}
+	return messageHandlerFactories, nil
+}
+
 func createSourceClients(
 	ctx context.Context,
 	logger logging.Logger,
+	metrics *evm.SourceClientMetrics,
 	cfg *config.Config,
 ) (map[ids.ID]ethclient.Client, error) {
 	var err error
 	clients := make(map[ids.ID]ethclient.Client)
 
 	for _, sourceBlockchain := range cfg.SourceBlockchains {
-		clients[sourceBlockchain.GetBlockchainID()], err = utils.NewEthClientWithConfig(
-			ctx,
-			sourceBlockchain.RPCEndpoint.BaseURL,
-			sourceBlockchain.RPCEndpoint.HTTPHeaders,
-			sourceBlockchain.RPCEndpoint.QueryParams,
-		)
+		clients[sourceBlockchain.GetBlockchainID()], err = createSourceClient(ctx, logger, metrics, sourceBlockchain)
 		if err != nil {
-			logger.Error(
-				"Failed to connect to node via RPC",
-				zap.String("blockchainID", sourceBlockchain.BlockchainID),
-				zap.Error(err),
-			)
 			return nil, err
 		}
 	}
 	return clients, nil
 }
 
+func createSourceClient(
+	ctx context.Context,
+	logger logging.Logger,
+	metrics *evm.SourceClientMetrics,
+	sourceBlockchain *config.SourceBlockchain,
+) (ethclient.Client, error) {
+	client, err := evm.NewSourceClient(ctx, logger, metrics, sourceBlockchain)
+	if err != nil {
+		logger.Error(
+			"Failed to connect to node via RPC",
+			zap.String("blockchainID", sourceBlockchain.BlockchainID),
+			zap.Object("endpoint", &sourceBlockchain.RPCEndpoint),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return client, nil
+}
+
 // Returns a map of application relayers, as well as a map of source blockchain IDs to starting heights.
 func createApplicationRelayers(
 	ctx context.Context,
@@ -335,14 +609,25 @@ func createApplicationRelayers(
 	cfg *config.Config,
 	sourceClients map[ids.ID]ethclient.Client,
 	destinationClients map[ids.ID]vms.DestinationClient,
+	shadowClients map[ids.ID]vms.DestinationClient,
+	sendQueues map[ids.ID]*relayer.SendQueue,
+	pendingMessages map[ids.ID]*relayer.PendingMessages,
+	signatureCache *peers.SignatureCache,
 ) (map[common.Hash]*relayer.ApplicationRelayer, map[ids.ID]uint64, error) {
 	applicationRelayers := make(map[common.Hash]*relayer.ApplicationRelayer)
 	minHeights := make(map[ids.ID]uint64)
+
 	for _, sourceBlockchain := range cfg.SourceBlockchains {
-		currentHeight, err := sourceClients[sourceBlockchain.GetBlockchainID()].BlockNumber(ctx)
-		if err != nil {
-			logger.Error("Failed to get current block height", zap.Error(err))
-			return nil, nil, err
+		// Messages relayed from a file are not checkpointed, so the source blockchain, which may be
+		// unavailable, is not queried for its height.
+		var currentHeight uint64
+		if cfg.RelayFile == "" {
+			var err error
+			currentHeight, err = sourceClients[sourceBlockchain.GetBlockchainID()].BlockNumber(ctx)
+			if err != nil {
+				logger.Error("Failed to get current block height", zap.Error(err))
+				return nil, nil, err
+			}
 		}
 
 		// Create the ApplicationRelayers
@@ -358,6 +643,10 @@ func createApplicationRelayers(
 			cfg,
 			currentHeight,
 			destinationClients,
+			shadowClients,
+			sendQueues,
+			pendingMessages,
+			signatureCache,
 		)
 		if err != nil {
 			logger.Error(
@@ -381,6 +670,38 @@ func createApplicationRelayers(
 	return applicationRelayers, minHeights, nil
 }
 
+// createSendQueues returns the limit on concurrent sends of each destination blockchain that has one.
+// All ApplicationRelayers for a destination blockchain share the same limit.
+func createSendQueues(cfg *config.Config) map[ids.ID]*relayer.SendQueue {
+	sendQueues := make(map[ids.ID]*relayer.SendQueue)
+	for _, destinationBlockchain := range cfg.DestinationBlockchains {
+		if destinationBlockchain.MaxConcurrentSends > 0 {
+			sendQueues[destinationBlockchain.GetBlockchainID()] = relayer.NewSendQueue(
+				destinationBlockchain.MaxConcurrentSends,
+				!destinationBlockchain.DisableSendPrioritization,
+			)
+		}
+	}
+	return sendQueues
+}
+
+// createPendingMessages returns the tracker of the messages pending delivery to each destination blockchain.
+// All ApplicationRelayers for a destination blockchain share the same tracker.
+func createPendingMessages(
+	cfg *config.Config,
+	metrics *relayer.ApplicationRelayerMetrics,
+) map[ids.ID]*relayer.PendingMessages {
+	pendingMessages := make(map[ids.ID]*relayer.PendingMessages)
+	for _, destinationBlockchain := range cfg.DestinationBlockchains {
+		pendingMessages[destinationBlockchain.GetBlockchainID()] = relayer.NewPendingMessages(
+			metrics,
+			destinationBlockchain.GetBlockchainID(),
+			destinationBlockchain.MaxPendingMessages,
+		)
+	}
+	return pendingMessages
+}
+
 // createApplicationRelayers creates Application Relayers for a given source blockchain.
 func createApplicationRelayersForSourceChain(
 	ctx context.Context,
@@ -394,6 +715,10 @@ func createApplicationRelayersForSourceChain(
 	cfg *config.Config,
 	currentHeight uint64,
 	destinationClients map[ids.ID]vms.DestinationClient,
+	shadowClients map[ids.ID]vms.DestinationClient,
+	sendQueues map[ids.ID]*relayer.SendQueue,
+	pendingMessages map[ids.ID]*relayer.PendingMessages,
+	signatureCache *peers.SignatureCache,
 ) (map[common.Hash]*relayer.ApplicationRelayer, uint64, error) {
 	// Create the ApplicationRelayers
 	logger.Info(
@@ -402,17 +727,30 @@ func createApplicationRelayersForSourceChain(
 	)
 	applicationRelayers := make(map[common.Hash]*relayer.ApplicationRelayer)
 
+	// All ApplicationRelayers for the source blockchain share the same limit on concurrent signature aggregations
+	var aggregationSemaphore *semaphore.Weighted
+	if sourceBlockchain.MaxConcurrentAggregations > 0 {
+		aggregationSemaphore = semaphore.NewWeighted(int64(sourceBlockchain.MaxConcurrentAggregations))
+	}
+
 	// Each ApplicationRelayer determines its starting height based on the database state.
 	// The Listener begins processing messages starting from the minimum height across all the ApplicationRelayers
 	minHeight := uint64(0)
 	for _, relayerID := range database.GetSourceBlockchainRelayerIDs(&sourceBlockchain) {
-		height, err := database.CalculateStartingBlockHeight(
-			logger,
-			db,
-			relayerID,
-			sourceBlockchain.ProcessHistoricalBlocksFromHeight,
-			currentHeight,
-		)
+		// A bounded range of historical blocks is processed independently of the latest processed block,
+		// so the ApplicationRelayers checkpoint from the chain head.
+		height := currentHeight
+		var err error
+		if !sourceBlockchain.HasHistoricalBlockRange() {
+			height, err = database.CalculateStartingBlockHeight(
+				logger,
+				db,
+				relayerID,
+				sourceBlockchain.ProcessHistoricalBlocksFromHeight,
+				sourceBlockchain.StartBlockHeight,
+				currentHeight,
+			)
+		}
 		if err != nil {
 			logger.Error(
 				"Failed to calculate starting block height",
@@ -433,8 +771,13 @@ func createApplicationRelayersForSourceChain(
 			db,
 			ticker,
 			destinationClients[relayerID.DestinationBlockchainID],
+			shadowClients[relayerID.DestinationBlockchainID],
 			sourceBlockchain,
 			height,
+			aggregationSemaphore,
+			sendQueues[relayerID.DestinationBlockchainID],
+			pendingMessages[relayerID.DestinationBlockchainID],
+			signatureCache,
 			cfg,
 		)
 		if err != nil {
@@ -476,14 +819,6 @@ func createDeciderConnection(url string) (*grpc.ClientConn, error) {
 	return connection, nil
 }
 
-func createHealthTrackers(cfg *config.Config) map[ids.ID]*atomic.Bool {
-	healthTrackers := make(map[ids.ID]*atomic.Bool, len(cfg.SourceBlockchains))
-	for _, sourceBlockchain := range cfg.SourceBlockchains {
-		healthTrackers[sourceBlockchain.GetBlockchainID()] = atomic.NewBool(true)
-	}
-	return healthTrackers
-}
-
 func startMetricsServer(logger logging.Logger, gatherer prometheus.Gatherer, port uint16) {
 	http.Handle("/metrics", promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
 
@@ -502,3 +837,27 @@ func initializeMetrics() (prometheus.Gatherer, prometheus.Registerer, error) {
 	}
 	return gatherer, registry, nil
 }
+
+// flushCheckpointsPeriodically writes the committed heights to the database, and the database's buffered writes
+// to its backing store, on each [interval] until [ctx] is canceled. Heights are flushed on shutdown regardless.
+func flushCheckpointsPeriodically(
+	ctx context.Context,
+	logger logging.Logger,
+	messageCoordinator *relayer.MessageCoordinator,
+	db database.RelayerDatabase,
+	interval time.Duration,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			messageCoordinator.FlushCheckpoints()
+			if err := database.Flush(db); err != nil {
+				logger.Error("Failed to flush database", zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,307 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/peers/validators"
+	"github.com/ava-labs/awm-relayer/utils"
+	"github.com/ava-labs/awm-relayer/vms/evm/signer"
+	"github.com/spf13/pflag"
+)
+
+// preflightCommand is the subcommand that checks connectivity to the endpoints configured in the config file
+const preflightCommand = "preflight"
+
+const preflightUsageText = `
+Usage:
+awm-relayer preflight --config-file path-to-config
+    Check connectivity to the P-Chain API and the source and destination RPC endpoints, that each
+    blockchain is validated by its configured subnet, and that each destination account is funded.
+    Exits with a nonzero status if any check fails.
+`
+
+// preflightCheckTimeout bounds each of the network requests made by a preflight check
+const preflightCheckTimeout = 10 * time.Second
+
+// preflightResult is the outcome of a single preflight check
+type preflightResult struct {
+	check  string
+	target string
+	err    error
+	detail string
+}
+
+// runPreflightCommand checks the endpoints configured in the config file, and prints a table of the results
+// to [out]. Returns an error if any check fails. [args] are the arguments following the subcommand.
+func runPreflightCommand(out io.Writer, args []string) error {
+	fs := pflag.NewFlagSet("awm-relayer preflight", pflag.ContinueOnError)
+	fs.String(config.ConfigFileKey, "", "Specifies the relayer config file")
+	if err := fs.Parse(args); err != nil {
+		fmt.Print(preflightUsageText)
+		return fmt.Errorf("couldn't parse flags: %w", err)
+	}
+	if !fs.Changed(config.ConfigFileKey) {
+		fmt.Print(preflightUsageText)
+		return fmt.Errorf("--%s is required", config.ConfigFileKey)
+	}
+
+	v, err := config.BuildViper(fs)
+	if err != nil {
+		return fmt.Errorf("couldn't configure flags: %w", err)
+	}
+	cfg, err := config.NewConfig(v)
+	if err != nil {
+		return fmt.Errorf("couldn't build config: %w", err)
+	}
+
+	results := runPreflightChecks(context.Background(), &cfg)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHECK\tTARGET\tRESULT\tDETAIL")
+	failed := 0
+	for _, result := range results {
+		status := "PASS"
+		detail := result.detail
+		if result.err != nil {
+			status = "FAIL"
+			detail = result.err.Error()
+			failed++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", result.check, result.target, status, detail)
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write results: %w", err)
+	}
+	if failed != 0 {
+		return fmt.Errorf("%d of %d preflight checks failed", failed, len(results))
+	}
+	return nil
+}
+
+// runPreflightChecks runs each of the preflight checks in turn. A failed check does not prevent the
+// remaining checks from running, so that all misconfigured endpoints are reported at once.
+func runPreflightChecks(ctx context.Context, cfg *config.Config) []preflightResult {
+	var results []preflightResult
+
+	validatorClient := validators.NewCanonicalValidatorClient(logging.NoLog{}, cfg.PChainAPI)
+	pChainResult := preflightResult{
+		check:  "p-chain-api",
+		target: cfg.PChainAPI.BaseURL,
+	}
+	checkCtx, cancel := context.WithTimeout(ctx, preflightCheckTimeout)
+	height, err := validatorClient.GetCurrentHeight(checkCtx)
+	cancel()
+	if err != nil {
+		pChainResult.err = fmt.Errorf("failed to get P-Chain height: %w", err)
+	} else {
+		pChainResult.detail = fmt.Sprintf("height %d", height)
+	}
+	results = append(results, pChainResult)
+
+	for _, sourceBlockchain := range cfg.SourceBlockchains {
+		blockchainID := sourceBlockchain.GetBlockchainID()
+		tlsConfig := sourceBlockchain.GetTLSConfig()
+		results = append(
+			results,
+			checkRPCEndpoint(ctx, "source-rpc", blockchainID, sourceBlockchain.RPCEndpoint, tlsConfig),
+		)
+		for _, endpoint := range sourceBlockchain.FallbackRPCEndpoints {
+			results = append(results, checkRPCEndpoint(ctx, "source-fallback-rpc", blockchainID, endpoint, tlsConfig))
+		}
+		if sourceBlockchain.HasArchivalRPCEndpoint() {
+			results = append(
+				results,
+				checkRPCEndpoint(
+					ctx,
+					"source-archival-rpc",
+					blockchainID,
+					sourceBlockchain.ArchivalRPCEndpoint,
+					tlsConfig,
+				),
+			)
+		}
+		results = append(
+			results,
+			checkSubnet(ctx, validatorClient, "source-subnet", blockchainID, sourceBlockchain.GetSubnetID()),
+		)
+		if sourceBlockchain.HasSigningSubnetID() {
+			results = append(
+				results,
+				checkSigningSubnet(validatorClient, blockchainID, sourceBlockchain.GetSigningSubnetID()),
+			)
+		}
+	}
+
+	for _, destinationBlockchain := range cfg.DestinationBlockchains {
+		blockchainID := destinationBlockchain.GetBlockchainID()
+		tlsConfig := destinationBlockchain.GetTLSConfig()
+		results = append(
+			results,
+			checkRPCEndpoint(ctx, "destination-rpc", blockchainID, destinationBlockchain.RPCEndpoint, tlsConfig),
+		)
+		for _, endpoint := range destinationBlockchain.FallbackRPCEndpoints {
+			results = append(
+				results,
+				checkRPCEndpoint(ctx, "destination-fallback-rpc", blockchainID, endpoint, tlsConfig),
+			)
+		}
+		results = append(
+			results,
+			checkSubnet(ctx, validatorClient, "destination-subnet", blockchainID, destinationBlockchain.GetSubnetID()),
+		)
+		results = append(results, checkAccountBalances(ctx, destinationBlockchain)...)
+	}
+	return results
+}
+
+// checkRPCEndpoint checks that [endpoint] serves the EVM RPC API, by fetching the latest block number
+func checkRPCEndpoint(
+	ctx context.Context,
+	check string,
+	blockchainID ids.ID,
+	endpoint config.APIConfig,
+	tlsConfig *tls.Config,
+) preflightResult {
+	result := preflightResult{
+		check:  check,
+		target: fmt.Sprintf("%s (%s)", blockchainID, endpoint.BaseURL),
+	}
+	ctx, cancel := context.WithTimeout(ctx, preflightCheckTimeout)
+	defer cancel()
+	client, err := utils.NewEthClientWithConfig(
+		ctx,
+		endpoint.BaseURL,
+		endpoint.HTTPHeaders,
+		endpoint.QueryParams,
+		tlsConfig,
+	)
+	if err != nil {
+		result.err = fmt.Errorf("failed to dial: %w", err)
+		return result
+	}
+	defer client.Close()
+	blockNumber, err := client.BlockNumber(ctx)
+	if err != nil {
+		result.err = fmt.Errorf("failed to get latest block: %w", err)
+		return result
+	}
+	result.detail = fmt.Sprintf("block %d", blockNumber)
+	return result
+}
+
+// checkSubnet checks that the P-Chain knows of [blockchainID], and that it is validated by [subnetID]
+func checkSubnet(
+	ctx context.Context,
+	validatorClient *validators.CanonicalValidatorClient,
+	check string,
+	blockchainID ids.ID,
+	subnetID ids.ID,
+) preflightResult {
+	result := preflightResult{
+		check:  check,
+		target: blockchainID.String(),
+	}
+	ctx, cancel := context.WithTimeout(ctx, preflightCheckTimeout)
+	defer cancel()
+	validatedBy, err := validatorClient.GetSubnetID(ctx, blockchainID)
+	if err != nil {
+		result.err = fmt.Errorf("failed to get subnet of blockchain: %w", err)
+		return result
+	}
+	if validatedBy != subnetID {
+		result.err = fmt.Errorf("blockchain is validated by subnet %s, but configured with %s", validatedBy, subnetID)
+		return result
+	}
+	result.detail = fmt.Sprintf("subnet %s", subnetID)
+	return result
+}
+
+// checkSigningSubnet checks that the P-Chain knows of the signing subnet [subnetID], and that it has validators
+// able to sign Warp messages
+func checkSigningSubnet(
+	validatorClient *validators.CanonicalValidatorClient,
+	blockchainID ids.ID,
+	subnetID ids.ID,
+) preflightResult {
+	result := preflightResult{
+		check:  "source-signing-subnet",
+		target: fmt.Sprintf("%s (%s)", blockchainID, subnetID),
+	}
+	_, totalWeight, err := validatorClient.GetCurrentCanonicalValidatorSet(subnetID)
+	if err != nil {
+		result.err = fmt.Errorf("failed to get validators of signing subnet: %w", err)
+		return result
+	}
+	if totalWeight == 0 {
+		result.err = fmt.Errorf("signing subnet has no validators")
+		return result
+	}
+	result.detail = fmt.Sprintf("total weight %d", totalWeight)
+	return result
+}
+
+// checkAccountBalances checks that each of the destination blockchain's signing accounts has a nonzero balance
+func checkAccountBalances(
+	ctx context.Context,
+	destinationBlockchain *config.DestinationBlockchain,
+) []preflightResult {
+	blockchainID := destinationBlockchain.GetBlockchainID()
+	signers, err := signer.NewSigners(destinationBlockchain)
+	if err != nil {
+		return []preflightResult{{
+			check:  "destination-balance",
+			target: blockchainID.String(),
+			err:    fmt.Errorf("failed to create signers: %w", err),
+		}}
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, preflightCheckTimeout)
+	defer cancel()
+	client, err := utils.NewEthClientWithConfig(
+		dialCtx,
+		destinationBlockchain.RPCEndpoint.BaseURL,
+		destinationBlockchain.RPCEndpoint.HTTPHeaders,
+		destinationBlockchain.RPCEndpoint.QueryParams,
+		destinationBlockchain.GetTLSConfig(),
+	)
+	if err == nil {
+		defer client.Close()
+	}
+
+	results := make([]preflightResult, 0, len(signers))
+	for _, sgnr := range signers {
+		result := preflightResult{
+			check:  "destination-balance",
+			target: fmt.Sprintf("%s (%s)", blockchainID, sgnr.Address()),
+		}
+		if err != nil {
+			result.err = fmt.Errorf("failed to dial: %w", err)
+			results = append(results, result)
+			continue
+		}
+		checkCtx, cancel := context.WithTimeout(ctx, preflightCheckTimeout)
+		balance, balanceErr := client.BalanceAt(checkCtx, sgnr.Address(), nil)
+		cancel()
+		switch {
+		case balanceErr != nil:
+			result.err = fmt.Errorf("failed to get balance: %w", balanceErr)
+		case balance.Sign() == 0:
+			result.err = fmt.Errorf("account has zero balance")
+		default:
+			result.detail = fmt.Sprintf("balance %s wei", balance)
+		}
+		results = append(results, result)
+	}
+	return results
+}
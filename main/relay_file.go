@@ -0,0 +1,59 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/relayer"
+	"go.uber.org/zap"
+)
+
+// relayMessagesFromFile relays the Warp messages in the configured relay file in order, and waits for their
+// checkpoints to be written. Returns the number of messages that failed to be relayed.
+func relayMessagesFromFile(
+	logger logging.Logger,
+	cfg *config.Config,
+	messageCoordinator *relayer.MessageCoordinator,
+) int {
+	warpMessages, err := relayer.ReadMessageFile(logger, cfg.SourceBlockchains, cfg.RelayFile)
+	if err != nil {
+		logger.Fatal("Failed to read relay file", zap.Error(err))
+		panic(err)
+	}
+	logger.Info(
+		"Relaying messages from file",
+		zap.String("relayFile", cfg.RelayFile),
+		zap.Int("numMessages", len(warpMessages)),
+	)
+
+	failed := 0
+	for _, warpMessage := range warpMessages {
+		txHash, err := messageCoordinator.ProcessWarpMessage(warpMessage)
+		if err != nil {
+			failed++
+			logger.Error(
+				"Failed to relay message from file",
+				zap.String("warpMessageID", warpMessage.UnsignedMessage.ID().String()),
+				zap.Error(err),
+			)
+			continue
+		}
+		logger.Info(
+			"Relayed message from file",
+			zap.String("warpMessageID", warpMessage.UnsignedMessage.ID().String()),
+			zap.String("txHash", txHash.String()),
+		)
+	}
+
+	messageCoordinator.Shutdown(time.Duration(cfg.ShutdownTimeoutSeconds) * time.Second)
+	logger.Info(
+		"Finished relaying messages from file",
+		zap.Int("relayedMessages", len(warpMessages)-failed),
+		zap.Int("failedMessages", failed),
+	)
+	return failed
+}
@@ -0,0 +1,264 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/database"
+	"github.com/ava-labs/awm-relayer/peers"
+	"github.com/ava-labs/awm-relayer/relayer"
+	"github.com/ava-labs/awm-relayer/utils"
+	"github.com/ava-labs/awm-relayer/vms"
+	"github.com/ava-labs/awm-relayer/vms/evm"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// configReloader applies changes to the configuration file when the relayer receives SIGHUP.
+// Source blockchains may be added, removed, or changed, and the signing keys of destination blockchains
+// may be changed. Changes to other options are not applied until the relayer is restarted.
+type configReloader struct {
+	logger logging.Logger
+	v      *viper.Viper
+	// The configuration the relayer was started with
+	cfg *config.Config
+	// The configuration the relayer is currently running with. Only the source blockchains and
+	// the destination blockchain signing keys differ from cfg.
+	running config.Config
+
+	listeners           *sourceListeners
+	messageCoordinator  *relayer.MessageCoordinator
+	sourceClientMetrics *evm.SourceClientMetrics
+	destinationClients  map[ids.ID]vms.DestinationClient
+	shadowClients       map[ids.ID]vms.DestinationClient
+	sendQueues          map[ids.ID]*relayer.SendQueue
+	pendingMessages     map[ids.ID]*relayer.PendingMessages
+	signatureCache      *peers.SignatureCache
+	relayerMetrics      *relayer.ApplicationRelayerMetrics
+	db                  database.RelayerDatabase
+	ticker              *utils.Ticker
+	network             *peers.AppRequestNetwork
+	messageCreator      message.Creator
+	deciderConnection   *grpc.ClientConn
+}
+
+// Run reloads the configuration on each SIGHUP until [ctx] is canceled.
+func (r *configReloader) Run(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+	for {
+		select {
+		case <-signals:
+			r.reload(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// reload reads and validates the configuration file, and applies the changes from the running configuration.
+// If the configuration is invalid, the running configuration is kept.
+func (r *configReloader) reload(ctx context.Context) {
+	r.logger.Info("Reloading configuration")
+	if err := r.v.ReadInConfig(); err != nil {
+		r.logger.Error(
+			"Failed to read configuration file. Keeping the running configuration.",
+			zap.Error(err),
+		)
+		return
+	}
+	reloaded, err := config.NewConfig(r.v)
+	if err != nil {
+		r.logger.Error(
+			"Invalid configuration. Keeping the running configuration.",
+			zap.Error(err),
+		)
+		return
+	}
+
+	changes := config.GetReloadChanges(&r.running, &reloaded)
+	if changes.RestartRequired {
+		r.logger.Warn(
+			"Only changes to source blockchains and destination signing keys are applied on reload. " +
+				"Other changes take effect when the relayer is restarted.",
+		)
+	}
+
+	for _, blockchainID := range changes.RemovedSources {
+		r.listeners.Stop(blockchainID)
+		r.messageCoordinator.RemoveSourceBlockchain(blockchainID)
+		r.setRunningSource(blockchainID, nil)
+		r.logger.Info(
+			"Stopped relaying from removed source blockchain",
+			zap.String("blockchainID", blockchainID.String()),
+		)
+	}
+	for _, sourceBlockchain := range changes.AddedSources {
+		// Sources that fail to start are not added to the running configuration, so are retried on the next reload.
+		// A changed source that fails to start keeps running with its previous configuration.
+		if err := r.startSource(ctx, sourceBlockchain); err != nil {
+			r.logger.Error(
+				"Failed to start relaying from source blockchain",
+				zap.String("blockchainID", sourceBlockchain.BlockchainID),
+				zap.Error(err),
+			)
+			continue
+		}
+		r.setRunningSource(sourceBlockchain.GetBlockchainID(), sourceBlockchain)
+		r.logger.Info(
+			"Started relaying from source blockchain",
+			zap.String("blockchainID", sourceBlockchain.BlockchainID),
+		)
+	}
+	for _, destinationBlockchain := range changes.UpdatedSigners {
+		if err := r.updateSigners(destinationBlockchain); err != nil {
+			r.logger.Error(
+				"Failed to update destination signing keys",
+				zap.String("blockchainID", destinationBlockchain.BlockchainID),
+				zap.Error(err),
+			)
+			continue
+		}
+		r.setRunningDestination(destinationBlockchain)
+		r.logger.Info(
+			"Updated destination signing keys",
+			zap.String("blockchainID", destinationBlockchain.BlockchainID),
+		)
+	}
+	r.logger.Info(
+		"Reloaded configuration",
+		zap.Int("addedOrChangedSources", len(changes.AddedSources)),
+		zap.Int("removedSources", len(changes.RemovedSources)),
+		zap.Int("updatedDestinationSigners", len(changes.UpdatedSigners)),
+	)
+}
+
+// startSource creates the ApplicationRelayers for [sourceBlockchain], and starts its Listener,
+// replacing any that are already running.
+func (r *configReloader) startSource(ctx context.Context, sourceBlockchain *config.SourceBlockchain) error {
+	// Destination clients and their Warp quorums are only created at startup
+	for _, relayerID := range database.GetSourceBlockchainRelayerIDs(sourceBlockchain) {
+		if _, ok := r.destinationClients[relayerID.DestinationBlockchainID]; !ok {
+			return fmt.Errorf(
+				"destination blockchain %s is not running. Restart the relayer to add destination blockchains",
+				relayerID.DestinationBlockchainID,
+			)
+		}
+	}
+	// The stake of source blockchains is only monitored if configured at startup
+	var minSourceStake uint64
+	for _, s := range r.cfg.SourceBlockchains {
+		if s.GetBlockchainID() == sourceBlockchain.GetBlockchainID() {
+			minSourceStake = s.MinSourceStake
+		}
+	}
+	if sourceBlockchain.MinSourceStake != minSourceStake {
+		return fmt.Errorf("min-source-stake changed. Restart the relayer to apply the change")
+	}
+
+	sourceClient, err := createSourceClient(ctx, r.logger, r.sourceClientMetrics, sourceBlockchain)
+	if err != nil {
+		return err
+	}
+	messageHandlerFactories, err := createMessageHandlerFactoriesForSourceChain(
+		r.logger,
+		sourceBlockchain,
+		r.deciderConnection,
+		sourceClient,
+		createFeatureFlagClient(r.logger, r.cfg),
+	)
+	if err != nil {
+		return err
+	}
+	if err := database.RegisterRelayerIDs(r.db, database.GetSourceBlockchainRelayerIDs(sourceBlockchain)); err != nil {
+		return err
+	}
+	currentHeight, err := sourceClient.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	applicationRelayers, minHeight, err := createApplicationRelayersForSourceChain(
+		ctx,
+		r.logger,
+		r.relayerMetrics,
+		r.db,
+		r.ticker,
+		*sourceBlockchain,
+		r.network,
+		r.messageCreator,
+		r.cfg,
+		currentHeight,
+		r.destinationClients,
+		r.shadowClients,
+		r.sendQueues,
+		r.pendingMessages,
+		r.signatureCache,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Stop the previous Listener before routing its messages to the new ApplicationRelayers.
+	// The previous ApplicationRelayers are stopped when they are replaced, so that their checkpoints are no
+	// longer written.
+	r.listeners.Stop(sourceBlockchain.GetBlockchainID())
+	r.messageCoordinator.AddSourceBlockchain(
+		sourceBlockchain.GetBlockchainID(),
+		messageHandlerFactories,
+		applicationRelayers,
+		sourceClient,
+	)
+	r.listeners.Start(sourceBlockchain, sourceClient, minHeight)
+	return nil
+}
+
+// updateSigners replaces the signing keys of the destination and shadow clients for [destinationBlockchain]
+func (r *configReloader) updateSigners(destinationBlockchain *config.DestinationBlockchain) error {
+	blockchainID := destinationBlockchain.GetBlockchainID()
+	if err := vms.UpdateSigners(r.destinationClients[blockchainID], destinationBlockchain); err != nil {
+		return err
+	}
+	if shadowClient, ok := r.shadowClients[blockchainID]; ok {
+		return vms.UpdateSigners(shadowClient, destinationBlockchain)
+	}
+	return nil
+}
+
+// setRunningSource sets the running configuration of the source blockchain [blockchainID],
+// or removes it if [sourceBlockchain] is nil.
+func (r *configReloader) setRunningSource(blockchainID ids.ID, sourceBlockchain *config.SourceBlockchain) {
+	sourceBlockchains := make([]*config.SourceBlockchain, 0, len(r.running.SourceBlockchains)+1)
+	for _, s := range r.running.SourceBlockchains {
+		if s.GetBlockchainID() != blockchainID {
+			sourceBlockchains = append(sourceBlockchains, s)
+		}
+	}
+	if sourceBlockchain != nil {
+		sourceBlockchains = append(sourceBlockchains, sourceBlockchain)
+	}
+	r.running.SourceBlockchains = sourceBlockchains
+}
+
+// setRunningDestination replaces the running configuration of [destinationBlockchain]
+func (r *configReloader) setRunningDestination(destinationBlockchain *config.DestinationBlockchain) {
+	destinationBlockchains := make([]*config.DestinationBlockchain, 0, len(r.running.DestinationBlockchains))
+	for _, d := range r.running.DestinationBlockchains {
+		if d.GetBlockchainID() == destinationBlockchain.GetBlockchainID() {
+			d = destinationBlockchain
+		}
+		destinationBlockchains = append(destinationBlockchains, d)
+	}
+	r.running.DestinationBlockchains = destinationBlockchains
+}
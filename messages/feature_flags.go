@@ -0,0 +1,140 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package messages
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/config"
+	"go.uber.org/zap"
+)
+
+const featureFlagRequestTimeout = 5 * time.Second
+
+// FeatureFlagResponse is the response expected from the feature flag service
+type FeatureFlagResponse struct {
+	Enabled bool `json:"enabled"`
+}
+
+type corridor struct {
+	sourceBlockchainID      ids.ID
+	destinationBlockchainID ids.ID
+}
+
+type featureFlagEntry struct {
+	enabled   bool
+	fetchedAt time.Time
+}
+
+// FeatureFlagClient determines whether relaying is enabled for a source and destination blockchain
+// pair (a corridor) by querying an external feature flag service.
+//
+// The service is queried with GET <url>?source-blockchain-id=<ID>&destination-blockchain-id=<ID>, using
+// the cb58 encoded blockchain IDs, and must respond with status 200 and a FeatureFlagResponse body.
+// Any other response is a failed lookup. Successful lookups are cached for the configured TTL. On a
+// failed lookup, the most recent result is used if there is one. Otherwise, the corridor is treated
+// as enabled (fail-open), or as disabled if the service is configured to fail closed.
+type FeatureFlagClient struct {
+	logger     logging.Logger
+	httpClient *http.Client
+	cfg        *config.FeatureFlagConfig
+	lock       sync.Mutex
+	cache      map[corridor]featureFlagEntry
+}
+
+func NewFeatureFlagClient(logger logging.Logger, cfg *config.FeatureFlagConfig) *FeatureFlagClient {
+	return &FeatureFlagClient{
+		logger:     logger,
+		httpClient: &http.Client{Timeout: featureFlagRequestTimeout},
+		cfg:        cfg,
+		cache:      make(map[corridor]featureFlagEntry),
+	}
+}
+
+// CorridorEnabled returns whether messages from [sourceBlockchainID] to [destinationBlockchainID]
+// should be relayed. A nil client enables all corridors.
+func (c *FeatureFlagClient) CorridorEnabled(sourceBlockchainID ids.ID, destinationBlockchainID ids.ID) bool {
+	if c == nil {
+		return true
+	}
+	key := corridor{
+		sourceBlockchainID:      sourceBlockchainID,
+		destinationBlockchainID: destinationBlockchainID,
+	}
+
+	c.lock.Lock()
+	entry, cached := c.cache[key]
+	c.lock.Unlock()
+	if cached && time.Since(entry.fetchedAt) < c.cfg.GetCacheTTL() {
+		return entry.enabled
+	}
+
+	enabled, err := c.fetch(key)
+	if err != nil {
+		fallback := !c.cfg.FailClosed
+		if cached {
+			fallback = entry.enabled
+		}
+		c.logger.Warn(
+			"Failed to query feature flag service",
+			zap.String("sourceBlockchainID", sourceBlockchainID.String()),
+			zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+			zap.Bool("usingCachedResult", cached),
+			zap.Bool("enabled", fallback),
+			zap.Error(err),
+		)
+		return fallback
+	}
+
+	c.lock.Lock()
+	c.cache[key] = featureFlagEntry{
+		enabled:   enabled,
+		fetchedAt: time.Now(),
+	}
+	c.lock.Unlock()
+	return enabled
+}
+
+func (c *FeatureFlagClient) fetch(key corridor) (bool, error) {
+	reqURL, err := url.Parse(c.cfg.URL)
+	if err != nil {
+		return false, err
+	}
+	query := reqURL.Query()
+	query.Set("source-blockchain-id", key.sourceBlockchainID.String())
+	query.Set("destination-blockchain-id", key.destinationBlockchainID.String())
+	reqURL.RawQuery = query.Encode()
+
+	ctx, cancel := context.WithTimeout(context.Background(), featureFlagRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return false, err
+	}
+	for name, value := range c.cfg.HTTPHeaders {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	var flag FeatureFlagResponse
+	if err := json.NewDecoder(resp.Body).Decode(&flag); err != nil {
+		return false, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return flag.Enabled, nil
+}
@@ -0,0 +1,124 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package messages
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorridorEnabled(t *testing.T) {
+	sourceBlockchainID := ids.GenerateTestID()
+	destinationBlockchainID := ids.GenerateTestID()
+
+	testCases := []struct {
+		name            string
+		statusCode      int
+		body            string
+		failClosed      bool
+		cachedEnabled   *bool
+		expectedEnabled bool
+	}{
+		{
+			name:            "enabled",
+			statusCode:      http.StatusOK,
+			body:            `{"enabled": true}`,
+			expectedEnabled: true,
+		},
+		{
+			name:            "disabled",
+			statusCode:      http.StatusOK,
+			body:            `{"enabled": false}`,
+			expectedEnabled: false,
+		},
+		{
+			name:            "error fails open",
+			statusCode:      http.StatusInternalServerError,
+			expectedEnabled: true,
+		},
+		{
+			name:            "error fails closed",
+			statusCode:      http.StatusInternalServerError,
+			failClosed:      true,
+			expectedEnabled: false,
+		},
+		{
+			name:            "malformed response fails closed",
+			statusCode:      http.StatusOK,
+			body:            `enabled`,
+			failClosed:      true,
+			expectedEnabled: false,
+		},
+		{
+			name:            "error uses expired cached result",
+			statusCode:      http.StatusInternalServerError,
+			failClosed:      true,
+			cachedEnabled:   new(bool),
+			expectedEnabled: false,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, sourceBlockchainID.String(), r.URL.Query().Get("source-blockchain-id"))
+				require.Equal(t, destinationBlockchainID.String(), r.URL.Query().Get("destination-blockchain-id"))
+				w.WriteHeader(test.statusCode)
+				_, _ = w.Write([]byte(test.body))
+			}))
+			defer server.Close()
+
+			client := NewFeatureFlagClient(logging.NoLog{}, &config.FeatureFlagConfig{
+				URL:        server.URL,
+				FailClosed: test.failClosed,
+			})
+			if test.cachedEnabled != nil {
+				client.cache[corridor{sourceBlockchainID, destinationBlockchainID}] = featureFlagEntry{
+					enabled:   *test.cachedEnabled,
+					fetchedAt: time.Now().Add(-time.Hour),
+				}
+			}
+			require.Equal(
+				t,
+				test.expectedEnabled,
+				client.CorridorEnabled(sourceBlockchainID, destinationBlockchainID),
+			)
+		})
+	}
+}
+
+func TestCorridorEnabledCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"enabled": true}`))
+	}))
+	defer server.Close()
+
+	client := NewFeatureFlagClient(logging.NoLog{}, &config.FeatureFlagConfig{
+		URL:             server.URL,
+		CacheTTLSeconds: 60,
+	})
+	sourceBlockchainID := ids.GenerateTestID()
+	destinationBlockchainID := ids.GenerateTestID()
+	for i := 0; i < 3; i++ {
+		require.True(t, client.CorridorEnabled(sourceBlockchainID, destinationBlockchainID))
+	}
+	require.Equal(t, 1, requests)
+
+	// Each corridor is cached separately
+	require.True(t, client.CorridorEnabled(destinationBlockchainID, sourceBlockchainID))
+	require.Equal(t, 2, requests)
+
+	// A nil client enables all corridors
+	var nilClient *FeatureFlagClient
+	require.True(t, nilClient.CorridorEnabled(sourceBlockchainID, destinationBlockchainID))
+}
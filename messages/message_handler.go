@@ -6,6 +6,9 @@
 package messages
 
 import (
+	"context"
+	"math/big"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/awm-relayer/vms"
@@ -19,16 +22,82 @@ type MessageHandlerFactory interface {
 	NewMessageHandler(unsignedMessage *warp.UnsignedMessage) (MessageHandler, error)
 }
 
+// MultiPayloadMessageHandlerFactory is optionally implemented by factories for message formats in which a
+// single Warp message carries multiple payloads. A MessageHandler is created for each payload, and each is
+// routed and relayed independently.
+type MultiPayloadMessageHandlerFactory interface {
+	MessageHandlerFactory
+
+	// Create a message handler for each payload of the Warp message
+	NewMessageHandlers(unsignedMessage *warp.UnsignedMessage) ([]MessageHandler, error)
+}
+
+// NewMessageHandlers creates the message handlers to relay the Warp message. Factories that do not
+// implement MultiPayloadMessageHandlerFactory create a single handler for the message.
+func NewMessageHandlers(factory MessageHandlerFactory, unsignedMessage *warp.UnsignedMessage) ([]MessageHandler, error) {
+	if multiFactory, ok := factory.(MultiPayloadMessageHandlerFactory); ok {
+		return multiFactory.NewMessageHandlers(unsignedMessage)
+	}
+	handler, err := factory.NewMessageHandler(unsignedMessage)
+	if err != nil {
+		return nil, err
+	}
+	return []MessageHandler{handler}, nil
+}
+
+// SkippedMessageError is returned by ShouldSendMessage for a message that is invalid, and so will never be
+// delivered. Rather than failing the block that contains it, the message is stored as a dead letter with [Reason].
+type SkippedMessageError struct {
+	Reason string
+}
+
+func (e *SkippedMessageError) Error() string {
+	return "message skipped: " + e.Reason
+}
+
+// PrioritizedMessageHandler is optionally implemented by message handlers for protocols that assign each message a
+// priority. If a destination blockchain limits the number of concurrent sends, messages waiting for a send slot
+// are given one in order of descending priority.
+type PrioritizedMessageHandler interface {
+	MessageHandler
+
+	// GetPriority returns the priority of the message, or nil if it cannot be determined, in which case the
+	// message has the lowest priority. Only called once ShouldSendMessage has returned true.
+	GetPriority() *big.Int
+}
+
+// DeliveryStatusFactory is optionally implemented by factories for message protocols that record the receipt of
+// each message on the destination chain, so that the delivery of a message can be queried by its ID.
+type DeliveryStatusFactory interface {
+	MessageHandlerFactory
+
+	// GetDeliveryStatus returns true if the message with the protocol-specific [messageID] sent from
+	// [sourceBlockchainID] has been delivered to the destination chain, along with the hash of the delivery
+	// transaction if it is known, or the empty hash otherwise.
+	GetDeliveryStatus(
+		ctx context.Context,
+		sourceBlockchainID ids.ID,
+		messageID ids.ID,
+		destinationClient vms.DestinationClient,
+	) (bool, common.Hash, error)
+}
+
 // MessageHandlers relay a single Warp message. A new instance should be created for each Warp message.
 type MessageHandler interface {
 	// ShouldSendMessage returns true if the message should be sent to the destination chain
-	// If an error is returned, the boolean should be ignored by the caller.
+	// If an error is returned, the boolean should be ignored by the caller. A *SkippedMessageError is returned
+	// if the message is invalid.
 	ShouldSendMessage(destinationClient vms.DestinationClient) (bool, error)
 
 	// SendMessage sends the signed message to the destination chain. The payload parsed according to
 	// the VM rules is also passed in, since MessageManager does not assume any particular VM
 	// returns the transaction hash if the transaction is successful.
-	SendMessage(signedMessage *warp.Message, destinationClient vms.DestinationClient) (common.Hash, error)
+	// The delivery is abandoned if [ctx] is canceled, for example when the relayer shuts down.
+	SendMessage(
+		ctx context.Context,
+		signedMessage *warp.Message,
+		destinationClient vms.DestinationClient,
+	) (common.Hash, error)
 
 	// GetMessageRoutingInfo returns the source chain ID, origin sender address,
 	// destination chain ID, and destination address.
@@ -42,4 +111,7 @@ type MessageHandler interface {
 
 	// GetUnsignedMessage returns the unsigned message
 	GetUnsignedMessage() *warp.UnsignedMessage
+
+	// GetMessageProtocol returns the name of the message protocol, as used in the configuration
+	GetMessageProtocol() string
 }
@@ -10,6 +10,8 @@
 package mocks
 
 import (
+	context "context"
+	big "math/big"
 	reflect "reflect"
 
 	ids "github.com/ava-labs/avalanchego/ids"
@@ -58,6 +60,226 @@ func (mr *MockMessageHandlerFactoryMockRecorder) NewMessageHandler(unsignedMessa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewMessageHandler", reflect.TypeOf((*MockMessageHandlerFactory)(nil).NewMessageHandler), unsignedMessage)
 }
 
+// MockMultiPayloadMessageHandlerFactory is a mock of MultiPayloadMessageHandlerFactory interface.
+type MockMultiPayloadMessageHandlerFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockMultiPayloadMessageHandlerFactoryMockRecorder
+}
+
+// MockMultiPayloadMessageHandlerFactoryMockRecorder is the mock recorder for MockMultiPayloadMessageHandlerFactory.
+type MockMultiPayloadMessageHandlerFactoryMockRecorder struct {
+	mock *MockMultiPayloadMessageHandlerFactory
+}
+
+// NewMockMultiPayloadMessageHandlerFactory creates a new mock instance.
+func NewMockMultiPayloadMessageHandlerFactory(ctrl *gomock.Controller) *MockMultiPayloadMessageHandlerFactory {
+	mock := &MockMultiPayloadMessageHandlerFactory{ctrl: ctrl}
+	mock.recorder = &MockMultiPayloadMessageHandlerFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMultiPayloadMessageHandlerFactory) EXPECT() *MockMultiPayloadMessageHandlerFactoryMockRecorder {
+	return m.recorder
+}
+
+// NewMessageHandler mocks base method.
+func (m *MockMultiPayloadMessageHandlerFactory) NewMessageHandler(unsignedMessage *warp.UnsignedMessage) (messages.MessageHandler, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewMessageHandler", unsignedMessage)
+	ret0, _ := ret[0].(messages.MessageHandler)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewMessageHandler indicates an expected call of NewMessageHandler.
+func (mr *MockMultiPayloadMessageHandlerFactoryMockRecorder) NewMessageHandler(unsignedMessage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewMessageHandler", reflect.TypeOf((*MockMultiPayloadMessageHandlerFactory)(nil).NewMessageHandler), unsignedMessage)
+}
+
+// NewMessageHandlers mocks base method.
+func (m *MockMultiPayloadMessageHandlerFactory) NewMessageHandlers(unsignedMessage *warp.UnsignedMessage) ([]messages.MessageHandler, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewMessageHandlers", unsignedMessage)
+	ret0, _ := ret[0].([]messages.MessageHandler)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewMessageHandlers indicates an expected call of NewMessageHandlers.
+func (mr *MockMultiPayloadMessageHandlerFactoryMockRecorder) NewMessageHandlers(unsignedMessage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewMessageHandlers", reflect.TypeOf((*MockMultiPayloadMessageHandlerFactory)(nil).NewMessageHandlers), unsignedMessage)
+}
+
+// MockPrioritizedMessageHandler is a mock of PrioritizedMessageHandler interface.
+type MockPrioritizedMessageHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrioritizedMessageHandlerMockRecorder
+}
+
+// MockPrioritizedMessageHandlerMockRecorder is the mock recorder for MockPrioritizedMessageHandler.
+type MockPrioritizedMessageHandlerMockRecorder struct {
+	mock *MockPrioritizedMessageHandler
+}
+
+// NewMockPrioritizedMessageHandler creates a new mock instance.
+func NewMockPrioritizedMessageHandler(ctrl *gomock.Controller) *MockPrioritizedMessageHandler {
+	mock := &MockPrioritizedMessageHandler{ctrl: ctrl}
+	mock.recorder = &MockPrioritizedMessageHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrioritizedMessageHandler) EXPECT() *MockPrioritizedMessageHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetMessageProtocol mocks base method.
+func (m *MockPrioritizedMessageHandler) GetMessageProtocol() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageProtocol")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetMessageProtocol indicates an expected call of GetMessageProtocol.
+func (mr *MockPrioritizedMessageHandlerMockRecorder) GetMessageProtocol() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageProtocol", reflect.TypeOf((*MockPrioritizedMessageHandler)(nil).GetMessageProtocol))
+}
+
+// GetMessageRoutingInfo mocks base method.
+func (m *MockPrioritizedMessageHandler) GetMessageRoutingInfo() (ids.ID, common.Address, ids.ID, common.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageRoutingInfo")
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(common.Address)
+	ret2, _ := ret[2].(ids.ID)
+	ret3, _ := ret[3].(common.Address)
+	ret4, _ := ret[4].(error)
+	return ret0, ret1, ret2, ret3, ret4
+}
+
+// GetMessageRoutingInfo indicates an expected call of GetMessageRoutingInfo.
+func (mr *MockPrioritizedMessageHandlerMockRecorder) GetMessageRoutingInfo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageRoutingInfo", reflect.TypeOf((*MockPrioritizedMessageHandler)(nil).GetMessageRoutingInfo))
+}
+
+// GetPriority mocks base method.
+func (m *MockPrioritizedMessageHandler) GetPriority() *big.Int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriority")
+	ret0, _ := ret[0].(*big.Int)
+	return ret0
+}
+
+// GetPriority indicates an expected call of GetPriority.
+func (mr *MockPrioritizedMessageHandlerMockRecorder) GetPriority() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriority", reflect.TypeOf((*MockPrioritizedMessageHandler)(nil).GetPriority))
+}
+
+// GetUnsignedMessage mocks base method.
+func (m *MockPrioritizedMessageHandler) GetUnsignedMessage() *warp.UnsignedMessage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnsignedMessage")
+	ret0, _ := ret[0].(*warp.UnsignedMessage)
+	return ret0
+}
+
+// GetUnsignedMessage indicates an expected call of GetUnsignedMessage.
+func (mr *MockPrioritizedMessageHandlerMockRecorder) GetUnsignedMessage() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnsignedMessage", reflect.TypeOf((*MockPrioritizedMessageHandler)(nil).GetUnsignedMessage))
+}
+
+// SendMessage mocks base method.
+func (m *MockPrioritizedMessageHandler) SendMessage(ctx context.Context, signedMessage *warp.Message, destinationClient vms.DestinationClient) (common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessage", ctx, signedMessage, destinationClient)
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendMessage indicates an expected call of SendMessage.
+func (mr *MockPrioritizedMessageHandlerMockRecorder) SendMessage(ctx, signedMessage, destinationClient any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockPrioritizedMessageHandler)(nil).SendMessage), ctx, signedMessage, destinationClient)
+}
+
+// ShouldSendMessage mocks base method.
+func (m *MockPrioritizedMessageHandler) ShouldSendMessage(destinationClient vms.DestinationClient) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShouldSendMessage", destinationClient)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShouldSendMessage indicates an expected call of ShouldSendMessage.
+func (mr *MockPrioritizedMessageHandlerMockRecorder) ShouldSendMessage(destinationClient any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShouldSendMessage", reflect.TypeOf((*MockPrioritizedMessageHandler)(nil).ShouldSendMessage), destinationClient)
+}
+
+// MockDeliveryStatusFactory is a mock of DeliveryStatusFactory interface.
+type MockDeliveryStatusFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryStatusFactoryMockRecorder
+}
+
+// MockDeliveryStatusFactoryMockRecorder is the mock recorder for MockDeliveryStatusFactory.
+type MockDeliveryStatusFactoryMockRecorder struct {
+	mock *MockDeliveryStatusFactory
+}
+
+// NewMockDeliveryStatusFactory creates a new mock instance.
+func NewMockDeliveryStatusFactory(ctrl *gomock.Controller) *MockDeliveryStatusFactory {
+	mock := &MockDeliveryStatusFactory{ctrl: ctrl}
+	mock.recorder = &MockDeliveryStatusFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryStatusFactory) EXPECT() *MockDeliveryStatusFactoryMockRecorder {
+	return m.recorder
+}
+
+// GetDeliveryStatus mocks base method.
+func (m *MockDeliveryStatusFactory) GetDeliveryStatus(ctx context.Context, sourceBlockchainID, messageID ids.ID, destinationClient vms.DestinationClient) (bool, common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveryStatus", ctx, sourceBlockchainID, messageID, destinationClient)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(common.Hash)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDeliveryStatus indicates an expected call of GetDeliveryStatus.
+func (mr *MockDeliveryStatusFactoryMockRecorder) GetDeliveryStatus(ctx, sourceBlockchainID, messageID, destinationClient any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryStatus", reflect.TypeOf((*MockDeliveryStatusFactory)(nil).GetDeliveryStatus), ctx, sourceBlockchainID, messageID, destinationClient)
+}
+
+// NewMessageHandler mocks base method.
+func (m *MockDeliveryStatusFactory) NewMessageHandler(unsignedMessage *warp.UnsignedMessage) (messages.MessageHandler, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewMessageHandler", unsignedMessage)
+	ret0, _ := ret[0].(messages.MessageHandler)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewMessageHandler indicates an expected call of NewMessageHandler.
+func (mr *MockDeliveryStatusFactoryMockRecorder) NewMessageHandler(unsignedMessage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewMessageHandler", reflect.TypeOf((*MockDeliveryStatusFactory)(nil).NewMessageHandler), unsignedMessage)
+}
+
 // MockMessageHandler is a mock of MessageHandler interface.
 type MockMessageHandler struct {
 	ctrl     *gomock.Controller
@@ -81,6 +303,20 @@ func (m *MockMessageHandler) EXPECT() *MockMessageHandlerMockRecorder {
 	return m.recorder
 }
 
+// GetMessageProtocol mocks base method.
+func (m *MockMessageHandler) GetMessageProtocol() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageProtocol")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetMessageProtocol indicates an expected call of GetMessageProtocol.
+func (mr *MockMessageHandlerMockRecorder) GetMessageProtocol() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageProtocol", reflect.TypeOf((*MockMessageHandler)(nil).GetMessageProtocol))
+}
+
 // GetMessageRoutingInfo mocks base method.
 func (m *MockMessageHandler) GetMessageRoutingInfo() (ids.ID, common.Address, ids.ID, common.Address, error) {
 	m.ctrl.T.Helper()
@@ -114,18 +350,18 @@ func (mr *MockMessageHandlerMockRecorder) GetUnsignedMessage() *gomock.Call {
 }
 
 // SendMessage mocks base method.
-func (m *MockMessageHandler) SendMessage(signedMessage *warp.Message, destinationClient vms.DestinationClient) (common.Hash, error) {
+func (m *MockMessageHandler) SendMessage(ctx context.Context, signedMessage *warp.Message, destinationClient vms.DestinationClient) (common.Hash, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendMessage", signedMessage, destinationClient)
+	ret := m.ctrl.Call(m, "SendMessage", ctx, signedMessage, destinationClient)
 	ret0, _ := ret[0].(common.Hash)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SendMessage indicates an expected call of SendMessage.
-func (mr *MockMessageHandlerMockRecorder) SendMessage(signedMessage, destinationClient any) *gomock.Call {
+func (mr *MockMessageHandlerMockRecorder) SendMessage(ctx, signedMessage, destinationClient any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockMessageHandler)(nil).SendMessage), signedMessage, destinationClient)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockMessageHandler)(nil).SendMessage), ctx, signedMessage, destinationClient)
 }
 
 // ShouldSendMessage mocks base method.
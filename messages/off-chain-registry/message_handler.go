@@ -4,7 +4,9 @@
 package offchainregistry
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -14,6 +16,7 @@ import (
 	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 	"github.com/ava-labs/awm-relayer/config"
 	"github.com/ava-labs/awm-relayer/messages"
+	"github.com/ava-labs/awm-relayer/utils"
 	"github.com/ava-labs/awm-relayer/vms"
 	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
 	"github.com/ava-labs/subnet-evm/ethclient"
@@ -32,6 +35,7 @@ const (
 type factory struct {
 	logger          logging.Logger
 	registryAddress common.Address
+	featureFlags    *messages.FeatureFlagClient
 }
 
 type messageHandler struct {
@@ -40,9 +44,19 @@ type messageHandler struct {
 	factory         *factory
 }
 
+func init() {
+	messages.RegisterManagerFactory(
+		config.OFF_CHAIN_REGISTRY,
+		func(params messages.ManagerFactoryParams) (messages.MessageHandlerFactory, error) {
+			return NewMessageHandlerFactory(params.Logger, params.Config, params.FeatureFlags)
+		},
+	)
+}
+
 func NewMessageHandlerFactory(
 	logger logging.Logger,
 	messageProtocolConfig config.MessageProtocolConfig,
+	featureFlags *messages.FeatureFlagClient,
 ) (messages.MessageHandlerFactory, error) {
 	// Marshal the map and unmarshal into the off-chain registry config
 	data, err := json.Marshal(messageProtocolConfig.Settings)
@@ -66,12 +80,19 @@ func NewMessageHandlerFactory(
 	return &factory{
 		logger:          logger,
 		registryAddress: common.HexToAddress(messageConfig.TeleporterRegistryAddress),
+		featureFlags:    featureFlags,
 	}, nil
 }
 
 func (f *factory) NewMessageHandler(unsignedMessage *warp.UnsignedMessage) (messages.MessageHandler, error) {
+	// Off-chain registry messages are delivered to the source blockchain
 	return &messageHandler{
-		logger:          f.logger,
+		logger: utils.NewMessageLogger(
+			f.logger,
+			unsignedMessage.SourceChainID,
+			unsignedMessage.SourceChainID,
+			unsignedMessage.ID(),
+		),
 		unsignedMessage: unsignedMessage,
 		factory:         f,
 	}, nil
@@ -81,10 +102,25 @@ func (m *messageHandler) GetUnsignedMessage() *warp.UnsignedMessage {
 	return m.unsignedMessage
 }
 
+func (m *messageHandler) GetMessageProtocol() string {
+	return config.OFF_CHAIN_REGISTRY.String()
+}
+
 // ShouldSendMessage returns false if any contract is already registered as the specified version
 // in the TeleporterRegistry contract. This is because a single contract address can be registered
 // to multiple versions, but each version may only map to a single contract address.
 func (m *messageHandler) ShouldSendMessage(destinationClient vms.DestinationClient) (bool, error) {
+	destinationBlockchainID := destinationClient.DestinationBlockchainID()
+	if !m.factory.featureFlags.CorridorEnabled(m.unsignedMessage.SourceChainID, destinationBlockchainID) {
+		m.logger.Info(
+			"Corridor disabled by feature flag. Skipping delivery.",
+			zap.String("sourceBlockchainID", m.unsignedMessage.SourceChainID.String()),
+			zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+			zap.String("warpMessageID", m.unsignedMessage.ID().String()),
+		)
+		return false, nil
+	}
+
 	addressedPayload, err := warpPayload.ParseAddressedCall(m.unsignedMessage.Payload)
 	if err != nil {
 		m.logger.Error(
@@ -151,6 +187,7 @@ func (m *messageHandler) ShouldSendMessage(destinationClient vms.DestinationClie
 }
 
 func (m *messageHandler) SendMessage(
+	ctx context.Context,
 	signedMessage *warp.Message,
 	destinationClient vms.DestinationClient,
 ) (common.Hash, error) {
@@ -170,11 +207,15 @@ func (m *messageHandler) SendMessage(
 	}
 
 	txHash, err := destinationClient.SendTx(
+		ctx,
 		signedMessage,
 		m.factory.registryAddress.Hex(),
 		addProtocolVersionGasLimit,
 		callData,
 	)
+	if errors.Is(err, vms.ErrDryRun) {
+		return common.Hash{}, err
+	}
 	if err != nil {
 		m.logger.Error(
 			"Failed to send tx.",
@@ -132,6 +132,7 @@ func TestShouldSendMessage(t *testing.T) {
 			factory, err := NewMessageHandlerFactory(
 				logger,
 				messageProtocolConfig,
+				nil,
 			)
 			require.NoError(t, err)
 			ethClient := mock_evm.NewMockClient(ctrl)
@@ -0,0 +1,44 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package raw
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/awm-relayer/utils"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type Config struct {
+	// The blockchain and contract to which messages are delivered
+	DestinationBlockchainID string `json:"destination-blockchain-id"`
+	ReceiverAddress         string `json:"receiver-address"`
+	// The gas limit of each delivery transaction
+	GasLimit uint64 `json:"gas-limit"`
+
+	// Parsed in Validate
+	destinationBlockchainID ids.ID
+	receiverAddress         common.Address
+}
+
+func (c *Config) Validate() error {
+	destinationBlockchainID, err := utils.HexOrCB58ToID(c.DestinationBlockchainID)
+	if err != nil {
+		return fmt.Errorf("invalid destination blockchain ID: %s", c.DestinationBlockchainID)
+	}
+	c.destinationBlockchainID = destinationBlockchainID
+	if !common.IsHexAddress(c.ReceiverAddress) {
+		return fmt.Errorf("invalid receiver address: %s", c.ReceiverAddress)
+	}
+	c.receiverAddress = common.HexToAddress(c.ReceiverAddress)
+	if c.receiverAddress == (common.Address{}) {
+		return errors.New("receiver address must be non-zero")
+	}
+	if c.GasLimit == 0 {
+		return errors.New("gas limit must be greater than 0")
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package raw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/messages"
+	"github.com/ava-labs/awm-relayer/utils"
+	"github.com/ava-labs/awm-relayer/vms"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// The entrypoint called on the receiver contract to deliver a raw Warp message. The receiver reads the
+// message from the Warp precompile at [messageIndex].
+const receiverABIJSON = `[{
+	"type": "function",
+	"name": "receiveWarpMessage",
+	"inputs": [
+		{"name": "messageIndex", "type": "uint32"}
+	],
+	"outputs": [],
+	"stateMutability": "nonpayable"
+}]`
+
+var receiverABI abi.ABI
+
+func init() {
+	var err error
+	receiverABI, err = abi.JSON(strings.NewReader(receiverABIJSON))
+	if err != nil {
+		panic(err)
+	}
+
+	messages.RegisterManagerFactory(
+		config.RAW,
+		func(params messages.ManagerFactoryParams) (messages.MessageHandlerFactory, error) {
+			return NewMessageHandlerFactory(params.Logger, params.Config, params.FeatureFlags)
+		},
+	)
+}
+
+type factory struct {
+	logger        logging.Logger
+	messageConfig Config
+	featureFlags  *messages.FeatureFlagClient
+}
+
+type messageHandler struct {
+	logger          logging.Logger
+	unsignedMessage *warp.UnsignedMessage
+	addressedCall   *warpPayload.AddressedCall
+	factory         *factory
+}
+
+// NewMessageHandlerFactory creates a factory for messages that are delivered to the configured receiver
+// without interpreting their payload.
+func NewMessageHandlerFactory(
+	logger logging.Logger,
+	messageProtocolConfig config.MessageProtocolConfig,
+	featureFlags *messages.FeatureFlagClient,
+) (messages.MessageHandlerFactory, error) {
+	// Marshal the map and unmarshal into the raw config
+	data, err := json.Marshal(messageProtocolConfig.Settings)
+	if err != nil {
+		logger.Error("Failed to marshal raw message config")
+		return nil, err
+	}
+	var messageConfig Config
+	if err := json.Unmarshal(data, &messageConfig); err != nil {
+		logger.Error("Failed to unmarshal raw message config")
+		return nil, err
+	}
+
+	if err := messageConfig.Validate(); err != nil {
+		logger.Error(
+			"Invalid raw message config.",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &factory{
+		logger:        logger,
+		messageConfig: messageConfig,
+		featureFlags:  featureFlags,
+	}, nil
+}
+
+// NewMessageHandler returns an error if the message payload is not an addressed call, since the sender
+// of the message cannot be determined.
+func (f *factory) NewMessageHandler(unsignedMessage *warp.UnsignedMessage) (messages.MessageHandler, error) {
+	addressedCall, err := warpPayload.ParseAddressedCall(unsignedMessage.Payload)
+	if err != nil {
+		f.logger.Error(
+			"Failed parsing addressed payload",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	return &messageHandler{
+		logger: utils.NewMessageLogger(
+			f.logger,
+			unsignedMessage.SourceChainID,
+			f.messageConfig.destinationBlockchainID,
+			unsignedMessage.ID(),
+		),
+		unsignedMessage: unsignedMessage,
+		addressedCall:   addressedCall,
+		factory:         f,
+	}, nil
+}
+
+func (m *messageHandler) GetUnsignedMessage() *warp.UnsignedMessage {
+	return m.unsignedMessage
+}
+
+func (m *messageHandler) GetMessageProtocol() string {
+	return config.RAW.String()
+}
+
+// ShouldSendMessage returns true unless the corridor is disabled. The payload is not interpreted. Messages
+// are routed by their addressed call source address, so are subject to the source blockchain's
+// allowed-origin-sender-addresses.
+func (m *messageHandler) ShouldSendMessage(destinationClient vms.DestinationClient) (bool, error) {
+	destinationBlockchainID := destinationClient.DestinationBlockchainID()
+	if !m.factory.featureFlags.CorridorEnabled(m.unsignedMessage.SourceChainID, destinationBlockchainID) {
+		m.logger.Info(
+			"Corridor disabled by feature flag. Skipping delivery.",
+			zap.String("sourceBlockchainID", m.unsignedMessage.SourceChainID.String()),
+			zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+			zap.String("warpMessageID", m.unsignedMessage.ID().String()),
+		)
+		return false, nil
+	}
+	return true, nil
+}
+
+// SendMessage delivers the signed message to the configured receiver, with the configured gas limit
+func (m *messageHandler) SendMessage(
+	ctx context.Context,
+	signedMessage *warp.Message,
+	destinationClient vms.DestinationClient,
+) (common.Hash, error) {
+	// Only one Warp message is sent at a time, so we hardcode the index to 0 in the call.
+	callData, err := receiverABI.Pack("receiveWarpMessage", uint32(0))
+	if err != nil {
+		m.logger.Error(
+			"Failed packing receiveWarpMessage call data",
+			zap.String("destinationBlockchainID", destinationClient.DestinationBlockchainID().String()),
+			zap.String("warpMessageID", signedMessage.ID().String()),
+		)
+		return common.Hash{}, err
+	}
+
+	txHash, err := destinationClient.SendTx(
+		ctx,
+		signedMessage,
+		m.factory.messageConfig.receiverAddress.Hex(),
+		m.factory.messageConfig.GasLimit,
+		callData,
+	)
+	if errors.Is(err, vms.ErrDryRun) {
+		return common.Hash{}, err
+	}
+	if err != nil {
+		m.logger.Error(
+			"Failed to send tx.",
+			zap.String("destinationBlockchainID", destinationClient.DestinationBlockchainID().String()),
+			zap.String("warpMessageID", signedMessage.ID().String()),
+			zap.Error(err),
+		)
+		return common.Hash{}, err
+	}
+	m.logger.Info(
+		"Sent message to destination chain",
+		zap.String("destinationBlockchainID", destinationClient.DestinationBlockchainID().String()),
+		zap.String("warpMessageID", signedMessage.ID().String()),
+	)
+	return txHash, nil
+}
+
+func (m *messageHandler) GetMessageRoutingInfo() (
+	ids.ID,
+	common.Address,
+	ids.ID,
+	common.Address,
+	error,
+) {
+	return m.unsignedMessage.SourceChainID,
+		common.BytesToAddress(m.addressedCall.SourceAddress),
+		m.factory.messageConfig.destinationBlockchainID,
+		m.factory.messageConfig.receiverAddress,
+		nil
+}
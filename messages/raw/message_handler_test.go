@@ -0,0 +1,144 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package raw
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/awm-relayer/config"
+	mock_vms "github.com/ava-labs/awm-relayer/vms/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	receiverAddress               = common.HexToAddress("0xd81545385803bCD83bd59f58Ba2d2c0562387F83")
+	senderAddress                 = common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+	destinationBlockchainIDString = "S4mMqUXe7vHsGiRAma6bv3CKnyaLssyAxmQ2KvFpX1KEvfFCD"
+)
+
+func newMessageProtocolConfig(settings map[string]interface{}) config.MessageProtocolConfig {
+	defaultSettings := map[string]interface{}{
+		"destination-blockchain-id": destinationBlockchainIDString,
+		"receiver-address":          receiverAddress.Hex(),
+		"gas-limit":                 200_000,
+	}
+	for key, value := range settings {
+		defaultSettings[key] = value
+	}
+	return config.MessageProtocolConfig{
+		MessageFormat: config.RAW.String(),
+		Settings:      defaultSettings,
+	}
+}
+
+func newUnsignedMessage(t *testing.T, sourceAddress common.Address) *warp.UnsignedMessage {
+	addressedCall, err := payload.NewAddressedCall(sourceAddress.Bytes(), []byte("governance proposal"))
+	require.NoError(t, err)
+	unsignedMessage, err := warp.NewUnsignedMessage(constants.UnitTestID, ids.GenerateTestID(), addressedCall.Bytes())
+	require.NoError(t, err)
+	return unsignedMessage
+}
+
+func TestNewMessageHandlerFactory(t *testing.T) {
+	testCases := []struct {
+		name        string
+		settings    map[string]interface{}
+		expectError bool
+	}{
+		{
+			name: "valid",
+		},
+		{
+			name:        "invalid destination blockchain ID",
+			settings:    map[string]interface{}{"destination-blockchain-id": "invalid"},
+			expectError: true,
+		},
+		{
+			name:        "zero receiver address",
+			settings:    map[string]interface{}{"receiver-address": common.Address{}.Hex()},
+			expectError: true,
+		},
+		{
+			name:        "zero gas limit",
+			settings:    map[string]interface{}{"gas-limit": 0},
+			expectError: true,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewMessageHandlerFactory(logging.NoLog{}, newMessageProtocolConfig(test.settings), nil)
+			if test.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestShouldSendMessage(t *testing.T) {
+	destinationBlockchainID, err := ids.FromString(destinationBlockchainIDString)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	factory, err := NewMessageHandlerFactory(logging.NoLog{}, newMessageProtocolConfig(nil), nil)
+	require.NoError(t, err)
+	unsignedMessage := newUnsignedMessage(t, senderAddress)
+	handler, err := factory.NewMessageHandler(unsignedMessage)
+	require.NoError(t, err)
+
+	// Messages are routed by the addressed call source address to the configured receiver
+	sourceBlockchainID, sender, routedDestinationBlockchainID, destinationAddress, err :=
+		handler.GetMessageRoutingInfo()
+	require.NoError(t, err)
+	require.Equal(t, unsignedMessage.SourceChainID, sourceBlockchainID)
+	require.Equal(t, senderAddress, sender)
+	require.Equal(t, destinationBlockchainID, routedDestinationBlockchainID)
+	require.Equal(t, receiverAddress, destinationAddress)
+
+	destinationClient := mock_vms.NewMockDestinationClient(ctrl)
+	destinationClient.EXPECT().DestinationBlockchainID().Return(destinationBlockchainID).AnyTimes()
+	result, err := handler.ShouldSendMessage(destinationClient)
+	require.NoError(t, err)
+	require.True(t, result)
+}
+
+func TestNewMessageHandlerNotAddressedCall(t *testing.T) {
+	factory, err := NewMessageHandlerFactory(logging.NoLog{}, newMessageProtocolConfig(nil), nil)
+	require.NoError(t, err)
+	unsignedMessage, err := warp.NewUnsignedMessage(constants.UnitTestID, ids.GenerateTestID(), []byte("not a payload"))
+	require.NoError(t, err)
+	_, err = factory.NewMessageHandler(unsignedMessage)
+	require.Error(t, err)
+}
+
+func TestSendMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	factory, err := NewMessageHandlerFactory(logging.NoLog{}, newMessageProtocolConfig(nil), nil)
+	require.NoError(t, err)
+	handler, err := factory.NewMessageHandler(newUnsignedMessage(t, senderAddress))
+	require.NoError(t, err)
+
+	expectedCallData, err := receiverABI.Pack("receiveWarpMessage", uint32(0))
+	require.NoError(t, err)
+	signedMessage := &warp.Message{}
+	destinationClient := mock_vms.NewMockDestinationClient(ctrl)
+	destinationClient.EXPECT().DestinationBlockchainID().Return(ids.GenerateTestID()).AnyTimes()
+	destinationClient.EXPECT().
+		SendTx(gomock.Any(), signedMessage, receiverAddress.Hex(), uint64(200_000), expectedCallData).
+		Return(common.Hash{1}, nil).
+		Times(1)
+
+	txHash, err := handler.SendMessage(context.Background(), signedMessage, destinationClient)
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{1}, txHash)
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package messages
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/grpc"
+)
+
+// ManagerFactoryParams are the inputs available to a ManagerFactory when creating the MessageHandlerFactory
+// for a message protocol contract.
+type ManagerFactoryParams struct {
+	Logger logging.Logger
+	// The address of the message protocol contract on the source blockchain
+	Address common.Address
+	Config  config.MessageProtocolConfig
+	// Nil if no decider is configured
+	DeciderConnection *grpc.ClientConn
+	SourceClient      ethclient.Client
+	FeatureFlags      *FeatureFlagClient
+}
+
+// ManagerFactory creates the MessageHandlerFactory for a message protocol contract.
+type ManagerFactory func(params ManagerFactoryParams) (MessageHandlerFactory, error)
+
+var (
+	managerFactoriesLock sync.RWMutex
+	managerFactories     = make(map[config.MessageProtocol]ManagerFactory)
+)
+
+// RegisterManagerFactory sets the factory used to create the MessageHandlerFactory of message contracts
+// configured with the message format [protocol], replacing any existing factory. Non-native message protocols
+// should first be registered with config.RegisterMessageProtocol. Must be called before the relayer creates its
+// message handler factories, typically from an init function.
+func RegisterManagerFactory(protocol config.MessageProtocol, factory ManagerFactory) {
+	managerFactoriesLock.Lock()
+	defer managerFactoriesLock.Unlock()
+	managerFactories[protocol] = factory
+}
+
+// NewManager creates the MessageHandlerFactory for the message contract described by [params], using the
+// ManagerFactory registered for its message format.
+func NewManager(params ManagerFactoryParams) (MessageHandlerFactory, error) {
+	protocol := config.ParseMessageProtocol(params.Config.MessageFormat)
+	managerFactoriesLock.RLock()
+	factory, ok := managerFactories[protocol]
+	managerFactoriesLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("invalid message format %s", params.Config.MessageFormat)
+	}
+	return factory(params)
+}
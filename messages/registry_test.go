@@ -0,0 +1,51 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package messages
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+type testManager struct {
+	address common.Address
+}
+
+func (*testManager) NewMessageHandler(*warp.UnsignedMessage) (MessageHandler, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestRegisterManagerFactory(t *testing.T) {
+	// Message formats are only valid once registered
+	const format = "test-governance"
+	require.Equal(t, config.UNKNOWN_MESSAGE_PROTOCOL, config.ParseMessageProtocol(format))
+	_, err := NewManager(ManagerFactoryParams{
+		Config: config.MessageProtocolConfig{MessageFormat: format},
+	})
+	require.Error(t, err)
+
+	protocol := config.RegisterMessageProtocol(format)
+	require.Equal(t, protocol, config.ParseMessageProtocol(format))
+	require.Equal(t, format, protocol.String())
+	RegisterManagerFactory(protocol, func(params ManagerFactoryParams) (MessageHandlerFactory, error) {
+		return &testManager{address: params.Address}, nil
+	})
+
+	address := common.HexToAddress("0x27aE10273D17Cd7e80de8580A51f476960626e5f")
+	manager, err := NewManager(ManagerFactoryParams{
+		Address: address,
+		Config:  config.MessageProtocolConfig{MessageFormat: format},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &testManager{address: address}, manager)
+
+	// Each message protocol may only be registered once
+	require.Panics(t, func() { config.RegisterMessageProtocol(format) })
+	require.Panics(t, func() { config.RegisterMessageProtocol(config.TELEPORTER.String()) })
+}
@@ -4,18 +4,250 @@
 package teleporter
 
 import (
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
+	"time"
 
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/awm-relayer/utils"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Teleporter fees are paid on the source chain, and are redeemable by the reward address
+// provided by the relayer upon delivery.
+const (
+	// Messages without an attached ERC-20 fee. Relayers delivering these messages are
+	// compensated outside of the protocol, e.g. in the native token.
+	nativeFeeType = "native"
+	// Messages with an attached ERC-20 fee, redeemable by the relayer's reward address
+	// via the source chain's Teleporter contract.
+	erc20FeeType = "erc20"
 )
 
 type Config struct {
-	RewardAddress string `json:"reward-address"`
+	RewardAddress       string   `json:"reward-address"`
+	AcceptedFeeTypes    []string `json:"accepted-fee-types"`
+	MinRequiredGasLimit uint64   `json:"min-required-gas-limit"`
+	// Maps ERC-20 fee token address to the minimum fee amount, as a base-10 integer string,
+	// required to relay a message paying its fee in that token
+	MinFeeWei map[string]string `json:"min-fee-wei"`
+	// ERC-20 fee token addresses. If set, messages paying their fee in any other ERC-20 token are not relayed
+	AllowedFeeTokens []string `json:"allowed-fee-tokens"`
+	// The address passed to receiveCrossChainMessage, for receivers that reward a deliverer distinct from
+	// the reward address. Defaults to RewardAddress.
+	DelivererAddress string `json:"deliverer-address"`
+	// If non-zero, receipts that remain queued on a destination chain for this long, because no message has
+	// been sent back to the source chain to carry them, are sent to the source chain in a receipt-only message.
+	ReceiptFlushIntervalSeconds uint64 `json:"receipt-flush-interval-seconds"`
+	// Teleporter message IDs, hex or cb58 encoded, that are never relayed
+	BlockedMessageIDs []string `json:"blocked-message-ids"`
+	// Hex encoded 4-byte function selectors. If set, only messages whose payload begins with one of them are relayed
+	PayloadSelectors []string `json:"payload-selectors"`
+	// Bounds on the size, in bytes, of the payload of messages that are relayed. A MaxPayloadBytes of zero
+	// does not bound the size.
+	MinPayloadBytes uint64 `json:"min-payload-bytes"`
+	MaxPayloadBytes uint64 `json:"max-payload-bytes"`
+	// If set, only this fraction of messages, between 0 and 1, is relayed. Messages are selected by their
+	// Teleporter message ID, so the same messages are selected across restarts and by other relayers.
+	RelaySampleRate *float64 `json:"relay-sample-rate"`
+
+	// Parsed from MinFeeWei in Validate
+	minFees map[common.Address]*big.Int
+	// Parsed from AllowedFeeTokens in Validate
+	allowedFeeTokens set.Set[common.Address]
+	// Parsed from DelivererAddress, or RewardAddress if unset, in Validate
+	delivererAddress common.Address
+	// Parsed from BlockedMessageIDs in Validate
+	blockedMessageIDs set.Set[ids.ID]
+	// Parsed from PayloadSelectors in Validate
+	payloadSelectors set.Set[[4]byte]
+}
+
+// parseConfig parses and validates the Teleporter settings of a message protocol configuration.
+func parseConfig(settings map[string]interface{}) (Config, error) {
+	// Marshal the map and unmarshal into the Teleporter config
+	var messageConfig Config
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return messageConfig, fmt.Errorf("failed to marshal Teleporter config: %w", err)
+	}
+	if err := json.Unmarshal(data, &messageConfig); err != nil {
+		return messageConfig, fmt.Errorf("failed to unmarshal Teleporter config: %w", err)
+	}
+	if err := messageConfig.Validate(); err != nil {
+		return messageConfig, err
+	}
+	return messageConfig, nil
 }
 
 func (c *Config) Validate() error {
 	if !common.IsHexAddress(c.RewardAddress) {
 		return fmt.Errorf("invalid reward address for EVM source subnet: %s", c.RewardAddress)
 	}
+	c.delivererAddress = common.HexToAddress(c.RewardAddress)
+	if c.DelivererAddress != "" {
+		if !common.IsHexAddress(c.DelivererAddress) {
+			return fmt.Errorf("invalid deliverer address for EVM source subnet: %s", c.DelivererAddress)
+		}
+		c.delivererAddress = common.HexToAddress(c.DelivererAddress)
+	}
+	for _, feeType := range c.AcceptedFeeTypes {
+		switch feeType {
+		case nativeFeeType:
+		case erc20FeeType:
+			// ERC-20 fees are only redeemable by the reward address, so it must be set
+			if common.HexToAddress(c.RewardAddress) == (common.Address{}) {
+				return fmt.Errorf("reward address must be non-zero to accept %s fees", erc20FeeType)
+			}
+		default:
+			return fmt.Errorf("invalid accepted fee type: %s", feeType)
+		}
+	}
+	c.minFees = make(map[common.Address]*big.Int, len(c.MinFeeWei))
+	for feeTokenAddress, minFee := range c.MinFeeWei {
+		if !common.IsHexAddress(feeTokenAddress) {
+			return fmt.Errorf("invalid min fee token address: %s", feeTokenAddress)
+		}
+		address := common.HexToAddress(feeTokenAddress)
+		if address == (common.Address{}) {
+			return fmt.Errorf(
+				"min fee token address must be non-zero. use accepted-fee-types to exclude %s fee messages",
+				nativeFeeType,
+			)
+		}
+		amount, ok := new(big.Int).SetString(minFee, 10)
+		if !ok || amount.Sign() < 0 {
+			return fmt.Errorf("invalid min fee for token %s: %s", feeTokenAddress, minFee)
+		}
+		c.minFees[address] = amount
+	}
+	c.allowedFeeTokens = set.NewSet[common.Address](len(c.AllowedFeeTokens))
+	for _, feeTokenAddress := range c.AllowedFeeTokens {
+		if !common.IsHexAddress(feeTokenAddress) {
+			return fmt.Errorf("invalid allowed fee token address: %s", feeTokenAddress)
+		}
+		address := common.HexToAddress(feeTokenAddress)
+		if address == (common.Address{}) {
+			return fmt.Errorf(
+				"allowed fee token address must be non-zero. use accepted-fee-types to select %s fee messages",
+				nativeFeeType,
+			)
+		}
+		c.allowedFeeTokens.Add(address)
+	}
+	c.blockedMessageIDs = set.NewSet[ids.ID](len(c.BlockedMessageIDs))
+	for _, messageIDStr := range c.BlockedMessageIDs {
+		messageID, err := utils.HexOrCB58ToID(messageIDStr)
+		if err != nil {
+			return fmt.Errorf("invalid blocked message ID %s: %w", messageIDStr, err)
+		}
+		c.blockedMessageIDs.Add(messageID)
+	}
+	c.payloadSelectors = set.NewSet[[4]byte](len(c.PayloadSelectors))
+	for _, selectorStr := range c.PayloadSelectors {
+		selector, err := hexutil.Decode(selectorStr)
+		if err != nil || len(selector) != 4 {
+			return fmt.Errorf("invalid payload selector %s: must be 4 hex encoded bytes", selectorStr)
+		}
+		c.payloadSelectors.Add([4]byte(selector))
+	}
+	if c.MaxPayloadBytes != 0 && c.MinPayloadBytes > c.MaxPayloadBytes {
+		return fmt.Errorf(
+			"min payload bytes %d must not exceed max payload bytes %d",
+			c.MinPayloadBytes,
+			c.MaxPayloadBytes,
+		)
+	}
+	if c.RelaySampleRate != nil && (*c.RelaySampleRate < 0 || *c.RelaySampleRate > 1) {
+		return fmt.Errorf("invalid relay sample rate %f: must be between 0 and 1", *c.RelaySampleRate)
+	}
 	return nil
 }
+
+// Returns true if a message with [payload] may be relayed given the configured payload selectors.
+// If no payload selectors are configured, all payloads are accepted.
+func (c *Config) acceptsPayload(payload []byte) bool {
+	if c.payloadSelectors.Len() == 0 {
+		return true
+	}
+	return len(payload) >= 4 && c.payloadSelectors.Contains([4]byte(payload[:4]))
+}
+
+// Returns true if the size of [payload] is within the configured bounds.
+func (c *Config) acceptsPayloadSize(payload []byte) bool {
+	size := uint64(len(payload))
+	return size >= c.MinPayloadBytes && (c.MaxPayloadBytes == 0 || size <= c.MaxPayloadBytes)
+}
+
+// Returns true if the Teleporter message with the given ID is among the configured fraction of messages
+// that are relayed. The decision is derived from the message ID, which is uniformly distributed.
+func (c *Config) isSampled(teleporterMessageID ids.ID) bool {
+	if c.RelaySampleRate == nil || *c.RelaySampleRate >= 1 {
+		return true
+	}
+	return float64(binary.BigEndian.Uint64(teleporterMessageID[:8])) < *c.RelaySampleRate*math.MaxUint64
+}
+
+// Returns true if the Teleporter message with the given ID must not be relayed.
+func (c *Config) isBlockedMessage(teleporterMessageID ids.ID) bool {
+	return c.blockedMessageIDs.Contains(teleporterMessageID)
+}
+
+// Returns the interval on which queued receipts are checked, or zero if receipts are not flushed.
+func (c *Config) getReceiptFlushInterval() time.Duration {
+	return time.Duration(c.ReceiptFlushIntervalSeconds) * time.Second
+}
+
+// Returns the address passed to receiveCrossChainMessage when delivering messages.
+func (c *Config) getDelivererAddress() common.Address {
+	return c.delivererAddress
+}
+
+// Returns the minimum fee required to relay messages paying their fee in [feeTokenAddress],
+// or nil if there is no minimum.
+func (c *Config) minFee(feeTokenAddress common.Address) *big.Int {
+	return c.minFees[feeTokenAddress]
+}
+
+// Returns the fee type of a message with the given fee info. Messages without an ERC-20 fee are
+// considered native fee messages.
+func getFeeType(feeTokenAddress common.Address, feeAmount *big.Int) string {
+	if feeTokenAddress == (common.Address{}) || feeAmount == nil || feeAmount.Sign() == 0 {
+		return nativeFeeType
+	}
+	return erc20FeeType
+}
+
+// Returns true if messages with the given fee type should be relayed.
+// If no fee types are configured, all fee types are accepted.
+func (c *Config) acceptsFeeType(feeType string) bool {
+	if len(c.AcceptedFeeTypes) == 0 {
+		return true
+	}
+	for _, accepted := range c.AcceptedFeeTypes {
+		if accepted == feeType {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if messages paying an ERC-20 fee in [feeTokenAddress] should be relayed.
+// If no fee tokens are configured, all fee tokens are accepted.
+func (c *Config) acceptsFeeToken(feeTokenAddress common.Address) bool {
+	return c.allowedFeeTokens.Len() == 0 || c.allowedFeeTokens.Contains(feeTokenAddress)
+}
+
+// Returns true if the message's required gas limit is non-zero, and at least the configured minimum. Not applicable
+// to receipt-only messages, which carry no message to execute.
+func (c *Config) isValidRequiredGasLimit(requiredGasLimit *big.Int) bool {
+	if requiredGasLimit == nil || requiredGasLimit.Sign() <= 0 {
+		return false
+	}
+	return requiredGasLimit.Cmp(new(big.Int).SetUint64(c.MinRequiredGasLimit)) >= 0
+}
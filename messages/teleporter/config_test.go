@@ -6,31 +6,199 @@ package teleporter
 import (
 	"testing"
 
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
 
 func TestConfigValidate(t *testing.T) {
+	sampleRate := func(rate float64) *float64 { return &rate }
 	testCases := []struct {
-		name          string
-		rewardAddress string
-		isError       bool
+		name              string
+		rewardAddress     string
+		delivererAddress  string
+		acceptedFeeTypes  []string
+		minFeeWei         map[string]string
+		allowedFeeTokens  []string
+		blockedMessageIDs []string
+		payloadSelectors  []string
+		minPayloadBytes   uint64
+		maxPayloadBytes   uint64
+		relaySampleRate   *float64
+		isError           bool
+		// The expected deliverer address, if valid
+		expectedDeliverer string
 	}{
 		{
-			name:          "valid",
-			rewardAddress: "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
-			isError:       false,
+			name:              "valid",
+			rewardAddress:     "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			isError:           false,
+			expectedDeliverer: "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+		},
+		{
+			name:              "valid deliverer address",
+			rewardAddress:     "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			delivererAddress:  "0xabcdef0123456789abcdef0123456789abcdef01",
+			isError:           false,
+			expectedDeliverer: "0xabcdef0123456789abcdef0123456789abcdef01",
+		},
+		{
+			name:             "invalid deliverer address",
+			rewardAddress:    "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			delivererAddress: "0xabcdef",
+			isError:          true,
 		},
 		{
 			name:          "invalid",
 			rewardAddress: "0x27aE10273D17Cd7e80de8580A51f476960626e5",
 			isError:       true,
 		},
+		{
+			name:             "valid fee types",
+			rewardAddress:    "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			acceptedFeeTypes: []string{nativeFeeType, erc20FeeType},
+			isError:          false,
+		},
+		{
+			name:             "invalid fee type",
+			rewardAddress:    "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			acceptedFeeTypes: []string{"unknown"},
+			isError:          true,
+		},
+		{
+			name:             "erc20 fee type with zero reward address",
+			rewardAddress:    "0x0000000000000000000000000000000000000000",
+			acceptedFeeTypes: []string{erc20FeeType},
+			isError:          true,
+		},
+		{
+			name:          "valid min fee",
+			rewardAddress: "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			minFeeWei:     map[string]string{"0xabcdef0123456789abcdef0123456789abcdef01": "1000000000000000000000"},
+			isError:       false,
+		},
+		{
+			name:          "invalid min fee token address",
+			rewardAddress: "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			minFeeWei:     map[string]string{"0xabcdef": "100"},
+			isError:       true,
+		},
+		{
+			name:          "zero min fee token address",
+			rewardAddress: "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			minFeeWei:     map[string]string{"0x0000000000000000000000000000000000000000": "100"},
+			isError:       true,
+		},
+		{
+			name:          "invalid min fee amount",
+			rewardAddress: "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			minFeeWei:     map[string]string{"0xabcdef0123456789abcdef0123456789abcdef01": "-1"},
+			isError:       true,
+		},
+		{
+			name:          "valid blocked message IDs",
+			rewardAddress: "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			blockedMessageIDs: []string{
+				"S4mMqUXe7vHsGiRAma6bv3CKnyaLssyAxmQ2KvFpX1KEvfFCD",
+				"0x0102030405060708091011121314151617181920212223242526272829303132",
+			},
+			isError: false,
+		},
+		{
+			name:             "valid allowed fee tokens",
+			rewardAddress:    "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			allowedFeeTokens: []string{"0xabcdef0123456789abcdef0123456789abcdef01"},
+			isError:          false,
+		},
+		{
+			name:             "invalid allowed fee token",
+			rewardAddress:    "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			allowedFeeTokens: []string{"0xabcdef"},
+			isError:          true,
+		},
+		{
+			name:             "zero allowed fee token",
+			rewardAddress:    "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			allowedFeeTokens: []string{"0x0000000000000000000000000000000000000000"},
+			isError:          true,
+		},
+		{
+			name:              "invalid blocked message ID",
+			rewardAddress:     "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			blockedMessageIDs: []string{"0x0102"},
+			isError:           true,
+		},
+		{
+			name:             "valid payload selectors",
+			rewardAddress:    "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			payloadSelectors: []string{"0x01020304", "0xa9059cbb"},
+			isError:          false,
+		},
+		{
+			name:             "invalid payload selector length",
+			rewardAddress:    "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			payloadSelectors: []string{"0x010203"},
+			isError:          true,
+		},
+		{
+			name:             "invalid payload selector encoding",
+			rewardAddress:    "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			payloadSelectors: []string{"01020304"},
+			isError:          true,
+		},
+		{
+			name:            "valid payload size bounds",
+			rewardAddress:   "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			minPayloadBytes: 4,
+			maxPayloadBytes: 4,
+			isError:         false,
+		},
+		{
+			name:            "min payload bytes without max",
+			rewardAddress:   "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			minPayloadBytes: 32,
+			isError:         false,
+		},
+		{
+			name:            "min payload bytes above max",
+			rewardAddress:   "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			minPayloadBytes: 32,
+			maxPayloadBytes: 16,
+			isError:         true,
+		},
+		{
+			name:            "valid relay sample rate",
+			rewardAddress:   "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			relaySampleRate: sampleRate(0.5),
+			isError:         false,
+		},
+		{
+			name:            "relay sample rate above one",
+			rewardAddress:   "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			relaySampleRate: sampleRate(1.5),
+			isError:         true,
+		},
+		{
+			name:            "negative relay sample rate",
+			rewardAddress:   "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+			relaySampleRate: sampleRate(-0.1),
+			isError:         true,
+		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
 			c := &Config{
-				RewardAddress: test.rewardAddress,
+				RewardAddress:     test.rewardAddress,
+				DelivererAddress:  test.delivererAddress,
+				AcceptedFeeTypes:  test.acceptedFeeTypes,
+				MinFeeWei:         test.minFeeWei,
+				AllowedFeeTokens:  test.allowedFeeTokens,
+				BlockedMessageIDs: test.blockedMessageIDs,
+				PayloadSelectors:  test.payloadSelectors,
+				MinPayloadBytes:   test.minPayloadBytes,
+				MaxPayloadBytes:   test.maxPayloadBytes,
+				RelaySampleRate:   test.relaySampleRate,
 			}
 			err := c.Validate()
 			if test.isError {
@@ -38,6 +206,30 @@ func TestConfigValidate(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 			}
+			if test.expectedDeliverer != "" {
+				require.Equal(t, common.HexToAddress(test.expectedDeliverer), c.getDelivererAddress())
+			}
 		})
 	}
 }
+
+func TestIsSampled(t *testing.T) {
+	const numMessages = 10_000
+	messageIDs := make([]ids.ID, numMessages)
+	for i := range messageIDs {
+		messageIDs[i] = ids.GenerateTestID()
+	}
+	for _, rate := range []float64{0, 0.25, 0.5, 1} {
+		c := &Config{RelaySampleRate: &rate}
+		sampled := 0
+		for _, messageID := range messageIDs {
+			if c.isSampled(messageID) {
+				sampled++
+			}
+			// The decision for a message is deterministic
+			require.Equal(t, c.isSampled(messageID), c.isSampled(messageID))
+		}
+		require.InDelta(t, rate, float64(sampled)/numMessages, 0.05)
+	}
+	require.True(t, (&Config{}).isSampled(messageIDs[0]))
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleporter
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/awm-relayer/vms"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// Number of recent destination blocks searched for the transaction that delivered a message. Bounded so that
+// the log query is accepted by RPC endpoints that limit the range of eth_getLogs.
+const deliveryLookbackBlocks = 2048
+
+// GetDeliveryStatus reports whether the Teleporter message with ID [messageID] sent from [sourceBlockchainID]
+// has been received by the destination chain's Teleporter contract. If so, the recent destination blocks are
+// searched for the transaction that delivered it.
+func (f *factory) GetDeliveryStatus(
+	ctx context.Context,
+	sourceBlockchainID ids.ID,
+	messageID ids.ID,
+	destinationClient vms.DestinationClient,
+) (bool, common.Hash, error) {
+	teleporterMessenger := f.getTeleporterMessenger(destinationClient)
+	delivered, err := teleporterMessenger.MessageReceived(&bind.CallOpts{Context: ctx}, messageID)
+	if err != nil {
+		f.logger.Error(
+			"Failed to check if message has been delivered to destination chain.",
+			zap.String("destinationBlockchainID", destinationClient.DestinationBlockchainID().String()),
+			zap.String("teleporterMessageID", messageID.String()),
+			zap.Error(err),
+		)
+		return false, common.Hash{}, err
+	}
+	if !delivered {
+		return false, common.Hash{}, nil
+	}
+
+	// The delivery transaction is reported if known, so failing to find it is not an error
+	txHash, err := f.findDeliveryTransaction(ctx, sourceBlockchainID, messageID, destinationClient)
+	if err != nil {
+		f.logger.Warn(
+			"Failed to find delivery transaction",
+			zap.String("destinationBlockchainID", destinationClient.DestinationBlockchainID().String()),
+			zap.String("teleporterMessageID", messageID.String()),
+			zap.Error(err),
+		)
+		return true, common.Hash{}, nil
+	}
+	return true, txHash, nil
+}
+
+// findDeliveryTransaction returns the hash of the transaction in the last deliveryLookbackBlocks destination
+// blocks that emitted the ReceiveCrossChainMessage event for the message, or the empty hash if there is none.
+func (f *factory) findDeliveryTransaction(
+	ctx context.Context,
+	sourceBlockchainID ids.ID,
+	messageID ids.ID,
+	destinationClient vms.DestinationClient,
+) (common.Hash, error) {
+	client := destinationClient.Client().(ethclient.Client)
+	latestBlock, err := client.BlockNumber(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	var fromBlock uint64
+	if latestBlock > deliveryLookbackBlocks {
+		fromBlock = latestBlock - deliveryLookbackBlocks
+	}
+
+	teleporterABI, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	logs, err := client.FilterLogs(ctx, interfaces.FilterQuery{
+		Addresses: []common.Address{f.protocolAddress},
+		Topics: [][]common.Hash{
+			{teleporterABI.Events["ReceiveCrossChainMessage"].ID},
+			{common.Hash(messageID)},
+			{common.Hash(sourceBlockchainID)},
+		},
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(latestBlock),
+	})
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if len(logs) == 0 {
+		return common.Hash{}, nil
+	}
+	return logs[0].TxHash, nil
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleporter
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/messages"
+	mock_evm "github.com/ava-labs/awm-relayer/vms/evm/mocks"
+	mock_vms "github.com/ava-labs/awm-relayer/vms/mocks"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetDeliveryStatus(t *testing.T) {
+	sourceBlockchainID := ids.GenerateTestID()
+	messageID := ids.GenerateTestID()
+	txHash := common.HexToHash("0x1234")
+
+	messageReceivedInput, err := teleportermessenger.PackMessageReceived(messageID)
+	require.NoError(t, err)
+	messageNotDelivered, err := teleportermessenger.PackMessageReceivedOutput(false)
+	require.NoError(t, err)
+	messageDelivered, err := teleportermessenger.PackMessageReceivedOutput(true)
+	require.NoError(t, err)
+	teleporterABI, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		messageReceived []byte
+		latestBlock     uint64
+		logs            []types.Log
+		filterLogsErr   error
+		// The block range searched for the delivery transaction, if any
+		expectedFromBlock uint64
+		expectedToBlock   uint64
+		expectedDelivered bool
+		expectedTxHash    common.Hash
+	}{
+		{
+			name:            "not delivered",
+			messageReceived: messageNotDelivered,
+		},
+		{
+			name:              "delivered",
+			messageReceived:   messageDelivered,
+			latestBlock:       5000,
+			logs:              []types.Log{{TxHash: txHash}},
+			expectedFromBlock: 5000 - deliveryLookbackBlocks,
+			expectedToBlock:   5000,
+			expectedDelivered: true,
+			expectedTxHash:    txHash,
+		},
+		{
+			name:              "delivered before lookback window",
+			messageReceived:   messageDelivered,
+			latestBlock:       100,
+			expectedFromBlock: 0,
+			expectedToBlock:   100,
+			expectedDelivered: true,
+		},
+		{
+			name:              "delivery transaction lookup failed",
+			messageReceived:   messageDelivered,
+			latestBlock:       100,
+			filterLogsErr:     errors.New("block range too large"),
+			expectedFromBlock: 0,
+			expectedToBlock:   100,
+			expectedDelivered: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			factory, err := NewMessageHandlerFactory(
+				logging.NoLog{},
+				messageProtocolAddress,
+				messageProtocolConfig,
+				nil,
+				nil,
+				nil,
+			)
+			require.NoError(t, err)
+
+			destinationClient := mock_vms.NewMockDestinationClient(ctrl)
+			ethClient := mock_evm.NewMockClient(ctrl)
+			destinationClient.EXPECT().Client().Return(ethClient).AnyTimes()
+			destinationClient.EXPECT().DestinationBlockchainID().Return(destinationBlockchainID).AnyTimes()
+			ethClient.EXPECT().
+				CallContract(gomock.Any(), gomock.Eq(interfaces.CallMsg{
+					To:   &messageProtocolAddress,
+					Data: messageReceivedInput,
+				}), gomock.Any()).
+				Return(test.messageReceived, nil).
+				Times(1)
+			if test.expectedDelivered {
+				ethClient.EXPECT().BlockNumber(gomock.Any()).Return(test.latestBlock, nil).Times(1)
+				ethClient.EXPECT().
+					FilterLogs(gomock.Any(), gomock.Eq(interfaces.FilterQuery{
+						Addresses: []common.Address{messageProtocolAddress},
+						Topics: [][]common.Hash{
+							{teleporterABI.Events["ReceiveCrossChainMessage"].ID},
+							{common.Hash(messageID)},
+							{common.Hash(sourceBlockchainID)},
+						},
+						FromBlock: new(big.Int).SetUint64(test.expectedFromBlock),
+						ToBlock:   new(big.Int).SetUint64(test.expectedToBlock),
+					})).
+					Return(test.logs, test.filterLogsErr).
+					Times(1)
+			}
+
+			deliveryStatusFactory, ok := factory.(messages.DeliveryStatusFactory)
+			require.True(t, ok)
+			delivered, txHash, err := deliveryStatusFactory.GetDeliveryStatus(
+				context.Background(),
+				sourceBlockchainID,
+				messageID,
+				destinationClient,
+			)
+			require.NoError(t, err)
+			require.Equal(t, test.expectedDelivered, delivered)
+			require.Equal(t, test.expectedTxHash, txHash)
+		})
+	}
+}
@@ -5,8 +5,9 @@ package teleporter
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -25,6 +26,8 @@ import (
 	gasUtils "github.com/ava-labs/teleporter/utils/gas-utils"
 	teleporterUtils "github.com/ava-labs/teleporter/utils/teleporter-utils"
 	"github.com/ethereum/go-ethereum/common"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
@@ -34,6 +37,8 @@ type factory struct {
 	protocolAddress common.Address
 	logger          logging.Logger
 	deciderClient   pbDecider.DeciderServiceClient
+	sourceClient    ethclient.Client
+	featureFlags    *messages.FeatureFlagClient
 }
 
 type messageHandler struct {
@@ -55,25 +60,32 @@ func (s *emptyDeciderClient) ShouldSendMessage(
 	return &pbDecider.ShouldSendMessageResponse{ShouldSendMessage: true}, nil
 }
 
+func init() {
+	messages.RegisterManagerFactory(
+		config.TELEPORTER,
+		func(params messages.ManagerFactoryParams) (messages.MessageHandlerFactory, error) {
+			return NewMessageHandlerFactory(
+				params.Logger,
+				params.Address,
+				params.Config,
+				params.DeciderConnection,
+				params.SourceClient,
+				params.FeatureFlags,
+			)
+		},
+	)
+}
+
 func NewMessageHandlerFactory(
 	logger logging.Logger,
 	messageProtocolAddress common.Address,
 	messageProtocolConfig config.MessageProtocolConfig,
 	deciderClientConn *grpc.ClientConn,
+	sourceClient ethclient.Client,
+	featureFlags *messages.FeatureFlagClient,
 ) (messages.MessageHandlerFactory, error) {
-	// Marshal the map and unmarshal into the Teleporter config
-	data, err := json.Marshal(messageProtocolConfig.Settings)
+	messageConfig, err := parseConfig(messageProtocolConfig.Settings)
 	if err != nil {
-		logger.Error("Failed to marshal Teleporter config")
-		return nil, err
-	}
-	var messageConfig Config
-	if err := json.Unmarshal(data, &messageConfig); err != nil {
-		logger.Error("Failed to unmarshal Teleporter config")
-		return nil, err
-	}
-
-	if err := messageConfig.Validate(); err != nil {
 		logger.Error(
 			"Invalid Teleporter config.",
 			zap.Error(err),
@@ -93,6 +105,8 @@ func NewMessageHandlerFactory(
 		protocolAddress: messageProtocolAddress,
 		logger:          logger,
 		deciderClient:   deciderClient,
+		sourceClient:    sourceClient,
+		featureFlags:    featureFlags,
 	}, nil
 }
 
@@ -105,8 +119,25 @@ func (f *factory) NewMessageHandler(unsignedMessage *warp.UnsignedMessage) (mess
 		)
 		return nil, err
 	}
+
+	// Attach the fields identifying the message to each log line about it
+	logger := utils.NewMessageLogger(
+		f.logger,
+		unsignedMessage.SourceChainID,
+		teleporterMessage.DestinationBlockchainID,
+		unsignedMessage.ID(),
+	)
+	teleporterMessageID, err := teleporterUtils.CalculateMessageID(
+		f.protocolAddress,
+		unsignedMessage.SourceChainID,
+		teleporterMessage.DestinationBlockchainID,
+		teleporterMessage.MessageNonce,
+	)
+	if err == nil {
+		logger = utils.LoggerWithFields(logger, zap.String(utils.MessageIDLogKey, teleporterMessageID.String()))
+	}
 	return &messageHandler{
-		logger:            f.logger,
+		logger:            logger,
 		teleporterMessage: teleporterMessage,
 		unsignedMessage:   unsignedMessage,
 		factory:           f,
@@ -132,6 +163,10 @@ func (m *messageHandler) GetUnsignedMessage() *warp.UnsignedMessage {
 	return m.unsignedMessage
 }
 
+func (m *messageHandler) GetMessageProtocol() string {
+	return config.TELEPORTER.String()
+}
+
 func (m *messageHandler) GetMessageRoutingInfo() (
 	ids.ID,
 	common.Address,
@@ -159,8 +194,97 @@ func (m *messageHandler) ShouldSendMessage(destinationClient vms.DestinationClie
 		return false, fmt.Errorf("failed to calculate Teleporter message ID: %w", err)
 	}
 
-	senderAddress := destinationClient.SenderAddress()
-	if !isAllowedRelayer(m.teleporterMessage.AllowedRelayerAddresses, senderAddress) {
+	if m.factory.messageConfig.isBlockedMessage(teleporterMessageID) {
+		m.logger.Warn(
+			"Message is blocked. Skipping delivery.",
+			zap.String("sourceBlockchainID", m.unsignedMessage.SourceChainID.String()),
+			zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+			zap.String("warpMessageID", m.unsignedMessage.ID().String()),
+			zap.String("teleporterMessageID", teleporterMessageID.String()),
+		)
+		return false, nil
+	}
+
+	// Receipt-only messages, such as those sent by sendSpecifiedReceipts, carry no message to execute, so are not
+	// subject to the filters on the message payload and required gas limit
+	receiptOnly := m.isReceiptOnly()
+
+	if !receiptOnly && !m.factory.messageConfig.acceptsPayload(m.teleporterMessage.Message) {
+		m.logger.Debug(
+			"Message payload does not match a configured payload selector. Skipping delivery.",
+			zap.String("sourceBlockchainID", m.unsignedMessage.SourceChainID.String()),
+			zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+			zap.String("warpMessageID", m.unsignedMessage.ID().String()),
+			zap.String("teleporterMessageID", teleporterMessageID.String()),
+		)
+		return false, nil
+	}
+
+	if !receiptOnly && !m.factory.messageConfig.acceptsPayloadSize(m.teleporterMessage.Message) {
+		m.logger.Debug(
+			"Message payload size is outside the configured bounds. Skipping delivery.",
+			zap.String("sourceBlockchainID", m.unsignedMessage.SourceChainID.String()),
+			zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+			zap.String("warpMessageID", m.unsignedMessage.ID().String()),
+			zap.String("teleporterMessageID", teleporterMessageID.String()),
+			zap.Int("payloadBytes", len(m.teleporterMessage.Message)),
+			zap.Uint64("minPayloadBytes", m.factory.messageConfig.MinPayloadBytes),
+			zap.Uint64("maxPayloadBytes", m.factory.messageConfig.MaxPayloadBytes),
+		)
+		return false, nil
+	}
+
+	if !m.factory.messageConfig.isSampled(teleporterMessageID) {
+		m.logger.Debug(
+			"Message not selected by the relay sample rate. Skipping delivery.",
+			zap.String("sourceBlockchainID", m.unsignedMessage.SourceChainID.String()),
+			zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+			zap.String("warpMessageID", m.unsignedMessage.ID().String()),
+			zap.String("teleporterMessageID", teleporterMessageID.String()),
+		)
+		return false, nil
+	}
+
+	if !m.factory.featureFlags.CorridorEnabled(m.unsignedMessage.SourceChainID, destinationBlockchainID) {
+		m.logger.Info(
+			"Corridor disabled by feature flag. Skipping delivery.",
+			zap.String("sourceBlockchainID", m.unsignedMessage.SourceChainID.String()),
+			zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+			zap.String("warpMessageID", m.unsignedMessage.ID().String()),
+			zap.String("teleporterMessageID", teleporterMessageID.String()),
+		)
+		return false, nil
+	}
+
+	// A message with a required gas limit of zero, or below the configured minimum, is invalid. Executing
+	// the message on the destination would fail, so do not attempt to deliver it.
+	if !receiptOnly && !m.factory.messageConfig.isValidRequiredGasLimit(m.teleporterMessage.RequiredGasLimit) {
+		m.logger.Warn(
+			"Message has an invalid required gas limit. Skipping delivery.",
+			zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+			zap.String("warpMessageID", m.unsignedMessage.ID().String()),
+			zap.String("teleporterMessageID", teleporterMessageID.String()),
+			zap.Stringer("requiredGasLimit", m.teleporterMessage.RequiredGasLimit),
+			zap.Uint64("minRequiredGasLimit", m.factory.messageConfig.MinRequiredGasLimit),
+		)
+		return false, &messages.SkippedMessageError{
+			Reason: fmt.Sprintf(
+				"required gas limit %s is zero or below the minimum of %d",
+				m.teleporterMessage.RequiredGasLimit,
+				m.factory.messageConfig.MinRequiredGasLimit,
+			),
+		}
+	}
+
+	// The delivery is sent from one of the relayer's sender addresses that is allowed, so at least one must be
+	allowed := false
+	for _, senderAddress := range destinationClient.SenderAddresses() {
+		if isAllowedRelayer(m.teleporterMessage.AllowedRelayerAddresses, senderAddress) {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
 		m.logger.Info(
 			"Relayer EOA not allowed to deliver this message.",
 			zap.String("destinationBlockchainID", destinationBlockchainID.String()),
@@ -170,6 +294,61 @@ func (m *messageHandler) ShouldSendMessage(destinationClient vms.DestinationClie
 		return false, nil
 	}
 
+	// Check if the message's fee is accepted by this relayer
+	if len(m.factory.messageConfig.AcceptedFeeTypes) != 0 ||
+		len(m.factory.messageConfig.MinFeeWei) != 0 ||
+		len(m.factory.messageConfig.AllowedFeeTokens) != 0 {
+		feeTokenAddress, feeAmount, err := m.getFeeInfo(teleporterMessageID)
+		if err != nil {
+			m.logger.Error(
+				"Failed to get message fee info from source chain.",
+				zap.String("warpMessageID", m.unsignedMessage.ID().String()),
+				zap.String("teleporterMessageID", teleporterMessageID.String()),
+				zap.Error(err),
+			)
+			return false, err
+		}
+		feeType := getFeeType(feeTokenAddress, feeAmount)
+		if !m.factory.messageConfig.acceptsFeeType(feeType) {
+			m.logger.Info(
+				"Message fee type not accepted by this relayer.",
+				zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+				zap.String("warpMessageID", m.unsignedMessage.ID().String()),
+				zap.String("teleporterMessageID", teleporterMessageID.String()),
+				zap.String("feeType", feeType),
+			)
+			return false, nil
+		}
+		if feeType == erc20FeeType && !m.factory.messageConfig.acceptsFeeToken(feeTokenAddress) {
+			m.logger.Info(
+				"Message fee token not accepted by this relayer. Skipping delivery.",
+				zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+				zap.String("warpMessageID", m.unsignedMessage.ID().String()),
+				zap.String("teleporterMessageID", teleporterMessageID.String()),
+				zap.String("feeTokenAddress", feeTokenAddress.Hex()),
+				zap.Stringer("feeAmount", feeAmount),
+			)
+			return false, nil
+		}
+		if minFee := m.factory.messageConfig.minFee(feeTokenAddress); minFee != nil && feeAmount.Cmp(minFee) < 0 {
+			// The estimated cost is in the destination chain's native token, so is logged to help operators
+			// tune the threshold rather than compared to the fee directly.
+			estimatedGas, estimatedCost := m.estimateDeliveryCost(destinationClient)
+			m.logger.Info(
+				"Message fee below minimum. Skipping delivery.",
+				zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+				zap.String("warpMessageID", m.unsignedMessage.ID().String()),
+				zap.String("teleporterMessageID", teleporterMessageID.String()),
+				zap.String("feeTokenAddress", feeTokenAddress.Hex()),
+				zap.Stringer("feeAmount", feeAmount),
+				zap.Stringer("minFee", minFee),
+				zap.Uint64("estimatedGas", estimatedGas),
+				zap.Stringer("estimatedCost", estimatedCost),
+			)
+			return false, nil
+		}
+	}
+
 	// Check if the message has already been delivered to the destination chain
 	teleporterMessenger := m.factory.getTeleporterMessenger(destinationClient)
 	delivered, err := teleporterMessenger.MessageReceived(&bind.CallOpts{}, teleporterMessageID)
@@ -213,6 +392,86 @@ func (m *messageHandler) ShouldSendMessage(destinationClient vms.DestinationClie
 	return decision, nil
 }
 
+// isReceiptOnly returns true if the message carries receipts, but no message to execute.
+func (m *messageHandler) isReceiptOnly() bool {
+	return len(m.teleporterMessage.Message) == 0 && len(m.teleporterMessage.Receipts) != 0
+}
+
+// GetPriority returns the fee amount attached to the message, so that messages with higher fees are delivered
+// first. Fees in different tokens are compared by amount alone.
+func (m *messageHandler) GetPriority() *big.Int {
+	teleporterMessageID, err := teleporterUtils.CalculateMessageID(
+		m.factory.protocolAddress,
+		m.unsignedMessage.SourceChainID,
+		m.teleporterMessage.DestinationBlockchainID,
+		m.teleporterMessage.MessageNonce,
+	)
+	if err != nil {
+		m.logger.Warn(
+			"Failed to calculate Teleporter message ID",
+			zap.String("warpMessageID", m.unsignedMessage.ID().String()),
+			zap.Error(err),
+		)
+		return nil
+	}
+	_, feeAmount, err := m.getFeeInfo(teleporterMessageID)
+	if err != nil {
+		m.logger.Warn(
+			"Failed to get message fee info from source chain. Delivering with the lowest priority.",
+			zap.String("warpMessageID", m.unsignedMessage.ID().String()),
+			zap.String("teleporterMessageID", teleporterMessageID.String()),
+			zap.Error(err),
+		)
+		return nil
+	}
+	return feeAmount
+}
+
+// Queries the source chain's Teleporter contract for the fee token address and amount attached to the message.
+func (m *messageHandler) getFeeInfo(teleporterMessageID ids.ID) (common.Address, *big.Int, error) {
+	if m.factory.sourceClient == nil {
+		return common.Address{}, nil, fmt.Errorf("no source client configured to query fee info")
+	}
+	teleporterMessenger, err := teleportermessenger.NewTeleporterMessenger(
+		m.factory.protocolAddress,
+		m.factory.sourceClient,
+	)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	return teleporterMessenger.GetFeeInfo(&bind.CallOpts{}, teleporterMessageID)
+}
+
+// Estimates the gas used to deliver the message, and its cost at the destination chain's suggested gas price.
+// The number of signers is not known before aggregation, so the estimate excludes signature verification.
+// Best effort: returns a nil cost if the destination gas price cannot be fetched.
+func (m *messageHandler) estimateDeliveryCost(destinationClient vms.DestinationClient) (uint64, *big.Int) {
+	gasLimit, err := gasUtils.CalculateReceiveMessageGasLimit(
+		0,
+		m.teleporterMessage.RequiredGasLimit,
+		len(m.unsignedMessage.Bytes()),
+		len(m.unsignedMessage.Payload),
+		len(m.teleporterMessage.Receipts),
+	)
+	if err != nil {
+		return 0, nil
+	}
+	client, ok := destinationClient.Client().(ethclient.Client)
+	if !ok {
+		return gasLimit, nil
+	}
+	gasPrice, err := client.SuggestGasPrice(context.Background())
+	if err != nil {
+		m.logger.Debug(
+			"Failed to get destination gas price",
+			zap.String("destinationBlockchainID", destinationClient.DestinationBlockchainID().String()),
+			zap.Error(err),
+		)
+		return gasLimit, nil
+	}
+	return gasLimit, new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), gasPrice)
+}
+
 // Queries the decider service to determine whether this message should be
 // sent. If the decider client is nil, returns true.
 func (m *messageHandler) getShouldSendMessageFromDecider() (bool, error) {
@@ -242,6 +501,7 @@ func (m *messageHandler) getShouldSendMessageFromDecider() (bool, error) {
 // method of the Teleporter contract, and dispatches transaction construction and broadcast to the
 // destination client.
 func (m *messageHandler) SendMessage(
+	ctx context.Context,
 	signedMessage *warp.Message,
 	destinationClient vms.DestinationClient,
 ) (common.Hash, error) {
@@ -255,6 +515,7 @@ func (m *messageHandler) SendMessage(
 	if err != nil {
 		return common.Hash{}, fmt.Errorf("failed to calculate Teleporter message ID: %w", err)
 	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String(utils.MessageIDLogKey, teleporterMessageID.String()))
 
 	m.logger.Info(
 		"Sending message to destination chain",
@@ -290,9 +551,11 @@ func (m *messageHandler) SendMessage(
 		return common.Hash{}, err
 	}
 	// Construct the transaction call data to call the receive cross chain message method of the receiver precompile.
+	// The deliverer address is recorded as the deliverer of the message, independently of the reward address
+	// that governs which fees are accepted.
 	callData, err := teleportermessenger.PackReceiveCrossChainMessage(
 		0,
-		common.HexToAddress(m.factory.messageConfig.RewardAddress),
+		m.factory.messageConfig.getDelivererAddress(),
 	)
 	if err != nil {
 		m.logger.Error(
@@ -304,12 +567,27 @@ func (m *messageHandler) SendMessage(
 		return common.Hash{}, err
 	}
 
+	// Restrict the delivery to the sender addresses allowed by the message
 	txHash, err := destinationClient.SendTx(
+		vms.WithAllowedSenders(ctx, m.teleporterMessage.AllowedRelayerAddresses),
 		signedMessage,
 		m.factory.protocolAddress.Hex(),
 		gasLimit,
 		callData,
 	)
+	if errors.Is(err, vms.ErrDryRun) {
+		return common.Hash{}, err
+	}
+	if errors.Is(err, vms.ErrMessageAlreadyReceived) {
+		// Another relayer delivered the message after ShouldSendMessage checked that it was not yet received
+		m.logger.Info(
+			"Message already delivered to destination chain. Not sending.",
+			zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+			zap.String("warpMessageID", signedMessage.ID().String()),
+			zap.String("teleporterMessageID", teleporterMessageID.String()),
+		)
+		return common.Hash{}, nil
+	}
 	if err != nil {
 		m.logger.Error(
 			"Failed to send tx.",
@@ -322,7 +600,7 @@ func (m *messageHandler) SendMessage(
 	}
 
 	// Wait for the message to be included in a block before returning
-	err = m.waitForReceipt(signedMessage, destinationClient, txHash, teleporterMessageID)
+	err = m.waitForReceipt(ctx, signedMessage, destinationClient, txHash, teleporterMessageID)
 	if err != nil {
 		return common.Hash{}, err
 	}
@@ -338,13 +616,14 @@ func (m *messageHandler) SendMessage(
 }
 
 func (m *messageHandler) waitForReceipt(
+	ctx context.Context,
 	signedMessage *warp.Message,
 	destinationClient vms.DestinationClient,
 	txHash common.Hash,
 	teleporterMessageID ids.ID,
 ) error {
 	destinationBlockchainID := destinationClient.DestinationBlockchainID()
-	callCtx, callCtxCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	callCtx, callCtxCancel := context.WithTimeout(ctx, 30*time.Second)
 	defer callCtxCancel()
 	receipt, err := utils.CallWithRetry[*types.Receipt](
 		callCtx,
@@ -12,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/messages"
 	mock_evm "github.com/ava-labs/awm-relayer/vms/evm/mocks"
 	mock_vms "github.com/ava-labs/awm-relayer/vms/mocks"
 	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
@@ -104,6 +105,42 @@ func TestShouldSendMessage(t *testing.T) {
 	messageDelivered, err := teleportermessenger.PackMessageReceivedOutput(true)
 	require.NoError(t, err)
 
+	zeroGasTeleporterMessage := validTeleporterMessage
+	zeroGasTeleporterMessage.RequiredGasLimit = big.NewInt(0)
+	zeroGasMessageBytes, err := teleportermessenger.PackTeleporterMessage(zeroGasTeleporterMessage)
+	require.NoError(t, err)
+	zeroGasAddressedCall, err := warpPayload.NewAddressedCall(
+		messageProtocolAddress.Bytes(),
+		zeroGasMessageBytes,
+	)
+	require.NoError(t, err)
+	zeroGasWarpUnsignedMessage, err := warp.NewUnsignedMessage(
+		0,
+		sourceBlockchainID,
+		zeroGasAddressedCall.Bytes(),
+	)
+	require.NoError(t, err)
+
+	// A receipt-only message, as sent by sendSpecifiedReceipts
+	receiptOnlyTeleporterMessage := validTeleporterMessage
+	receiptOnlyTeleporterMessage.DestinationAddress = common.Address{}
+	receiptOnlyTeleporterMessage.RequiredGasLimit = big.NewInt(0)
+	receiptOnlyTeleporterMessage.AllowedRelayerAddresses = []common.Address{}
+	receiptOnlyTeleporterMessage.Message = []byte{}
+	receiptOnlyMessageBytes, err := teleportermessenger.PackTeleporterMessage(receiptOnlyTeleporterMessage)
+	require.NoError(t, err)
+	receiptOnlyAddressedCall, err := warpPayload.NewAddressedCall(
+		messageProtocolAddress.Bytes(),
+		receiptOnlyMessageBytes,
+	)
+	require.NoError(t, err)
+	receiptOnlyWarpUnsignedMessage, err := warp.NewUnsignedMessage(
+		0,
+		sourceBlockchainID,
+		receiptOnlyAddressedCall.Bytes(),
+	)
+	require.NoError(t, err)
+
 	invalidAddressedCall, err := warpPayload.NewAddressedCall(
 		messageProtocolAddress.Bytes(),
 		validMessageBytes,
@@ -116,22 +153,29 @@ func TestShouldSendMessage(t *testing.T) {
 	)
 	require.NoError(t, err)
 
+	fullSampleRate, zeroSampleRate := 1.0, 0.0
 	testCases := []struct {
 		name                    string
 		destinationBlockchainID ids.ID
 		warpUnsignedMessage     *warp.UnsignedMessage
-		senderAddressResult     common.Address
+		senderAddressResult     []common.Address
 		senderAddressTimes      int
 		clientTimes             int
 		messageReceivedCall     *CallContractChecker
+		blockedMessageIDs       []string
+		payloadSelectors        []string
+		minPayloadBytes         uint64
+		maxPayloadBytes         uint64
+		relaySampleRate         *float64
 		expectedParseError      bool
+		expectedSkipped         bool
 		expectedResult          bool
 	}{
 		{
 			name:                    "valid message",
 			destinationBlockchainID: destinationBlockchainID,
 			warpUnsignedMessage:     warpUnsignedMessage,
-			senderAddressResult:     validRelayerAddress,
+			senderAddressResult:     []common.Address{validRelayerAddress},
 			senderAddressTimes:      1,
 			clientTimes:             1,
 			messageReceivedCall: &CallContractChecker{
@@ -150,7 +194,7 @@ func TestShouldSendMessage(t *testing.T) {
 		{
 			name:                    "invalid destination chain id",
 			destinationBlockchainID: ids.Empty,
-			senderAddressResult:     common.Address{},
+			senderAddressResult:     []common.Address{{}},
 			senderAddressTimes:      1,
 			warpUnsignedMessage:     warpUnsignedMessage,
 		},
@@ -158,7 +202,30 @@ func TestShouldSendMessage(t *testing.T) {
 			name:                    "not allowed",
 			destinationBlockchainID: destinationBlockchainID,
 			warpUnsignedMessage:     warpUnsignedMessage,
-			senderAddressResult:     common.Address{},
+			senderAddressResult:     []common.Address{{}},
+			senderAddressTimes:      1,
+			clientTimes:             0,
+			expectedResult:          false,
+		},
+		{
+			name:                    "one of multiple sender addresses not allowed",
+			destinationBlockchainID: destinationBlockchainID,
+			warpUnsignedMessage:     warpUnsignedMessage,
+			senderAddressResult:     []common.Address{{}, validRelayerAddress},
+			senderAddressTimes:      1,
+			clientTimes:             1,
+			messageReceivedCall: &CallContractChecker{
+				input:          messageReceivedInput,
+				expectedResult: messageNotDelivered,
+				times:          1,
+			},
+			expectedResult: true,
+		},
+		{
+			name:                    "none of multiple sender addresses allowed",
+			destinationBlockchainID: destinationBlockchainID,
+			warpUnsignedMessage:     warpUnsignedMessage,
+			senderAddressResult:     []common.Address{{}, common.HexToAddress("0x01")},
 			senderAddressTimes:      1,
 			clientTimes:             0,
 			expectedResult:          false,
@@ -167,7 +234,7 @@ func TestShouldSendMessage(t *testing.T) {
 			name:                    "message already delivered",
 			destinationBlockchainID: destinationBlockchainID,
 			warpUnsignedMessage:     warpUnsignedMessage,
-			senderAddressResult:     validRelayerAddress,
+			senderAddressResult:     []common.Address{validRelayerAddress},
 			senderAddressTimes:      1,
 			clientTimes:             1,
 			messageReceivedCall: &CallContractChecker{
@@ -177,6 +244,125 @@ func TestShouldSendMessage(t *testing.T) {
 			},
 			expectedResult: false,
 		},
+		{
+			name:                    "blocked message",
+			destinationBlockchainID: destinationBlockchainID,
+			warpUnsignedMessage:     warpUnsignedMessage,
+			blockedMessageIDs:       []string{messageID.String()},
+			expectedResult:          false,
+		},
+		{
+			name:                    "matching payload selector",
+			destinationBlockchainID: destinationBlockchainID,
+			warpUnsignedMessage:     warpUnsignedMessage,
+			senderAddressResult:     []common.Address{validRelayerAddress},
+			senderAddressTimes:      1,
+			clientTimes:             1,
+			messageReceivedCall: &CallContractChecker{
+				input:          messageReceivedInput,
+				expectedResult: messageNotDelivered,
+				times:          1,
+			},
+			payloadSelectors: []string{"0xa9059cbb", "0x01020304"},
+			expectedResult:   true,
+		},
+		{
+			name:                    "non-matching payload selector",
+			destinationBlockchainID: destinationBlockchainID,
+			warpUnsignedMessage:     warpUnsignedMessage,
+			payloadSelectors:        []string{"0xa9059cbb"},
+			expectedResult:          false,
+		},
+		{
+			name:                    "payload size within bounds",
+			destinationBlockchainID: destinationBlockchainID,
+			warpUnsignedMessage:     warpUnsignedMessage,
+			senderAddressResult:     []common.Address{validRelayerAddress},
+			senderAddressTimes:      1,
+			clientTimes:             1,
+			messageReceivedCall: &CallContractChecker{
+				input:          messageReceivedInput,
+				expectedResult: messageNotDelivered,
+				times:          1,
+			},
+			minPayloadBytes: 4,
+			maxPayloadBytes: 4,
+			expectedResult:  true,
+		},
+		{
+			name:                    "payload below min size",
+			destinationBlockchainID: destinationBlockchainID,
+			warpUnsignedMessage:     warpUnsignedMessage,
+			minPayloadBytes:         5,
+			expectedResult:          false,
+		},
+		{
+			name:                    "payload above max size",
+			destinationBlockchainID: destinationBlockchainID,
+			warpUnsignedMessage:     warpUnsignedMessage,
+			maxPayloadBytes:         3,
+			expectedResult:          false,
+		},
+		{
+			name:                    "selected by relay sample rate",
+			destinationBlockchainID: destinationBlockchainID,
+			warpUnsignedMessage:     warpUnsignedMessage,
+			senderAddressResult:     []common.Address{validRelayerAddress},
+			senderAddressTimes:      1,
+			clientTimes:             1,
+			messageReceivedCall: &CallContractChecker{
+				input:          messageReceivedInput,
+				expectedResult: messageNotDelivered,
+				times:          1,
+			},
+			relaySampleRate: &fullSampleRate,
+			expectedResult:  true,
+		},
+		{
+			name:                    "not selected by relay sample rate",
+			destinationBlockchainID: destinationBlockchainID,
+			warpUnsignedMessage:     warpUnsignedMessage,
+			relaySampleRate:         &zeroSampleRate,
+			expectedResult:          false,
+		},
+		{
+			name:                    "zero required gas limit",
+			destinationBlockchainID: destinationBlockchainID,
+			warpUnsignedMessage:     zeroGasWarpUnsignedMessage,
+			senderAddressTimes:      0,
+			clientTimes:             0,
+			expectedSkipped:         true,
+		},
+		{
+			name:                    "receipt-only message",
+			destinationBlockchainID: destinationBlockchainID,
+			warpUnsignedMessage:     receiptOnlyWarpUnsignedMessage,
+			senderAddressResult:     []common.Address{validRelayerAddress},
+			senderAddressTimes:      1,
+			clientTimes:             1,
+			messageReceivedCall: &CallContractChecker{
+				input:          messageReceivedInput,
+				expectedResult: messageNotDelivered,
+				times:          1,
+			},
+			expectedResult: true,
+		},
+		{
+			name:                    "receipt-only message with payload filters",
+			destinationBlockchainID: destinationBlockchainID,
+			warpUnsignedMessage:     receiptOnlyWarpUnsignedMessage,
+			senderAddressResult:     []common.Address{validRelayerAddress},
+			senderAddressTimes:      1,
+			clientTimes:             1,
+			messageReceivedCall: &CallContractChecker{
+				input:          messageReceivedInput,
+				expectedResult: messageNotDelivered,
+				times:          1,
+			},
+			payloadSelectors: []string{"0xa9059cbb"},
+			minPayloadBytes:  4,
+			expectedResult:   true,
+		},
 	}
 	for _, test := range testCases {
 		t.Run(test.name, func(t *testing.T) {
@@ -185,10 +371,24 @@ func TestShouldSendMessage(t *testing.T) {
 
 			mockClient := mock_vms.NewMockDestinationClient(ctrl)
 
+			protocolConfig := messageProtocolConfig
+			if test.blockedMessageIDs != nil || test.payloadSelectors != nil || test.relaySampleRate != nil ||
+				test.minPayloadBytes != 0 || test.maxPayloadBytes != 0 {
+				protocolConfig.Settings = map[string]interface{}{
+					"reward-address":      messageProtocolConfig.Settings["reward-address"],
+					"blocked-message-ids": test.blockedMessageIDs,
+					"payload-selectors":   test.payloadSelectors,
+					"min-payload-bytes":   test.minPayloadBytes,
+					"max-payload-bytes":   test.maxPayloadBytes,
+					"relay-sample-rate":   test.relaySampleRate,
+				}
+			}
 			factory, err := NewMessageHandlerFactory(
 				logger,
 				messageProtocolAddress,
-				messageProtocolConfig,
+				protocolConfig,
+				nil,
+				nil,
 				nil,
 			)
 			require.NoError(t, err)
@@ -206,7 +406,7 @@ func TestShouldSendMessage(t *testing.T) {
 				Return(ethClient).
 				Times(test.clientTimes)
 			mockClient.EXPECT().
-				SenderAddress().
+				SenderAddresses().
 				Return(test.senderAddressResult).
 				Times(test.senderAddressTimes)
 			mockClient.EXPECT().DestinationBlockchainID().Return(destinationBlockchainID).AnyTimes()
@@ -222,6 +422,196 @@ func TestShouldSendMessage(t *testing.T) {
 					Times(test.messageReceivedCall.times)
 			}
 
+			result, err := messageHandler.ShouldSendMessage(mockClient)
+			if test.expectedSkipped {
+				var skippedErr *messages.SkippedMessageError
+				require.ErrorAs(t, err, &skippedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expectedResult, result)
+		})
+	}
+}
+
+func TestShouldSendMessageFeeTypes(t *testing.T) {
+	validMessageBytes, err := teleportermessenger.PackTeleporterMessage(validTeleporterMessage)
+	require.NoError(t, err)
+
+	validAddressedCall, err := warpPayload.NewAddressedCall(
+		messageProtocolAddress.Bytes(),
+		validMessageBytes,
+	)
+	require.NoError(t, err)
+
+	sourceBlockchainID := ids.Empty
+	warpUnsignedMessage, err := warp.NewUnsignedMessage(
+		0,
+		sourceBlockchainID,
+		validAddressedCall.Bytes(),
+	)
+	require.NoError(t, err)
+
+	messageID, err := teleporterUtils.CalculateMessageID(
+		messageProtocolAddress,
+		sourceBlockchainID,
+		destinationBlockchainID,
+		validTeleporterMessage.MessageNonce,
+	)
+	require.NoError(t, err)
+
+	messageReceivedInput, err := teleportermessenger.PackMessageReceived(messageID)
+	require.NoError(t, err)
+	messageNotDelivered, err := teleportermessenger.PackMessageReceivedOutput(false)
+	require.NoError(t, err)
+
+	teleporterABI, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	require.NoError(t, err)
+	getFeeInfoInput, err := teleporterABI.Pack("getFeeInfo", messageID)
+	require.NoError(t, err)
+	erc20FeeInfo, err := teleporterABI.Methods["getFeeInfo"].Outputs.Pack(
+		common.HexToAddress("0xabcdef0123456789abcdef0123456789abcdef01"),
+		big.NewInt(100),
+	)
+	require.NoError(t, err)
+	nativeFeeInfo, err := teleporterABI.Methods["getFeeInfo"].Outputs.Pack(
+		common.Address{},
+		big.NewInt(0),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name             string
+		acceptedFeeTypes []string
+		minFeeWei        map[string]string
+		allowedFeeTokens []string
+		feeInfoResult    []byte
+		expectedResult   bool
+	}{
+		{
+			name:             "native fee accepted",
+			acceptedFeeTypes: []string{nativeFeeType},
+			feeInfoResult:    nativeFeeInfo,
+			expectedResult:   true,
+		},
+		{
+			name:             "erc20 fee accepted",
+			acceptedFeeTypes: []string{erc20FeeType},
+			feeInfoResult:    erc20FeeInfo,
+			expectedResult:   true,
+		},
+		{
+			name:             "native fee not accepted",
+			acceptedFeeTypes: []string{erc20FeeType},
+			feeInfoResult:    nativeFeeInfo,
+			expectedResult:   false,
+		},
+		{
+			name:             "erc20 fee not accepted",
+			acceptedFeeTypes: []string{nativeFeeType},
+			feeInfoResult:    erc20FeeInfo,
+			expectedResult:   false,
+		},
+		{
+			name:             "both fee types accepted",
+			acceptedFeeTypes: []string{nativeFeeType, erc20FeeType},
+			feeInfoResult:    erc20FeeInfo,
+			expectedResult:   true,
+		},
+		{
+			name:           "fee at minimum",
+			minFeeWei:      map[string]string{"0xabcdef0123456789abcdef0123456789abcdef01": "100"},
+			feeInfoResult:  erc20FeeInfo,
+			expectedResult: true,
+		},
+		{
+			name:           "fee below minimum",
+			minFeeWei:      map[string]string{"0xabcdef0123456789abcdef0123456789abcdef01": "101"},
+			feeInfoResult:  erc20FeeInfo,
+			expectedResult: false,
+		},
+		{
+			name:           "no minimum for fee token",
+			minFeeWei:      map[string]string{"0x1111111111111111111111111111111111111111": "101"},
+			feeInfoResult:  erc20FeeInfo,
+			expectedResult: true,
+		},
+		{
+			name: "allowed fee token",
+			allowedFeeTokens: []string{
+				"0x1111111111111111111111111111111111111111",
+				"0xabcdef0123456789abcdef0123456789abcdef01",
+			},
+			feeInfoResult:  erc20FeeInfo,
+			expectedResult: true,
+		},
+		{
+			name:             "fee token not allowed",
+			allowedFeeTokens: []string{"0x1111111111111111111111111111111111111111"},
+			feeInfoResult:    erc20FeeInfo,
+			expectedResult:   false,
+		},
+		{
+			name:             "native fee with allowed fee tokens",
+			allowedFeeTokens: []string{"0x1111111111111111111111111111111111111111"},
+			feeInfoResult:    nativeFeeInfo,
+			expectedResult:   true,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			logger := logging.NoLog{}
+
+			mockClient := mock_vms.NewMockDestinationClient(ctrl)
+			sourceClient := mock_evm.NewMockClient(ctrl)
+			destinationClient := mock_evm.NewMockClient(ctrl)
+
+			feeConfig := config.MessageProtocolConfig{
+				MessageFormat: config.TELEPORTER.String(),
+				Settings: map[string]interface{}{
+					"reward-address":     "0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+					"accepted-fee-types": test.acceptedFeeTypes,
+					"min-fee-wei":        test.minFeeWei,
+					"allowed-fee-tokens": test.allowedFeeTokens,
+				},
+			}
+			factory, err := NewMessageHandlerFactory(
+				logger,
+				messageProtocolAddress,
+				feeConfig,
+				nil,
+				sourceClient,
+				nil,
+			)
+			require.NoError(t, err)
+			messageHandler, err := factory.NewMessageHandler(warpUnsignedMessage)
+			require.NoError(t, err)
+
+			mockClient.EXPECT().SenderAddresses().Return([]common.Address{validRelayerAddress}).Times(1)
+			mockClient.EXPECT().DestinationBlockchainID().Return(destinationBlockchainID).AnyTimes()
+			sourceClient.EXPECT().
+				CallContract(gomock.Any(), gomock.Eq(interfaces.CallMsg{
+					To:   &messageProtocolAddress,
+					Data: getFeeInfoInput,
+				}), gomock.Any()).
+				Return(test.feeInfoResult, nil).
+				Times(1)
+			if test.expectedResult {
+				mockClient.EXPECT().Client().Return(destinationClient).Times(1)
+				destinationClient.EXPECT().
+					CallContract(gomock.Any(), gomock.Eq(interfaces.CallMsg{
+						To:   &messageProtocolAddress,
+						Data: messageReceivedInput,
+					}), gomock.Any()).
+					Return(messageNotDelivered, nil).
+					Times(1)
+			} else if test.minFeeWei != nil {
+				// The estimated delivery cost is logged for messages below the minimum fee
+				mockClient.EXPECT().Client().Return(destinationClient).Times(1)
+				destinationClient.EXPECT().SuggestGasPrice(gomock.Any()).Return(big.NewInt(25), nil).Times(1)
+			}
+
 			result, err := messageHandler.ShouldSendMessage(mockClient)
 			require.NoError(t, err)
 			require.Equal(t, test.expectedResult, result)
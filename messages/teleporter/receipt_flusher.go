@@ -0,0 +1,212 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/vms"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/teleporter/TeleporterMessenger"
+	teleporterUtils "github.com/ava-labs/teleporter/utils/teleporter-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// Maximum number of receipts sent in a single receipt-only message
+const maxFlushedReceipts = 20
+
+// ReceiptFlusher sends the receipts queued on a destination chain's Teleporter contract for messages from a
+// source chain back to the source chain, if no other message carries them. Receipts are normally included in
+// the next message sent from the destination to the source, so on routes without traffic in that direction,
+// relayer fees on the source chain are not settled.
+//
+// Each receipt that is observed in the queue on two consecutive checks is sent via sendSpecifiedReceipts, which
+// creates a Teleporter message without a payload. That message is relayed to the source chain like any other,
+// so the relayer must also be configured to relay from the destination to the source.
+type ReceiptFlusher struct {
+	logger             logging.Logger
+	messengerAddress   common.Address
+	sourceBlockchainID ids.ID
+	destinationClient  vms.DestinationClient
+	interval           time.Duration
+
+	// IDs of the messages whose receipts were queued at the previous check
+	queued set.Set[ids.ID]
+	// IDs of the messages whose receipts have been sent, and are still queued
+	flushed set.Set[ids.ID]
+}
+
+// NewReceiptFlushers creates a ReceiptFlusher for each destination of [sourceBlockchain], for each of its
+// Teleporter contracts that is configured with a receipt flush interval.
+func NewReceiptFlushers(
+	logger logging.Logger,
+	sourceBlockchain *config.SourceBlockchain,
+	destinationClients map[ids.ID]vms.DestinationClient,
+) ([]*ReceiptFlusher, error) {
+	var flushers []*ReceiptFlusher
+	for address, messageProtocolConfig := range sourceBlockchain.MessageContracts {
+		if config.ParseMessageProtocol(messageProtocolConfig.MessageFormat) != config.TELEPORTER {
+			continue
+		}
+		messageConfig, err := parseConfig(messageProtocolConfig.Settings)
+		if err != nil {
+			logger.Error(
+				"Invalid Teleporter config.",
+				zap.Error(err),
+			)
+			return nil, err
+		}
+		interval := messageConfig.getReceiptFlushInterval()
+		if interval == 0 {
+			continue
+		}
+		for _, destination := range sourceBlockchain.SupportedDestinations {
+			destinationClient, ok := destinationClients[destination.GetBlockchainID()]
+			if !ok {
+				continue
+			}
+			flushers = append(flushers, &ReceiptFlusher{
+				logger:             logger,
+				messengerAddress:   common.HexToAddress(address),
+				sourceBlockchainID: sourceBlockchain.GetBlockchainID(),
+				destinationClient:  destinationClient,
+				interval:           interval,
+			})
+		}
+	}
+	return flushers, nil
+}
+
+// Run checks the receipt queue on each interval until [ctx] is canceled.
+func (f *ReceiptFlusher) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := f.flush(ctx); err != nil {
+				f.logger.Warn(
+					"Failed to flush Teleporter receipts",
+					zap.String("sourceBlockchainID", f.sourceBlockchainID.String()),
+					zap.String("destinationBlockchainID", f.destinationClient.DestinationBlockchainID().String()),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+}
+
+// flush sends the receipts that have remained queued since the previous check, and have not already been sent.
+func (f *ReceiptFlusher) flush(ctx context.Context) error {
+	client, ok := f.destinationClient.Client().(ethclient.Client)
+	if !ok {
+		return errors.New("destination client is not an Ethereum client")
+	}
+	messenger, err := teleportermessenger.NewTeleporterMessengerCaller(f.messengerAddress, client)
+	if err != nil {
+		return err
+	}
+	destinationBlockchainID := f.destinationClient.DestinationBlockchainID()
+	opts := &bind.CallOpts{Context: ctx}
+	size, err := messenger.GetReceiptQueueSize(opts, f.sourceBlockchainID)
+	if err != nil {
+		return fmt.Errorf("failed to get receipt queue size: %w", err)
+	}
+
+	var (
+		queued      set.Set[ids.ID]
+		receiptIDs  [][32]byte
+		numReceipts = size.Int64()
+	)
+	for i := int64(0); i < numReceipts; i++ {
+		receipt, err := messenger.GetReceiptAtIndex(opts, f.sourceBlockchainID, big.NewInt(i))
+		if err != nil {
+			return fmt.Errorf("failed to get receipt at index %d: %w", i, err)
+		}
+		messageID, err := teleporterUtils.CalculateMessageID(
+			f.messengerAddress,
+			f.sourceBlockchainID,
+			destinationBlockchainID,
+			receipt.ReceivedMessageNonce,
+		)
+		if err != nil {
+			return err
+		}
+		queued.Add(messageID)
+		if f.queued.Contains(messageID) && !f.flushed.Contains(messageID) && len(receiptIDs) < maxFlushedReceipts {
+			receiptIDs = append(receiptIDs, messageID)
+		}
+	}
+	f.queued = queued
+	// Receipts sent by sendSpecifiedReceipts remain queued until carried by another message
+	for messageID := range f.flushed {
+		if !queued.Contains(messageID) {
+			f.flushed.Remove(messageID)
+		}
+	}
+	if len(receiptIDs) == 0 {
+		return nil
+	}
+
+	txHash, err := f.sendReceipts(ctx, client, receiptIDs)
+	if err != nil {
+		return err
+	}
+	for _, messageID := range receiptIDs {
+		f.flushed.Add(messageID)
+	}
+	f.logger.Info(
+		"Sent receipt-only Teleporter message",
+		zap.String("sourceBlockchainID", f.sourceBlockchainID.String()),
+		zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+		zap.Int("numReceipts", len(receiptIDs)),
+		zap.String("txHash", txHash.String()),
+	)
+	return nil
+}
+
+// sendReceipts calls sendSpecifiedReceipts on the destination chain's Teleporter contract for [receiptIDs],
+// without a fee or allowed relayers.
+func (f *ReceiptFlusher) sendReceipts(
+	ctx context.Context,
+	client ethclient.Client,
+	receiptIDs [][32]byte,
+) (common.Hash, error) {
+	abi, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	callData, err := abi.Pack(
+		"sendSpecifiedReceipts",
+		f.sourceBlockchainID,
+		receiptIDs,
+		teleportermessenger.TeleporterFeeInfo{Amount: big.NewInt(0)},
+		[]common.Address{},
+	)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to pack sendSpecifiedReceipts call data: %w", err)
+	}
+	gasLimit, err := client.EstimateGas(ctx, interfaces.CallMsg{
+		From: f.destinationClient.SenderAddresses()[0],
+		To:   &f.messengerAddress,
+		Data: callData,
+	})
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+	return vms.SendCall(ctx, f.destinationClient, f.messengerAddress.Hex(), gasLimit, callData)
+}
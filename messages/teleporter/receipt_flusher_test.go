@@ -0,0 +1,245 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package teleporter
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	mock_evm "github.com/ava-labs/awm-relayer/vms/evm/mocks"
+	mock_vms "github.com/ava-labs/awm-relayer/vms/mocks"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/teleporter/TeleporterMessenger"
+	teleporterUtils "github.com/ava-labs/teleporter/utils/teleporter-utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// callSendingDestinationClient is a destination client that supports sending transactions without a Warp message
+type callSendingDestinationClient struct {
+	*mock_vms.MockDestinationClient
+	*mock_vms.MockCallSender
+}
+
+func TestReceiptFlusher(t *testing.T) {
+	sourceBlockchainID := ids.GenerateTestID()
+	abi, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	require.NoError(t, err)
+
+	messageID := func(nonce int64) ids.ID {
+		id, err := teleporterUtils.CalculateMessageID(
+			messageProtocolAddress,
+			sourceBlockchainID,
+			destinationBlockchainID,
+			big.NewInt(nonce),
+		)
+		require.NoError(t, err)
+		return id
+	}
+
+	testCases := []struct {
+		name string
+		// The nonces of the messages whose receipts are queued at each check
+		queues [][]int64
+		// The nonces of the messages whose receipts are sent at each check
+		expectedFlushes [][]int64
+	}{
+		{
+			name:            "empty queue",
+			queues:          [][]int64{{}, {}},
+			expectedFlushes: [][]int64{nil, nil},
+		},
+		{
+			name:            "receipts carried by another message",
+			queues:          [][]int64{{1, 2}, {3}},
+			expectedFlushes: [][]int64{nil, nil},
+		},
+		{
+			name:            "idle receipts flushed once",
+			queues:          [][]int64{{1, 2}, {1, 2, 3}, {1, 2, 3}},
+			expectedFlushes: [][]int64{nil, {1, 2}, {3}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ethClient := mock_evm.NewMockClient(ctrl)
+			destinationClient := &callSendingDestinationClient{
+				MockDestinationClient: mock_vms.NewMockDestinationClient(ctrl),
+				MockCallSender:        mock_vms.NewMockCallSender(ctrl),
+			}
+			destinationClient.MockDestinationClient.EXPECT().Client().Return(ethClient).AnyTimes()
+			destinationClient.MockDestinationClient.EXPECT().
+				DestinationBlockchainID().
+				Return(destinationBlockchainID).
+				AnyTimes()
+			destinationClient.MockDestinationClient.EXPECT().
+				SenderAddresses().
+				Return([]common.Address{validRelayerAddress}).
+				AnyTimes()
+			flusher := &ReceiptFlusher{
+				logger:             logging.NoLog{},
+				messengerAddress:   messageProtocolAddress,
+				sourceBlockchainID: sourceBlockchainID,
+				destinationClient:  destinationClient,
+			}
+
+			for i, queue := range test.queues {
+				// Serve the receipt queue from the destination's Teleporter contract
+				ethClient.EXPECT().CallContract(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, call interfaces.CallMsg, _ *big.Int) ([]byte, error) {
+						method, err := abi.MethodById(call.Data)
+						require.NoError(t, err)
+						args, err := method.Inputs.Unpack(call.Data[4:])
+						require.NoError(t, err)
+						if method.Name == "getReceiptQueueSize" {
+							return method.Outputs.Pack(big.NewInt(int64(len(queue))))
+						}
+						require.Equal(t, "getReceiptAtIndex", method.Name)
+						index := args[1].(*big.Int).Int64()
+						return method.Outputs.Pack(teleportermessenger.TeleporterMessageReceipt{
+							ReceivedMessageNonce: big.NewInt(queue[index]),
+							RelayerRewardAddress: validRelayerAddress,
+						})
+					},
+				).Times(len(queue) + 1)
+
+				expectedFlush := test.expectedFlushes[i]
+				if expectedFlush != nil {
+					ethClient.EXPECT().EstimateGas(gomock.Any(), gomock.Any()).Return(uint64(200_000), nil)
+					destinationClient.MockCallSender.EXPECT().
+						SendCall(gomock.Any(), messageProtocolAddress.Hex(), uint64(200_000), gomock.Any()).
+						DoAndReturn(func(_ context.Context, _ string, _ uint64, callData []byte) (common.Hash, error) {
+							args, err := abi.Methods["sendSpecifiedReceipts"].Inputs.Unpack(callData[4:])
+							require.NoError(t, err)
+							var expectedIDs [][32]byte
+							for _, nonce := range expectedFlush {
+								expectedIDs = append(expectedIDs, messageID(nonce))
+							}
+							require.Equal(t, [32]byte(sourceBlockchainID), args[0])
+							require.Equal(t, expectedIDs, args[1])
+							return common.Hash{1}, nil
+						})
+				}
+				require.NoError(t, flusher.flush(context.Background()))
+			}
+		})
+	}
+}
+
+// Test that the receipt-only message sent by the flusher is relayed back to the source blockchain.
+func TestFlushedReceiptsRelayed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sourceBlockchainID := ids.GenerateTestID()
+	abi, err := teleportermessenger.TeleporterMessengerMetaData.GetAbi()
+	require.NoError(t, err)
+
+	// Flush the receipt of a single message, and capture the sendSpecifiedReceipts call
+	ethClient := mock_evm.NewMockClient(ctrl)
+	flusherClient := &callSendingDestinationClient{
+		MockDestinationClient: mock_vms.NewMockDestinationClient(ctrl),
+		MockCallSender:        mock_vms.NewMockCallSender(ctrl),
+	}
+	flusherClient.MockDestinationClient.EXPECT().Client().Return(ethClient).AnyTimes()
+	flusherClient.MockDestinationClient.EXPECT().DestinationBlockchainID().Return(destinationBlockchainID).AnyTimes()
+	flusherClient.MockDestinationClient.EXPECT().
+		SenderAddresses().
+		Return([]common.Address{validRelayerAddress}).
+		AnyTimes()
+	receipt := teleportermessenger.TeleporterMessageReceipt{
+		ReceivedMessageNonce: big.NewInt(1),
+		RelayerRewardAddress: validRelayerAddress,
+	}
+	ethClient.EXPECT().CallContract(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, call interfaces.CallMsg, _ *big.Int) ([]byte, error) {
+			method, err := abi.MethodById(call.Data)
+			require.NoError(t, err)
+			if method.Name == "getReceiptQueueSize" {
+				return method.Outputs.Pack(big.NewInt(1))
+			}
+			return method.Outputs.Pack(receipt)
+		},
+	).Times(4)
+	ethClient.EXPECT().EstimateGas(gomock.Any(), gomock.Any()).Return(uint64(200_000), nil)
+	var sendSpecifiedReceiptsArgs []interface{}
+	flusherClient.MockCallSender.EXPECT().
+		SendCall(gomock.Any(), messageProtocolAddress.Hex(), uint64(200_000), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ uint64, callData []byte) (common.Hash, error) {
+			sendSpecifiedReceiptsArgs, err = abi.Methods["sendSpecifiedReceipts"].Inputs.Unpack(callData[4:])
+			require.NoError(t, err)
+			return common.Hash{1}, nil
+		})
+	flusher := &ReceiptFlusher{
+		logger:             logging.NoLog{},
+		messengerAddress:   messageProtocolAddress,
+		sourceBlockchainID: sourceBlockchainID,
+		destinationClient:  flusherClient,
+	}
+	require.NoError(t, flusher.flush(context.Background()))
+	require.NoError(t, flusher.flush(context.Background()))
+	require.NotNil(t, sendSpecifiedReceiptsArgs)
+
+	// The Teleporter message emitted by sendSpecifiedReceipts carries the receipts, with no message to execute
+	allowedRelayerAddresses := sendSpecifiedReceiptsArgs[3].([]common.Address)
+	teleporterMessage := teleportermessenger.TeleporterMessage{
+		MessageNonce:            big.NewInt(1),
+		OriginSenderAddress:     validRelayerAddress,
+		DestinationBlockchainID: sendSpecifiedReceiptsArgs[0].([32]byte),
+		DestinationAddress:      common.Address{},
+		RequiredGasLimit:        big.NewInt(0),
+		AllowedRelayerAddresses: allowedRelayerAddresses,
+		Receipts:                []teleportermessenger.TeleporterMessageReceipt{receipt},
+		Message:                 []byte{},
+	}
+	teleporterMessageBytes, err := teleportermessenger.PackTeleporterMessage(teleporterMessage)
+	require.NoError(t, err)
+	addressedCall, err := warpPayload.NewAddressedCall(messageProtocolAddress.Bytes(), teleporterMessageBytes)
+	require.NoError(t, err)
+	unsignedMessage, err := warp.NewUnsignedMessage(0, destinationBlockchainID, addressedCall.Bytes())
+	require.NoError(t, err)
+	signedMessage, err := warp.NewMessage(unsignedMessage, &warp.BitSetSignature{})
+	require.NoError(t, err)
+
+	// The message is relayed to the source blockchain
+	relayEthClient := mock_evm.NewMockClient(ctrl)
+	relayClient := mock_vms.NewMockDestinationClient(ctrl)
+	relayClient.EXPECT().Client().Return(relayEthClient).AnyTimes()
+	relayClient.EXPECT().DestinationBlockchainID().Return(sourceBlockchainID).AnyTimes()
+	relayClient.EXPECT().SenderAddresses().Return([]common.Address{validRelayerAddress}).AnyTimes()
+	messageNotDelivered, err := teleportermessenger.PackMessageReceivedOutput(false)
+	require.NoError(t, err)
+	relayEthClient.EXPECT().CallContract(gomock.Any(), gomock.Any(), gomock.Any()).Return(messageNotDelivered, nil)
+	relayClient.EXPECT().
+		SendTx(gomock.Any(), signedMessage, messageProtocolAddress.Hex(), gomock.Any(), gomock.Any()).
+		Return(common.Hash{2}, nil)
+	relayEthClient.EXPECT().TransactionReceipt(gomock.Any(), common.Hash{2}).Return(&types.Receipt{
+		Status: types.ReceiptStatusSuccessful,
+	}, nil).AnyTimes()
+
+	factory, err := NewMessageHandlerFactory(
+		logging.NoLog{},
+		messageProtocolAddress,
+		messageProtocolConfig,
+		nil,
+		nil,
+		nil,
+	)
+	require.NoError(t, err)
+	handler, err := factory.NewMessageHandler(unsignedMessage)
+	require.NoError(t, err)
+	shouldSend, err := handler.ShouldSendMessage(relayClient)
+	require.NoError(t, err)
+	require.True(t, shouldSend)
+	txHash, err := handler.SendMessage(context.Background(), signedMessage, relayClient)
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{2}, txHash)
+}
@@ -5,8 +5,10 @@ package peers
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"os"
+	"slices"
 	"sync"
 	"time"
 
@@ -14,12 +16,14 @@ import (
 	"github.com/ava-labs/avalanchego/network"
 	snowVdrs "github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/awm-relayer/config"
 	"github.com/ava-labs/awm-relayer/peers/validators"
 	"github.com/ava-labs/awm-relayer/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
@@ -36,12 +40,30 @@ type AppRequestNetwork struct {
 	logger          logging.Logger
 	lock            *sync.Mutex
 	validatorClient *validators.CanonicalValidatorClient
+	metrics         *AppRequestNetworkMetrics
+
+	// If enabled, concurrent requests to connect to the same subnet's validators are
+	// deduplicated, so that they share a single validator set query and set of connections.
+	deduplicateRequests bool
+	connectLock         sync.Mutex
+	connectCalls        map[ids.ID]*connectCall // In-flight calls to connect to each subnet's validators
+}
+
+// connectCall is an in-flight call to connect to the validators of a subnet, whose result is shared with
+// the concurrent callers for the same subnet.
+type connectCall struct {
+	done    chan struct{} // Closed once the call has returned, after which the result is set
+	waiters int           // Number of concurrent callers waiting on the call. Guarded by connectLock.
+
+	connectedValidators *ConnectedCanonicalValidators
+	err                 error
 }
 
 // NewNetwork creates a p2p network client for interacting with validators
 func NewNetwork(
 	logLevel logging.Level,
 	registerer prometheus.Registerer,
+	metrics *AppRequestNetworkMetrics,
 	cfg *config.Config,
 ) (*AppRequestNetwork, error) {
 	logger := logging.NewLogger(
@@ -83,6 +105,9 @@ func NewNetwork(
 	var trackedSubnets set.Set[ids.ID]
 	for _, sourceBlockchain := range cfg.SourceBlockchains {
 		trackedSubnets.Add(sourceBlockchain.GetSubnetID())
+		if sourceBlockchain.HasSigningSubnetID() {
+			trackedSubnets.Add(sourceBlockchain.GetSigningSubnetID())
+		}
 	}
 
 	testNetwork, err := network.NewTestNetwork(logger, networkID, snowVdrs.NewManager(), trackedSubnets, handler)
@@ -97,20 +122,22 @@ func NewNetwork(
 	validatorClient := validators.NewCanonicalValidatorClient(logger, cfg.PChainAPI)
 
 	arNetwork := &AppRequestNetwork{
-		Network:         testNetwork,
-		Handler:         handler,
-		infoAPI:         infoAPI,
-		logger:          logger,
-		lock:            new(sync.Mutex),
-		validatorClient: validatorClient,
+		Network:             testNetwork,
+		Handler:             handler,
+		infoAPI:             infoAPI,
+		logger:              logger,
+		lock:                new(sync.Mutex),
+		validatorClient:     validatorClient,
+		metrics:             metrics,
+		deduplicateRequests: cfg.DeduplicateSignatureRequests,
 	}
 
-	// Manually connect to the validators of each of the source subnets.
+	// Manually connect to the validators of each of the source subnets, or of their signing subnets if configured.
 	// We return an error if we are unable to connect to sufficient stake on any of the subnets.
 	// Sufficient stake is determined by the Warp quora of the configured supported destinations,
 	// or if the subnet supports all destinations, by the quora of all configured destinations.
 	for _, sourceBlockchain := range cfg.SourceBlockchains {
-		if sourceBlockchain.GetSubnetID() == constants.PrimaryNetworkID {
+		if sourceBlockchain.GetSubnetID() == constants.PrimaryNetworkID && !sourceBlockchain.HasSigningSubnetID() {
 			if err := arNetwork.connectToPrimaryNetworkPeers(cfg, sourceBlockchain); err != nil {
 				return nil, err
 			}
@@ -197,6 +224,7 @@ type ConnectedCanonicalValidators struct {
 	TotalValidatorWeight  uint64
 	ValidatorSet          []*warp.Validator
 	nodeValidatorIndexMap map[ids.NodeID]int
+	connectedNodes        set.Set[ids.NodeID]
 }
 
 // Returns the Warp Validator and its index in the canonical Validator ordering for a given nodeID
@@ -205,8 +233,127 @@ func (c *ConnectedCanonicalValidators) GetValidator(nodeID ids.NodeID) (*warp.Va
 }
 
 // ConnectToCanonicalValidators connects to the canonical validators of the given subnet and returns the connected
-// validator information
+// validator information. If request deduplication is enabled, concurrent calls for the same subnet share the
+// result of a single in-flight call.
 func (n *AppRequestNetwork) ConnectToCanonicalValidators(subnetID ids.ID) (*ConnectedCanonicalValidators, error) {
+	if !n.deduplicateRequests {
+		return n.connectToCanonicalValidators(subnetID)
+	}
+	return n.connectDeduplicated(subnetID, n.connectToCanonicalValidators)
+}
+
+// connectDeduplicated calls [connect] for [subnetID], unless a call for the same subnet is already in flight, in
+// which case its result is shared.
+func (n *AppRequestNetwork) connectDeduplicated(
+	subnetID ids.ID,
+	connect func(ids.ID) (*ConnectedCanonicalValidators, error),
+) (*ConnectedCanonicalValidators, error) {
+	n.connectLock.Lock()
+	if call, ok := n.connectCalls[subnetID]; ok {
+		call.waiters++
+		n.connectLock.Unlock()
+		<-call.done
+		if call.err != nil {
+			return nil, call.err
+		}
+		// The connections established by the in-flight call are reused by this caller
+		n.metrics.connectionReuseCount.Add(float64(call.connectedValidators.connectedNodes.Len()))
+		return call.connectedValidators, nil
+	}
+	call := &connectCall{done: make(chan struct{})}
+	if n.connectCalls == nil {
+		n.connectCalls = make(map[ids.ID]*connectCall)
+	}
+	n.connectCalls[subnetID] = call
+	n.connectLock.Unlock()
+
+	call.connectedValidators, call.err = connect(subnetID)
+
+	n.connectLock.Lock()
+	delete(n.connectCalls, subnetID)
+	n.connectLock.Unlock()
+	close(call.done)
+	return call.connectedValidators, call.err
+}
+
+// Validator describes a validator of a subnet, as known to the relayer
+type Validator struct {
+	NodeID ids.NodeID `json:"node-id"`
+	Weight uint64     `json:"weight"`
+	// Hex encoded compressed BLS public key. Empty if the validator has not registered a BLS public key,
+	// in which case it cannot sign Warp messages.
+	BLSPublicKey string `json:"bls-public-key,omitempty"`
+	// True if the relayer is connected to the validator, and may request its signatures
+	Connected bool `json:"connected"`
+}
+
+// SubnetValidators is the validator set of a subnet at a P-Chain height
+type SubnetValidators struct {
+	SubnetID     ids.ID      `json:"subnet-id"`
+	PChainHeight uint64      `json:"p-chain-height"`
+	TotalWeight  uint64      `json:"total-weight"`
+	Validators   []Validator `json:"validators"`
+}
+
+// GetSubnetValidators returns the current validator set of the given subnet, ordered by node ID, including the
+// validators without a BLS public key that are excluded from the canonical validator set.
+func (n *AppRequestNetwork) GetSubnetValidators(ctx context.Context, subnetID ids.ID) (*SubnetValidators, error) {
+	height, err := n.validatorClient.GetCurrentHeight(ctx)
+	if err != nil {
+		n.logger.Error(
+			"Failed to get P-Chain height",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	validatorSet, err := n.validatorClient.GetValidatorSet(ctx, height, subnetID)
+	if err != nil {
+		n.logger.Error(
+			"Failed to get the subnet validator set",
+			zap.String("subnetID", subnetID.String()),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	nodeIDs := make([]ids.NodeID, 0, len(validatorSet))
+	for nodeID := range validatorSet {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	var connectedNodes set.Set[ids.NodeID]
+	if len(nodeIDs) != 0 {
+		// PeerInfo returns all peers if no node IDs are given
+		for _, peer := range n.Network.PeerInfo(nodeIDs) {
+			connectedNodes.Add(peer.ID)
+		}
+	}
+
+	subnetValidators := &SubnetValidators{
+		SubnetID:     subnetID,
+		PChainHeight: height,
+		Validators:   make([]Validator, 0, len(validatorSet)),
+	}
+	for nodeID, vdr := range validatorSet {
+		validator := Validator{
+			NodeID:    nodeID,
+			Weight:    vdr.Weight,
+			Connected: connectedNodes.Contains(nodeID),
+		}
+		if vdr.PublicKey != nil {
+			validator.BLSPublicKey = hexutil.Encode(bls.PublicKeyToCompressedBytes(vdr.PublicKey))
+		}
+		subnetValidators.TotalWeight += vdr.Weight
+		subnetValidators.Validators = append(subnetValidators.Validators, validator)
+	}
+	slices.SortFunc(subnetValidators.Validators, func(a, b Validator) int {
+		return a.NodeID.Compare(b.NodeID)
+	})
+	return subnetValidators, nil
+}
+
+// Private helpers
+
+func (n *AppRequestNetwork) connectToCanonicalValidators(subnetID ids.ID) (*ConnectedCanonicalValidators, error) {
 	// Get the subnet's current canonical validator set
 	validatorSet, totalValidatorWeight, err := n.validatorClient.GetCurrentCanonicalValidatorSet(subnetID)
 	if err != nil {
@@ -242,18 +389,20 @@ func (n *AppRequestNetwork) ConnectToCanonicalValidators(subnetID ids.ID) (*Conn
 		TotalValidatorWeight:  totalValidatorWeight,
 		ValidatorSet:          validatorSet,
 		nodeValidatorIndexMap: nodeValidatorIndexMap,
+		connectedNodes:        connectedNodes,
 	}, nil
 }
 
-// Private helpers
-
-// Connect to the validators of the source blockchain. For each destination blockchain,
+// Connect to the validators of the source blockchain's signing subnet. For each destination blockchain,
 // verify that we have connected to a threshold of stake.
 func (n *AppRequestNetwork) connectToNonPrimaryNetworkPeers(
 	cfg *config.Config,
 	sourceBlockchain *config.SourceBlockchain,
 ) error {
 	subnetID := sourceBlockchain.GetSubnetID()
+	if sourceBlockchain.HasSigningSubnetID() {
+		subnetID = sourceBlockchain.GetSigningSubnetID()
+	}
 	connectedValidators, err := n.ConnectToCanonicalValidators(subnetID)
 	if err != nil {
 		n.logger.Error(
@@ -263,6 +412,19 @@ func (n *AppRequestNetwork) connectToNonPrimaryNetworkPeers(
 		)
 		return err
 	}
+	// A configured signing subnet that the P-Chain does not know of has no validators
+	if sourceBlockchain.HasSigningSubnetID() && connectedValidators.TotalValidatorWeight == 0 {
+		n.logger.Error(
+			"Signing subnet has no validators",
+			zap.String("sourceBlockchainID", sourceBlockchain.GetBlockchainID().String()),
+			zap.String("signingSubnetID", subnetID.String()),
+		)
+		return fmt.Errorf(
+			"signing subnet %s of source blockchain %s has no validators",
+			subnetID,
+			sourceBlockchain.GetBlockchainID(),
+		)
+	}
 	for _, destination := range sourceBlockchain.SupportedDestinations {
 		blockchainID := destination.GetBlockchainID()
 		if ok, quorum, err := n.checkForSufficientConnectedStake(cfg, connectedValidators, blockchainID); !ok {
@@ -0,0 +1,61 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peers
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ErrFailedToCreateAppRequestNetworkMetrics = errors.New("failed to create app request network metrics")
+)
+
+type AppRequestNetworkMetrics struct {
+	connectionReuseCount    prometheus.Counter
+	signatureCacheHitCount  prometheus.Counter
+	signatureCacheMissCount prometheus.Counter
+}
+
+func NewAppRequestNetworkMetrics(registerer prometheus.Registerer) (*AppRequestNetworkMetrics, error) {
+	connectionReuseCount := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "connection_reuse_count",
+			Help: "Number of validator connections shared with a concurrent request for the same subnet",
+		},
+	)
+	if connectionReuseCount == nil {
+		return nil, ErrFailedToCreateAppRequestNetworkMetrics
+	}
+	registerer.MustRegister(connectionReuseCount)
+
+	signatureCacheHitCount := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "signature_cache_hit_count",
+			Help: "Number of aggregate signatures served from the signature cache",
+		},
+	)
+	if signatureCacheHitCount == nil {
+		return nil, ErrFailedToCreateAppRequestNetworkMetrics
+	}
+	registerer.MustRegister(signatureCacheHitCount)
+
+	signatureCacheMissCount := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "signature_cache_miss_count",
+			Help: "Number of aggregate signatures not found in the signature cache",
+		},
+	)
+	if signatureCacheMissCount == nil {
+		return nil, ErrFailedToCreateAppRequestNetworkMetrics
+	}
+	registerer.MustRegister(signatureCacheMissCount)
+
+	return &AppRequestNetworkMetrics{
+		connectionReuseCount:    connectionReuseCount,
+		signatureCacheHitCount:  signatureCacheHitCount,
+		signatureCacheMissCount: signatureCacheMissCount,
+	}, nil
+}
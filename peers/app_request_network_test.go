@@ -0,0 +1,102 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peers
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectDeduplicated(t *testing.T) {
+	metrics, err := NewAppRequestNetworkMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+	network := &AppRequestNetwork{
+		metrics:             metrics,
+		deduplicateRequests: true,
+	}
+	subnetID := ids.GenerateTestID()
+	connectedValidators := &ConnectedCanonicalValidators{
+		connectedNodes: set.Of(ids.GenerateTestNodeID(), ids.GenerateTestNodeID()),
+	}
+
+	// The connect call blocks until released, so that it remains in flight while the other requests are made
+	var (
+		calls   atomic.Int32
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+	connect := func(id ids.ID) (*ConnectedCanonicalValidators, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		if id != subnetID {
+			return nil, fmt.Errorf("unexpected subnet ID %s", id)
+		}
+		return connectedValidators, nil
+	}
+
+	type result struct {
+		connectedValidators *ConnectedCanonicalValidators
+		err                 error
+	}
+	const numRequests = 5
+	results := make(chan result, numRequests)
+	request := func() {
+		res, err := network.connectDeduplicated(subnetID, connect)
+		results <- result{res, err}
+	}
+
+	// Concurrent requests for the same subnet are merged into the in-flight request
+	go request()
+	<-started
+	for i := 1; i < numRequests; i++ {
+		go request()
+	}
+	require.Eventually(t, func() bool {
+		return connectWaiters(network, subnetID) == numRequests-1
+	}, time.Second, time.Millisecond)
+	close(release)
+	for i := 0; i < numRequests; i++ {
+		res := <-results
+		require.NoError(t, res.err)
+		require.Same(t, connectedValidators, res.connectedValidators)
+	}
+	require.Equal(t, int32(1), calls.Load())
+
+	// Only the requests that waited for the in-flight request reuse its connections
+	require.Equal(
+		t,
+		float64((numRequests-1)*connectedValidators.connectedNodes.Len()),
+		testutil.ToFloat64(metrics.connectionReuseCount),
+	)
+
+	// Requests made once the in-flight request has completed are not merged
+	_, err = network.connectDeduplicated(subnetID, connect)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), calls.Load())
+	require.Equal(
+		t,
+		float64((numRequests-1)*connectedValidators.connectedNodes.Len()),
+		testutil.ToFloat64(metrics.connectionReuseCount),
+	)
+}
+
+// connectWaiters returns the number of requests waiting on the in-flight call to connect to [subnetID].
+func connectWaiters(network *AppRequestNetwork, subnetID ids.ID) int {
+	network.connectLock.Lock()
+	defer network.connectLock.Unlock()
+	if call, ok := network.connectCalls[subnetID]; ok {
+		return call.waiters
+	}
+	return 0
+}
@@ -0,0 +1,83 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peers
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+// SignatureCacheKey identifies an aggregate signature. The same message may be signed by different subnets,
+// for example if it originates from the primary network, and to different quorums, depending on the destination.
+type SignatureCacheKey struct {
+	MessageID       ids.ID
+	SigningSubnetID ids.ID
+	QuorumNumerator uint64
+}
+
+type signatureCacheEntry struct {
+	signedMessage       *warp.Message
+	connectedValidators *ConnectedCanonicalValidators
+	expiry              time.Time
+}
+
+// SignatureCache is a bounded LRU cache of aggregated signed Warp messages, along with the validators
+// that were queried for their signatures. Entries expire after a fixed TTL,
+// since the validator set that signed the message may change. A nil *SignatureCache is a disabled cache.
+type SignatureCache struct {
+	metrics *AppRequestNetworkMetrics
+	ttl     time.Duration
+	entries *cache.LRU[SignatureCacheKey, signatureCacheEntry]
+}
+
+// NewSignatureCache returns a cache holding at most [size] signed messages for [ttl],
+// or nil if [size] is zero.
+func NewSignatureCache(metrics *AppRequestNetworkMetrics, size int, ttl time.Duration) *SignatureCache {
+	if size == 0 {
+		return nil
+	}
+	return &SignatureCache{
+		metrics: metrics,
+		ttl:     ttl,
+		entries: &cache.LRU[SignatureCacheKey, signatureCacheEntry]{Size: size},
+	}
+}
+
+// Get returns the cached signed message for [key] and the validators queried for its signatures,
+// if it is present and has not expired. The validators are nil if the signature was fetched via the Warp API.
+func (c *SignatureCache) Get(key SignatureCacheKey) (*warp.Message, *ConnectedCanonicalValidators, bool) {
+	if c == nil {
+		return nil, nil, false
+	}
+	entry, ok := c.entries.Get(key)
+	if ok && time.Now().After(entry.expiry) {
+		c.entries.Evict(key)
+		ok = false
+	}
+	if !ok {
+		c.metrics.signatureCacheMissCount.Inc()
+		return nil, nil, false
+	}
+	c.metrics.signatureCacheHitCount.Inc()
+	return entry.signedMessage, entry.connectedValidators, true
+}
+
+// Put caches [signedMessage] and the [connectedValidators] queried for its signatures under [key].
+func (c *SignatureCache) Put(
+	key SignatureCacheKey,
+	signedMessage *warp.Message,
+	connectedValidators *ConnectedCanonicalValidators,
+) {
+	if c == nil {
+		return
+	}
+	c.entries.Put(key, signatureCacheEntry{
+		signedMessage:       signedMessage,
+		connectedValidators: connectedValidators,
+		expiry:              time.Now().Add(c.ttl),
+	})
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignatureCache(t *testing.T) {
+	metrics, err := NewAppRequestNetworkMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+	signatureCache := NewSignatureCache(metrics, 2, time.Hour)
+
+	newKey := func() SignatureCacheKey {
+		return SignatureCacheKey{
+			MessageID:       ids.GenerateTestID(),
+			SigningSubnetID: ids.GenerateTestID(),
+			QuorumNumerator: 67,
+		}
+	}
+	key1, key2, key3 := newKey(), newKey(), newKey()
+	signedMessage := &warp.Message{}
+
+	_, _, ok := signatureCache.Get(key1)
+	require.False(t, ok)
+
+	connectedValidators := &ConnectedCanonicalValidators{TotalValidatorWeight: 100}
+	signatureCache.Put(key1, signedMessage, connectedValidators)
+	cached, cachedValidators, ok := signatureCache.Get(key1)
+	require.True(t, ok)
+	require.Equal(t, signedMessage, cached)
+	require.Equal(t, connectedValidators, cachedValidators)
+
+	// A different quorum is a different entry
+	differentQuorum := key1
+	differentQuorum.QuorumNumerator = 100
+	_, _, ok = signatureCache.Get(differentQuorum)
+	require.False(t, ok)
+
+	// key1 is the most recently used entry, so key2 is evicted when key3 is added
+	signatureCache.Put(key2, signedMessage, nil)
+	_, _, ok = signatureCache.Get(key1)
+	require.True(t, ok)
+	signatureCache.Put(key3, signedMessage, nil)
+	_, _, ok = signatureCache.Get(key2)
+	require.False(t, ok)
+
+	require.Equal(t, float64(2), testutil.ToFloat64(metrics.signatureCacheHitCount))
+	require.Equal(t, float64(3), testutil.ToFloat64(metrics.signatureCacheMissCount))
+
+	// Expired entries are not returned
+	expiringCache := NewSignatureCache(metrics, 2, 0)
+	expiringCache.Put(key1, signedMessage, nil)
+	time.Sleep(time.Millisecond)
+	_, _, ok = expiringCache.Get(key1)
+	require.False(t, ok)
+
+	// A zero size disables the cache
+	disabledCache := NewSignatureCache(metrics, 0, time.Hour)
+	require.Nil(t, disabledCache)
+	disabledCache.Put(key1, signedMessage, nil)
+	_, _, ok = disabledCache.Get(key1)
+	require.False(t, ok)
+}
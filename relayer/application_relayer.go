@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"math/rand"
 	"sync"
@@ -27,6 +28,7 @@ import (
 	"github.com/ava-labs/awm-relayer/messages"
 	"github.com/ava-labs/awm-relayer/peers"
 	"github.com/ava-labs/awm-relayer/relayer/checkpoint"
+	relayerTypes "github.com/ava-labs/awm-relayer/types"
 	"github.com/ava-labs/awm-relayer/utils"
 	"github.com/ava-labs/awm-relayer/vms"
 	coreEthMsg "github.com/ava-labs/coreth/plugin/evm/message"
@@ -34,7 +36,10 @@ import (
 	"github.com/ava-labs/subnet-evm/rpc"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
 
 	"go.uber.org/zap"
 )
@@ -49,32 +54,78 @@ const (
 	signatureRequestRetryWaitPeriodMs = 10_000
 )
 
+// Outcomes of signature aggregation, used to label the aggregation stake percentage metric
+const (
+	aggregationOutcomeSuccess = "success"
+	aggregationOutcomeFailure = "failure"
+)
+
 var (
 	codec        = msg.Codec
 	coreEthCodec = coreEthMsg.Codec
 	// Errors
-	errNotEnoughSignatures     = errors.New("failed to collect a threshold of signatures")
-	errFailedToGetAggSig       = errors.New("failed to get aggregate signature from node endpoint")
-	errNotEnoughConnectedStake = errors.New("failed to connect to a threshold of stake")
+	errNotEnoughSignatures          = errors.New("failed to collect a threshold of signatures")
+	errFailedToGetAggSig            = errors.New("failed to get aggregate signature from node endpoint")
+	errNotEnoughConnectedStake      = errors.New("failed to connect to a threshold of stake")
+	errFailedToSendSignatureRequest = errors.New("failed to send signature request")
+	errSignatureAggregationTimeout  = errors.New("timed out collecting a threshold of signatures")
 )
 
+// deliveryTracker registers deliveries made in the background, such as those to the shadow chain, as in-flight,
+// so that they are drained on shutdown. Implemented by MessageCoordinator.
+type deliveryTracker interface {
+	startProcessing(numMessages int) bool
+	finishProcessing(numMessages int)
+}
+
 // ApplicationRelayers define a Warp message route from a specific source address on a specific source blockchain
 // to a specific destination address on a specific destination blockchain. This routing information is
 // encapsulated in [relayerID], which also represents the database key for an ApplicationRelayer.
 type ApplicationRelayer struct {
-	logger                    logging.Logger
-	metrics                   *ApplicationRelayerMetrics
-	network                   *peers.AppRequestNetwork
-	messageCreator            message.Creator
-	sourceBlockchain          config.SourceBlockchain
-	signingSubnetID           ids.ID
-	destinationClient         vms.DestinationClient
+	logger            logging.Logger
+	metrics           *ApplicationRelayerMetrics
+	network           *peers.AppRequestNetwork
+	messageCreator    message.Creator
+	sourceBlockchain  config.SourceBlockchain
+	signingSubnetID   ids.ID
+	destinationClient vms.DestinationClient
+	shadowClient      vms.DestinationClient // nil if no shadow endpoint is configured for the destination
+	deliveryTracker   deliveryTracker       // set by the MessageCoordinator. nil if shadow deliveries are not tracked
+	shadowOnly        bool
+	confirmations     uint64 // 0 if deliveries are not awaited to reach a confirmation depth
+	// Messages are skipped rather than buffered while the route is paused
+	skipPaused bool
+	// pauseLock guards resumed, which is closed when the route is resumed. nil if the route is not paused.
+	pauseLock                 sync.Mutex
+	resumed                   chan struct{}
+	dryRun                    bool
 	relayerID                 database.RelayerID
 	warpQuorum                config.WarpQuorum
+	signatureCache            *peers.SignatureCache
 	checkpointManager         *checkpoint.CheckpointManager
+	ticker                    *utils.Ticker
+	writeSignal               chan struct{} // checkpointManager's subscription to ticker
 	currentRequestID          uint32
 	lock                      *sync.RWMutex
 	sourceWarpSignatureClient *rpc.Client // nil if configured to fetch signatures via AppRequest for the source blockchain
+	// Shared by all ApplicationRelayers for the source blockchain. nil if concurrent aggregations are unlimited
+	aggregationSemaphore *semaphore.Weighted
+	// Shared by all ApplicationRelayers for the destination blockchain. nil if concurrent sends are unlimited
+	sendQueue *SendQueue
+	// Shared by all ApplicationRelayers for the destination blockchain
+	pendingMessages *PendingMessages
+	// 0 if signature aggregation is only bounded by the number of attempts
+	signatureAggregationTimeout time.Duration
+	slaTracker                  *slaTracker // nil if no delivery SLA is configured for the route
+	// nil if aggregation records are not persisted
+	aggregationStore *database.AggregationStore
+	// nil if delivered messages are not deduplicated
+	deliveredMessages *database.DeliveredMessageStore
+	// nil if standby mode is not configured
+	lease              *database.Lease
+	standbyGracePeriod time.Duration
+	// nil if messages from the same origin sender are not delivered in order
+	senderSequencer *senderSequencer
 }
 
 func NewApplicationRelayer(
@@ -86,8 +137,13 @@ func NewApplicationRelayer(
 	db database.RelayerDatabase,
 	ticker *utils.Ticker,
 	destinationClient vms.DestinationClient,
+	shadowClient vms.DestinationClient,
 	sourceBlockchain config.SourceBlockchain,
 	startingHeight uint64,
+	aggregationSemaphore *semaphore.Weighted,
+	sendQueue *SendQueue,
+	pendingMessages *PendingMessages,
+	signatureCache *peers.SignatureCache,
 	cfg *config.Config,
 ) (*ApplicationRelayer, error) {
 	quorum, err := cfg.GetWarpQuorum(relayerID.DestinationBlockchainID)
@@ -100,7 +156,9 @@ func NewApplicationRelayer(
 		return nil, err
 	}
 	var signingSubnet ids.ID
-	if sourceBlockchain.GetSubnetID() == constants.PrimaryNetworkID {
+	if sourceBlockchain.HasSigningSubnetID() {
+		signingSubnet = sourceBlockchain.GetSigningSubnetID()
+	} else if sourceBlockchain.GetSubnetID() == constants.PrimaryNetworkID {
 		// If the message originates from the primary subnet, then we instead "self sign"
 		// the message using the validators of the destination subnet.
 		signingSubnet = cfg.GetSubnetID(relayerID.DestinationBlockchainID)
@@ -109,6 +167,77 @@ func NewApplicationRelayer(
 		signingSubnet = sourceBlockchain.GetSubnetID()
 	}
 
+	var (
+		shadowOnly    bool
+		confirmations uint64
+	)
+	for _, destination := range cfg.DestinationBlockchains {
+		if destination.GetBlockchainID() == relayerID.DestinationBlockchainID {
+			shadowOnly = destination.ShadowOnly
+			confirmations = destination.DestinationConfirmations
+		}
+	}
+
+	var tracker *slaTracker
+	for _, destination := range sourceBlockchain.SupportedDestinations {
+		if destination.GetBlockchainID() == relayerID.DestinationBlockchainID && destination.DeliverySLA != nil {
+			tracker = newSLATracker(destination.DeliverySLA)
+		}
+	}
+
+	var aggregationStore *database.AggregationStore
+	if cfg.PersistAggregations {
+		aggregationStore = database.NewAggregationStore(
+			db,
+			time.Duration(cfg.AggregationRetentionSeconds)*time.Second,
+		)
+	}
+
+	var deliveredMessages *database.DeliveredMessageStore
+	if cfg.DedupWindowSeconds != 0 {
+		deliveredMessages, err = database.NewDeliveredMessageStore(
+			db,
+			relayerID.ID,
+			time.Duration(cfg.DedupWindowSeconds)*time.Second,
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to load delivered messages",
+				zap.String("relayerID", relayerID.ID.String()),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+	}
+
+	var (
+		lease              *database.Lease
+		standbyGracePeriod time.Duration
+	)
+	if cfg.Standby != nil {
+		lease = database.NewLease(
+			db,
+			relayerID.ID,
+			cfg.Standby.GetInstanceID(),
+			cfg.Standby.GetLeaseDuration(),
+		)
+		standbyGracePeriod = cfg.Standby.GetGracePeriod()
+	}
+
+	var sequencer *senderSequencer
+	if sourceBlockchain.PreserveOrderPerSender {
+		sequencer = newSenderSequencer()
+	}
+
+	pausedRouteMode, err := cfg.GetPausedRouteMode()
+	if err != nil {
+		logger.Error(
+			"Invalid paused route mode",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
 	sub := ticker.Subscribe()
 
 	checkpointManager := checkpoint.NewCheckpointManager(
@@ -130,6 +259,7 @@ func NewApplicationRelayer(
 			sourceBlockchain.WarpAPIEndpoint.BaseURL,
 			sourceBlockchain.WarpAPIEndpoint.HTTPHeaders,
 			sourceBlockchain.WarpAPIEndpoint.QueryParams,
+			sourceBlockchain.GetTLSConfig(),
 		)
 		if err != nil {
 			logger.Error(
@@ -141,19 +271,37 @@ func NewApplicationRelayer(
 	}
 
 	ar := ApplicationRelayer{
-		logger:                    logger,
-		metrics:                   metrics,
-		network:                   network,
-		messageCreator:            messageCreator,
-		sourceBlockchain:          sourceBlockchain,
-		destinationClient:         destinationClient,
-		relayerID:                 relayerID,
-		signingSubnetID:           signingSubnet,
-		warpQuorum:                quorum,
-		checkpointManager:         checkpointManager,
-		currentRequestID:          rand.Uint32(), // TODONOW: pass via ctor
-		lock:                      &sync.RWMutex{},
-		sourceWarpSignatureClient: warpClient,
+		logger:                      logger,
+		metrics:                     metrics,
+		network:                     network,
+		messageCreator:              messageCreator,
+		sourceBlockchain:            sourceBlockchain,
+		destinationClient:           destinationClient,
+		shadowClient:                shadowClient,
+		shadowOnly:                  shadowOnly,
+		confirmations:               confirmations,
+		skipPaused:                  pausedRouteMode == config.PausedRouteModeSkip,
+		dryRun:                      cfg.DryRun,
+		relayerID:                   relayerID,
+		signingSubnetID:             signingSubnet,
+		warpQuorum:                  quorum,
+		signatureCache:              signatureCache,
+		checkpointManager:           checkpointManager,
+		ticker:                      ticker,
+		writeSignal:                 sub,
+		currentRequestID:            rand.Uint32(), // TODONOW: pass via ctor
+		lock:                        &sync.RWMutex{},
+		sourceWarpSignatureClient:   warpClient,
+		aggregationSemaphore:        aggregationSemaphore,
+		sendQueue:                   sendQueue,
+		pendingMessages:             pendingMessages,
+		signatureAggregationTimeout: cfg.GetSignatureAggregationTimeout(),
+		slaTracker:                  tracker,
+		aggregationStore:            aggregationStore,
+		deliveredMessages:           deliveredMessages,
+		lease:                       lease,
+		standbyGracePeriod:          standbyGracePeriod,
+		senderSequencer:             sequencer,
 	}
 
 	return &ar, nil
@@ -163,18 +311,36 @@ func NewApplicationRelayer(
 // Checkpoints the height with the checkpoint manager when all messages are relayed.
 // ProcessHeight is expected to be called for every block greater than or equal to the
 // [startingHeight] provided in the constructor.
+// Returns an error if any message fails to be relayed, in which case the height is not checkpointed. Messages skipped
+// as invalid are not failures.
+// If the destination requires confirmations, the height is not checkpointed until each delivery has reached them.
 func (r *ApplicationRelayer) ProcessHeight(
 	height uint64,
-	handlers []messages.MessageHandler,
-	errChan chan error,
-) {
+	deliveries []messageDelivery,
+) error {
+	r.pendingMessages.add(len(deliveries))
+	defer r.pendingMessages.remove(len(deliveries))
+
 	var eg errgroup.Group
-	for _, handler := range handlers {
+	for _, delivery := range deliveries {
 		// Copy the loop variable to a local variable to avoid the loop variable being captured by the
 		// goroutine. Once we upgrade to Go 1.22, we can use the loop variable directly in the goroutine.
-		h := handler
+		d := delivery
 		eg.Go(func() error {
-			_, err := r.ProcessMessage(h)
+			if d.ticket != nil {
+				d.ticket.wait()
+				defer d.ticket.release()
+			}
+			txHash, err := r.processMessageConfirmed(d.ctx, d.handler)
+			d.done(err)
+			if err == nil && txHash != (common.Hash{}) && r.deliveredMessages != nil {
+				r.addDeliveredMessage(d.handler.GetUnsignedMessage().ID(), height)
+			}
+			// Invalid messages are stored as dead letters, and do not prevent the height from being checkpointed
+			var skippedErr *messages.SkippedMessageError
+			if errors.As(err, &skippedErr) {
+				return nil
+			}
 			return err
 		})
 	}
@@ -185,29 +351,235 @@ func (r *ApplicationRelayer) ProcessHeight(
 			zap.String("relayerID", r.relayerID.ID.String()),
 			zap.Error(err),
 		)
-		errChan <- err
-		return
+		return err
+	}
+	// Messages are not delivered to the destination blockchain in shadow-only or dry-run mode,
+	// so do not checkpoint the height
+	if !r.shadowOnly && !r.dryRun {
+		r.checkpointManager.StageCommittedHeight(height)
+		if r.deliveredMessages != nil {
+			r.pruneDeliveredMessages()
+		}
 	}
-	r.checkpointManager.StageCommittedHeight(height)
 	r.logger.Debug(
 		"Processed block",
 		zap.Uint64("height", height),
 		zap.String("sourceBlockchainID", r.relayerID.SourceBlockchainID.String()),
 		zap.String("relayerID", r.relayerID.ID.String()),
-		zap.Int("numMessages", len(handlers)),
+		zap.Int("numMessages", len(deliveries)),
 	)
+	return nil
+}
+
+// skipExpiredMessages returns [deliveries], unless [block] is older than the source blockchain's maximum message age,
+// in which case the messages are logged and skipped. The block is still processed, so that its height is checkpointed.
+func (r *ApplicationRelayer) skipExpiredMessages(
+	block *relayerTypes.WarpBlockInfo,
+	deliveries []messageDelivery,
+) []messageDelivery {
+	maxMessageAge := r.sourceBlockchain.GetMaxMessageAge()
+	if maxMessageAge == 0 || len(deliveries) == 0 {
+		return deliveries
+	}
+	age := time.Since(time.Unix(int64(block.BlockTimestamp), 0))
+	if age <= maxMessageAge {
+		return deliveries
+	}
+	for _, delivery := range deliveries {
+		r.logger.Info(
+			"Message is older than the maximum message age. Skipping",
+			zap.String("sourceBlockchainID", r.relayerID.SourceBlockchainID.String()),
+			zap.String("destinationBlockchainID", r.relayerID.DestinationBlockchainID.String()),
+			zap.String("warpMessageID", delivery.handler.GetUnsignedMessage().ID().String()),
+			zap.Uint64("height", block.BlockNumber),
+			zap.Duration("age", age),
+			zap.Duration("maxMessageAge", maxMessageAge),
+		)
+		trace.SpanFromContext(delivery.ctx).AddEvent("Skipped message older than the maximum message age")
+		delivery.done(nil)
+	}
+	return nil
+}
+
+// sequenceDeliveries registers [deliveries] with the sender sequencer, if messages from the same origin sender are
+// delivered in order, so that each is delivered once the previously registered messages from its sender have been
+// processed. Must be called for each height in the order in which the source's blocks are processed.
+func (r *ApplicationRelayer) sequenceDeliveries(deliveries []messageDelivery) {
+	if r.senderSequencer == nil {
+		return
+	}
+	for i := range deliveries {
+		_, originSenderAddress, _, _, err := deliveries[i].handler.GetMessageRoutingInfo()
+		if err != nil {
+			// The message was routed using the same information, so this is not expected
+			r.logger.Warn(
+				"Failed to get message routing information. Delivering message unordered",
+				zap.String("warpMessageID", deliveries[i].handler.GetUnsignedMessage().ID().String()),
+				zap.Error(err),
+			)
+			continue
+		}
+		deliveries[i].ticket = r.senderSequencer.register(originSenderAddress)
+	}
+}
+
+// CheckpointedHeight returns the greatest height written to the database.
+func (r *ApplicationRelayer) CheckpointedHeight() uint64 {
+	return r.checkpointManager.CheckpointedHeight()
+}
+
+// CheckpointStatus returns the heights tracked by the ApplicationRelayer's checkpoint manager.
+func (r *ApplicationRelayer) CheckpointStatus() checkpoint.Status {
+	return r.checkpointManager.Status()
+}
+
+// FlushCheckpoint writes the committed height to the database without waiting for the next write signal,
+// along with the delivered messages that have not yet been written.
+func (r *ApplicationRelayer) FlushCheckpoint() {
+	r.checkpointManager.Flush()
+	if r.deliveredMessages != nil {
+		r.flushDeliveredMessages()
+	}
+}
+
+// Stop writes the committed height to the database, and stops checkpointing thereafter. This is used if the
+// ApplicationRelayer is replaced or removed. Messages already dispatched to it are still relayed.
+func (r *ApplicationRelayer) Stop() {
+	r.ticker.Unsubscribe(r.writeSignal)
+	r.checkpointManager.Stop()
+	if r.deliveredMessages != nil {
+		r.flushDeliveredMessages()
+	}
+}
+
+// RollbackCheckpoint lowers the committed height to [height], so that the blocks above it are committed again
+// once they are reprocessed.
+func (r *ApplicationRelayer) RollbackCheckpoint(height uint64) {
+	r.checkpointManager.Rollback(height)
 }
 
 // Relays a message to the destination chain. Does not checkpoint the height.
 // returns the transaction hash if the message is successfully relayed.
-func (r *ApplicationRelayer) ProcessMessage(handler messages.MessageHandler) (common.Hash, error) {
+// The delivery is abandoned if [ctx] is canceled.
+func (r *ApplicationRelayer) ProcessMessage(ctx context.Context, handler messages.MessageHandler) (common.Hash, error) {
 	// Increment the request ID. Make sure we don't hold the lock while we relay the message.
 	r.lock.Lock()
 	r.currentRequestID++
 	reqID := r.currentRequestID
 	r.lock.Unlock()
 
-	return r.relayMessage(reqID, handler)
+	startTime := time.Now()
+	txHash, err := r.relayMessage(ctx, reqID, handler)
+	if err == nil && txHash != (common.Hash{}) {
+		// SendMessage returns once the message protocol considers the delivery confirmed
+		r.recordRelayLatency(handler.GetMessageProtocol(), time.Since(startTime))
+	}
+	if r.slaTracker != nil {
+		// Messages that are not sent because the message protocol determined they should not be
+		// are not counted against the SLA.
+		if err != nil {
+			r.recordSLA(r.slaTracker.recordFailure(time.Now()))
+		} else if txHash != (common.Hash{}) {
+			r.recordSLA(r.slaTracker.recordDelivery(time.Now(), time.Since(startTime)))
+		}
+	}
+	return txHash, err
+}
+
+// processMessageConfirmed relays a message with ProcessMessage, and then waits for the delivery transaction to reach
+// the confirmation depth configured for the destination. If the delivery is reorged out before then, the message is
+// relayed again.
+func (r *ApplicationRelayer) processMessageConfirmed(
+	ctx context.Context,
+	handler messages.MessageHandler,
+) (common.Hash, error) {
+	for {
+		txHash, err := r.ProcessMessage(ctx, handler)
+		// Deliveries to the shadow endpoint do not advance the checkpoint, so are not awaited
+		if err != nil || txHash == (common.Hash{}) || r.confirmations == 0 || r.shadowOnly {
+			return txHash, err
+		}
+		_, span := startSpan(ctx, awaitConfirmationsSpanName, attribute.String("tx_hash", txHash.String()))
+		err = vms.WaitForConfirmations(ctx, r.destinationClient, txHash, r.confirmations)
+		endSpan(span, err)
+		if !errors.Is(err, vms.ErrTxReorged) {
+			if err != nil {
+				r.logger.Error(
+					"Failed to wait for delivery confirmations",
+					zap.String("relayerID", r.relayerID.ID.String()),
+					zap.String("warpMessageID", handler.GetUnsignedMessage().ID().String()),
+					zap.String("txHash", txHash.String()),
+					zap.Error(err),
+				)
+			}
+			return txHash, err
+		}
+		r.logger.Warn(
+			"Delivery reorged out of the destination chain. Relaying message again",
+			zap.String("relayerID", r.relayerID.ID.String()),
+			zap.String("warpMessageID", handler.GetUnsignedMessage().ID().String()),
+			zap.String("txHash", txHash.String()),
+		)
+	}
+}
+
+// addDeliveredMessage records the delivery of [messageID] so that it is not relayed again if [height] is
+// reprocessed. Failures are logged, but do not fail the relay.
+func (r *ApplicationRelayer) addDeliveredMessage(messageID ids.ID, height uint64) {
+	if err := r.deliveredMessages.Add(messageID, height); err != nil {
+		r.logger.Warn(
+			"Failed to store delivered message",
+			zap.String("warpMessageID", messageID.String()),
+			zap.String("relayerID", r.relayerID.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+// pruneDeliveredMessages drops delivered messages in blocks that have been written to the database,
+// since those blocks will not be reprocessed.
+func (r *ApplicationRelayer) pruneDeliveredMessages() {
+	if err := r.deliveredMessages.Prune(r.checkpointManager.CheckpointedHeight()); err != nil {
+		r.logger.Warn(
+			"Failed to prune delivered messages",
+			zap.String("relayerID", r.relayerID.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+// flushDeliveredMessages writes the delivered messages that have not yet been written to the database.
+func (r *ApplicationRelayer) flushDeliveredMessages() {
+	if err := r.deliveredMessages.Flush(); err != nil {
+		r.logger.Warn(
+			"Failed to flush delivered messages",
+			zap.String("relayerID", r.relayerID.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+func (r *ApplicationRelayer) recordSLA(compliance float64, breached bool, changed bool) {
+	r.setDeliverySLACompliance(compliance)
+	r.setDeliverySLABreach(breached)
+	if !changed {
+		return
+	}
+	if breached {
+		r.logger.Warn(
+			"Delivery SLA breached",
+			zap.String("sourceBlockchainID", r.sourceBlockchain.GetBlockchainID().String()),
+			zap.String("destinationBlockchainID", r.relayerID.DestinationBlockchainID.String()),
+			zap.Float64("compliance", compliance),
+		)
+	} else {
+		r.logger.Info(
+			"Delivery SLA restored",
+			zap.String("sourceBlockchainID", r.sourceBlockchain.GetBlockchainID().String()),
+			zap.String("destinationBlockchainID", r.relayerID.DestinationBlockchainID.String()),
+			zap.Float64("compliance", compliance),
+		)
+	}
 }
 
 func (r *ApplicationRelayer) RelayerID() database.RelayerID {
@@ -216,18 +588,55 @@ func (r *ApplicationRelayer) RelayerID() database.RelayerID {
 
 // returns the transaction hash if the message is successfully relayed.
 func (r *ApplicationRelayer) relayMessage(
+	ctx context.Context,
 	requestID uint32,
 	handler messages.MessageHandler,
 ) (common.Hash, error) {
-	r.logger.Debug(
+	// Attach the fields identifying the message to each log line about it
+	logger := utils.NewMessageLogger(
+		r.logger,
+		r.relayerID.SourceBlockchainID,
+		r.relayerID.DestinationBlockchainID,
+		handler.GetUnsignedMessage().ID(),
+	)
+	logger.Debug(
 		"Relaying message",
 		zap.Uint32("requestID", requestID),
 		zap.String("sourceBlockchainID", r.sourceBlockchain.BlockchainID),
 		zap.String("relayerID", r.relayerID.ID.String()),
 	)
-	shouldSend, err := handler.ShouldSendMessage(r.destinationClient)
+	// In shadow-only mode, the shadow chain takes the place of the destination chain
+	destinationClient := r.destinationClient
+	if r.shadowOnly {
+		destinationClient = r.shadowClient
+	}
+
+	// Skip messages recently delivered by this relayer, without querying the destination chain
+	if r.deliveredMessages != nil && r.deliveredMessages.Contains(handler.GetUnsignedMessage().ID()) {
+		logger.Info(
+			"Message already delivered. Skipping",
+			zap.String("warpMessageID", handler.GetUnsignedMessage().ID().String()),
+			zap.String("relayerID", r.relayerID.ID.String()),
+		)
+		return common.Hash{}, nil
+	}
+
+	if resumed, err := r.waitIfPaused(ctx, logger); !resumed {
+		return common.Hash{}, err
+	}
+
+	shouldSend, err := handler.ShouldSendMessage(destinationClient)
+	var skippedErr *messages.SkippedMessageError
+	if errors.As(err, &skippedErr) {
+		logger.Warn(
+			"Message is invalid. Skipping",
+			zap.String("reason", skippedErr.Reason),
+		)
+		r.incFailedRelayMessageCount("invalid message")
+		return common.Hash{}, err
+	}
 	if err != nil {
-		r.logger.Error(
+		logger.Error(
 			"Failed to check if message should be sent",
 			zap.Error(err),
 		)
@@ -235,76 +644,308 @@ func (r *ApplicationRelayer) relayMessage(
 		return common.Hash{}, err
 	}
 	if !shouldSend {
-		r.logger.Info("Message should not be sent")
+		logger.Info("Message should not be sent")
 		return common.Hash{}, nil
 	}
 	unsignedMessage := handler.GetUnsignedMessage()
 
 	startCreateSignedMessageTime := time.Now()
-	// Query nodes on the origin chain for signatures, and construct the signed warp message.
-	var signedMessage *avalancheWarp.Message
-
-	// sourceWarpSignatureClient is nil iff the source blockchain is configured to fetch signatures via AppRequest
-	if r.sourceWarpSignatureClient == nil {
-		r.incFetchSignatureAppRequestCount()
-		signedMessage, err = r.createSignedMessageAppRequest(unsignedMessage, requestID)
-		if err != nil {
-			r.logger.Error(
-				"Failed to create signed warp message via AppRequest network",
-				zap.Error(err),
-			)
-			r.incFailedRelayMessageCount("failed to create signed warp message via AppRequest network")
-			return common.Hash{}, err
-		}
-	} else {
-		r.incFetchSignatureRPCCount()
-		signedMessage, err = r.createSignedMessage(unsignedMessage)
-		if err != nil {
-			r.logger.Error(
-				"Failed to create signed warp message via RPC",
-				zap.Error(err),
-			)
-			r.incFailedRelayMessageCount("failed to create signed warp message via RPC")
-			return common.Hash{}, err
-		}
+	aggregateCtx, aggregateSpan := startSpan(
+		ctx,
+		aggregateSignaturesSpanName,
+		attribute.String(utils.DestinationBlockchainIDLogKey, r.relayerID.DestinationBlockchainID.String()),
+		attribute.String("signing_subnet_id", r.signingSubnetID.String()),
+	)
+	signedMessage, connectedValidators, err := r.createSignedMessageWithLimit(
+		aggregateCtx,
+		logger,
+		unsignedMessage,
+		requestID,
+	)
+	endSpan(aggregateSpan, err)
+	if err != nil {
+		return common.Hash{}, err
 	}
 
 	// create signed message latency (ms)
 	r.setCreateSignedMessageLatencyMS(float64(time.Since(startCreateSignedMessageTime).Milliseconds()))
 
-	txHash, err := handler.SendMessage(signedMessage, r.destinationClient)
+	if send, err := r.waitIfStandby(ctx, logger, handler, destinationClient); !send {
+		return common.Hash{}, err
+	}
+
+	if r.shadowOnly {
+		return r.relayShadowMessage(ctx, logger, handler, signedMessage)
+	}
+	if r.shadowClient != nil {
+		r.relayShadowMessageAsync(ctx, logger, handler, signedMessage)
+	}
+
+	sendCtx, sendSpan := startSpan(
+		ctx,
+		sendMessageSpanName,
+		attribute.String(utils.DestinationBlockchainIDLogKey, r.relayerID.DestinationBlockchainID.String()),
+	)
+	txHash, err := r.sendMessageWithLimit(sendCtx, logger, handler, signedMessage, destinationClient)
+	if errors.Is(err, vms.ErrDryRun) {
+		// The delivery transaction was logged by the destination client
+		sendSpan.End()
+		return common.Hash{}, nil
+	}
 	if err != nil {
-		r.logger.Error(
+		endSpan(sendSpan, err)
+		logger.Error(
 			"Failed to send warp message",
 			zap.Error(err),
 		)
 		r.incFailedRelayMessageCount("failed to send warp message")
 		return common.Hash{}, err
 	}
-	r.logger.Info(
+	sendSpan.SetAttributes(attribute.String("tx_hash", txHash.Hex()))
+	sendSpan.End()
+	logger.Info(
 		"Finished relaying message to destination chain",
 		zap.String("destinationBlockchainID", r.relayerID.DestinationBlockchainID.String()),
 		zap.String("txHash", txHash.Hex()),
 	)
 	r.incSuccessfulRelayMessageCount()
 
+	if r.aggregationStore != nil {
+		r.storeAggregationRecord(logger, signedMessage, connectedValidators, txHash)
+	}
+
 	return txHash, nil
 }
 
+// storeAggregationRecord persists how the aggregate signature of the delivered message was formed.
+// [connectedValidators] is nil if the signature was fetched via the Warp API. Failures are logged,
+// but do not fail the relay.
+func (r *ApplicationRelayer) storeAggregationRecord(
+	logger logging.Logger,
+	signedMessage *avalancheWarp.Message,
+	connectedValidators *peers.ConnectedCanonicalValidators,
+	txHash common.Hash,
+) {
+	record := &database.AggregationRecord{
+		WarpMessageID:           signedMessage.ID().String(),
+		SourceBlockchainID:      r.sourceBlockchain.GetBlockchainID().String(),
+		DestinationBlockchainID: r.relayerID.DestinationBlockchainID.String(),
+		SigningSubnetID:         r.signingSubnetID.String(),
+		TransactionHash:         txHash.Hex(),
+		Timestamp:               time.Now().Unix(),
+	}
+	if sig, ok := signedMessage.Signature.(*avalancheWarp.BitSetSignature); ok {
+		record.Signature = hexutil.Encode(sig.Signature[:])
+		signers := set.BitsFromBytes(sig.Signers)
+		for i := 0; i < signers.BitLen(); i++ {
+			if !signers.Contains(i) {
+				continue
+			}
+			record.Signers = append(record.Signers, i)
+			if connectedValidators == nil || i >= len(connectedValidators.ValidatorSet) {
+				continue
+			}
+			validator := connectedValidators.ValidatorSet[i]
+			signer := database.AggregationSigner{Weight: validator.Weight}
+			for _, nodeID := range validator.NodeIDs {
+				signer.NodeIDs = append(signer.NodeIDs, nodeID.String())
+			}
+			record.SignerValidators = append(record.SignerValidators, signer)
+			record.SignedWeight += validator.Weight
+		}
+	}
+	if connectedValidators != nil {
+		record.TotalWeight = connectedValidators.TotalValidatorWeight
+	}
+	if err := r.aggregationStore.Put(r.relayerID.ID, record); err != nil {
+		logger.Warn(
+			"Failed to persist aggregation record",
+			zap.String("warpMessageID", record.WarpMessageID),
+			zap.Error(err),
+		)
+	}
+}
+
+// relayShadowMessageAsync delivers the signed message to the shadow chain in the background, so that shadow
+// deliveries do not affect delivery to the destination chain. The delivery is registered as in-flight, and is
+// skipped if the relayer is shutting down.
+func (r *ApplicationRelayer) relayShadowMessageAsync(
+	ctx context.Context,
+	logger logging.Logger,
+	handler messages.MessageHandler,
+	signedMessage *avalancheWarp.Message,
+) {
+	if r.deliveryTracker == nil {
+		go func() {
+			_, _ = r.relayShadowMessage(ctx, logger, handler, signedMessage)
+		}()
+		return
+	}
+	if !r.deliveryTracker.startProcessing(1) {
+		logger.Debug("Relayer is shutting down. Skipping delivery to shadow chain.")
+		return
+	}
+	go func() {
+		defer r.deliveryTracker.finishProcessing(1)
+		_, _ = r.relayShadowMessage(ctx, logger, handler, signedMessage)
+	}()
+}
+
+// relayShadowMessage delivers the signed message to the shadow chain, and records the outcome.
+// Returns the transaction hash if the message is successfully delivered.
+func (r *ApplicationRelayer) relayShadowMessage(
+	ctx context.Context,
+	logger logging.Logger,
+	handler messages.MessageHandler,
+	signedMessage *avalancheWarp.Message,
+) (common.Hash, error) {
+	txHash, err := handler.SendMessage(ctx, signedMessage, r.shadowClient)
+	if errors.Is(err, vms.ErrDryRun) {
+		return common.Hash{}, nil
+	}
+	if err != nil {
+		logger.Warn(
+			"Failed to send warp message to shadow chain",
+			zap.String("destinationBlockchainID", r.relayerID.DestinationBlockchainID.String()),
+			zap.String("warpMessageID", signedMessage.ID().String()),
+			zap.Error(err),
+		)
+		r.incShadowRelayMessageCount("failure")
+		return common.Hash{}, err
+	}
+	logger.Info(
+		"Finished relaying message to shadow chain",
+		zap.String("destinationBlockchainID", r.relayerID.DestinationBlockchainID.String()),
+		zap.String("txHash", txHash.Hex()),
+	)
+	r.incShadowRelayMessageCount("success")
+	return txHash, nil
+}
+
+// sendMessageWithLimit delivers [signedMessage] to the destination chain. If the destination blockchain limits
+// the number of concurrent sends, blocks until a slot is available. Messages whose handler assigns them a
+// priority are given a slot ahead of those with a lower priority.
+func (r *ApplicationRelayer) sendMessageWithLimit(
+	ctx context.Context,
+	logger logging.Logger,
+	handler messages.MessageHandler,
+	signedMessage *avalancheWarp.Message,
+	destinationClient vms.DestinationClient,
+) (common.Hash, error) {
+	if r.sendQueue != nil {
+		var getPriority func() *big.Int
+		if prioritizedHandler, ok := handler.(messages.PrioritizedMessageHandler); ok {
+			getPriority = prioritizedHandler.GetPriority
+		}
+		if err := r.sendQueue.acquire(ctx, getPriority); err != nil {
+			logger.Error(
+				"Failed to acquire send slot",
+				zap.Error(err),
+			)
+			r.incFailedRelayMessageCount("failed to acquire send slot")
+			return common.Hash{}, err
+		}
+		defer r.sendQueue.release()
+	}
+	return handler.SendMessage(ctx, signedMessage, destinationClient)
+}
+
+// createSignedMessageWithLimit queries nodes on the origin chain for signatures, and constructs the signed
+// warp message. If the source blockchain limits the number of concurrent aggregations, blocks until a slot
+// is available or [ctx] is canceled.
+// Also returns the validators queried for signatures, or nil if the signature was fetched via the Warp API.
+func (r *ApplicationRelayer) createSignedMessageWithLimit(
+	ctx context.Context,
+	logger logging.Logger,
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	requestID uint32,
+) (*avalancheWarp.Message, *peers.ConnectedCanonicalValidators, error) {
+	// Messages that have already been signed, for example if they are being re-delivered,
+	// do not need to be re-aggregated
+	cacheKey := peers.SignatureCacheKey{
+		MessageID:       unsignedMessage.ID(),
+		SigningSubnetID: r.signingSubnetID,
+		QuorumNumerator: r.warpQuorum.QuorumNumerator,
+	}
+	if signedMessage, connectedValidators, ok := r.signatureCache.Get(cacheKey); ok {
+		logger.Debug(
+			"Using cached aggregate signature",
+			zap.String("warpMessageID", unsignedMessage.ID().String()),
+			zap.String("signingSubnetID", r.signingSubnetID.String()),
+		)
+		return signedMessage, connectedValidators, nil
+	}
+
+	if r.aggregationSemaphore != nil {
+		if err := r.aggregationSemaphore.Acquire(ctx, 1); err != nil {
+			logger.Error(
+				"Failed to acquire signature aggregation slot",
+				zap.Error(err),
+			)
+			r.incFailedRelayMessageCount("failed to acquire signature aggregation slot")
+			return nil, nil, err
+		}
+		defer r.aggregationSemaphore.Release(1)
+	}
+
+	// sourceWarpSignatureClient is nil iff the source blockchain is configured to fetch signatures via AppRequest
+	if r.sourceWarpSignatureClient == nil {
+		r.incFetchSignatureAppRequestCount()
+		signedMessage, connectedValidators, err := r.createSignedMessageAppRequest(
+			ctx,
+			logger,
+			unsignedMessage,
+			requestID,
+		)
+		if err != nil {
+			logger.Error(
+				"Failed to create signed warp message via AppRequest network",
+				zap.Error(err),
+			)
+			r.incFailedRelayMessageCount("failed to create signed warp message via AppRequest network")
+			return nil, nil, err
+		}
+		r.signatureCache.Put(cacheKey, signedMessage, connectedValidators)
+		return signedMessage, connectedValidators, nil
+	}
+
+	r.incFetchSignatureRPCCount()
+	signedMessage, err := r.createSignedMessage(ctx, logger, unsignedMessage)
+	if err != nil {
+		logger.Error(
+			"Failed to create signed warp message via RPC",
+			zap.Error(err),
+		)
+		r.incFailedRelayMessageCount("failed to create signed warp message via RPC")
+		return nil, nil, err
+	}
+	r.signatureCache.Put(cacheKey, signedMessage, nil)
+	return signedMessage, nil, nil
+}
+
 // createSignedMessage fetches the signed Warp message from the source chain via RPC.
 // Each VM may implement their own RPC method to construct the aggregate signature, which
-// will need to be accounted for here.
+// will need to be accounted for here. Stops retrying once [ctx] is canceled, or the signature
+// aggregation timeout elapses.
 func (r *ApplicationRelayer) createSignedMessage(
+	ctx context.Context,
+	logger logging.Logger,
 	unsignedMessage *avalancheWarp.UnsignedMessage,
 ) (*avalancheWarp.Message, error) {
-	r.logger.Info("Fetching aggregate signature from the source chain validators via API")
+	logger.Info("Fetching aggregate signature from the source chain validators via API")
+
+	if r.signatureAggregationTimeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.signatureAggregationTimeout)
+		defer cancel()
+	}
 
 	var (
 		signedWarpMessageBytes hexutil.Bytes
 		err                    error
 	)
 	for attempt := 1; attempt <= maxRelayerQueryAttempts; attempt++ {
-		r.logger.Debug(
+		logger.Debug(
 			"Relayer collecting signatures from peers.",
 			zap.Int("attempt", attempt),
 			zap.String("sourceBlockchainID", r.sourceBlockchain.GetBlockchainID().String()),
@@ -313,7 +954,7 @@ func (r *ApplicationRelayer) createSignedMessage(
 		)
 
 		err = r.sourceWarpSignatureClient.CallContext(
-			context.Background(),
+			ctx,
 			&signedWarpMessageBytes,
 			"warp_getMessageAggregateSignature",
 			unsignedMessage.ID(),
@@ -323,7 +964,7 @@ func (r *ApplicationRelayer) createSignedMessage(
 		if err == nil {
 			warpMsg, err := avalancheWarp.ParseMessage(signedWarpMessageBytes)
 			if err != nil {
-				r.logger.Error(
+				logger.Error(
 					"Failed to parse signed warp message",
 					zap.Error(err),
 				)
@@ -331,7 +972,7 @@ func (r *ApplicationRelayer) createSignedMessage(
 			}
 			return warpMsg, err
 		}
-		r.logger.Info(
+		logger.Info(
 			"Failed to get aggregate signature from node endpoint. Retrying.",
 			zap.Int("attempt", attempt),
 			zap.Error(err),
@@ -339,10 +980,26 @@ func (r *ApplicationRelayer) createSignedMessage(
 		if attempt != maxRelayerQueryAttempts {
 			// Sleep such that all retries are uniformly spread across totalRelayerQueryPeriodMs
 			// TODO: We may want to consider an exponential back off rather than a uniform sleep period.
-			time.Sleep(time.Duration(signatureRequestRetryWaitPeriodMs/maxRelayerQueryAttempts) * time.Millisecond)
+			select {
+			case <-time.After(time.Duration(signatureRequestRetryWaitPeriodMs/maxRelayerQueryAttempts) * time.Millisecond):
+			case <-ctx.Done():
+				err = ctx.Err()
+				if errors.Is(err, context.DeadlineExceeded) {
+					err = errSignatureAggregationTimeout
+				}
+				logger.Warn(
+					"Abandoned fetching aggregate signature from node endpoint",
+					zap.Int("attempts", attempt),
+					zap.String("sourceBlockchainID", r.sourceBlockchain.GetBlockchainID().String()),
+					zap.String("destinationBlockchainID", r.relayerID.DestinationBlockchainID.String()),
+					zap.String("signingSubnetID", r.signingSubnetID.String()),
+					zap.Error(err),
+				)
+				return nil, err
+			}
 		}
 	}
-	r.logger.Warn(
+	logger.Warn(
 		"Failed to get aggregate signature from node endpoint",
 		zap.Int("attempts", maxRelayerQueryAttempts),
 		zap.String("sourceBlockchainID", r.sourceBlockchain.GetBlockchainID().String()),
@@ -354,11 +1011,14 @@ func (r *ApplicationRelayer) createSignedMessage(
 
 // createSignedMessageAppRequest collects signatures from nodes by directly querying them
 // via AppRequest, then aggregates the signatures, and constructs the signed warp message.
+// Also returns the validators that were queried. Each request is traced as a child of the span carried by [ctx].
 func (r *ApplicationRelayer) createSignedMessageAppRequest(
+	ctx context.Context,
+	logger logging.Logger,
 	unsignedMessage *avalancheWarp.UnsignedMessage,
 	requestID uint32,
-) (*avalancheWarp.Message, error) {
-	r.logger.Info(
+) (*avalancheWarp.Message, *peers.ConnectedCanonicalValidators, error) {
+	logger.Info(
 		"Fetching aggregate signature from the source chain validators via AppRequest",
 		zap.String("warpMessageID", unsignedMessage.ID().String()),
 		zap.String("sourceBlockchainID", r.sourceBlockchain.GetBlockchainID().String()),
@@ -366,12 +1026,12 @@ func (r *ApplicationRelayer) createSignedMessageAppRequest(
 	)
 	connectedValidators, err := r.network.ConnectToCanonicalValidators(r.signingSubnetID)
 	if err != nil {
-		r.logger.Error(
+		logger.Error(
 			"Failed to connect to canonical validators",
 			zap.String("warpMessageID", unsignedMessage.ID().String()),
 			zap.Error(err),
 		)
-		return nil, err
+		return nil, nil, err
 	}
 	if !utils.CheckStakeWeightExceedsThreshold(
 		big.NewInt(0).SetUint64(connectedValidators.ConnectedWeight),
@@ -379,13 +1039,13 @@ func (r *ApplicationRelayer) createSignedMessageAppRequest(
 		r.warpQuorum.QuorumNumerator,
 		r.warpQuorum.QuorumDenominator,
 	) {
-		r.logger.Error(
+		logger.Error(
 			"Failed to connect to a threshold of stake",
 			zap.Uint64("connectedWeight", connectedValidators.ConnectedWeight),
 			zap.Uint64("totalValidatorWeight", connectedValidators.TotalValidatorWeight),
 			zap.Any("warpQuorum", r.warpQuorum),
 		)
-		return nil, errNotEnoughConnectedStake
+		return nil, nil, errNotEnoughConnectedStake
 	}
 
 	// Make sure to use the correct codec
@@ -402,12 +1062,12 @@ func (r *ApplicationRelayer) createSignedMessageAppRequest(
 		reqBytes, err = msg.RequestToBytes(codec, req)
 	}
 	if err != nil {
-		r.logger.Error(
+		logger.Error(
 			"Failed to marshal request bytes",
 			zap.String("warpMessageID", unsignedMessage.ID().String()),
 			zap.Error(err),
 		)
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Construct the AppRequest
@@ -418,21 +1078,35 @@ func (r *ApplicationRelayer) createSignedMessageAppRequest(
 		reqBytes,
 	)
 	if err != nil {
-		r.logger.Error(
+		logger.Error(
 			"Failed to create app request message",
 			zap.String("warpMessageID", unsignedMessage.ID().String()),
 			zap.Error(err),
 		)
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Query the validators with retries. On each retry, query one node per unique BLS pubkey
 	accumulatedSignatureWeight := big.NewInt(0)
 
 	signatureMap := make(map[int]blsSignatureBuf)
+	// The request to each node is traced until its response is handled, or the attempt ends
+	requestSpans := make(map[ids.NodeID]trace.Span)
+	defer endRequestSpans(requestSpans)
+	// The time each node was last queried, to log the latency of its response
+	requestTimes := make(map[ids.NodeID]time.Time)
+
+	// If aggregation is bounded in time, stop collecting signatures once the timeout elapses. A quorum of
+	// signatures is aggregated as soon as it is collected, so aggregation fails if the timeout elapses.
+	var timeout <-chan time.Time
+	if r.signatureAggregationTimeout != 0 {
+		timer := time.NewTimer(r.signatureAggregationTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
 	for attempt := 1; attempt <= maxRelayerQueryAttempts; attempt++ {
 		responsesExpected := len(connectedValidators.ValidatorSet) - len(signatureMap)
-		r.logger.Debug(
+		logger.Debug(
 			"Relayer collecting signatures from peers.",
 			zap.Int("attempt", attempt),
 			zap.String("sourceBlockchainID", r.sourceBlockchain.GetBlockchainID().String()),
@@ -452,7 +1126,13 @@ func (r *ApplicationRelayer) createSignedMessageAppRequest(
 			// TODO: Track failures and iterate through the validator's node list on subsequent query attempts
 			nodeID := vdr.NodeIDs[0]
 			vdrSet.Add(nodeID)
-			r.logger.Debug(
+			_, requestSpans[nodeID] = startSpan(
+				ctx,
+				signatureRequestSpanName,
+				attribute.String("node_id", nodeID.String()),
+				attribute.Int("attempt", attempt),
+			)
+			logger.Debug(
 				"Added node ID to query.",
 				zap.String("nodeID", nodeID.String()),
 				zap.String("warpMessageID", unsignedMessage.ID().String()),
@@ -469,16 +1149,23 @@ func (r *ApplicationRelayer) createSignedMessageAppRequest(
 				Op:                 byte(message.AppResponseOp),
 			}
 			r.network.Handler.RegisterAppRequest(reqID)
+			requestTimes[nodeID] = time.Now()
 		}
 		responseChan := r.network.Handler.RegisterRequestID(requestID, vdrSet.Len())
 
+		// The requests are sent to the validators of the configured signing subnet, which track that subnet
+		// rather than the source subnet
+		sendSubnetID := r.sourceBlockchain.GetSubnetID()
+		if r.sourceBlockchain.HasSigningSubnetID() {
+			sendSubnetID = r.signingSubnetID
+		}
 		sentTo := r.network.Network.Send(
 			outMsg,
 			avagoCommon.SendConfig{NodeIDs: vdrSet},
-			r.sourceBlockchain.GetSubnetID(),
+			sendSubnetID,
 			subnets.NoOpAllower,
 		)
-		r.logger.Debug(
+		logger.Debug(
 			"Sent signature request to network",
 			zap.String("warpMessageID", unsignedMessage.ID().String()),
 			zap.Any("sentTo", sentTo),
@@ -487,75 +1174,222 @@ func (r *ApplicationRelayer) createSignedMessageAppRequest(
 		)
 		for nodeID := range vdrSet {
 			if !sentTo.Contains(nodeID) {
-				r.logger.Warn(
+				logger.Warn(
 					"Failed to make async request to node",
 					zap.String("nodeID", nodeID.String()),
 					zap.Error(err),
 				)
 				responsesExpected--
+				endSpan(requestSpans[nodeID], errFailedToSendSignatureRequest)
+				delete(requestSpans, nodeID)
 			}
 		}
 
+		// Handle the responses until we've had successful or unsuccessful responses from each requested node.
+		// For each response, we need to call response.OnFinishedHandling() exactly once.
 		responseCount := 0
-		if responsesExpected > 0 {
-			// Handle the responses. For each response, we need to call response.OnFinishedHandling() exactly once.
-			// Wrap the loop body in an anonymous function so that we do so on each loop iteration
-			for response := range responseChan {
-				r.logger.Debug(
-					"Processing response from node",
-					zap.String("nodeID", response.NodeID().String()),
-					zap.String("warpMessageID", unsignedMessage.ID().String()),
-					zap.String("sourceBlockchainID", r.sourceBlockchain.GetBlockchainID().String()),
-					zap.String("destinationBlockchainID", r.relayerID.DestinationBlockchainID.String()),
-				)
-				signedMsg, relevant, err := r.handleResponse(
-					response,
-					sentTo,
-					requestID,
-					connectedValidators,
+	responses:
+		for responseCount < responsesExpected {
+			var response message.InboundMessage
+			select {
+			case resp, ok := <-responseChan:
+				if !ok {
+					break responses
+				}
+				response = resp
+			case <-timeout:
+				// Release the responses that arrive after aggregation is abandoned
+				go drainResponses(responseChan)
+				return nil, nil, r.signatureAggregationFailed(
+					logger,
 					unsignedMessage,
+					connectedValidators,
 					signatureMap,
 					accumulatedSignatureWeight,
+					attempt,
+					errSignatureAggregationTimeout,
 				)
-				if err != nil {
-					return nil, err
-				}
-				if relevant {
-					responseCount++
-				}
-				// If we have sufficient signatures, return here.
-				if signedMsg != nil {
-					r.logger.Info(
-						"Created signed message.",
-						zap.String("warpMessageID", unsignedMessage.ID().String()),
-						zap.Uint64("signatureWeight", accumulatedSignatureWeight.Uint64()),
-						zap.String("sourceBlockchainID", r.sourceBlockchain.GetBlockchainID().String()),
-						zap.String("destinationBlockchainID", r.relayerID.DestinationBlockchainID.String()),
-					)
-					return signedMsg, nil
-				}
-				// Break once we've had successful or unsuccessful responses from each requested node
-				if responseCount == responsesExpected {
-					break
+			}
+			nodeID := response.NodeID()
+			logger.Debug(
+				"Processing response from node",
+				zap.String("nodeID", nodeID.String()),
+				zap.Duration("latency", time.Since(requestTimes[nodeID])),
+				zap.String("warpMessageID", unsignedMessage.ID().String()),
+				zap.String("sourceBlockchainID", r.sourceBlockchain.GetBlockchainID().String()),
+				zap.String("destinationBlockchainID", r.relayerID.DestinationBlockchainID.String()),
+			)
+			signedMsg, relevant, err := r.handleResponse(
+				logger,
+				response,
+				sentTo,
+				requestID,
+				connectedValidators,
+				unsignedMessage,
+				signatureMap,
+				accumulatedSignatureWeight,
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+			if relevant {
+				responseCount++
+				if span, ok := requestSpans[nodeID]; ok {
+					span.End()
+					delete(requestSpans, nodeID)
 				}
 			}
+			// If we have sufficient signatures, return here.
+			if signedMsg != nil {
+				collectedPercentage := stakePercentage(
+					accumulatedSignatureWeight,
+					connectedValidators.TotalValidatorWeight,
+				)
+				r.observeAggregationStakePercentage(aggregationOutcomeSuccess, collectedPercentage)
+				logger.Info(
+					"Created signed message.",
+					zap.String("warpMessageID", unsignedMessage.ID().String()),
+					zap.Uint64("signatureWeight", accumulatedSignatureWeight.Uint64()),
+					zap.Float64("collectedStakePercentage", collectedPercentage),
+					zap.String("sourceBlockchainID", r.sourceBlockchain.GetBlockchainID().String()),
+					zap.String("destinationBlockchainID", r.relayerID.DestinationBlockchainID.String()),
+				)
+				return signedMsg, connectedValidators, nil
+			}
 		}
+		endRequestSpans(requestSpans)
 		if attempt != maxRelayerQueryAttempts {
 			// Sleep such that all retries are uniformly spread across totalRelayerQueryPeriodMs
 			// TODO: We may want to consider an exponential back off rather than a uniform sleep period.
-			time.Sleep(time.Duration(signatureRequestRetryWaitPeriodMs/maxRelayerQueryAttempts) * time.Millisecond)
+			select {
+			case <-time.After(time.Duration(signatureRequestRetryWaitPeriodMs/maxRelayerQueryAttempts) * time.Millisecond):
+			case <-timeout:
+				return nil, nil, r.signatureAggregationFailed(
+					logger,
+					unsignedMessage,
+					connectedValidators,
+					signatureMap,
+					accumulatedSignatureWeight,
+					attempt,
+					errSignatureAggregationTimeout,
+				)
+			}
 		}
 	}
 
-	r.logger.Warn(
+	return nil, nil, r.signatureAggregationFailed(
+		logger,
+		unsignedMessage,
+		connectedValidators,
+		signatureMap,
+		accumulatedSignatureWeight,
+		maxRelayerQueryAttempts,
+		errNotEnoughSignatures,
+	)
+}
+
+// signatureAggregationFailed logs the validators that did not provide a valid signature for [unsignedMessage], and
+// the stake weight by which the collected signatures fall short of the quorum. Returns [err] annotated with the same.
+func (r *ApplicationRelayer) signatureAggregationFailed(
+	logger logging.Logger,
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+	connectedValidators *peers.ConnectedCanonicalValidators,
+	signatureMap map[int]blsSignatureBuf,
+	accumulatedSignatureWeight *big.Int,
+	attempts int,
+	err error,
+) error {
+	missingValidators, missingWeight := signatureShortfall(
+		connectedValidators,
+		signatureMap,
+		accumulatedSignatureWeight,
+		r.warpQuorum,
+	)
+	collectedPercentage := stakePercentage(accumulatedSignatureWeight, connectedValidators.TotalValidatorWeight)
+	r.observeAggregationStakePercentage(aggregationOutcomeFailure, collectedPercentage)
+	logger.Warn(
 		"Failed to collect a threshold of signatures",
-		zap.Int("attempts", maxRelayerQueryAttempts),
+		zap.Int("attempts", attempts),
 		zap.String("warpMessageID", unsignedMessage.ID().String()),
 		zap.Uint64("accumulatedWeight", accumulatedSignatureWeight.Uint64()),
+		zap.Uint64("totalValidatorWeight", connectedValidators.TotalValidatorWeight),
+		zap.Uint64("missingWeight", missingWeight),
+		zap.Float64("collectedStakePercentage", collectedPercentage),
+		zap.Float64(
+			"requiredStakePercentage",
+			100*float64(r.warpQuorum.QuorumNumerator)/float64(r.warpQuorum.QuorumDenominator),
+		),
+		zap.Stringers("missingValidators", missingValidators),
 		zap.String("sourceBlockchainID", r.sourceBlockchain.GetBlockchainID().String()),
 		zap.String("destinationBlockchainID", r.relayerID.DestinationBlockchainID.String()),
+		zap.Error(err),
+	)
+	return fmt.Errorf(
+		"%w: collected %d of %d stake weight, %d short of quorum. validators without a signature: %v",
+		err,
+		accumulatedSignatureWeight.Uint64(),
+		connectedValidators.TotalValidatorWeight,
+		missingWeight,
+		missingValidators,
 	)
-	return nil, errNotEnoughSignatures
+}
+
+// signatureShortfall returns the node IDs of the validators whose signatures are not in [signatureMap], and the
+// additional stake weight that must sign for [accumulatedSignatureWeight] to reach [quorum].
+func signatureShortfall(
+	connectedValidators *peers.ConnectedCanonicalValidators,
+	signatureMap map[int]blsSignatureBuf,
+	accumulatedSignatureWeight *big.Int,
+	quorum config.WarpQuorum,
+) ([]ids.NodeID, uint64) {
+	var missingValidators []ids.NodeID
+	for i, vdr := range connectedValidators.ValidatorSet {
+		if _, ok := signatureMap[i]; !ok {
+			missingValidators = append(missingValidators, vdr.NodeIDs...)
+		}
+	}
+
+	// The quorum is reached once the signature weight is at least ceil(totalWeight * numerator / denominator)
+	requiredWeight := new(big.Int).Mul(
+		new(big.Int).SetUint64(connectedValidators.TotalValidatorWeight),
+		new(big.Int).SetUint64(quorum.QuorumNumerator),
+	)
+	denominator := new(big.Int).SetUint64(quorum.QuorumDenominator)
+	requiredWeight.Add(requiredWeight, new(big.Int).Sub(denominator, big.NewInt(1)))
+	requiredWeight.Div(requiredWeight, denominator)
+	missingWeight := requiredWeight.Sub(requiredWeight, accumulatedSignatureWeight)
+	if missingWeight.Sign() < 0 {
+		return missingValidators, 0
+	}
+	return missingValidators, missingWeight.Uint64()
+}
+
+// stakePercentage returns [accumulatedSignatureWeight] as a percentage of [totalValidatorWeight]
+func stakePercentage(accumulatedSignatureWeight *big.Int, totalValidatorWeight uint64) float64 {
+	if totalValidatorWeight == 0 {
+		return 0
+	}
+	percentage, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(new(big.Int).Mul(accumulatedSignatureWeight, big.NewInt(100))),
+		new(big.Float).SetUint64(totalValidatorWeight),
+	).Float64()
+	return percentage
+}
+
+// drainResponses marks the responses to an abandoned request as handled as they arrive. The response channel
+// is closed once each queried node has responded or timed out.
+func drainResponses(responseChan chan message.InboundMessage) {
+	for response := range responseChan {
+		response.OnFinishedHandling()
+	}
+}
+
+// endRequestSpans ends the spans of the signature requests that are still awaiting a response
+func endRequestSpans(requestSpans map[ids.NodeID]trace.Span) {
+	for nodeID, span := range requestSpans {
+		span.End()
+		delete(requestSpans, nodeID)
+	}
 }
 
 // Attempts to create a signed warp message from the accumulated responses.
@@ -564,6 +1398,7 @@ func (r *ApplicationRelayer) createSignedMessageAppRequest(
 // aggregation request. Returns an error only if a non-recoverable error occurs, otherwise returns a nil error
 // to continue processing responses.
 func (r *ApplicationRelayer) handleResponse(
+	logger logging.Logger,
 	response message.InboundMessage,
 	sentTo set.Set[ids.NodeID],
 	requestID uint32,
@@ -580,26 +1415,26 @@ func (r *ApplicationRelayer) handleResponse(
 	rcvReqID, ok := message.GetRequestID(m)
 	if !ok {
 		// This should never occur, since inbound message validity is already checked by the inbound handler
-		r.logger.Error("Could not get requestID from message")
+		logger.Error("Could not get requestID from message")
 		return nil, false, nil
 	}
 	nodeID := response.NodeID()
 	if !sentTo.Contains(nodeID) || rcvReqID != requestID {
-		r.logger.Debug("Skipping irrelevant app response")
+		logger.Debug("Skipping irrelevant app response")
 		return nil, false, nil
 	}
 
 	// If we receive an AppRequestFailed, then the request timed out.
 	// This is still a relevant response, since we are no longer expecting a response from that node.
 	if response.Op() == message.AppErrorOp {
-		r.logger.Debug("Request timed out")
+		logger.Debug("Request timed out")
 		return nil, true, nil
 	}
 
 	validator, vdrIndex := connectedValidators.GetValidator(nodeID)
-	signature, valid := r.isValidSignatureResponse(unsignedMessage, response, validator.PublicKey)
+	signature, valid := r.isValidSignatureResponse(logger, unsignedMessage, response, validator.PublicKey)
 	if valid {
-		r.logger.Debug(
+		logger.Debug(
 			"Got valid signature response",
 			zap.String("nodeID", nodeID.String()),
 			zap.Uint64("stakeWeight", validator.Weight),
@@ -610,7 +1445,7 @@ func (r *ApplicationRelayer) handleResponse(
 		signatureMap[vdrIndex] = signature
 		accumulatedSignatureWeight.Add(accumulatedSignatureWeight, new(big.Int).SetUint64(validator.Weight))
 	} else {
-		r.logger.Debug(
+		logger.Debug(
 			"Got invalid signature response",
 			zap.String("nodeID", nodeID.String()),
 			zap.Uint64("stakeWeight", validator.Weight),
@@ -630,7 +1465,7 @@ func (r *ApplicationRelayer) handleResponse(
 	) {
 		aggSig, vdrBitSet, err := r.aggregateSignatures(signatureMap)
 		if err != nil {
-			r.logger.Error(
+			logger.Error(
 				"Failed to aggregate signature.",
 				zap.String("sourceBlockchainID", r.sourceBlockchain.GetBlockchainID().String()),
 				zap.String("destinationBlockchainID", r.relayerID.DestinationBlockchainID.String()),
@@ -648,7 +1483,7 @@ func (r *ApplicationRelayer) handleResponse(
 			},
 		)
 		if err != nil {
-			r.logger.Error(
+			logger.Error(
 				"Failed to create new signed message",
 				zap.String("sourceBlockchainID", r.sourceBlockchain.GetBlockchainID().String()),
 				zap.String("destinationBlockchainID", r.relayerID.DestinationBlockchainID.String()),
@@ -667,13 +1502,14 @@ func (r *ApplicationRelayer) handleResponse(
 // the signature against the node's public key. If we are unable to generate the signature or verify
 // correctly, false will be returned to indicate no valid signature was found in response.
 func (r *ApplicationRelayer) isValidSignatureResponse(
+	logger logging.Logger,
 	unsignedMessage *avalancheWarp.UnsignedMessage,
 	response message.InboundMessage,
 	pubKey *bls.PublicKey,
 ) (blsSignatureBuf, bool) {
 	// If the handler returned an error response, count the response and continue
 	if response.Op() == message.AppErrorOp {
-		r.logger.Debug(
+		logger.Debug(
 			"Relayer async response failed",
 			zap.String("nodeID", response.NodeID().String()),
 		)
@@ -682,7 +1518,7 @@ func (r *ApplicationRelayer) isValidSignatureResponse(
 
 	appResponse, ok := response.Message().(*p2p.AppResponse)
 	if !ok {
-		r.logger.Debug(
+		logger.Debug(
 			"Relayer async response was not an AppResponse",
 			zap.String("nodeID", response.NodeID().String()),
 		)
@@ -691,7 +1527,7 @@ func (r *ApplicationRelayer) isValidSignatureResponse(
 
 	var sigResponse msg.SignatureResponse
 	if _, err := msg.Codec.Unmarshal(appResponse.AppBytes, &sigResponse); err != nil {
-		r.logger.Error(
+		logger.Error(
 			"Error unmarshaling signature response",
 			zap.Error(err),
 		)
@@ -701,7 +1537,7 @@ func (r *ApplicationRelayer) isValidSignatureResponse(
 	// If the node returned an empty signature, then it has not yet seen the warp message. Retry later.
 	emptySignature := blsSignatureBuf{}
 	if bytes.Equal(signature[:], emptySignature[:]) {
-		r.logger.Debug(
+		logger.Debug(
 			"Response contained an empty signature",
 			zap.String("nodeID", response.NodeID().String()),
 			zap.String("destinationBlockchainID", r.relayerID.DestinationBlockchainID.String()),
@@ -711,7 +1547,7 @@ func (r *ApplicationRelayer) isValidSignatureResponse(
 
 	sig, err := bls.SignatureFromBytes(signature[:])
 	if err != nil {
-		r.logger.Debug(
+		logger.Debug(
 			"Failed to create signature from response",
 			zap.String("destinationBlockchainID", r.relayerID.DestinationBlockchainID.String()),
 		)
@@ -719,7 +1555,7 @@ func (r *ApplicationRelayer) isValidSignatureResponse(
 	}
 
 	if !bls.Verify(pubKey, sig, unsignedMessage.Bytes()) {
-		r.logger.Debug(
+		logger.Debug(
 			"Failed verification for signature",
 			zap.String("destinationBlockchainID", r.relayerID.DestinationBlockchainID.String()),
 		)
@@ -807,3 +1643,54 @@ func (r *ApplicationRelayer) incFetchSignatureAppRequestCount() {
 			r.sourceBlockchain.GetBlockchainID().String(),
 			r.sourceBlockchain.GetSubnetID().String()).Inc()
 }
+
+func (r *ApplicationRelayer) incShadowRelayMessageCount(status string) {
+	r.metrics.shadowRelayMessageCount.
+		WithLabelValues(
+			r.relayerID.DestinationBlockchainID.String(),
+			r.sourceBlockchain.GetBlockchainID().String(),
+			r.sourceBlockchain.GetSubnetID().String(),
+			status).Inc()
+}
+
+func (r *ApplicationRelayer) setDeliverySLACompliance(compliance float64) {
+	r.metrics.deliverySLACompliance.
+		WithLabelValues(
+			r.relayerID.DestinationBlockchainID.String(),
+			r.sourceBlockchain.GetBlockchainID().String(),
+			r.sourceBlockchain.GetSubnetID().String()).Set(compliance)
+}
+
+func (r *ApplicationRelayer) recordRelayLatency(messageProtocol string, latency time.Duration) {
+	r.metrics.relayLatencySeconds.
+		WithLabelValues(
+			r.sourceBlockchain.GetBlockchainID().String(),
+			r.relayerID.DestinationBlockchainID.String(),
+			messageProtocol).Observe(latency.Seconds())
+	r.metrics.messagesRelayedTotal.
+		WithLabelValues(
+			r.sourceBlockchain.GetBlockchainID().String(),
+			r.relayerID.DestinationBlockchainID.String(),
+			messageProtocol).Inc()
+}
+
+func (r *ApplicationRelayer) observeAggregationStakePercentage(outcome string, percentage float64) {
+	r.metrics.aggregationStakePercentage.
+		WithLabelValues(
+			r.relayerID.DestinationBlockchainID.String(),
+			r.sourceBlockchain.GetBlockchainID().String(),
+			r.sourceBlockchain.GetSubnetID().String(),
+			outcome).Observe(percentage)
+}
+
+func (r *ApplicationRelayer) setDeliverySLABreach(breached bool) {
+	value := 0.0
+	if breached {
+		value = 1.0
+	}
+	r.metrics.deliverySLABreach.
+		WithLabelValues(
+			r.relayerID.DestinationBlockchainID.String(),
+			r.sourceBlockchain.GetBlockchainID().String(),
+			r.sourceBlockchain.GetSubnetID().String()).Set(value)
+}
@@ -19,6 +19,13 @@ type ApplicationRelayerMetrics struct {
 	failedRelayMessageCount       *prometheus.CounterVec
 	fetchSignatureAppRequestCount *prometheus.CounterVec
 	fetchSignatureRPCCount        *prometheus.CounterVec
+	shadowRelayMessageCount       *prometheus.CounterVec
+	deliverySLACompliance         *prometheus.GaugeVec
+	deliverySLABreach             *prometheus.GaugeVec
+	relayLatencySeconds           *prometheus.HistogramVec
+	messagesRelayedTotal          *prometheus.CounterVec
+	pendingMessages               *prometheus.GaugeVec
+	aggregationStakePercentage    *prometheus.HistogramVec
 }
 
 func NewApplicationRelayerMetrics(registerer prometheus.Registerer) (*ApplicationRelayerMetrics, error) {
@@ -79,11 +86,104 @@ func NewApplicationRelayerMetrics(registerer prometheus.Registerer) (*Applicatio
 	}
 	registerer.MustRegister(fetchSignatureRPCCount)
 
+	shadowRelayMessageCount := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "shadow_relay_message_count",
+			Help: "Number of messages relayed to shadow destination chains, by delivery status",
+		},
+		[]string{"destination_chain_id", "source_chain_id", "source_subnet_id", "status"},
+	)
+	if shadowRelayMessageCount == nil {
+		return nil, ErrFailedToCreateApplicationRelayerMetrics
+	}
+	registerer.MustRegister(shadowRelayMessageCount)
+
+	deliverySLACompliance := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "delivery_sla_compliance_ratio",
+			Help: "Fraction of messages delivered within the delivery SLA latency over the SLA window",
+		},
+		[]string{"destination_chain_id", "source_chain_id", "source_subnet_id"},
+	)
+	if deliverySLACompliance == nil {
+		return nil, ErrFailedToCreateApplicationRelayerMetrics
+	}
+	registerer.MustRegister(deliverySLACompliance)
+
+	deliverySLABreach := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "delivery_sla_breach",
+			Help: "1 if the delivery SLA is currently breached, 0 otherwise",
+		},
+		[]string{"destination_chain_id", "source_chain_id", "source_subnet_id"},
+	)
+	if deliverySLABreach == nil {
+		return nil, ErrFailedToCreateApplicationRelayerMetrics
+	}
+	registerer.MustRegister(deliverySLABreach)
+
+	relayLatencySeconds := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "relay_latency_seconds",
+			Help:    "Time from when a message is dequeued for relaying to when its delivery is confirmed",
+			Buckets: prometheus.ExponentialBuckets(0.25, 2, 12),
+		},
+		[]string{"source_chain_id", "destination_chain_id", "message_protocol"},
+	)
+	if relayLatencySeconds == nil {
+		return nil, ErrFailedToCreateApplicationRelayerMetrics
+	}
+	registerer.MustRegister(relayLatencySeconds)
+
+	messagesRelayedTotal := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "messages_relayed_total",
+			Help: "Number of messages delivered to the destination chain",
+		},
+		[]string{"source_chain_id", "destination_chain_id", "message_protocol"},
+	)
+	if messagesRelayedTotal == nil {
+		return nil, ErrFailedToCreateApplicationRelayerMetrics
+	}
+	registerer.MustRegister(messagesRelayedTotal)
+
+	pendingMessages := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "pending_messages",
+			Help: "Number of messages from processed source blocks pending delivery to the destination chain",
+		},
+		[]string{"destination_chain_id"},
+	)
+	if pendingMessages == nil {
+		return nil, ErrFailedToCreateApplicationRelayerMetrics
+	}
+	registerer.MustRegister(pendingMessages)
+
+	aggregationStakePercentage := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "signature_aggregation_stake_percentage",
+			Help:    "Percentage of the signing validator set's stake weight that signed a message when aggregation completed",
+			Buckets: prometheus.LinearBuckets(5, 5, 20),
+		},
+		[]string{"destination_chain_id", "source_chain_id", "source_subnet_id", "outcome"},
+	)
+	if aggregationStakePercentage == nil {
+		return nil, ErrFailedToCreateApplicationRelayerMetrics
+	}
+	registerer.MustRegister(aggregationStakePercentage)
+
 	return &ApplicationRelayerMetrics{
 		successfulRelayMessageCount:   successfulRelayMessageCount,
 		createSignedMessageLatencyMS:  createSignedMessageLatencyMS,
 		failedRelayMessageCount:       failedRelayMessageCount,
 		fetchSignatureAppRequestCount: fetchSignatureAppRequestCount,
 		fetchSignatureRPCCount:        fetchSignatureRPCCount,
+		shadowRelayMessageCount:       shadowRelayMessageCount,
+		deliverySLACompliance:         deliverySLACompliance,
+		deliverySLABreach:             deliverySLABreach,
+		relayLatencySeconds:           relayLatencySeconds,
+		messagesRelayedTotal:          messagesRelayedTotal,
+		pendingMessages:               pendingMessages,
+		aggregationStakePercentage:    aggregationStakePercentage,
 	}, nil
 }
@@ -0,0 +1,400 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/database"
+	"github.com/ava-labs/awm-relayer/messages"
+	mock_messages "github.com/ava-labs/awm-relayer/messages/mocks"
+	"github.com/ava-labs/awm-relayer/peers"
+	relayerTypes "github.com/ava-labs/awm-relayer/types"
+	"github.com/ava-labs/awm-relayer/utils"
+	"github.com/ava-labs/awm-relayer/vms"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/sync/semaphore"
+)
+
+func TestSendMessageWithLimit(t *testing.T) {
+	const (
+		maxConcurrentSends = 2
+		numMessages        = 6
+	)
+	ctrl := gomock.NewController(t)
+	r := &ApplicationRelayer{
+		logger:    logging.NoLog{},
+		sendQueue: NewSendQueue(maxConcurrentSends, true),
+	}
+
+	var inFlight, maxInFlight atomic.Int64
+	handler := mock_messages.NewMockMessageHandler(ctrl)
+	handler.EXPECT().SendMessage(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, *warp.Message, vms.DestinationClient) (common.Hash, error) {
+			n := inFlight.Add(1)
+			for {
+				current := maxInFlight.Load()
+				if n <= current || maxInFlight.CompareAndSwap(current, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			inFlight.Add(-1)
+			return common.Hash{1}, nil
+		},
+	).Times(numMessages)
+
+	var wg sync.WaitGroup
+	for i := 0; i < numMessages; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			txHash, err := r.sendMessageWithLimit(context.Background(), r.logger, handler, &warp.Message{}, nil)
+			require.NoError(t, err)
+			require.Equal(t, common.Hash{1}, txHash)
+		}()
+	}
+	wg.Wait()
+	require.LessOrEqual(t, maxInFlight.Load(), int64(maxConcurrentSends))
+}
+
+func TestSkipExpiredMessages(t *testing.T) {
+	now := uint64(time.Now().Unix())
+	testCases := []struct {
+		name                 string
+		maxMessageAgeSeconds uint64
+		blockTimestamp       uint64
+		expectSkipped        bool
+	}{
+		{
+			name:           "no maximum age",
+			blockTimestamp: now - 3600,
+		},
+		{
+			name:                 "within maximum age",
+			maxMessageAgeSeconds: 60,
+			blockTimestamp:       now - 10,
+		},
+		{
+			name:                 "older than maximum age",
+			maxMessageAgeSeconds: 60,
+			blockTimestamp:       now - 3600,
+			expectSkipped:        true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			handler := mock_messages.NewMockMessageHandler(ctrl)
+			handler.EXPECT().GetUnsignedMessage().Return(&warp.UnsignedMessage{}).AnyTimes()
+			r := &ApplicationRelayer{
+				logger: logging.NoLog{},
+				sourceBlockchain: config.SourceBlockchain{
+					MaxMessageAgeSeconds: testCase.maxMessageAgeSeconds,
+				},
+			}
+			block := &relayerTypes.WarpBlockInfo{
+				BlockNumber:    1,
+				BlockTimestamp: testCase.blockTimestamp,
+			}
+			var done int
+			deliveries := r.skipExpiredMessages(block, []messageDelivery{{
+				ctx:     context.Background(),
+				handler: handler,
+				done:    func(error) { done++ },
+			}})
+			if testCase.expectSkipped {
+				require.Empty(t, deliveries)
+				require.Equal(t, 1, done)
+			} else {
+				require.Len(t, deliveries, 1)
+				require.Zero(t, done)
+			}
+		})
+	}
+}
+
+func TestSignatureShortfall(t *testing.T) {
+	nodeIDs := []ids.NodeID{ids.GenerateTestNodeID(), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()}
+	connectedValidators := &peers.ConnectedCanonicalValidators{
+		TotalValidatorWeight: 100,
+		ValidatorSet: []*warp.Validator{
+			{Weight: 50, NodeIDs: []ids.NodeID{nodeIDs[0]}},
+			{Weight: 30, NodeIDs: []ids.NodeID{nodeIDs[1]}},
+			{Weight: 20, NodeIDs: []ids.NodeID{nodeIDs[2]}},
+		},
+	}
+	testCases := []struct {
+		name                      string
+		signedIndices             []int
+		accumulatedWeight         uint64
+		quorum                    config.WarpQuorum
+		expectedMissingValidators []ids.NodeID
+		expectedMissingWeight     uint64
+	}{
+		{
+			name:                      "no signatures",
+			quorum:                    config.WarpQuorum{QuorumNumerator: 67, QuorumDenominator: 100},
+			expectedMissingValidators: nodeIDs,
+			expectedMissingWeight:     67,
+		},
+		{
+			name:                      "partial signatures",
+			signedIndices:             []int{0},
+			accumulatedWeight:         50,
+			quorum:                    config.WarpQuorum{QuorumNumerator: 67, QuorumDenominator: 100},
+			expectedMissingValidators: nodeIDs[1:],
+			expectedMissingWeight:     17,
+		},
+		{
+			name:                      "required weight rounded up",
+			signedIndices:             []int{0},
+			accumulatedWeight:         50,
+			quorum:                    config.WarpQuorum{QuorumNumerator: 2, QuorumDenominator: 3},
+			expectedMissingValidators: nodeIDs[1:],
+			expectedMissingWeight:     17,
+		},
+		{
+			name:                      "quorum reached",
+			signedIndices:             []int{0, 1},
+			accumulatedWeight:         80,
+			quorum:                    config.WarpQuorum{QuorumNumerator: 67, QuorumDenominator: 100},
+			expectedMissingValidators: nodeIDs[2:],
+			expectedMissingWeight:     0,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			signatureMap := make(map[int]blsSignatureBuf)
+			for _, i := range test.signedIndices {
+				signatureMap[i] = blsSignatureBuf{}
+			}
+			missingValidators, missingWeight := signatureShortfall(
+				connectedValidators,
+				signatureMap,
+				new(big.Int).SetUint64(test.accumulatedWeight),
+				test.quorum,
+			)
+			require.Equal(t, test.expectedMissingValidators, missingValidators)
+			require.Equal(t, test.expectedMissingWeight, missingWeight)
+		})
+	}
+}
+
+func TestStakePercentage(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		accumulatedWeight    *big.Int
+		totalValidatorWeight uint64
+		expectedPercentage   float64
+	}{
+		{
+			name:                 "no signatures",
+			accumulatedWeight:    big.NewInt(0),
+			totalValidatorWeight: 100,
+			expectedPercentage:   0,
+		},
+		{
+			name:                 "partial signatures",
+			accumulatedWeight:    big.NewInt(1),
+			totalValidatorWeight: 3,
+			expectedPercentage:   100.0 / 3,
+		},
+		{
+			name:                 "all signatures",
+			accumulatedWeight:    new(big.Int).SetUint64(math.MaxUint64),
+			totalValidatorWeight: math.MaxUint64,
+			expectedPercentage:   100,
+		},
+		{
+			name:                 "no validator weight",
+			accumulatedWeight:    big.NewInt(0),
+			totalValidatorWeight: 0,
+			expectedPercentage:   0,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			require.InDelta(
+				t,
+				test.expectedPercentage,
+				stakePercentage(test.accumulatedWeight, test.totalValidatorWeight),
+				1e-9,
+			)
+		})
+	}
+}
+
+// Test that a message skipped as invalid is reported to its delivery, but does not fail its block.
+func TestProcessHeightSkippedMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	relayerID := database.NewRelayerID(
+		ids.GenerateTestID(),
+		ids.GenerateTestID(),
+		database.AllAllowedAddress,
+		database.AllAllowedAddress,
+	)
+	metrics, err := NewApplicationRelayerMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+	r := newStoppableAppRelayer(relayerID, utils.NewTicker(1))
+	r.logger = logging.NoLog{}
+	r.lock = &sync.RWMutex{}
+	r.metrics = metrics
+	r.pendingMessages = NewPendingMessages(metrics, relayerID.DestinationBlockchainID, 0)
+
+	unsignedMessage, err := warp.NewUnsignedMessage(0, relayerID.SourceBlockchainID, nil)
+	require.NoError(t, err)
+	skippedErr := &messages.SkippedMessageError{Reason: "invalid required gas limit"}
+	handler := mock_messages.NewMockMessageHandler(ctrl)
+	handler.EXPECT().GetUnsignedMessage().Return(unsignedMessage).AnyTimes()
+	handler.EXPECT().ShouldSendMessage(gomock.Any()).Return(false, skippedErr)
+
+	var deliveryErr error
+	require.NoError(t, r.ProcessHeight(1, []messageDelivery{{
+		ctx:     context.Background(),
+		handler: handler,
+		done:    func(err error) { deliveryErr = err },
+	}}))
+	require.ErrorIs(t, deliveryErr, skippedErr)
+	require.Equal(t, uint64(1), r.checkpointManager.Status().CommittedHeight)
+}
+
+// Test that a cached aggregate signature is returned along with the validators that signed it,
+// so that the aggregation record of a re-delivered message is complete.
+func TestCreateSignedMessageCached(t *testing.T) {
+	metrics, err := peers.NewAppRequestNetworkMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+	signatureCache := peers.NewSignatureCache(metrics, 1, time.Hour)
+
+	r := &ApplicationRelayer{
+		signingSubnetID: ids.GenerateTestID(),
+		warpQuorum:      config.WarpQuorum{QuorumNumerator: 67, QuorumDenominator: 100},
+		signatureCache:  signatureCache,
+	}
+	unsignedMessage, err := warp.NewUnsignedMessage(0, ids.GenerateTestID(), []byte{1, 2, 3})
+	require.NoError(t, err)
+	signedMessage := &warp.Message{UnsignedMessage: *unsignedMessage}
+	connectedValidators := &peers.ConnectedCanonicalValidators{TotalValidatorWeight: 100}
+	signatureCache.Put(
+		peers.SignatureCacheKey{
+			MessageID:       unsignedMessage.ID(),
+			SigningSubnetID: r.signingSubnetID,
+			QuorumNumerator: r.warpQuorum.QuorumNumerator,
+		},
+		signedMessage,
+		connectedValidators,
+	)
+
+	cached, cachedValidators, err := r.createSignedMessageWithLimit(
+		context.Background(),
+		logging.NoLog{},
+		unsignedMessage,
+		0,
+	)
+	require.NoError(t, err)
+	require.Equal(t, signedMessage, cached)
+	require.Equal(t, connectedValidators, cachedValidators)
+}
+
+// Test that the aggregation record identifies each signing validator and its weight.
+func TestStoreAggregationRecord(t *testing.T) {
+	relayerID := database.NewRelayerID(ids.GenerateTestID(), ids.GenerateTestID(), common.Address{}, common.Address{})
+	db, err := database.NewJSONFileStorage(logging.NoLog{}, t.TempDir(), []database.RelayerID{relayerID})
+	require.NoError(t, err)
+	r := &ApplicationRelayer{
+		relayerID:        relayerID,
+		signingSubnetID:  ids.GenerateTestID(),
+		aggregationStore: database.NewAggregationStore(db, 0),
+	}
+
+	nodeID1, nodeID2, nodeID3 := ids.GenerateTestNodeID(), ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	connectedValidators := &peers.ConnectedCanonicalValidators{
+		TotalValidatorWeight: 100,
+		ValidatorSet: []*warp.Validator{
+			{Weight: 50, NodeIDs: []ids.NodeID{nodeID1}},
+			{Weight: 30, NodeIDs: []ids.NodeID{nodeID2}},
+			{Weight: 20, NodeIDs: []ids.NodeID{nodeID3}},
+		},
+	}
+	unsignedMessage, err := warp.NewUnsignedMessage(0, ids.GenerateTestID(), []byte{1, 2, 3})
+	require.NoError(t, err)
+	signedMessage, err := warp.NewMessage(
+		unsignedMessage,
+		&warp.BitSetSignature{Signers: set.NewBits(0, 2).Bytes()},
+	)
+	require.NoError(t, err)
+
+	r.storeAggregationRecord(logging.NoLog{}, signedMessage, connectedValidators, common.Hash{1})
+
+	record, err := r.aggregationStore.Get(relayerID.ID, signedMessage.ID().String())
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 2}, record.Signers)
+	require.Equal(t, []database.AggregationSigner{
+		{NodeIDs: []string{nodeID1.String()}, Weight: 50},
+		{NodeIDs: []string{nodeID3.String()}, Weight: 20},
+	}, record.SignerValidators)
+	require.Equal(t, uint64(70), record.SignedWeight)
+	require.Equal(t, uint64(100), record.TotalWeight)
+}
+
+// Test that waiting for a signature aggregation slot is abandoned once the context is canceled.
+func TestCreateSignedMessageSlotCanceled(t *testing.T) {
+	metrics, err := NewApplicationRelayerMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+	aggregationSemaphore := semaphore.NewWeighted(1)
+	require.True(t, aggregationSemaphore.TryAcquire(1))
+	r := &ApplicationRelayer{
+		metrics:              metrics,
+		aggregationSemaphore: aggregationSemaphore,
+	}
+	unsignedMessage, err := warp.NewUnsignedMessage(0, ids.GenerateTestID(), []byte{1, 2, 3})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = r.createSignedMessageWithLimit(ctx, logging.NoLog{}, unsignedMessage, 0)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// Test that fetching the aggregate signature via the Warp API stops retrying once the aggregation timeout elapses.
+func TestCreateSignedMessageRPCTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	client, err := rpc.Dial(server.URL)
+	require.NoError(t, err)
+	defer client.Close()
+
+	r := &ApplicationRelayer{
+		sourceWarpSignatureClient:   client,
+		signatureAggregationTimeout: 50 * time.Millisecond,
+	}
+	unsignedMessage, err := warp.NewUnsignedMessage(0, ids.GenerateTestID(), []byte{1, 2, 3})
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = r.createSignedMessage(context.Background(), logging.NoLog{}, unsignedMessage)
+	require.ErrorIs(t, err, errSignatureAggregationTimeout)
+	retryWait := time.Duration(signatureRequestRetryWaitPeriodMs/maxRelayerQueryAttempts) * time.Millisecond
+	require.Less(t, time.Since(start), retryWait)
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/utils"
+	"go.uber.org/zap"
+)
+
+type laggingSource struct {
+	maxBlockLag   uint64
+	checkInterval time.Duration
+}
+
+// BlockLagMonitor periodically compares the latest checkpointed block of each source blockchain with a
+// configured maximum block lag against the source chain head, and warns if the relayer has fallen behind.
+type BlockLagMonitor struct {
+	logger             logging.Logger
+	metrics            *MessageCoordinatorMetrics
+	messageCoordinator *MessageCoordinator
+	sources            map[ids.ID]laggingSource
+}
+
+// NewBlockLagMonitor returns a monitor for the source blockchains that configure a maximum block lag,
+// or nil if there are none.
+func NewBlockLagMonitor(
+	logger logging.Logger,
+	metrics *MessageCoordinatorMetrics,
+	messageCoordinator *MessageCoordinator,
+	cfg *config.Config,
+) *BlockLagMonitor {
+	sources := make(map[ids.ID]laggingSource)
+	for _, sourceBlockchain := range cfg.SourceBlockchains {
+		if sourceBlockchain.MaxBlockLag == 0 {
+			continue
+		}
+		sources[sourceBlockchain.GetBlockchainID()] = laggingSource{
+			maxBlockLag:   sourceBlockchain.MaxBlockLag,
+			checkInterval: sourceBlockchain.GetBlockLagCheckInterval(),
+		}
+	}
+	if len(sources) == 0 {
+		return nil
+	}
+	return &BlockLagMonitor{
+		logger:             logger,
+		metrics:            metrics,
+		messageCoordinator: messageCoordinator,
+		sources:            sources,
+	}
+}
+
+// Run checks the block lag of each monitored source at its configured interval until the context is canceled.
+func (m *BlockLagMonitor) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for blockchainID, source := range m.sources {
+		wg.Add(1)
+		go func(blockchainID ids.ID, source laggingSource) {
+			defer wg.Done()
+			ticker := time.NewTicker(source.checkInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					m.CheckLag(ctx, blockchainID)
+				}
+			}
+		}(blockchainID, source)
+	}
+	wg.Wait()
+}
+
+// CheckLag sets the block lag metric of the source blockchain [blockchainID], and logs a warning if the lag
+// exceeds its configured maximum. Sources that are not running, such as those removed on reload, are skipped.
+func (m *BlockLagMonitor) CheckLag(ctx context.Context, blockchainID ids.ID) {
+	source, ok := m.sources[blockchainID]
+	if !ok {
+		return
+	}
+	checkpointedHeight, ok := m.messageCoordinator.CheckpointedHeight(blockchainID)
+	if !ok {
+		return
+	}
+	sourceClient, ok := m.messageCoordinator.getSourceClient(blockchainID)
+	if !ok {
+		return
+	}
+	cctx, cancel := context.WithTimeout(ctx, utils.DefaultRPCRetryTimeout)
+	defer cancel()
+	latestHeight, err := sourceClient.BlockNumber(cctx)
+	if err != nil {
+		m.logger.Warn(
+			"Failed to get latest block of source blockchain",
+			zap.String("sourceBlockchainID", blockchainID.String()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	var blockLag uint64
+	if latestHeight > checkpointedHeight {
+		blockLag = latestHeight - checkpointedHeight
+	}
+	m.metrics.blockLag.
+		WithLabelValues(blockchainID.String()).
+		Set(float64(blockLag))
+	if blockLag > source.maxBlockLag {
+		m.logger.Warn(
+			"Relayer is lagging behind the source blockchain",
+			zap.String("sourceBlockchainID", blockchainID.String()),
+			zap.Uint64("checkpointedHeight", checkpointedHeight),
+			zap.Uint64("latestHeight", latestHeight),
+			zap.Uint64("blockLag", blockLag),
+			zap.Uint64("maxBlockLag", source.maxBlockLag),
+		)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/database"
+	"github.com/ava-labs/awm-relayer/messages"
+	"github.com/ava-labs/awm-relayer/relayer/checkpoint"
+	mock_evm "github.com/ava-labs/awm-relayer/vms/evm/mocks"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBlockLagMonitor(t *testing.T) {
+	sourceBlockchain := config.TestValidSourceBlockchainConfig
+	destinations := set.Of(config.TestValidDestinationBlockchainConfig.BlockchainID)
+	require.NoError(t, sourceBlockchain.Validate(&destinations))
+	sourceBlockchainID := sourceBlockchain.GetBlockchainID()
+
+	// Sources without a maximum block lag are not monitored
+	require.Nil(t, NewBlockLagMonitor(
+		logging.NoLog{},
+		nil,
+		nil,
+		&config.Config{SourceBlockchains: []*config.SourceBlockchain{&sourceBlockchain}},
+	))
+	sourceBlockchain.MaxBlockLag = 100
+
+	testCases := []struct {
+		name             string
+		latestHeight     uint64
+		latestHeightErr  error
+		expectedBlockLag float64
+	}{
+		{
+			name:             "within max block lag",
+			latestHeight:     1050,
+			expectedBlockLag: 50,
+		},
+		{
+			name:             "exceeds max block lag",
+			latestHeight:     1500,
+			expectedBlockLag: 500,
+		},
+		{
+			name:             "head behind checkpoint",
+			latestHeight:     900,
+			expectedBlockLag: 0,
+		},
+		{
+			name:             "failed to get latest block",
+			latestHeightErr:  errors.New("connection refused"),
+			expectedBlockLag: 0,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			metrics, err := NewMessageCoordinatorMetrics(prometheus.NewRegistry())
+			require.NoError(t, err)
+
+			// The lag is measured from the lowest checkpoint of the source's ApplicationRelayers
+			applicationRelayers := make(map[common.Hash]*ApplicationRelayer)
+			for _, height := range []uint64{1000, 1200} {
+				relayerID := database.NewRelayerID(
+					sourceBlockchainID,
+					ids.GenerateTestID(),
+					database.AllAllowedAddress,
+					database.AllAllowedAddress,
+				)
+				applicationRelayers[relayerID.ID] = &ApplicationRelayer{
+					relayerID:         relayerID,
+					checkpointManager: checkpoint.NewCheckpointManager(logging.NoLog{}, nil, nil, relayerID, height),
+				}
+			}
+			sourceClient := mock_evm.NewMockClient(gomock.NewController(t))
+			sourceClient.EXPECT().
+				BlockNumber(gomock.Any()).
+				Return(testCase.latestHeight, testCase.latestHeightErr).
+				Times(1)
+
+			messageCoordinator := NewMessageCoordinator(
+				logging.NoLog{},
+				metrics,
+				make(map[ids.ID]map[common.Address]messages.MessageHandlerFactory),
+				applicationRelayers,
+				map[ids.ID]ethclient.Client{sourceBlockchainID: sourceClient},
+				nil,
+				nil,
+				nil,
+			)
+			monitor := NewBlockLagMonitor(
+				logging.NoLog{},
+				metrics,
+				messageCoordinator,
+				&config.Config{SourceBlockchains: []*config.SourceBlockchain{&sourceBlockchain}},
+			)
+			require.NotNil(t, monitor)
+
+			monitor.CheckLag(context.Background(), sourceBlockchainID)
+			require.Equal(
+				t,
+				testCase.expectedBlockLag,
+				testutil.ToFloat64(metrics.blockLag.WithLabelValues(sourceBlockchainID.String())),
+			)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"container/heap"
 	"strconv"
 	"sync"
+	"sync/atomic"
 
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/awm-relayer/database"
@@ -24,8 +25,24 @@ type CheckpointManager struct {
 	writeSignal     chan struct{}
 	relayerID       database.RelayerID
 	committedHeight uint64
-	lock            *sync.RWMutex
-	pendingCommits  *utils.UInt64Heap
+	// Greatest height known to be written to the database
+	checkpointedHeight atomic.Uint64
+	lock               *sync.RWMutex
+	pendingCommits     *utils.UInt64Heap
+	// Set once the CheckpointManager is stopped, after which the committed height is no longer written
+	stopped bool
+}
+
+// Status is a snapshot of the heights tracked by a CheckpointManager
+type Status struct {
+	// Greatest height at and below which all heights have been committed
+	CommittedHeight uint64
+	// Greatest height known to be written to the database
+	CheckpointedHeight uint64
+	// Number of heights committed out of order, waiting for the heights below them to be committed
+	PendingHeights int
+	// Greatest pending height, or zero if there are none
+	MaxPendingHeight uint64
 }
 
 func NewCheckpointManager(
@@ -42,7 +59,7 @@ func NewCheckpointManager(
 		zap.String("relayerID", relayerID.ID.String()),
 		zap.Uint64("startingHeight", startingHeight),
 	)
-	return &CheckpointManager{
+	cm := &CheckpointManager{
 		logger:          logger,
 		database:        database,
 		writeSignal:     writeSignal,
@@ -51,6 +68,29 @@ func NewCheckpointManager(
 		lock:            &sync.RWMutex{},
 		pendingCommits:  h,
 	}
+	cm.checkpointedHeight.Store(startingHeight)
+	return cm
+}
+
+// CheckpointedHeight returns the greatest height known to be written to the database.
+// Heights at or below it will not be reprocessed should the relayer restart.
+func (cm *CheckpointManager) CheckpointedHeight() uint64 {
+	return cm.checkpointedHeight.Load()
+}
+
+// Status returns the committed and checkpointed heights, and the heights pending commit
+func (cm *CheckpointManager) Status() Status {
+	cm.lock.RLock()
+	defer cm.lock.RUnlock()
+	status := Status{
+		CommittedHeight:    cm.committedHeight,
+		CheckpointedHeight: cm.checkpointedHeight.Load(),
+		PendingHeights:     cm.pendingCommits.Len(),
+	}
+	for _, height := range *cm.pendingCommits {
+		status.MaxPendingHeight = max(status.MaxPendingHeight, height)
+	}
+	return status
 }
 
 func (cm *CheckpointManager) Run() {
@@ -61,7 +101,7 @@ func (cm *CheckpointManager) writeToDatabase() {
 	cm.lock.RLock()
 	defer cm.lock.RUnlock()
 	// Defensively ensure we're not writing the default value
-	if cm.committedHeight == 0 {
+	if cm.stopped || cm.committedHeight == 0 {
 		return
 	}
 	storedHeight, err := database.GetLatestProcessedBlockHeight(cm.database, cm.relayerID)
@@ -74,6 +114,7 @@ func (cm *CheckpointManager) writeToDatabase() {
 		return
 	}
 	if storedHeight >= cm.committedHeight {
+		cm.checkpointedHeight.Store(cm.committedHeight)
 		return
 	}
 	cm.logger.Debug(
@@ -94,6 +135,22 @@ func (cm *CheckpointManager) writeToDatabase() {
 		)
 		return
 	}
+	cm.checkpointedHeight.Store(cm.committedHeight)
+}
+
+// Flush writes the committed height to the database immediately, for example before the relayer exits.
+func (cm *CheckpointManager) Flush() {
+	cm.writeToDatabase()
+}
+
+// Stop writes the committed height to the database, and stops writing it thereafter. This is used if the
+// ApplicationRelayer is replaced, so that its heights do not overwrite those written by its replacement.
+// The write signal channel is expected to be closed by its sender.
+func (cm *CheckpointManager) Stop() {
+	cm.writeToDatabase()
+	cm.lock.Lock()
+	defer cm.lock.Unlock()
+	cm.stopped = true
 }
 
 func (cm *CheckpointManager) listenForWriteSignal() {
@@ -106,6 +163,8 @@ func (cm *CheckpointManager) listenForWriteSignal() {
 // Heights are committed in sequence, so if height is not exactly one
 // greater than the current committedHeight, it is instead cached in memory
 // to potentially be committed later.
+// Callers must only stage a height once its deliveries are final, for example
+// once they have reached the destination's required confirmations.
 func (cm *CheckpointManager) StageCommittedHeight(height uint64) {
 	cm.lock.Lock()
 	defer cm.lock.Unlock()
@@ -129,16 +188,63 @@ func (cm *CheckpointManager) StageCommittedHeight(height uint64) {
 		zap.String("relayerID", cm.relayerID.ID.String()),
 	)
 
-	for cm.pendingCommits.Peek() == cm.committedHeight+1 {
+	for cm.pendingCommits.Len() != 0 && cm.pendingCommits.Peek() <= cm.committedHeight+1 {
 		h := heap.Pop(cm.pendingCommits).(uint64)
+		// A height is staged more than once if its block is reprocessed following a rollback
+		if h <= cm.committedHeight {
+			continue
+		}
 		cm.logger.Debug(
 			"Committing height",
 			zap.Uint64("height", height),
 			zap.String("relayerID", cm.relayerID.ID.String()),
 		)
 		cm.committedHeight = h
-		if cm.pendingCommits.Len() == 0 {
-			break
+	}
+}
+
+// Rollback lowers the committed height to [height], and discards the pending heights above it, so that the
+// blocks above it are committed again once they are reprocessed. This is used if those blocks were orphaned by
+// a reorg of the source blockchain. If the committed height is lowered, it is written to the database
+// immediately, so that the blocks are also reprocessed should the relayer restart.
+func (cm *CheckpointManager) Rollback(height uint64) {
+	cm.lock.Lock()
+	defer cm.lock.Unlock()
+	pendingCommits := &utils.UInt64Heap{}
+	for _, h := range *cm.pendingCommits {
+		if h <= height {
+			*pendingCommits = append(*pendingCommits, h)
 		}
 	}
+	heap.Init(pendingCommits)
+	cm.pendingCommits = pendingCommits
+	if height >= cm.committedHeight {
+		return
+	}
+
+	cm.logger.Info(
+		"Rolling back committed height",
+		zap.Uint64("committedHeight", cm.committedHeight),
+		zap.Uint64("height", height),
+		zap.String("relayerID", cm.relayerID.ID.String()),
+	)
+	cm.committedHeight = height
+	if cm.stopped || cm.checkpointedHeight.Load() <= height {
+		return
+	}
+	// Put does not move the checkpoint backwards in databases shared by multiple relayers
+	err := cm.database.Rewind(
+		cm.relayerID.ID,
+		database.LatestProcessedBlockKey,
+		[]byte(strconv.FormatUint(height, 10)),
+	)
+	if err != nil {
+		cm.logger.Error(
+			"Failed to write rolled back height",
+			zap.Error(err),
+			zap.String("relayerID", cm.relayerID.ID.String()),
+		)
+		return
+	}
+	cm.checkpointedHeight.Store(height)
 }
@@ -21,6 +21,9 @@ func TestCommitHeight(t *testing.T) {
 		commitHeight      uint64
 		pendingHeights    *utils.UInt64Heap
 		expectedMaxHeight uint64
+		// The heights still pending commit
+		expectedPendingHeights   int
+		expectedMaxPendingHeight uint64
 	}{
 		{
 			name:              "commit height is the next height",
@@ -42,6 +45,9 @@ func TestCommitHeight(t *testing.T) {
 			commitHeight:      12,
 			pendingHeights:    &utils.UInt64Heap{},
 			expectedMaxHeight: 10,
+
+			expectedPendingHeights:   1,
+			expectedMaxPendingHeight: 12,
 		},
 		{
 			name:              "commit height is not the next height with pending heights",
@@ -49,6 +55,9 @@ func TestCommitHeight(t *testing.T) {
 			commitHeight:      12,
 			pendingHeights:    &utils.UInt64Heap{13, 14},
 			expectedMaxHeight: 10,
+
+			expectedPendingHeights:   3,
+			expectedMaxPendingHeight: 14,
 		},
 		{
 			name:              "commit height is not the next height with next height pending",
@@ -57,6 +66,16 @@ func TestCommitHeight(t *testing.T) {
 			pendingHeights:    &utils.UInt64Heap{11},
 			expectedMaxHeight: 12,
 		},
+		{
+			name:              "commit height is the next height with pending heights above a gap",
+			currentMaxHeight:  10,
+			commitHeight:      11,
+			pendingHeights:    &utils.UInt64Heap{15, 13},
+			expectedMaxHeight: 11,
+
+			expectedPendingHeights:   2,
+			expectedMaxPendingHeight: 15,
+		},
 	}
 	db := mock_database.NewMockRelayerDatabase(gomock.NewController(t))
 	for _, test := range testCases {
@@ -69,5 +88,62 @@ func TestCommitHeight(t *testing.T) {
 		cm.committedHeight = test.currentMaxHeight
 		cm.StageCommittedHeight(test.commitHeight)
 		require.Equal(t, test.expectedMaxHeight, cm.committedHeight, test.name)
+		status := cm.Status()
+		require.Equal(t, test.expectedMaxHeight, status.CommittedHeight, test.name)
+		require.Equal(t, test.expectedPendingHeights, status.PendingHeights, test.name)
+		require.Equal(t, test.expectedMaxPendingHeight, status.MaxPendingHeight, test.name)
 	}
 }
+
+func TestRollback(t *testing.T) {
+	db := mock_database.NewMockRelayerDatabase(gomock.NewController(t))
+	id := database.RelayerID{
+		ID: common.BytesToHash(crypto.Keccak256([]byte("rollback"))),
+	}
+	cm := NewCheckpointManager(logging.NoLog{}, db, nil, id, 10)
+	cm.StageCommittedHeight(11)
+	cm.StageCommittedHeight(12)
+	cm.StageCommittedHeight(14)
+	cm.StageCommittedHeight(15)
+	cm.checkpointedHeight.Store(12)
+
+	// Pending heights above the rolled back height are discarded, and the lowered height is written
+	db.EXPECT().Rewind(id.ID, database.LatestProcessedBlockKey, []byte("11")).Return(nil).Times(1)
+	cm.Rollback(11)
+	require.Equal(t, Status{CommittedHeight: 11, CheckpointedHeight: 11}, cm.Status())
+
+	// Reprocessed heights are committed again, including those staged more than once
+	cm.StageCommittedHeight(13)
+	cm.StageCommittedHeight(13)
+	cm.StageCommittedHeight(12)
+	require.Equal(t, Status{CommittedHeight: 13, CheckpointedHeight: 11}, cm.Status())
+
+	// Rolling back to a height above the committed height only discards the pending heights above it
+	cm.StageCommittedHeight(16)
+	cm.Rollback(15)
+	require.Equal(t, Status{CommittedHeight: 13, CheckpointedHeight: 11}, cm.Status())
+}
+
+func TestStop(t *testing.T) {
+	db := mock_database.NewMockRelayerDatabase(gomock.NewController(t))
+	id := database.RelayerID{
+		ID: common.BytesToHash(crypto.Keccak256([]byte("stop"))),
+	}
+	writeSignal := make(chan struct{})
+	cm := NewCheckpointManager(logging.NoLog{}, db, writeSignal, id, 10)
+	cm.Run()
+	cm.StageCommittedHeight(11)
+
+	// The committed height is written once when stopped
+	db.EXPECT().Get(id.ID, database.LatestProcessedBlockKey).Return([]byte("10"), nil).Times(1)
+	db.EXPECT().Put(id.ID, database.LatestProcessedBlockKey, []byte("11")).Return(nil).Times(1)
+	cm.Stop()
+	close(writeSignal)
+	require.Equal(t, uint64(11), cm.CheckpointedHeight())
+
+	// Heights committed or rolled back once stopped are not written
+	cm.StageCommittedHeight(12)
+	cm.Flush()
+	cm.Rollback(10)
+	require.Equal(t, uint64(11), cm.CheckpointedHeight())
+}
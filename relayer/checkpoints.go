@@ -0,0 +1,77 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SourceCheckpoints describes the progress of the ApplicationRelayers of a source blockchain through its blocks
+type SourceCheckpoints struct {
+	SourceBlockchainID ids.ID `json:"source-blockchain-id"`
+	// Lowest committed height of the ApplicationRelayers, at and below which all blocks have been processed
+	CommittedHeight uint64 `json:"committed-height"`
+	// Total number of heights processed out of order, waiting for the heights below them to be processed
+	PendingHeights int `json:"pending-heights"`
+	// Greatest pending height of the ApplicationRelayers, or zero if there are none
+	MaxPendingHeight uint64              `json:"max-pending-height"`
+	Relayers         []RelayerCheckpoint `json:"relayers"`
+}
+
+// RelayerCheckpoint describes the progress of an ApplicationRelayer through the blocks of its source blockchain
+type RelayerCheckpoint struct {
+	RelayerID               common.Hash `json:"relayer-id"`
+	DestinationBlockchainID ids.ID      `json:"destination-blockchain-id"`
+	CommittedHeight         uint64      `json:"committed-height"`
+	// Greatest height written to the database
+	CheckpointedHeight uint64 `json:"checkpointed-height"`
+	PendingHeights     int    `json:"pending-heights"`
+	MaxPendingHeight   uint64 `json:"max-pending-height"`
+}
+
+// Checkpoints returns the checkpoint state of the ApplicationRelayers of each source blockchain
+func (mc *MessageCoordinator) Checkpoints() []SourceCheckpoints {
+	mc.sourcesLock.RLock()
+	defer mc.sourcesLock.RUnlock()
+
+	sources := make(map[ids.ID]*SourceCheckpoints)
+	for relayerID, appRelayer := range mc.applicationRelayers {
+		status := appRelayer.CheckpointStatus()
+		sourceBlockchainID := appRelayer.relayerID.SourceBlockchainID
+		source, ok := sources[sourceBlockchainID]
+		if !ok {
+			source = &SourceCheckpoints{
+				SourceBlockchainID: sourceBlockchainID,
+				CommittedHeight:    status.CommittedHeight,
+			}
+			sources[sourceBlockchainID] = source
+		}
+		source.CommittedHeight = min(source.CommittedHeight, status.CommittedHeight)
+		source.PendingHeights += status.PendingHeights
+		source.MaxPendingHeight = max(source.MaxPendingHeight, status.MaxPendingHeight)
+		source.Relayers = append(source.Relayers, RelayerCheckpoint{
+			RelayerID:               relayerID,
+			DestinationBlockchainID: appRelayer.relayerID.DestinationBlockchainID,
+			CommittedHeight:         status.CommittedHeight,
+			CheckpointedHeight:      status.CheckpointedHeight,
+			PendingHeights:          status.PendingHeights,
+			MaxPendingHeight:        status.MaxPendingHeight,
+		})
+	}
+
+	checkpoints := make([]SourceCheckpoints, 0, len(sources))
+	for _, source := range sources {
+		sort.Slice(source.Relayers, func(i, j int) bool {
+			return source.Relayers[i].RelayerID.Cmp(source.Relayers[j].RelayerID) < 0
+		})
+		checkpoints = append(checkpoints, *source)
+	}
+	sort.Slice(checkpoints, func(i, j int) bool {
+		return checkpoints[i].SourceBlockchainID.Compare(checkpoints[j].SourceBlockchainID) < 0
+	})
+	return checkpoints
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/database"
+	"github.com/ava-labs/awm-relayer/messages"
+	"github.com/ava-labs/awm-relayer/relayer/checkpoint"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpoints(t *testing.T) {
+	sourceBlockchainID := ids.GenerateTestID()
+	newAppRelayer := func(startingHeight uint64, stagedHeights ...uint64) *ApplicationRelayer {
+		relayerID := database.NewRelayerID(
+			sourceBlockchainID,
+			ids.GenerateTestID(),
+			database.AllAllowedAddress,
+			database.AllAllowedAddress,
+		)
+		checkpointManager := checkpoint.NewCheckpointManager(logging.NoLog{}, nil, nil, relayerID, startingHeight)
+		for _, height := range stagedHeights {
+			checkpointManager.StageCommittedHeight(height)
+		}
+		return &ApplicationRelayer{relayerID: relayerID, checkpointManager: checkpointManager}
+	}
+	// One relayer is stuck at height 10, with blocks above it processed out of order
+	stuck := newAppRelayer(10, 12, 14)
+	advancing := newAppRelayer(10, 11, 12, 13)
+
+	messageCoordinator := NewMessageCoordinator(
+		logging.NoLog{},
+		nil,
+		make(map[ids.ID]map[common.Address]messages.MessageHandlerFactory),
+		map[common.Hash]*ApplicationRelayer{stuck.relayerID.ID: stuck, advancing.relayerID.ID: advancing},
+		make(map[ids.ID]ethclient.Client),
+		nil,
+		nil,
+		nil,
+	)
+
+	checkpoints := messageCoordinator.Checkpoints()
+	require.Len(t, checkpoints, 1)
+	require.Equal(t, sourceBlockchainID, checkpoints[0].SourceBlockchainID)
+	require.Equal(t, uint64(10), checkpoints[0].CommittedHeight)
+	require.Equal(t, 2, checkpoints[0].PendingHeights)
+	require.Equal(t, uint64(14), checkpoints[0].MaxPendingHeight)
+	require.Len(t, checkpoints[0].Relayers, 2)
+	for _, relayer := range checkpoints[0].Relayers {
+		switch relayer.RelayerID {
+		case stuck.relayerID.ID:
+			require.Equal(t, uint64(10), relayer.CommittedHeight)
+			require.Equal(t, 2, relayer.PendingHeights)
+		case advancing.relayerID.ID:
+			require.Equal(t, uint64(13), relayer.CommittedHeight)
+			require.Zero(t, relayer.PendingHeights)
+		}
+	}
+}
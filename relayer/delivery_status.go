@@ -0,0 +1,109 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/awm-relayer/messages"
+	"github.com/ava-labs/awm-relayer/vms"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+var errDeliveryStatusUnsupported = errors.New(
+	"no message protocol configured for the source blockchain supports delivery status queries",
+)
+
+// DeliveryStatus reports whether a message has been delivered to a destination blockchain
+type DeliveryStatus struct {
+	SourceBlockchainID      ids.ID         `json:"source-blockchain-id"`
+	DestinationBlockchainID ids.ID         `json:"destination-blockchain-id"`
+	MessageProtocolAddress  common.Address `json:"message-protocol-address"`
+	Delivered               bool           `json:"delivered"`
+	// Empty if the message is not delivered, or the delivery transaction is not known
+	TransactionHash string `json:"transaction-hash,omitempty"`
+}
+
+// IsDeliveryStatusUnavailableError returns true if the relayer's configuration does not allow the delivery of
+// messages from the source blockchain to be queried.
+func IsDeliveryStatusUnavailableError(err error) bool {
+	return errors.Is(err, errUnknownRoute) || errors.Is(err, errDeliveryStatusUnsupported)
+}
+
+// GetDeliveryStatus queries the destination blockchains of [sourceBlockchainID] for the delivery of the message
+// with the protocol-specific [messageID], via each message protocol configured for the source that supports it.
+// If [destinationBlockchainID] is not empty, only that destination is queried.
+func (mc *MessageCoordinator) GetDeliveryStatus(
+	ctx context.Context,
+	sourceBlockchainID ids.ID,
+	messageID ids.ID,
+	destinationBlockchainID ids.ID,
+) ([]DeliveryStatus, error) {
+	// Collect the factories and destination clients for the source, so that the lock is not held while the
+	// destinations are queried
+	mc.sourcesLock.RLock()
+	factories := make(map[common.Address]messages.DeliveryStatusFactory)
+	for address, factory := range mc.messageHandlerFactories[sourceBlockchainID] {
+		if deliveryStatusFactory, ok := factory.(messages.DeliveryStatusFactory); ok {
+			factories[address] = deliveryStatusFactory
+		}
+	}
+	destinationClients := make(map[ids.ID]vms.DestinationClient)
+	for _, appRelayer := range mc.applicationRelayers {
+		relayerID := appRelayer.relayerID
+		if relayerID.SourceBlockchainID != sourceBlockchainID {
+			continue
+		}
+		if destinationBlockchainID != ids.Empty && relayerID.DestinationBlockchainID != destinationBlockchainID {
+			continue
+		}
+		destinationClients[relayerID.DestinationBlockchainID] = appRelayer.destinationClient
+	}
+	mc.sourcesLock.RUnlock()
+
+	if len(destinationClients) == 0 {
+		return nil, errUnknownRoute
+	}
+	if len(factories) == 0 {
+		return nil, errDeliveryStatusUnsupported
+	}
+
+	var statuses []DeliveryStatus
+	for address, factory := range factories {
+		for destinationID, destinationClient := range destinationClients {
+			delivered, txHash, err := factory.GetDeliveryStatus(ctx, sourceBlockchainID, messageID, destinationClient)
+			if err != nil {
+				mc.logger.Error(
+					"Failed to get delivery status",
+					zap.String("sourceBlockchainID", sourceBlockchainID.String()),
+					zap.String("destinationBlockchainID", destinationID.String()),
+					zap.String("messageID", messageID.String()),
+					zap.Error(err),
+				)
+				return nil, err
+			}
+			status := DeliveryStatus{
+				SourceBlockchainID:      sourceBlockchainID,
+				DestinationBlockchainID: destinationID,
+				MessageProtocolAddress:  address,
+				Delivered:               delivered,
+			}
+			if txHash != (common.Hash{}) {
+				status.TransactionHash = txHash.Hex()
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		if c := statuses[i].DestinationBlockchainID.Compare(statuses[j].DestinationBlockchainID); c != 0 {
+			return c < 0
+		}
+		return statuses[i].MessageProtocolAddress.Cmp(statuses[j].MessageProtocolAddress) < 0
+	})
+	return statuses, nil
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/awm-relayer/config"
+)
+
+// FinalityGate delays the delivery of the messages in a source blockchain's blocks until the blocks are considered
+// final, as configured by the source's finality-delay-blocks and finality-delay-seconds. Blocks are considered
+// final once both delays have passed. A nil FinalityGate does not delay delivery.
+type FinalityGate struct {
+	delayBlocks uint64
+	delay       time.Duration
+
+	lock sync.Mutex
+	// Greatest height received from the source blockchain
+	latestHeight uint64
+	// Closed, and replaced, each time a greater height is received
+	heightAdvanced chan struct{}
+}
+
+// NewFinalityGate returns the FinalityGate of [sourceBlockchain], or nil if its messages are delivered as soon
+// as their blocks are received.
+func NewFinalityGate(sourceBlockchain *config.SourceBlockchain) *FinalityGate {
+	if sourceBlockchain.FinalityDelayBlocks == 0 && sourceBlockchain.FinalityDelaySeconds == 0 {
+		return nil
+	}
+	return &FinalityGate{
+		delayBlocks:    sourceBlockchain.FinalityDelayBlocks,
+		delay:          sourceBlockchain.GetFinalityDelay(),
+		heightAdvanced: make(chan struct{}),
+	}
+}
+
+// ObserveHeight records that the block at [height] has been received from the source blockchain
+func (g *FinalityGate) ObserveHeight(height uint64) {
+	if g == nil {
+		return
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if height <= g.latestHeight {
+		return
+	}
+	g.latestHeight = height
+	close(g.heightAdvanced)
+	g.heightAdvanced = make(chan struct{})
+}
+
+// Wait blocks until the block at [height], with the timestamp [timestamp] in seconds since the Unix epoch,
+// is considered final. Returns false if [done] is closed first.
+func (g *FinalityGate) Wait(height uint64, timestamp uint64, done <-chan struct{}) bool {
+	if g == nil {
+		return true
+	}
+	if wait := time.Until(time.Unix(int64(timestamp), 0).Add(g.delay)); g.delay != 0 && wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-done:
+			return false
+		}
+	}
+	for {
+		g.lock.Lock()
+		final := g.latestHeight >= height+g.delayBlocks
+		heightAdvanced := g.heightAdvanced
+		g.lock.Unlock()
+		if final {
+			return true
+		}
+		select {
+		case <-heightAdvanced:
+		case <-done:
+			return false
+		}
+	}
+}
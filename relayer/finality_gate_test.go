@@ -0,0 +1,97 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinalityGate(t *testing.T) {
+	now := uint64(time.Now().Unix())
+	testCases := []struct {
+		name                 string
+		finalityDelayBlocks  uint64
+		finalityDelaySeconds uint64
+		latestHeight         uint64
+		timestamp            uint64
+		expectedFinal        bool
+	}{
+		{
+			name:          "no delay",
+			latestHeight:  100,
+			timestamp:     now,
+			expectedFinal: true,
+		},
+		{
+			name:                "buried by enough blocks",
+			finalityDelayBlocks: 5,
+			latestHeight:        105,
+			timestamp:           now,
+			expectedFinal:       true,
+		},
+		{
+			name:                "not buried by enough blocks",
+			finalityDelayBlocks: 5,
+			latestHeight:        104,
+			timestamp:           now,
+			expectedFinal:       false,
+		},
+		{
+			name:                 "old enough",
+			finalityDelaySeconds: 60,
+			latestHeight:         100,
+			timestamp:            now - 60,
+			expectedFinal:        true,
+		},
+		{
+			name:                 "not old enough",
+			finalityDelaySeconds: 60,
+			latestHeight:         100,
+			timestamp:            now,
+			expectedFinal:        false,
+		},
+		{
+			name:                 "old enough but not buried by enough blocks",
+			finalityDelayBlocks:  5,
+			finalityDelaySeconds: 60,
+			latestHeight:         104,
+			timestamp:            now - 60,
+			expectedFinal:        false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			gate := NewFinalityGate(&config.SourceBlockchain{
+				FinalityDelayBlocks:  testCase.finalityDelayBlocks,
+				FinalityDelaySeconds: testCase.finalityDelaySeconds,
+			})
+			gate.ObserveHeight(testCase.latestHeight)
+
+			// Blocks that are not yet final are waited on until [done] is closed
+			done := make(chan struct{})
+			time.AfterFunc(50*time.Millisecond, func() { close(done) })
+			require.Equal(t, testCase.expectedFinal, gate.Wait(100, testCase.timestamp, done))
+		})
+	}
+}
+
+func TestFinalityGateHeightAdvances(t *testing.T) {
+	gate := NewFinalityGate(&config.SourceBlockchain{FinalityDelayBlocks: 2})
+	gate.ObserveHeight(100)
+
+	final := make(chan bool, 1)
+	go func() {
+		final <- gate.Wait(100, 0, make(chan struct{}))
+	}()
+	gate.ObserveHeight(101)
+	require.Empty(t, final)
+	// Lower heights, such as those of blocks missed while disconnected, do not advance the latest height
+	gate.ObserveHeight(99)
+	gate.ObserveHeight(102)
+	require.True(t, <-final)
+}
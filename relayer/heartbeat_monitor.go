@@ -0,0 +1,253 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/utils"
+	"github.com/ava-labs/awm-relayer/vms/evm/signer"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	teleportermessenger "github.com/ava-labs/teleporter/abi-bindings/go/teleporter/TeleporterMessenger"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// Interval at which the destination blockchain is queried for the delivery of a heartbeat message
+const defaultHeartbeatPollInterval = time.Second
+
+// Reasons a heartbeat fails, used to label the heartbeat failure metric
+const (
+	heartbeatFailureSend     = "send"
+	heartbeatFailureDeadline = "deadline"
+)
+
+var errHeartbeatNotSent = errors.New("heartbeat transaction did not emit a SendCrossChainMessage event")
+
+type heartbeat struct {
+	cfg    *config.HeartbeatConfig
+	signer signer.Signer
+}
+
+// HeartbeatMonitor periodically sends a Teleporter message over each configured heartbeat route, and measures
+// the time until the message is delivered by the relayer. Heartbeats that fail to send, or that are not delivered
+// within their deadline, are counted as failures.
+type HeartbeatMonitor struct {
+	logger             logging.Logger
+	metrics            *MessageCoordinatorMetrics
+	messageCoordinator *MessageCoordinator
+	heartbeats         []heartbeat
+	pollInterval       time.Duration
+}
+
+// NewHeartbeatMonitor returns a monitor for the configured heartbeats, or nil if there are none.
+func NewHeartbeatMonitor(
+	logger logging.Logger,
+	metrics *MessageCoordinatorMetrics,
+	messageCoordinator *MessageCoordinator,
+	cfg *config.Config,
+) (*HeartbeatMonitor, error) {
+	if len(cfg.Heartbeats) == 0 {
+		return nil, nil
+	}
+	heartbeats := make([]heartbeat, 0, len(cfg.Heartbeats))
+	for _, heartbeatConfig := range cfg.Heartbeats {
+		txSigner, err := signer.NewTxSigner(heartbeatConfig.GetAccountPrivateKey())
+		if err != nil {
+			return nil, err
+		}
+		heartbeats = append(heartbeats, heartbeat{
+			cfg:    heartbeatConfig,
+			signer: txSigner,
+		})
+	}
+	return &HeartbeatMonitor{
+		logger:             logger,
+		metrics:            metrics,
+		messageCoordinator: messageCoordinator,
+		heartbeats:         heartbeats,
+		pollInterval:       defaultHeartbeatPollInterval,
+	}, nil
+}
+
+// Run sends each heartbeat at its configured interval until the context is canceled. A heartbeat is not sent
+// again until the previous one has been delivered, or its deadline has passed.
+func (m *HeartbeatMonitor) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, hb := range m.heartbeats {
+		wg.Add(1)
+		go func(hb heartbeat) {
+			defer wg.Done()
+			ticker := time.NewTicker(hb.cfg.GetInterval())
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					m.sendHeartbeat(ctx, hb)
+				}
+			}
+		}(hb)
+	}
+	wg.Wait()
+}
+
+// sendHeartbeat sends a heartbeat message and waits for its delivery, recording the round trip time or failure.
+func (m *HeartbeatMonitor) sendHeartbeat(ctx context.Context, hb heartbeat) {
+	sourceBlockchainID := hb.cfg.GetSourceBlockchainID()
+	destinationBlockchainID := hb.cfg.GetDestinationBlockchainID()
+	deadlineCtx, cancel := context.WithTimeout(ctx, hb.cfg.GetDeadline())
+	defer cancel()
+
+	start := time.Now()
+	messageID, err := m.send(deadlineCtx, hb)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		m.logger.Warn(
+			"Failed to send heartbeat message",
+			zap.String("sourceBlockchainID", sourceBlockchainID.String()),
+			zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+			zap.Error(err),
+		)
+		m.metrics.heartbeatFailures.
+			WithLabelValues(sourceBlockchainID.String(), destinationBlockchainID.String(), heartbeatFailureSend).
+			Inc()
+		return
+	}
+
+	delivered := m.awaitDelivery(deadlineCtx, hb, messageID)
+	if !delivered {
+		if ctx.Err() != nil {
+			return
+		}
+		m.logger.Warn(
+			"Heartbeat message was not delivered within the deadline",
+			zap.String("sourceBlockchainID", sourceBlockchainID.String()),
+			zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+			zap.String("teleporterMessageID", messageID.String()),
+			zap.Duration("deadline", hb.cfg.GetDeadline()),
+		)
+		m.metrics.heartbeatFailures.
+			WithLabelValues(sourceBlockchainID.String(), destinationBlockchainID.String(), heartbeatFailureDeadline).
+			Inc()
+		return
+	}
+	roundTrip := time.Since(start)
+	m.logger.Debug(
+		"Heartbeat message delivered",
+		zap.String("sourceBlockchainID", sourceBlockchainID.String()),
+		zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+		zap.String("teleporterMessageID", messageID.String()),
+		zap.Duration("roundTrip", roundTrip),
+	)
+	m.metrics.heartbeatRoundTrip.
+		WithLabelValues(sourceBlockchainID.String(), destinationBlockchainID.String()).
+		Set(roundTrip.Seconds())
+}
+
+// send issues a transaction sending the heartbeat's Teleporter message to the destination blockchain, and returns
+// the Teleporter message ID once the transaction is accepted. The message is addressed to the sender's account,
+// and may be delivered by any relayer without a fee.
+func (m *HeartbeatMonitor) send(ctx context.Context, hb heartbeat) (ids.ID, error) {
+	sourceClient, ok := m.messageCoordinator.getSourceClient(hb.cfg.GetSourceBlockchainID())
+	if !ok {
+		return ids.Empty, errUnknownRoute
+	}
+	evmChainID, err := sourceClient.ChainID(ctx)
+	if err != nil {
+		return ids.Empty, fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	teleporterMessenger, err := teleportermessenger.NewTeleporterMessenger(
+		hb.cfg.GetTeleporterMessengerAddress(),
+		sourceClient,
+	)
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	opts := &bind.TransactOpts{
+		From: hb.signer.Address(),
+		Signer: func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return hb.signer.SignTx(tx, evmChainID)
+		},
+		Context: ctx,
+	}
+	tx, err := teleporterMessenger.SendCrossChainMessage(opts, teleportermessenger.TeleporterMessageInput{
+		DestinationBlockchainID: hb.cfg.GetDestinationBlockchainID(),
+		DestinationAddress:      hb.signer.Address(),
+		FeeInfo: teleportermessenger.TeleporterFeeInfo{
+			FeeTokenAddress: common.Address{},
+			Amount:          big.NewInt(0),
+		},
+		RequiredGasLimit:        big.NewInt(0),
+		AllowedRelayerAddresses: []common.Address{},
+		Message:                 []byte{},
+	})
+	if err != nil {
+		return ids.Empty, fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	receipt, err := utils.CallWithRetry[*types.Receipt](
+		ctx,
+		func() (*types.Receipt, error) {
+			return sourceClient.TransactionReceipt(ctx, tx.Hash())
+		},
+	)
+	if err != nil {
+		return ids.Empty, fmt.Errorf("failed to get receipt of transaction %s: %w", tx.Hash(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return ids.Empty, fmt.Errorf("transaction %s failed with status: %d", tx.Hash(), receipt.Status)
+	}
+	for _, log := range receipt.Logs {
+		if log.Address != hb.cfg.GetTeleporterMessengerAddress() {
+			continue
+		}
+		event, err := teleporterMessenger.ParseSendCrossChainMessage(*log)
+		if err != nil {
+			continue
+		}
+		return ids.ID(event.MessageID), nil
+	}
+	return ids.Empty, errHeartbeatNotSent
+}
+
+// awaitDelivery queries the destination blockchain for the delivery of the heartbeat message [messageID] until it
+// has been delivered, or the context is canceled. Returns true if the message was delivered.
+func (m *HeartbeatMonitor) awaitDelivery(ctx context.Context, hb heartbeat, messageID ids.ID) bool {
+	ticker := time.NewTicker(m.pollInterval)
+	defer ticker.Stop()
+	for {
+		statuses, err := m.messageCoordinator.GetDeliveryStatus(
+			ctx,
+			hb.cfg.GetSourceBlockchainID(),
+			messageID,
+			hb.cfg.GetDestinationBlockchainID(),
+		)
+		if err == nil {
+			for _, status := range statuses {
+				if status.Delivered {
+					return true
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/database"
+	"github.com/ava-labs/awm-relayer/messages"
+	mock_messages "github.com/ava-labs/awm-relayer/messages/mocks"
+	"github.com/ava-labs/awm-relayer/vms"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// deliveryStatusFactory reports a message as delivered once it has been queried [deliverAfter] times
+type deliveryStatusFactory struct {
+	*mock_messages.MockMessageHandlerFactory
+	deliverAfter int
+	queries      int
+}
+
+func (f *deliveryStatusFactory) GetDeliveryStatus(
+	context.Context,
+	ids.ID,
+	ids.ID,
+	vms.DestinationClient,
+) (bool, common.Hash, error) {
+	f.queries++
+	return f.deliverAfter != 0 && f.queries >= f.deliverAfter, common.Hash{}, nil
+}
+
+func TestHeartbeatAwaitDelivery(t *testing.T) {
+	testCases := []struct {
+		name              string
+		deliverAfter      int
+		expectedDelivered bool
+	}{
+		{
+			name:              "delivered",
+			deliverAfter:      3,
+			expectedDelivered: true,
+		},
+		{
+			name:              "not delivered",
+			expectedDelivered: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			metrics, err := NewMessageCoordinatorMetrics(prometheus.NewRegistry())
+			require.NoError(t, err)
+
+			cfg := config.TestValidConfig
+			sourceBlockchain := *config.TestValidConfig.SourceBlockchains[0]
+			destinationBlockchain := *config.TestValidConfig.DestinationBlockchains[0]
+			cfg.SourceBlockchains = []*config.SourceBlockchain{&sourceBlockchain}
+			cfg.DestinationBlockchains = []*config.DestinationBlockchain{&destinationBlockchain}
+			cfg.Heartbeats = []*config.HeartbeatConfig{{
+				SourceBlockchainID:         sourceBlockchain.BlockchainID,
+				DestinationBlockchainID:    destinationBlockchain.BlockchainID,
+				TeleporterMessengerAddress: "0xd81545385803bCD83bd59f58Ba2d2c0562387F83",
+				AccountPrivateKey:          destinationBlockchain.AccountPrivateKey,
+			}}
+			require.NoError(t, cfg.Validate())
+			heartbeatConfig := cfg.Heartbeats[0]
+
+			relayerID := database.NewRelayerID(
+				heartbeatConfig.GetSourceBlockchainID(),
+				heartbeatConfig.GetDestinationBlockchainID(),
+				database.AllAllowedAddress,
+				database.AllAllowedAddress,
+			)
+			factory := &deliveryStatusFactory{
+				MockMessageHandlerFactory: mock_messages.NewMockMessageHandlerFactory(ctrl),
+				deliverAfter:              testCase.deliverAfter,
+			}
+			messageCoordinator := NewMessageCoordinator(
+				logging.NoLog{},
+				metrics,
+				map[ids.ID]map[common.Address]messages.MessageHandlerFactory{
+					heartbeatConfig.GetSourceBlockchainID(): {
+						heartbeatConfig.GetTeleporterMessengerAddress(): factory,
+					},
+				},
+				map[common.Hash]*ApplicationRelayer{relayerID.ID: {relayerID: relayerID}},
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			monitor, err := NewHeartbeatMonitor(logging.NoLog{}, metrics, messageCoordinator, &cfg)
+			require.NoError(t, err)
+			monitor.pollInterval = time.Millisecond
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			delivered := monitor.awaitDelivery(ctx, monitor.heartbeats[0], ids.GenerateTestID())
+			require.Equal(t, testCase.expectedDelivered, delivered)
+			if testCase.expectedDelivered {
+				require.Equal(t, testCase.deliverAfter, factory.queries)
+			}
+		})
+	}
+}
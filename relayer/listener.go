@@ -14,16 +14,15 @@ import (
 	"github.com/ava-labs/awm-relayer/config"
 	"github.com/ava-labs/awm-relayer/utils"
 	"github.com/ava-labs/awm-relayer/vms"
+	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
 const (
 	maxSubscribeAttempts = 10
-	// TODO attempt to resubscribe in perpetuity once we are able to process missed blocks and
-	// refresh the chain config on reconnect.
-	maxResubscribeAttempts = 10
 )
 
 // Listener handles all messages sent from a given source chain
@@ -32,11 +31,34 @@ type Listener struct {
 	currentRequestID   uint32
 	contractMessage    vms.ContractMessage
 	logger             logging.Logger
+	metrics            *ListenerMetrics
 	sourceBlockchain   config.SourceBlockchain
 	catchUpResultChan  chan bool
 	healthStatus       *atomic.Bool
 	ethClient          ethclient.Client
+	sourceClient       vms.SourceClient
+	finalityGate       *FinalityGate
 	messageCoordinator *MessageCoordinator
+	// The height following the highest block received, from which blocks missed while the subscription
+	// was disconnected are processed. Zero if no block has been received and there is no starting height.
+	nextHeight uint64
+	// Receives the result of processing the blocks missed while the subscription was disconnected.
+	// Nil if no such processing is in progress.
+	gapFillResultChan chan bool
+	// Client of the source's archival node, used to process blocks at or below archivalHeight, the chain head
+	// when the listener was created. Nil if no archival endpoint is configured, or no historical blocks are processed.
+	archivalEthClient    ethclient.Client
+	archivalSourceClient vms.SourceClient
+	archivalHeight       uint64
+	// If messages from the same origin sender are delivered in order, closed once the most recently received
+	// block has been processed by the message coordinator. Nil if no block has been received.
+	blockProcessed chan struct{}
+	// Hashes of the blocks received within the source's reorg-depth of the latest block, by height.
+	// Nil if reorgs are not detected.
+	blockHashes map[uint64]common.Hash
+	// Receives the result of reprocessing the blocks that replaced those orphaned by a reorg.
+	// Nil if no such processing is in progress.
+	reorgResultChan chan bool
 }
 
 // runListener creates a Listener instance and the ApplicationRelayers for a subnet.
@@ -44,6 +66,7 @@ type Listener struct {
 func RunListener(
 	ctx context.Context,
 	logger logging.Logger,
+	metrics *ListenerMetrics,
 	sourceBlockchain config.SourceBlockchain,
 	ethRPCClient ethclient.Client,
 	relayerHealth *atomic.Bool,
@@ -55,6 +78,7 @@ func RunListener(
 	listener, err := newListener(
 		ctx,
 		logger,
+		metrics,
 		sourceBlockchain,
 		ethRPCClient,
 		relayerHealth,
@@ -79,6 +103,7 @@ func RunListener(
 func newListener(
 	ctx context.Context,
 	logger logging.Logger,
+	metrics *ListenerMetrics,
 	sourceBlockchain config.SourceBlockchain,
 	ethRPCClient ethclient.Client,
 	relayerHealth *atomic.Bool,
@@ -95,21 +120,17 @@ func newListener(
 		return nil, err
 	}
 
-	ethWSClient, err := utils.NewEthClientWithConfig(
-		ctx,
-		sourceBlockchain.WSEndpoint.BaseURL,
-		sourceBlockchain.WSEndpoint.HTTPHeaders,
-		sourceBlockchain.WSEndpoint.QueryParams,
-	)
+	// Open the subscription. We must do this before processing any missed messages, otherwise we may
+	// miss an incoming message in between fetching the latest block and subscribing.
+	sub, err := subscribe(ctx, logger, &sourceBlockchain)
 	if err != nil {
 		logger.Error(
-			"Failed to connect to node via WS",
+			"Failed to subscribe to node",
 			zap.String("blockchainID", blockchainID.String()),
 			zap.Error(err),
 		)
 		return nil, err
 	}
-	sub := vms.NewSubscriber(logger, config.ParseVM(sourceBlockchain.VM), blockchainID, ethWSClient)
 
 	// Marks when the listener has finished the catch-up process on startup.
 	// Until that time, we do not know the order in which messages are processed,
@@ -131,28 +152,40 @@ func newListener(
 		currentRequestID:   rand.Uint32(), // Initialize to a random value to mitigate requestID collision
 		contractMessage:    vms.NewContractMessage(logger, sourceBlockchain),
 		logger:             logger,
+		metrics:            metrics,
 		sourceBlockchain:   sourceBlockchain,
 		catchUpResultChan:  catchUpResultChan,
 		healthStatus:       relayerHealth,
 		ethClient:          ethRPCClient,
+		sourceClient:       vms.NewSourceClient(&sourceBlockchain, ethRPCClient),
+		finalityGate:       NewFinalityGate(&sourceBlockchain),
 		messageCoordinator: messageCoordinator,
 	}
 
-	// Open the subscription. We must do this before processing any missed messages, otherwise we may
-	// miss an incoming message in between fetching the latest block and subscribing.
-	err = lstnr.Subscriber.Subscribe(maxSubscribeAttempts)
-	if err != nil {
-		logger.Error(
-			"Failed to subscribe to node",
-			zap.Error(err),
-		)
-		return nil, err
+	if sourceBlockchain.ReorgDepth != 0 {
+		lstnr.blockHashes = make(map[uint64]common.Hash)
+	}
+
+	if sourceBlockchain.HasArchivalRPCEndpoint() && (sourceBlockchain.HasHistoricalBlockRange() || processMissedBlocks) {
+		if err := lstnr.connectArchivalClient(ctx); err != nil {
+			sub.Cancel()
+			return nil, err
+		}
 	}
 
-	if processMissedBlocks {
+	if sourceBlockchain.HasHistoricalBlockRange() {
+		// A bounded range of historical blocks takes the place of catching up from the latest processed block.
+		// As above, process the range in a separate goroutine.
+		go sub.ProcessHeightRange(
+			big.NewInt(0).SetUint64(sourceBlockchain.ProcessHistoricalBlocksFromHeight),
+			big.NewInt(0).SetUint64(sourceBlockchain.ProcessHistoricalBlocksToHeight),
+			lstnr.catchUpResultChan,
+		)
+	} else if processMissedBlocks {
 		// Process historical blocks in a separate goroutine so that the main processing loop can
 		// start processing new blocks as soon as possible. Otherwise, it's possible for
 		// ProcessFromHeight to overload the message queue and cause a deadlock.
+		lstnr.nextHeight = startingHeight
 		go sub.ProcessFromHeight(big.NewInt(0).SetUint64(startingHeight), lstnr.catchUpResultChan)
 	} else {
 		lstnr.logger.Info(
@@ -165,10 +198,145 @@ func newListener(
 	return &lstnr, nil
 }
 
+// connectArchivalClient connects to the source's archival endpoint, which is used to process the blocks produced
+// before the current chain head. Blocks produced afterwards are processed via the live endpoint.
+func (lstnr *Listener) connectArchivalClient(ctx context.Context) error {
+	sourceBlockchainID := lstnr.sourceBlockchain.GetBlockchainID()
+	head, err := lstnr.ethClient.BlockNumber(ctx)
+	if err != nil {
+		lstnr.logger.Error(
+			"Failed to get latest block",
+			zap.String("blockchainID", sourceBlockchainID.String()),
+			zap.Error(err),
+		)
+		return err
+	}
+	archivalEthClient, err := utils.NewEthClientWithConfig(
+		ctx,
+		lstnr.sourceBlockchain.ArchivalRPCEndpoint.BaseURL,
+		lstnr.sourceBlockchain.ArchivalRPCEndpoint.HTTPHeaders,
+		lstnr.sourceBlockchain.ArchivalRPCEndpoint.QueryParams,
+		lstnr.sourceBlockchain.GetTLSConfig(),
+	)
+	if err != nil {
+		lstnr.logger.Error(
+			"Failed to connect to archival node via RPC",
+			zap.String("blockchainID", sourceBlockchainID.String()),
+			zap.Object("endpoint", &lstnr.sourceBlockchain.ArchivalRPCEndpoint),
+			zap.Error(err),
+		)
+		return err
+	}
+	lstnr.logger.Info(
+		"Processing historical blocks via archival node",
+		zap.String("blockchainID", sourceBlockchainID.String()),
+		zap.Uint64("toBlockHeight", head),
+	)
+	lstnr.archivalEthClient = archivalEthClient
+	lstnr.archivalSourceClient = vms.NewSourceClient(&lstnr.sourceBlockchain, archivalEthClient)
+	lstnr.archivalHeight = head
+	return nil
+}
+
+// sourceClientForHeight returns the client used to fetch the Warp messages of the block at [height]
+func (lstnr *Listener) sourceClientForHeight(height uint64) vms.SourceClient {
+	if lstnr.archivalSourceClient != nil && height <= lstnr.archivalHeight {
+		return lstnr.archivalSourceClient
+	}
+	return lstnr.sourceClient
+}
+
+// subscribe returns a Subscriber to new blocks on [sourceBlockchain] according to its subscription mode. In auto
+// mode, the relayer subscribes via the WebSocket endpoint if one is configured, and falls back to polling the
+// RPC endpoint if the subscription cannot be established.
+func subscribe(
+	ctx context.Context,
+	logger logging.Logger,
+	sourceBlockchain *config.SourceBlockchain,
+) (vms.Subscriber, error) {
+	subscriptionMode, err := sourceBlockchain.GetSubscriptionMode()
+	if err != nil {
+		return nil, err
+	}
+	if subscriptionMode != config.SubscriptionModePolling && sourceBlockchain.WSEndpoint.BaseURL != "" {
+		// Fall back to polling after a single failed attempt in auto mode
+		attempts := maxSubscribeAttempts
+		if subscriptionMode == config.SubscriptionModeAuto {
+			attempts = 1
+		}
+		sub, err := subscribeWebSocket(ctx, logger, sourceBlockchain, attempts)
+		if err == nil || subscriptionMode == config.SubscriptionModeWebSocket {
+			return sub, err
+		}
+		logger.Warn(
+			"Failed to subscribe via WS. Falling back to polling.",
+			zap.String("blockchainID", sourceBlockchain.GetBlockchainID().String()),
+			zap.Error(err),
+		)
+	}
+
+	// Polling uses a dedicated client, since the subscriber closes its client when canceled
+	ethRPCClient, err := utils.NewEthClientWithConfig(
+		ctx,
+		sourceBlockchain.RPCEndpoint.BaseURL,
+		sourceBlockchain.RPCEndpoint.HTTPHeaders,
+		sourceBlockchain.RPCEndpoint.QueryParams,
+		sourceBlockchain.GetTLSConfig(),
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to connect to node via RPC",
+			zap.String("blockchainID", sourceBlockchain.GetBlockchainID().String()),
+			zap.Object("endpoint", &sourceBlockchain.RPCEndpoint),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	sub := vms.NewPollingSubscriber(logger, sourceBlockchain, ethRPCClient)
+	if err := sub.Subscribe(ctx, maxSubscribeAttempts); err != nil {
+		sub.Cancel()
+		return nil, err
+	}
+	return sub, nil
+}
+
+func subscribeWebSocket(
+	ctx context.Context,
+	logger logging.Logger,
+	sourceBlockchain *config.SourceBlockchain,
+	attempts int,
+) (vms.Subscriber, error) {
+	ethWSClient, err := utils.NewEthClientWithConfig(
+		ctx,
+		sourceBlockchain.WSEndpoint.BaseURL,
+		sourceBlockchain.WSEndpoint.HTTPHeaders,
+		sourceBlockchain.WSEndpoint.QueryParams,
+		sourceBlockchain.GetTLSConfig(),
+	)
+	if err != nil {
+		logger.Error(
+			"Failed to connect to node via WS",
+			zap.String("blockchainID", sourceBlockchain.GetBlockchainID().String()),
+			zap.Object("endpoint", &sourceBlockchain.WSEndpoint),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	sub := vms.NewSubscriber(logger, sourceBlockchain, ethWSClient)
+	if err := sub.Subscribe(ctx, attempts); err != nil {
+		sub.Cancel()
+		return nil, err
+	}
+	return sub, nil
+}
+
 // Listens to the Subscriber logs channel to process them.
 // On subscriber error, attempts to reconnect and errors if unable.
 // Exits if context is cancelled by another goroutine.
 func (lstnr *Listener) processLogs(ctx context.Context) error {
+	if lstnr.archivalEthClient != nil {
+		defer lstnr.archivalEthClient.Close()
+	}
 	// Error channel for application relayer errors
 	errChan := make(chan error)
 	for {
@@ -202,8 +370,51 @@ func (lstnr *Listener) processLogs(ctx context.Context) error {
 				)
 				return fmt.Errorf("failed to catch up on historical blocks")
 			}
+		case gapFillResult, ok := <-lstnr.gapFillResultChan:
+			lstnr.gapFillResultChan = nil
+			if !ok || !gapFillResult {
+				lstnr.healthStatus.Store(false)
+				lstnr.logger.Error(
+					"Failed to process blocks missed while disconnected. Exiting listener goroutine.",
+					zap.String("sourceBlockchainID", lstnr.sourceBlockchain.GetBlockchainID().String()),
+				)
+				lstnr.Subscriber.Cancel()
+				return fmt.Errorf("failed to process blocks missed while disconnected")
+			}
+		case reorgResult, ok := <-lstnr.reorgResultChan:
+			lstnr.reorgResultChan = nil
+			if !ok || !reorgResult {
+				lstnr.healthStatus.Store(false)
+				lstnr.logger.Error(
+					"Failed to reprocess blocks following a reorg. Exiting listener goroutine.",
+					zap.String("sourceBlockchainID", lstnr.sourceBlockchain.GetBlockchainID().String()),
+				)
+				lstnr.Subscriber.Cancel()
+				return fmt.Errorf("failed to reprocess blocks following a reorg")
+			}
 		case blockHeader := <-lstnr.Subscriber.Headers():
-			go lstnr.messageCoordinator.ProcessBlock(blockHeader, lstnr.ethClient, errChan)
+			// Stop consuming new blocks while a destination has too many messages pending delivery
+			if err := lstnr.messageCoordinator.WaitForPendingMessages(
+				ctx,
+				lstnr.sourceBlockchain.GetBlockchainID(),
+			); err != nil {
+				lstnr.logger.Info(
+					"Exiting listener while waiting for pending messages",
+					zap.String("sourceBlockchainID", lstnr.sourceBlockchain.GetBlockchainID().String()),
+					zap.Error(err),
+				)
+				lstnr.healthStatus.Store(false)
+				lstnr.Subscriber.Cancel()
+				return nil
+			}
+			if height := blockHeader.Number.Uint64() + 1; height > lstnr.nextHeight {
+				lstnr.nextHeight = height
+			}
+			lstnr.finalityGate.ObserveHeight(blockHeader.Number.Uint64())
+			if lstnr.blockHashes != nil {
+				lstnr.handleReorg(ctx, blockHeader)
+			}
+			lstnr.processBlock(blockHeader, errChan)
 		case err := <-lstnr.Subscriber.Err():
 			lstnr.healthStatus.Store(false)
 			lstnr.logger.Error(
@@ -211,15 +422,14 @@ func (lstnr *Listener) processLogs(ctx context.Context) error {
 				zap.String("sourceBlockchainID", lstnr.sourceBlockchain.GetBlockchainID().String()),
 				zap.Error(err),
 			)
-			// TODO try to resubscribe in perpetuity once we have a mechanism for refreshing state
-			// variables such as Quorum values and processing missed blocks.
-			err = lstnr.reconnectToSubscriber()
+			err = lstnr.reconnectToSubscriber(ctx)
 			if err != nil {
 				lstnr.logger.Error(
 					"Relayer goroutine exiting.",
 					zap.String("sourceBlockchainID", lstnr.sourceBlockchain.GetBlockchainID().String()),
 					zap.Error(err),
 				)
+				lstnr.Subscriber.Cancel()
 				return fmt.Errorf("listener goroutine exiting: %w", err)
 			}
 		case <-ctx.Done():
@@ -228,20 +438,149 @@ func (lstnr *Listener) processLogs(ctx context.Context) error {
 				"Exiting listener because context cancelled",
 				zap.String("sourceBlockchainID", lstnr.sourceBlockchain.GetBlockchainID().String()),
 			)
+			lstnr.Subscriber.Cancel()
 			return nil
 		}
 	}
 }
 
-// Sets the listener health status to false while attempting to reconnect.
-func (lstnr *Listener) reconnectToSubscriber() error {
+// handleReorg records the hash of [header], and checks whether [header] reorgs the blocks previously received.
+// If so, the checkpoints of the source's ApplicationRelayers are rolled back to the common ancestor of the orphaned
+// and new blocks, and the new blocks between the common ancestor and [header] are reprocessed in a separate
+// goroutine. [header] itself is processed as usual.
+func (lstnr *Listener) handleReorg(ctx context.Context, header *types.Header) {
+	height := header.Number.Uint64()
+	defer lstnr.recordBlockHash(height, header.Hash())
+	orphanedHash, ok := lstnr.blockHashes[height-1]
+	if height == 0 || !ok || orphanedHash == header.ParentHash {
+		return
+	}
+
+	ancestorHeight := lstnr.findCommonAncestor(ctx, header)
+	lstnr.logger.Warn(
+		"Detected reorg of source blockchain. Reprocessing blocks following the common ancestor.",
+		zap.String("sourceBlockchainID", lstnr.sourceBlockchain.GetBlockchainID().String()),
+		zap.Uint64("height", height),
+		zap.String("blockHash", header.Hash().String()),
+		zap.String("oldParentHash", orphanedHash.String()),
+		zap.String("newParentHash", header.ParentHash.String()),
+		zap.Uint64("commonAncestorHeight", ancestorHeight),
+	)
+	lstnr.metrics.reorgCount.
+		WithLabelValues(lstnr.sourceBlockchain.GetBlockchainID().String()).
+		Inc()
+	for h := range lstnr.blockHashes {
+		if h > ancestorHeight {
+			delete(lstnr.blockHashes, h)
+		}
+	}
+
+	lstnr.messageCoordinator.RollbackCheckpoints(lstnr.sourceBlockchain.GetBlockchainID(), ancestorHeight)
+	lstnr.reorgResultChan = make(chan bool, 1)
+	go lstnr.Subscriber.ProcessHeightRange(
+		big.NewInt(0).SetUint64(ancestorHeight+1),
+		big.NewInt(0).SetUint64(height-1),
+		lstnr.reorgResultChan,
+	)
+}
+
+// findCommonAncestor returns the height of the latest previously received block that [header] descends from,
+// by walking back through the ancestors of [header]. If no such block is found within the source's reorg-depth
+// of [header], or the ancestors of [header] cannot be fetched, returns the height below the reorg-depth.
+func (lstnr *Listener) findCommonAncestor(ctx context.Context, header *types.Header) uint64 {
+	height := header.Number.Uint64()
+	minHeight := height - min(height, lstnr.sourceBlockchain.ReorgDepth+1)
+	ancestorHeight, ancestorHash := height-1, header.ParentHash
+	for ancestorHeight > minHeight {
+		orphanedHash, ok := lstnr.blockHashes[ancestorHeight]
+		if !ok || orphanedHash == ancestorHash {
+			return ancestorHeight
+		}
+		cctx, cancel := context.WithTimeout(ctx, utils.DefaultRPCRetryTimeout)
+		ancestor, err := lstnr.ethClient.HeaderByHash(cctx, ancestorHash)
+		cancel()
+		if err != nil {
+			lstnr.logger.Error(
+				"Failed to get ancestor of reorged block. Reprocessing all blocks within the reorg depth.",
+				zap.String("sourceBlockchainID", lstnr.sourceBlockchain.GetBlockchainID().String()),
+				zap.String("blockHash", ancestorHash.String()),
+				zap.Error(err),
+			)
+			return minHeight
+		}
+		ancestorHeight, ancestorHash = ancestorHeight-1, ancestor.ParentHash
+	}
+	return minHeight
+}
+
+// recordBlockHash records [hash] as the hash of the block at [height], and forgets the hashes of the blocks
+// below the source's reorg-depth of the latest block.
+func (lstnr *Listener) recordBlockHash(height uint64, hash common.Hash) {
+	lstnr.blockHashes[height] = hash
+	for h := range lstnr.blockHashes {
+		if h+lstnr.sourceBlockchain.ReorgDepth+1 < lstnr.nextHeight {
+			delete(lstnr.blockHashes, h)
+		}
+	}
+}
+
+// processBlock processes [blockHeader] in a separate goroutine. If messages from the same origin sender are
+// delivered in order, the message coordinator processes blocks in the order in which they are received, so that
+// their messages are registered with each ApplicationRelayer's sender sequencer in source order.
+func (lstnr *Listener) processBlock(blockHeader *types.Header, errChan chan error) {
+	process := func() {
+		lstnr.messageCoordinator.ProcessBlock(
+			lstnr.sourceBlockchain.GetBlockchainID(),
+			blockHeader,
+			lstnr.sourceClientForHeight(blockHeader.Number.Uint64()),
+			lstnr.finalityGate,
+			errChan,
+		)
+	}
+	if !lstnr.sourceBlockchain.PreserveOrderPerSender {
+		go process()
+		return
+	}
+	prevBlockProcessed := lstnr.blockProcessed
+	blockProcessed := make(chan struct{})
+	lstnr.blockProcessed = blockProcessed
+	go func() {
+		defer close(blockProcessed)
+		if prevBlockProcessed != nil {
+			<-prevBlockProcessed
+		}
+		process()
+	}()
+}
+
+// Attempts to reconnect the subscription with exponential backoff. Once reconnected, sets the listener
+// health status to true, and processes the blocks missed while disconnected in a separate goroutine.
+func (lstnr *Listener) reconnectToSubscriber(ctx context.Context) error {
 	// Attempt to reconnect the subscription
-	err := lstnr.Subscriber.Subscribe(maxResubscribeAttempts)
+	err := lstnr.Subscriber.Subscribe(ctx, int(lstnr.sourceBlockchain.MaxReconnectAttempts))
 	if err != nil {
 		return fmt.Errorf("failed to resubscribe to node: %w", err)
 	}
 
 	// Success
+	lstnr.metrics.reconnectCount.
+		WithLabelValues(lstnr.sourceBlockchain.GetBlockchainID().String()).
+		Inc()
 	lstnr.healthStatus.Store(true)
+
+	// Blocks produced while disconnected are not delivered by the new subscription. Process them from
+	// the block following the last one received, up to the latest block.
+	if lstnr.nextHeight != 0 {
+		lstnr.logger.Info(
+			"Processing blocks missed while disconnected",
+			zap.String("sourceBlockchainID", lstnr.sourceBlockchain.GetBlockchainID().String()),
+			zap.Uint64("fromBlockHeight", lstnr.nextHeight),
+		)
+		lstnr.gapFillResultChan = make(chan bool, 1)
+		go lstnr.Subscriber.ProcessFromHeight(
+			big.NewInt(0).SetUint64(lstnr.nextHeight),
+			lstnr.gapFillResultChan,
+		)
+	}
 	return nil
 }
@@ -0,0 +1,50 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ErrFailedToCreateListenerMetrics = errors.New("failed to create listener metrics")
+)
+
+type ListenerMetrics struct {
+	reconnectCount *prometheus.CounterVec
+	reorgCount     *prometheus.CounterVec
+}
+
+func NewListenerMetrics(registerer prometheus.Registerer) (*ListenerMetrics, error) {
+	reconnectCount := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "reconnects_total",
+			Help: "Number of times the subscription to the source blockchain was lost and reconnected",
+		},
+		[]string{"source_chain_id"},
+	)
+	if reconnectCount == nil {
+		return nil, ErrFailedToCreateListenerMetrics
+	}
+	registerer.MustRegister(reconnectCount)
+
+	reorgCount := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "source_reorgs_total",
+			Help: "Number of reorgs of the source blockchain detected within its reorg-depth",
+		},
+		[]string{"source_chain_id"},
+	)
+	if reorgCount == nil {
+		return nil, ErrFailedToCreateListenerMetrics
+	}
+	registerer.MustRegister(reorgCount)
+
+	return &ListenerMetrics{
+		reconnectCount: reconnectCount,
+		reorgCount:     reorgCount,
+	}, nil
+}
@@ -0,0 +1,239 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/vms"
+	"github.com/ava-labs/awm-relayer/vms/evm"
+	mock_evm "github.com/ava-labs/awm-relayer/vms/evm/mocks"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"go.uber.org/mock/gomock"
+)
+
+// testSubscriber records the heights from which missed blocks are processed
+type testSubscriber struct {
+	vms.Subscriber
+	subscribeErr    error
+	processed       []uint64
+	processedRanges [][2]uint64
+}
+
+func (s *testSubscriber) Subscribe(context.Context, int) error {
+	return s.subscribeErr
+}
+
+func (s *testSubscriber) ProcessFromHeight(height *big.Int, done chan bool) {
+	s.processed = append(s.processed, height.Uint64())
+	done <- true
+	close(done)
+}
+
+func (s *testSubscriber) ProcessHeightRange(fromHeight *big.Int, toHeight *big.Int, done chan bool) {
+	s.processedRanges = append(s.processedRanges, [2]uint64{fromHeight.Uint64(), toHeight.Uint64()})
+	done <- true
+	close(done)
+}
+
+func TestReconnectToSubscriber(t *testing.T) {
+	sourceBlockchain := config.TestValidSourceBlockchainConfig
+	destinations := set.Of(config.TestValidDestinationBlockchainConfig.BlockchainID)
+	require.NoError(t, sourceBlockchain.Validate(&destinations))
+
+	testCases := []struct {
+		name              string
+		subscribeErr      error
+		nextHeight        uint64
+		expectedErr       bool
+		expectedProcessed []uint64
+		expectedReconnect float64
+	}{
+		{
+			name:              "processes missed blocks",
+			nextHeight:        101,
+			expectedProcessed: []uint64{101},
+			expectedReconnect: 1,
+		},
+		{
+			name:              "no block received",
+			expectedReconnect: 1,
+		},
+		{
+			name:         "resubscribe fails",
+			subscribeErr: errors.New("connection refused"),
+			nextHeight:   101,
+			expectedErr:  true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			metrics, err := NewListenerMetrics(prometheus.NewRegistry())
+			require.NoError(t, err)
+			subscriber := &testSubscriber{subscribeErr: testCase.subscribeErr}
+			health := atomic.NewBool(false)
+			lstnr := &Listener{
+				Subscriber:       subscriber,
+				logger:           logging.NoLog{},
+				metrics:          metrics,
+				sourceBlockchain: sourceBlockchain,
+				healthStatus:     health,
+				nextHeight:       testCase.nextHeight,
+			}
+
+			err = lstnr.reconnectToSubscriber(context.Background())
+			if testCase.expectedErr {
+				require.ErrorIs(t, err, testCase.subscribeErr)
+				require.False(t, health.Load())
+			} else {
+				require.NoError(t, err)
+				require.True(t, health.Load())
+			}
+			if lstnr.gapFillResultChan != nil {
+				require.True(t, <-lstnr.gapFillResultChan)
+			}
+			require.Equal(t, testCase.expectedProcessed, subscriber.processed)
+			require.Equal(
+				t,
+				testCase.expectedReconnect,
+				testutil.ToFloat64(metrics.reconnectCount.WithLabelValues(sourceBlockchain.GetBlockchainID().String())),
+			)
+		})
+	}
+}
+
+func TestSourceClientForHeight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	liveClient := evm.NewWarpSourceClient(mock_evm.NewMockClient(ctrl))
+	archivalClient := evm.NewWarpSourceClient(mock_evm.NewMockClient(ctrl))
+
+	lstnr := &Listener{sourceClient: liveClient}
+	require.Same(t, liveClient, lstnr.sourceClientForHeight(1))
+
+	lstnr.archivalSourceClient = archivalClient
+	lstnr.archivalHeight = 100
+	require.Same(t, archivalClient, lstnr.sourceClientForHeight(1))
+	require.Same(t, archivalClient, lstnr.sourceClientForHeight(100))
+	require.Same(t, liveClient, lstnr.sourceClientForHeight(101))
+}
+
+func TestHandleReorg(t *testing.T) {
+	sourceBlockchain := config.TestValidSourceBlockchainConfig
+	destinations := set.Of(config.TestValidDestinationBlockchainConfig.BlockchainID)
+	require.NoError(t, sourceBlockchain.Validate(&destinations))
+
+	// Blocks 10 to 13 of chain A are received, and then block 14 of chain B, which forks from chain A after
+	// block 11
+	newChain := func(fork byte, parentHash common.Hash, from uint64, to uint64) map[uint64]*types.Header {
+		headers := make(map[uint64]*types.Header)
+		for height := from; height <= to; height++ {
+			headers[height] = &types.Header{
+				Number:     big.NewInt(int64(height)),
+				ParentHash: parentHash,
+				Extra:      []byte{fork},
+			}
+			parentHash = headers[height].Hash()
+		}
+		return headers
+	}
+	chainA := newChain('a', common.Hash{}, 10, 13)
+	chainB := newChain('b', chainA[11].Hash(), 12, 14)
+
+	testCases := []struct {
+		name           string
+		reorgDepth     uint64
+		fetchErr       error
+		expectedRanges [][2]uint64
+	}{
+		{
+			name:           "reorg within reorg depth",
+			reorgDepth:     3,
+			expectedRanges: [][2]uint64{{12, 13}},
+		},
+		{
+			name:           "reorg deeper than reorg depth",
+			reorgDepth:     1,
+			expectedRanges: [][2]uint64{{13, 13}},
+		},
+		{
+			name:           "ancestor not fetched",
+			reorgDepth:     3,
+			fetchErr:       errors.New("connection reset"),
+			expectedRanges: [][2]uint64{{11, 13}},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			metrics, err := NewListenerMetrics(prometheus.NewRegistry())
+			require.NoError(t, err)
+			ethClient := mock_evm.NewMockClient(gomock.NewController(t))
+			ethClient.EXPECT().HeaderByHash(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, hash common.Hash) (*types.Header, error) {
+					for _, header := range chainB {
+						if header.Hash() == hash {
+							return header, testCase.fetchErr
+						}
+					}
+					return nil, errors.New("not found")
+				},
+			).AnyTimes()
+
+			subscriber := &testSubscriber{}
+			sourceBlockchain.ReorgDepth = testCase.reorgDepth
+			lstnr := &Listener{
+				Subscriber:       subscriber,
+				logger:           logging.NoLog{},
+				metrics:          metrics,
+				sourceBlockchain: sourceBlockchain,
+				ethClient:        ethClient,
+				messageCoordinator: NewMessageCoordinator(
+					logging.NoLog{},
+					nil,
+					nil,
+					map[common.Hash]*ApplicationRelayer{},
+					nil,
+					nil,
+					nil,
+					nil,
+				),
+				blockHashes: make(map[uint64]common.Hash),
+			}
+			receive := func(header *types.Header) {
+				lstnr.nextHeight = max(lstnr.nextHeight, header.Number.Uint64()+1)
+				lstnr.handleReorg(context.Background(), header)
+			}
+
+			for height := uint64(10); height <= 13; height++ {
+				receive(chainA[height])
+			}
+			require.Empty(t, subscriber.processedRanges)
+
+			receive(chainB[14])
+			require.True(t, <-lstnr.reorgResultChan)
+			require.Equal(t, testCase.expectedRanges, subscriber.processedRanges)
+			require.Equal(
+				t,
+				float64(1),
+				testutil.ToFloat64(metrics.reorgCount.WithLabelValues(sourceBlockchain.GetBlockchainID().String())),
+			)
+
+			// The reprocessed blocks of chain B are not reorgs of the blocks already received
+			for height := uint64(12); height <= 13; height++ {
+				receive(chainB[height])
+			}
+			require.Len(t, subscriber.processedRanges, 1)
+		})
+	}
+}
@@ -5,20 +5,30 @@ package relayer
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/awm-relayer/database"
 	"github.com/ava-labs/awm-relayer/messages"
 	relayerTypes "github.com/ava-labs/awm-relayer/types"
+	"github.com/ava-labs/awm-relayer/utils"
+	"github.com/ava-labs/awm-relayer/vms"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/ethclient"
 	"github.com/ava-labs/subnet-evm/interfaces"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -27,40 +37,231 @@ import (
 // so that it can parse the message(s) and pass them the the proper ApplicationRelayer.
 type MessageCoordinator struct {
 	logger logging.Logger
+	// Guards the source blockchain routing state below, which may change when the configuration is reloaded
+	sourcesLock *sync.RWMutex
 	// Maps Source blockchain ID and protocol address to a Message Handler Factory
 	messageHandlerFactories map[ids.ID]map[common.Address]messages.MessageHandlerFactory
 	applicationRelayers     map[common.Hash]*ApplicationRelayer
 	sourceClients           map[ids.ID]ethclient.Client
+	metrics                 *MessageCoordinatorMetrics
+	sourceStakeMonitor      *SourceStakeMonitor // nil if no source has a minimum stake
+	tracer                  trace.Tracer        // nil if tracing is disabled
+	// nil if messages that fail to be delivered are not persisted
+	deadLetters *database.DeadLetterStore
+
+	// Maps source blockchain ID to the set of destination blockchain IDs configured for that source
+	destinations map[ids.ID]set.Set[ids.ID]
+	// Maps source blockchain ID to the maximum payload size of the messages relayed from that source
+	maxMessageBytes map[ids.ID]uint64
+	// Source and destination blockchain ID pairs paused via the API. Guarded by sourcesLock.
+	pausedRoutes set.Set[[2]ids.ID]
+	// Source and destination blockchain ID pairs for which an unroutable message has been logged
+	loggedUnroutable set.Set[[2]ids.ID]
+	lock             *sync.Mutex
+
+	// Messages are delivered with sendCtx, which is canceled if in-flight messages are not drained
+	// within the shutdown timeout.
+	sendCtx     context.Context
+	cancelSends context.CancelFunc
+	// Tracks the blocks and messages being processed, so that they may be drained on shutdown.
+	// inFlightLock guards shuttingDown, so that no processing begins once draining has started.
+	inFlightLock      sync.Mutex
+	shuttingDown      bool
+	shutdownChan      chan struct{}
+	inFlight          sync.WaitGroup
+	inFlightMessages  atomic.Int64
+	completedMessages atomic.Int64
 }
 
 func NewMessageCoordinator(
 	logger logging.Logger,
+	metrics *MessageCoordinatorMetrics,
 	messageHandlerFactories map[ids.ID]map[common.Address]messages.MessageHandlerFactory,
 	applicationRelayers map[common.Hash]*ApplicationRelayer,
 	sourceClients map[ids.ID]ethclient.Client,
+	sourceStakeMonitor *SourceStakeMonitor,
+	tracer trace.Tracer,
+	deadLetters *database.DeadLetterStore,
 ) *MessageCoordinator {
-	return &MessageCoordinator{
+	destinations := make(map[ids.ID]set.Set[ids.ID])
+	maxMessageBytes := make(map[ids.ID]uint64)
+	for _, appRelayer := range applicationRelayers {
+		sourceBlockchainID := appRelayer.relayerID.SourceBlockchainID
+		destinationsForSource := destinations[sourceBlockchainID]
+		destinationsForSource.Add(appRelayer.relayerID.DestinationBlockchainID)
+		destinations[sourceBlockchainID] = destinationsForSource
+		maxMessageBytes[sourceBlockchainID] = appRelayer.sourceBlockchain.GetMaxMessageBytes()
+	}
+	sendCtx, cancelSends := context.WithCancel(context.Background())
+	mc := &MessageCoordinator{
 		logger:                  logger,
+		sourcesLock:             &sync.RWMutex{},
 		messageHandlerFactories: messageHandlerFactories,
 		applicationRelayers:     applicationRelayers,
 		sourceClients:           sourceClients,
+		metrics:                 metrics,
+		sourceStakeMonitor:      sourceStakeMonitor,
+		tracer:                  tracer,
+		deadLetters:             deadLetters,
+		destinations:            destinations,
+		maxMessageBytes:         maxMessageBytes,
+		lock:                    &sync.Mutex{},
+		sendCtx:                 sendCtx,
+		cancelSends:             cancelSends,
+		shutdownChan:            make(chan struct{}),
+	}
+	for _, appRelayer := range applicationRelayers {
+		appRelayer.deliveryTracker = mc
+	}
+	return mc
+}
+
+// startProcessing registers [numMessages] messages as in-flight. Returns false if the relayer is shutting down,
+// in which case the messages must not be processed. Each successful call must be followed by a call to
+// finishProcessing with the same number of messages.
+func (mc *MessageCoordinator) startProcessing(numMessages int) bool {
+	mc.inFlightLock.Lock()
+	defer mc.inFlightLock.Unlock()
+	if mc.shuttingDown {
+		return false
+	}
+	mc.inFlight.Add(1)
+	mc.inFlightMessages.Add(int64(numMessages))
+	return true
+}
+
+func (mc *MessageCoordinator) finishProcessing(numMessages int) {
+	mc.inFlightMessages.Add(-int64(numMessages))
+	mc.completedMessages.Add(int64(numMessages))
+	mc.inFlight.Done()
+}
+
+// Shutdown stops processing new blocks and messages, and waits up to [timeout] for in-flight messages to be
+// delivered. The heights of the application relayers are then written to the database. Deliveries still in
+// progress after [timeout] are canceled, and their heights are not checkpointed, so they are retried when the
+// relayer restarts. Returns the number of messages that completed while draining, and the number abandoned.
+func (mc *MessageCoordinator) Shutdown(timeout time.Duration) (int64, int64) {
+	mc.inFlightLock.Lock()
+	if mc.shuttingDown {
+		mc.inFlightLock.Unlock()
+		return 0, 0
+	}
+	mc.shuttingDown = true
+	close(mc.shutdownChan)
+	mc.inFlightLock.Unlock()
+
+	completedBefore := mc.completedMessages.Load()
+	drained := make(chan struct{})
+	go func() {
+		mc.inFlight.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(timeout):
+		mc.logger.Warn(
+			"Timed out waiting for in-flight messages. Canceling remaining deliveries.",
+			zap.Duration("timeout", timeout),
+		)
 	}
+	abandoned := mc.inFlightMessages.Load()
+	mc.cancelSends()
+
+	mc.FlushCheckpoints()
+	return mc.completedMessages.Load() - completedBefore, abandoned
 }
 
-// getAppRelayerMessageHandler returns the ApplicationRelayer that is configured to handle this message,
-// as well as a one-time MessageHandler instance that the ApplicationRelayer uses to relay this specific message.
+// FlushCheckpoints writes the committed height of each application relayer to the database without waiting
+// for the next write signal.
+func (mc *MessageCoordinator) FlushCheckpoints() {
+	mc.sourcesLock.RLock()
+	defer mc.sourcesLock.RUnlock()
+	for _, appRelayer := range mc.applicationRelayers {
+		appRelayer.FlushCheckpoint()
+	}
+}
+
+// RollbackCheckpoints lowers the committed height of each application relayer of the source blockchain
+// [blockchainID] to [height], for example if the blocks above it were orphaned by a reorg.
+func (mc *MessageCoordinator) RollbackCheckpoints(blockchainID ids.ID, height uint64) {
+	mc.sourcesLock.RLock()
+	defer mc.sourcesLock.RUnlock()
+	for _, appRelayer := range mc.applicationRelayers {
+		if appRelayer.relayerID.SourceBlockchainID == blockchainID {
+			appRelayer.RollbackCheckpoint(height)
+		}
+	}
+}
+
+// AddSourceBlockchain begins routing messages from the source blockchain [blockchainID] to
+// [applicationRelayers], replacing any existing routes for the source blockchain.
+func (mc *MessageCoordinator) AddSourceBlockchain(
+	blockchainID ids.ID,
+	messageHandlerFactories map[common.Address]messages.MessageHandlerFactory,
+	applicationRelayers map[common.Hash]*ApplicationRelayer,
+	sourceClient ethclient.Client,
+) {
+	mc.sourcesLock.Lock()
+	defer mc.sourcesLock.Unlock()
+	mc.removeSourceBlockchain(blockchainID)
+
+	var destinationsForSource set.Set[ids.ID]
+	for relayerID, appRelayer := range applicationRelayers {
+		if mc.pausedRoutes.Contains([2]ids.ID{blockchainID, appRelayer.relayerID.DestinationBlockchainID}) {
+			appRelayer.pause()
+		}
+		appRelayer.deliveryTracker = mc
+		mc.applicationRelayers[relayerID] = appRelayer
+		destinationsForSource.Add(appRelayer.relayerID.DestinationBlockchainID)
+		mc.maxMessageBytes[blockchainID] = appRelayer.sourceBlockchain.GetMaxMessageBytes()
+	}
+	mc.messageHandlerFactories[blockchainID] = messageHandlerFactories
+	mc.sourceClients[blockchainID] = sourceClient
+	mc.destinations[blockchainID] = destinationsForSource
+}
+
+// RemoveSourceBlockchain stops routing messages from the source blockchain [blockchainID], and stops its
+// ApplicationRelayers. Messages already dispatched to them are still relayed, but no longer checkpointed.
+func (mc *MessageCoordinator) RemoveSourceBlockchain(blockchainID ids.ID) {
+	mc.sourcesLock.Lock()
+	defer mc.sourcesLock.Unlock()
+	mc.removeSourceBlockchain(blockchainID)
+}
+
+// removeSourceBlockchain must be called with sourcesLock held.
+func (mc *MessageCoordinator) removeSourceBlockchain(blockchainID ids.ID) {
+	for relayerID, appRelayer := range mc.applicationRelayers {
+		if appRelayer.relayerID.SourceBlockchainID == blockchainID {
+			appRelayer.Stop()
+			delete(mc.applicationRelayers, relayerID)
+		}
+	}
+	delete(mc.messageHandlerFactories, blockchainID)
+	delete(mc.sourceClients, blockchainID)
+	delete(mc.destinations, blockchainID)
+	delete(mc.maxMessageBytes, blockchainID)
+}
+
+// routedMessageHandler pairs a one-time MessageHandler with the ApplicationRelayer configured to relay it.
+type routedMessageHandler struct {
+	appRelayer *ApplicationRelayer
+	handler    messages.MessageHandler
+}
+
+// getAppRelayerMessageHandlers returns the one-time MessageHandler instances that relay this message, each paired
+// with the ApplicationRelayer that is configured to handle it. Most message formats carry a single payload, and so
+// have a single handler. Multi-payload formats have a handler per payload, each of which is routed independently.
+// Handlers without a configured ApplicationRelayer are omitted.
 // The MessageHandler and ApplicationRelayer are decoupled to support batch workflows in which a single
 // ApplicationRelayer processes multiple messages (using their corresponding MessageHandlers) in a single shot.
-func (mc *MessageCoordinator) getAppRelayerMessageHandler(
+func (mc *MessageCoordinator) getAppRelayerMessageHandlers(
 	warpMessageInfo *relayerTypes.WarpMessageInfo,
-) (
-	*ApplicationRelayer,
-	messages.MessageHandler,
-	error,
-) {
+) ([]routedMessageHandler, error) {
 	// Check that the warp message is from a supported message protocol contract address.
+	mc.sourcesLock.RLock()
 	//nolint:lll
 	messageHandlerFactory, supportedMessageProtocol := mc.messageHandlerFactories[warpMessageInfo.UnsignedMessage.SourceChainID][warpMessageInfo.SourceAddress]
+	mc.sourcesLock.RUnlock()
 	if !supportedMessageProtocol {
 		// Do not return an error here because it is expected for there to be messages from other contracts
 		// than just the ones supported by a single listener instance.
@@ -68,20 +269,120 @@ func (mc *MessageCoordinator) getAppRelayerMessageHandler(
 			"Warp message from unsupported message protocol address. Not relaying.",
 			zap.String("protocolAddress", warpMessageInfo.SourceAddress.Hex()),
 		)
-		return nil, nil, nil
+		return nil, nil
 	}
-	messageHandler, err := messageHandlerFactory.NewMessageHandler(warpMessageInfo.UnsignedMessage)
+
+	// Do not relay messages whose payload exceeds the maximum message size of the source
+	sourceBlockchainID := warpMessageInfo.UnsignedMessage.SourceChainID
+	mc.sourcesLock.RLock()
+	maxMessageBytes, ok := mc.maxMessageBytes[sourceBlockchainID]
+	mc.sourcesLock.RUnlock()
+	if payloadBytes := len(warpMessageInfo.UnsignedMessage.Payload); ok && uint64(payloadBytes) > maxMessageBytes {
+		mc.logger.Warn(
+			"Warp message payload exceeds the maximum message size. Not relaying.",
+			zap.String("sourceBlockchainID", sourceBlockchainID.String()),
+			zap.String("warpMessageID", warpMessageInfo.UnsignedMessage.ID().String()),
+			zap.Int("payloadBytes", payloadBytes),
+			zap.Uint64("maxMessageBytes", maxMessageBytes),
+		)
+		return nil, nil
+	}
+
+	// Do not relay messages from sources whose stake is below the configured minimum. Messages from sources whose
+	// stake is unverified are not skipped, since their heights would be committed.
+	verified, secure := mc.sourceStakeMonitor.status(sourceBlockchainID)
+	if !verified {
+		return nil, errSourceStakeUnverified
+	}
+	if !secure {
+		mc.metrics.insecureSourceMessageCount.WithLabelValues(sourceBlockchainID.String()).Inc()
+		mc.logger.Debug(
+			"Source subnet stake is below the configured minimum. Skipping message relay.",
+			zap.String("sourceBlockchainID", sourceBlockchainID.String()),
+			zap.String("warpMessageID", warpMessageInfo.UnsignedMessage.ID().String()),
+		)
+		return nil, nil
+	}
+
+	messageHandlers, err := messages.NewMessageHandlers(messageHandlerFactory, warpMessageInfo.UnsignedMessage)
 	if err != nil {
 		mc.logger.Error("Failed to create message handler", zap.Error(err))
-		return nil, nil, err
+		return nil, err
 	}
 
+	var routed []routedMessageHandler
+	for _, messageHandler := range messageHandlers {
+		appRelayer, err := mc.getMessageHandlerAppRelayer(messageHandler, warpMessageInfo)
+		if err != nil {
+			return nil, err
+		}
+		if appRelayer == nil {
+			continue
+		}
+		routed = append(routed, routedMessageHandler{
+			appRelayer: appRelayer,
+			handler:    messageHandler,
+		})
+	}
+	return routed, nil
+}
+
+// startMessageSpan starts the root span of the relay of [warpMessageInfo]. The returned context is derived
+// from sendCtx, so that the deliveries of the message are canceled if the relayer fails to drain on shutdown.
+func (mc *MessageCoordinator) startMessageSpan(
+	warpMessageInfo *relayerTypes.WarpMessageInfo,
+) (context.Context, trace.Span) {
+	if mc.tracer == nil {
+		return mc.sendCtx, trace.SpanFromContext(mc.sendCtx)
+	}
+	return mc.tracer.Start(
+		mc.sendCtx,
+		relayMessageSpanName,
+		trace.WithAttributes(
+			attribute.String(utils.WarpMessageIDLogKey, warpMessageInfo.UnsignedMessage.ID().String()),
+			attribute.String(utils.SourceBlockchainIDLogKey, warpMessageInfo.UnsignedMessage.SourceChainID.String()),
+			attribute.String("source_address", warpMessageInfo.SourceAddress.String()),
+		),
+	)
+}
+
+// routeMessage returns the routed message handlers of [warpMessageInfo], recording the unpacking of the
+// message as a child of the span carried by [ctx].
+func (mc *MessageCoordinator) routeMessage(
+	ctx context.Context,
+	warpMessageInfo *relayerTypes.WarpMessageInfo,
+) ([]routedMessageHandler, error) {
+	_, span := startSpan(ctx, unpackMessageSpanName)
+	routed, err := mc.getAppRelayerMessageHandlers(warpMessageInfo)
+	span.SetAttributes(attribute.Int("routed_handlers", len(routed)))
+	endSpan(span, err)
+	return routed, err
+}
+
+// getMessageHandlerAppRelayer returns the ApplicationRelayer that is configured to relay the message handled
+// by [messageHandler], or nil if there is none.
+func (mc *MessageCoordinator) getMessageHandlerAppRelayer(
+	messageHandler messages.MessageHandler,
+	warpMessageInfo *relayerTypes.WarpMessageInfo,
+) (*ApplicationRelayer, error) {
 	// Fetch the message delivery data
 	//nolint:lll
 	sourceBlockchainID, originSenderAddress, destinationBlockchainID, destinationAddress, err := messageHandler.GetMessageRoutingInfo()
 	if err != nil {
 		mc.logger.Error("Failed to get message routing information", zap.Error(err))
-		return nil, nil, err
+		return nil, err
+	}
+
+	// Skip messages to destinations that are not supported by the source blockchain before doing any further
+	// work, for example if the source only relays to some destinations, or if the destination was removed from
+	// the configuration after the message was sent.
+	mc.sourcesLock.RLock()
+	destinations := mc.destinations[sourceBlockchainID]
+	routable := destinations.Contains(destinationBlockchainID)
+	mc.sourcesLock.RUnlock()
+	if !routable {
+		mc.handleUnroutableMessage(sourceBlockchainID, destinationBlockchainID, warpMessageInfo)
+		return nil, nil
 	}
 
 	mc.logger.Info(
@@ -93,16 +394,44 @@ func (mc *MessageCoordinator) getAppRelayerMessageHandler(
 		zap.String("warpMessageID", warpMessageInfo.UnsignedMessage.ID().String()),
 	)
 
-	appRelayer := mc.getApplicationRelayer(
+	return mc.getApplicationRelayer(
 		sourceBlockchainID,
 		originSenderAddress,
 		destinationBlockchainID,
 		destinationAddress,
-	)
-	if appRelayer == nil {
-		return nil, nil, nil
+	), nil
+}
+
+// handleUnroutableMessage records a message whose destination is not configured. The message is logged at
+// most once per source and destination pair to avoid flooding the logs.
+func (mc *MessageCoordinator) handleUnroutableMessage(
+	sourceBlockchainID ids.ID,
+	destinationBlockchainID ids.ID,
+	warpMessageInfo *relayerTypes.WarpMessageInfo,
+) {
+	mc.metrics.unroutableMessageCount.WithLabelValues(sourceBlockchainID.String()).Inc()
+
+	route := [2]ids.ID{sourceBlockchainID, destinationBlockchainID}
+	mc.lock.Lock()
+	logged := mc.loggedUnroutable.Contains(route)
+	mc.loggedUnroutable.Add(route)
+	mc.lock.Unlock()
+	if logged {
+		mc.logger.Debug(
+			"Destination not configured for source blockchain. Skipping message relay.",
+			zap.String("sourceBlockchainID", sourceBlockchainID.String()),
+			zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+			zap.String("warpMessageID", warpMessageInfo.UnsignedMessage.ID().String()),
+		)
+		return
 	}
-	return appRelayer, messageHandler, nil
+	mc.logger.Warn(
+		"Destination not configured for source blockchain. Skipping message relay. "+
+			"Further messages to this destination will not be logged.",
+		zap.String("sourceBlockchainID", sourceBlockchainID.String()),
+		zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+		zap.String("warpMessageID", warpMessageInfo.UnsignedMessage.ID().String()),
+	)
 }
 
 // Unpacks the Warp message and fetches the appropriate application relayer
@@ -120,6 +449,9 @@ func (mc *MessageCoordinator) getApplicationRelayer(
 	destinationBlockchainID ids.ID,
 	destinationAddress common.Address,
 ) *ApplicationRelayer {
+	mc.sourcesLock.RLock()
+	defer mc.sourcesLock.RUnlock()
+
 	// Check for an exact match
 	applicationRelayerID := database.CalculateRelayerID(
 		sourceBlockchainID,
@@ -176,8 +508,18 @@ func (mc *MessageCoordinator) getApplicationRelayer(
 	return nil
 }
 
-func (mc *MessageCoordinator) ProcessWarpMessage(warpMessage *relayerTypes.WarpMessageInfo) (common.Hash, error) {
-	appRelayer, handler, err := mc.getAppRelayerMessageHandler(warpMessage)
+// ProcessWarpMessage relays the Warp message, and returns the hash of the delivery transaction.
+// The payloads of a multi-payload message are relayed in order, and the hash of the last
+// delivery transaction is returned.
+func (mc *MessageCoordinator) ProcessWarpMessage(
+	warpMessage *relayerTypes.WarpMessageInfo,
+) (txHash common.Hash, err error) {
+	ctx, span := mc.startMessageSpan(warpMessage)
+	defer func() {
+		endSpan(span, err)
+	}()
+
+	routed, err := mc.routeMessage(ctx, warpMessage)
 	if err != nil {
 		mc.logger.Error(
 			"Failed to parse Warp message.",
@@ -186,12 +528,24 @@ func (mc *MessageCoordinator) ProcessWarpMessage(warpMessage *relayerTypes.WarpM
 		)
 		return common.Hash{}, err
 	}
-	if appRelayer == nil {
+	if len(routed) == 0 {
 		mc.logger.Error("Application relayer not found")
 		return common.Hash{}, errors.New("application relayer not found")
 	}
 
-	return appRelayer.ProcessMessage(handler)
+	if !mc.startProcessing(len(routed)) {
+		return common.Hash{}, errors.New("relayer is shutting down")
+	}
+	defer mc.finishProcessing(len(routed))
+
+	for _, r := range routed {
+		txHash, err = r.appRelayer.ProcessMessage(ctx, r.handler)
+		mc.recordDeliveryOutcome(r.appRelayer, warpMessage, err)
+		if err != nil {
+			return common.Hash{}, err
+		}
+	}
+	return txHash, nil
 }
 
 func (mc *MessageCoordinator) ProcessMessageID(
@@ -199,7 +553,7 @@ func (mc *MessageCoordinator) ProcessMessageID(
 	messageID ids.ID,
 	blockNum *big.Int,
 ) (common.Hash, error) {
-	ethClient, ok := mc.sourceClients[blockchainID]
+	ethClient, ok := mc.getSourceClient(blockchainID)
 	if !ok {
 		mc.logger.Error(
 			"Source client not found",
@@ -221,25 +575,143 @@ func (mc *MessageCoordinator) ProcessMessageID(
 	return mc.ProcessWarpMessage(warpMessage)
 }
 
+// ProcessTransactionLog relays the Warp message emitted by the transaction with hash [txHash] on the source
+// blockchain. [eventIndex] is the index of the message among the Warp messages emitted by the transaction.
+func (mc *MessageCoordinator) ProcessTransactionLog(
+	blockchainID ids.ID,
+	txHash common.Hash,
+	eventIndex uint,
+) (common.Hash, error) {
+	ethClient, ok := mc.getSourceClient(blockchainID)
+	if !ok {
+		mc.logger.Error(
+			"Source client not found",
+			zap.String("blockchainID", blockchainID.String()),
+		)
+		return common.Hash{}, fmt.Errorf("source client not set for blockchain: %s", blockchainID.String())
+	}
+
+	warpMessage, err := FetchTransactionWarpMessage(ethClient, txHash, eventIndex)
+	if err != nil {
+		mc.logger.Error(
+			"Failed to fetch warp message from transaction",
+			zap.String("blockchainID", blockchainID.String()),
+			zap.String("txHash", txHash.String()),
+			zap.Uint("eventIndex", eventIndex),
+			zap.Error(err),
+		)
+		return common.Hash{}, fmt.Errorf("could not fetch warp message from transaction: %w", err)
+	}
+
+	return mc.ProcessWarpMessage(warpMessage)
+}
+
+// CheckpointedHeight returns the lowest height checkpointed by the ApplicationRelayers of the source blockchain
+// [blockchainID], below which all blocks have been processed. Returns false if the source blockchain has no
+// ApplicationRelayers.
+func (mc *MessageCoordinator) CheckpointedHeight(blockchainID ids.ID) (uint64, bool) {
+	mc.sourcesLock.RLock()
+	defer mc.sourcesLock.RUnlock()
+	var (
+		minHeight uint64
+		found     bool
+	)
+	for _, appRelayer := range mc.applicationRelayers {
+		if appRelayer.relayerID.SourceBlockchainID != blockchainID {
+			continue
+		}
+		if height := appRelayer.CheckpointedHeight(); !found || height < minHeight {
+			minHeight = height
+		}
+		found = true
+	}
+	return minHeight, found
+}
+
+func (mc *MessageCoordinator) getSourceClient(blockchainID ids.ID) (ethclient.Client, bool) {
+	mc.sourcesLock.RLock()
+	defer mc.sourcesLock.RUnlock()
+	ethClient, ok := mc.sourceClients[blockchainID]
+	return ethClient, ok
+}
+
 // Meant to be ran asynchronously. Errors should be sent to errChan.
+// Blocks received once the relayer is shutting down are not processed.
+// Warp messages in the block that cannot be parsed are skipped, and the block is processed without them.
+// If the block has messages from a source whose stake is unverified, it is not processed until the stake is verified.
+// If [finalityGate] is non-nil, the block's messages are not delivered until the gate reports it final.
 func (mc *MessageCoordinator) ProcessBlock(
+	sourceBlockchainID ids.ID,
 	blockHeader *types.Header,
-	ethClient ethclient.Client,
+	sourceClient vms.SourceClient,
+	finalityGate *FinalityGate,
 	errChan chan error,
 ) {
+	if !mc.startProcessing(0) {
+		mc.logger.Debug(
+			"Relayer is shutting down. Skipping block",
+			zap.Uint64("height", blockHeader.Number.Uint64()),
+		)
+		return
+	}
+	defer mc.finishProcessing(0)
+
 	// Parse the logs in the block, and group by application relayer
-	block, err := relayerTypes.NewWarpBlockInfo(blockHeader, ethClient)
+	block, err := sourceClient.WarpBlockInfo(blockHeader)
 	if err != nil {
 		mc.logger.Error("Failed to create Warp block info", zap.Error(err))
-		errChan <- err
+		mc.sendError(errChan, err)
+		return
+	}
+	for _, log := range block.MalformedLogs {
+		mc.metrics.malformedMessageCount.WithLabelValues(sourceBlockchainID.String()).Inc()
+		mc.logger.Debug(
+			"Skipping malformed Warp message",
+			zap.String("sourceBlockchainID", sourceBlockchainID.String()),
+			zap.Uint64("height", block.BlockNumber),
+			zap.String("txHash", log.TxHash.String()),
+			zap.Uint("logIndex", log.Index),
+			zap.String("data", hex.EncodeToString(log.Data)),
+		)
+	}
+
+	// Wait for the block to be final before delivering its messages. Blocks without messages are checkpointed
+	// right away, since there is nothing to deliver if they are reorged out.
+	if len(block.Messages) != 0 && !finalityGate.Wait(block.BlockNumber, block.BlockTimestamp, mc.shutdownChan) {
+		mc.logger.Debug(
+			"Relayer is shutting down. Skipping block",
+			zap.Uint64("height", block.BlockNumber),
+		)
 		return
 	}
 
 	// Register each message in the block with the appropriate application relayer
-	messageHandlers := make(map[common.Hash][]messages.MessageHandler)
+	deliveries := make(map[common.Hash][]messageDelivery)
+	// The handlers for each payload of a multi-payload message are registered separately. Since an
+	// application relayer only checkpoints a height once all of its handlers succeed, the height is not
+	// committed until each payload routed to that application relayer has been delivered.
 	for _, warpLogInfo := range block.Messages {
-		appRelayer, handler, err := mc.getAppRelayerMessageHandler(warpLogInfo)
+		ctx, span := mc.startMessageSpan(warpLogInfo)
+		routed, err := mc.routeMessage(ctx, warpLogInfo)
+		// The block is not processed until the stake of its source is verified, so that its height is not committed
+		for errors.Is(err, errSourceStakeUnverified) {
+			if !mc.sourceStakeMonitor.waitVerified(sourceBlockchainID, mc.shutdownChan) {
+				endSpan(span, err)
+				for _, appRelayerDeliveries := range deliveries {
+					for _, delivery := range appRelayerDeliveries {
+						delivery.done(context.Canceled)
+					}
+				}
+				mc.logger.Debug(
+					"Relayer is shutting down. Skipping block",
+					zap.Uint64("height", block.BlockNumber),
+				)
+				return
+			}
+			routed, err = mc.routeMessage(ctx, warpLogInfo)
+		}
 		if err != nil {
+			endSpan(span, err)
 			mc.logger.Error(
 				"Failed to parse message",
 				zap.String("blockchainID", warpLogInfo.UnsignedMessage.SourceChainID.String()),
@@ -248,19 +720,97 @@ func (mc *MessageCoordinator) ProcessBlock(
 			)
 			continue
 		}
-		if appRelayer == nil {
+		if len(routed) == 0 {
+			span.End()
 			mc.logger.Debug("Application relayer not found. Skipping message relay")
 			continue
 		}
-		messageHandlers[appRelayer.relayerID.ID] = append(messageHandlers[appRelayer.relayerID.ID], handler)
+		// The root span of the message ends once each of its payloads has been relayed
+		spanDone := endSpanAfter(span, len(routed))
+		// Copy the loop variable to a local variable to avoid the loop variable being captured by the closure
+		warpMessageInfo := warpLogInfo
+		for _, r := range routed {
+			appRelayer := r.appRelayer
+			deliveries[appRelayer.relayerID.ID] = append(deliveries[appRelayer.relayerID.ID], messageDelivery{
+				ctx:     ctx,
+				handler: r.handler,
+				done: func(err error) {
+					mc.recordDeliveryOutcome(appRelayer, warpMessageInfo, err)
+					spanDone(err)
+				},
+			})
+		}
 	}
 	// Initiate message relay of all registered messages
+	mc.sourcesLock.RLock()
+	defer mc.sourcesLock.RUnlock()
 	for _, appRelayer := range mc.applicationRelayers {
 		// Dispatch all messages in the block to the appropriate application relayer.
 		// An empty slice is still a valid argument to ProcessHeight; in this case the height is immediately committed.
-		handlers := messageHandlers[appRelayer.relayerID.ID]
+		appRelayerDeliveries := appRelayer.skipExpiredMessages(block, deliveries[appRelayer.relayerID.ID])
+		appRelayer.sequenceDeliveries(appRelayerDeliveries)
+
+		// The block is registered as in-flight until this function returns, so the relayer cannot have
+		// finished draining, and the messages may be registered directly.
+		mc.inFlight.Add(1)
+		mc.inFlightMessages.Add(int64(len(appRelayerDeliveries)))
+		go func(appRelayer *ApplicationRelayer) {
+			defer mc.finishProcessing(len(appRelayerDeliveries))
+			if err := appRelayer.ProcessHeight(block.BlockNumber, appRelayerDeliveries); err != nil {
+				mc.sendError(errChan, err)
+			}
+		}(appRelayer)
+	}
+}
+
+// recordDeliveryOutcome stores [warpMessageInfo] as a dead letter of [appRelayer] if its delivery failed with [err],
+// and otherwise removes any dead letter stored for it. Deliveries canceled on shutdown are not recorded, since
+// their heights are not checkpointed, and so they are relayed again once the relayer restarts.
+func (mc *MessageCoordinator) recordDeliveryOutcome(
+	appRelayer *ApplicationRelayer,
+	warpMessageInfo *relayerTypes.WarpMessageInfo,
+	err error,
+) {
+	if mc.deadLetters == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+	relayerID := appRelayer.relayerID
+	warpMessageID := warpMessageInfo.UnsignedMessage.ID().String()
+	if err == nil {
+		err = mc.deadLetters.Remove(relayerID.ID, warpMessageID)
+	} else {
+		mc.logger.Warn(
+			"Failed to deliver message. Storing dead letter",
+			zap.String("warpMessageID", warpMessageID),
+			zap.String("relayerID", relayerID.ID.String()),
+			zap.String("reason", err.Error()),
+		)
+		err = mc.deadLetters.Put(relayerID.ID, &database.DeadLetter{
+			WarpMessageID:           warpMessageID,
+			SourceBlockchainID:      relayerID.SourceBlockchainID.String(),
+			DestinationBlockchainID: relayerID.DestinationBlockchainID.String(),
+			SourceAddress:           warpMessageInfo.SourceAddress.Hex(),
+			UnsignedMessage:         hexutil.Encode(warpMessageInfo.UnsignedMessage.Bytes()),
+			Reason:                  err.Error(),
+			Timestamp:               time.Now().Unix(),
+		})
+	}
+	if err != nil {
+		mc.logger.Error(
+			"Failed to update dead letters",
+			zap.String("warpMessageID", warpMessageID),
+			zap.String("relayerID", relayerID.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
 
-		go appRelayer.ProcessHeight(block.BlockNumber, handlers, errChan)
+// sendError sends [err] to [errChan], unless the relayer is shutting down, in which case the Listener
+// reading from [errChan] may have exited.
+func (mc *MessageCoordinator) sendError(errChan chan error, err error) {
+	select {
+	case errChan <- err:
+	case <-mc.shutdownChan:
 	}
 }
 
@@ -284,3 +834,32 @@ func FetchWarpMessage(
 
 	return relayerTypes.NewWarpMessageInfo(logs[0])
 }
+
+// FetchTransactionWarpMessage returns the [eventIndex]th Warp message emitted by the transaction with hash [txHash].
+func FetchTransactionWarpMessage(
+	ethClient ethclient.Client,
+	txHash common.Hash,
+	eventIndex uint,
+) (*relayerTypes.WarpMessageInfo, error) {
+	receipt, err := ethClient.TransactionReceipt(context.Background(), txHash)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch transaction receipt: %w", err)
+	}
+	var warpLogs []*types.Log
+	for _, log := range receipt.Logs {
+		if log.Address == warp.ContractAddress &&
+			len(log.Topics) > 0 &&
+			log.Topics[0] == relayerTypes.WarpPrecompileLogFilter {
+			warpLogs = append(warpLogs, log)
+		}
+	}
+	if eventIndex >= uint(len(warpLogs)) {
+		return nil, fmt.Errorf(
+			"event index %d out of range, transaction emitted %d warp messages",
+			eventIndex,
+			len(warpLogs),
+		)
+	}
+
+	return relayerTypes.NewWarpMessageInfo(*warpLogs[eventIndex])
+}
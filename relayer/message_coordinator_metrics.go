@@ -0,0 +1,120 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ErrFailedToCreateMessageCoordinatorMetrics = errors.New("failed to create message coordinator metrics")
+)
+
+type MessageCoordinatorMetrics struct {
+	unroutableMessageCount     *prometheus.CounterVec
+	malformedMessageCount      *prometheus.CounterVec
+	insecureSourceMessageCount *prometheus.CounterVec
+	sourceStake                *prometheus.GaugeVec
+	blockLag                   *prometheus.GaugeVec
+	heartbeatRoundTrip         *prometheus.GaugeVec
+	heartbeatFailures          *prometheus.CounterVec
+}
+
+func NewMessageCoordinatorMetrics(registerer prometheus.Registerer) (*MessageCoordinatorMetrics, error) {
+	unroutableMessageCount := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "relayer_messages_unroutable_total",
+			Help: "Number of messages skipped because their destination is not configured",
+		},
+		[]string{"source"},
+	)
+	if unroutableMessageCount == nil {
+		return nil, ErrFailedToCreateMessageCoordinatorMetrics
+	}
+	registerer.MustRegister(unroutableMessageCount)
+
+	malformedMessageCount := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "malformed_messages_total",
+			Help: "Number of Warp messages skipped because they could not be parsed",
+		},
+		[]string{"source_chain_id"},
+	)
+	if malformedMessageCount == nil {
+		return nil, ErrFailedToCreateMessageCoordinatorMetrics
+	}
+	registerer.MustRegister(malformedMessageCount)
+
+	insecureSourceMessageCount := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "insecure_source_message_count",
+			Help: "Number of messages skipped because the source subnet's stake is below the configured minimum",
+		},
+		[]string{"source_chain_id"},
+	)
+	if insecureSourceMessageCount == nil {
+		return nil, ErrFailedToCreateMessageCoordinatorMetrics
+	}
+	registerer.MustRegister(insecureSourceMessageCount)
+
+	sourceStake := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "source_stake",
+			Help: "Total stake weight of the source subnet's canonical validator set",
+		},
+		[]string{"source_chain_id", "source_subnet_id"},
+	)
+	if sourceStake == nil {
+		return nil, ErrFailedToCreateMessageCoordinatorMetrics
+	}
+	registerer.MustRegister(sourceStake)
+
+	blockLag := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "block_lag",
+			Help: "Number of blocks between the source chain head and the latest checkpointed block",
+		},
+		[]string{"source_chain_id"},
+	)
+	if blockLag == nil {
+		return nil, ErrFailedToCreateMessageCoordinatorMetrics
+	}
+	registerer.MustRegister(blockLag)
+
+	heartbeatRoundTrip := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "heartbeat_round_trip_seconds",
+			Help: "Seconds between sending the latest delivered heartbeat message and observing its delivery",
+		},
+		[]string{"source_chain_id", "destination_chain_id"},
+	)
+	if heartbeatRoundTrip == nil {
+		return nil, ErrFailedToCreateMessageCoordinatorMetrics
+	}
+	registerer.MustRegister(heartbeatRoundTrip)
+
+	heartbeatFailures := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "heartbeat_failures_total",
+			Help: "Number of heartbeat messages that failed to send, or were not delivered within the deadline",
+		},
+		[]string{"source_chain_id", "destination_chain_id", "reason"},
+	)
+	if heartbeatFailures == nil {
+		return nil, ErrFailedToCreateMessageCoordinatorMetrics
+	}
+	registerer.MustRegister(heartbeatFailures)
+
+	return &MessageCoordinatorMetrics{
+		unroutableMessageCount:     unroutableMessageCount,
+		malformedMessageCount:      malformedMessageCount,
+		insecureSourceMessageCount: insecureSourceMessageCount,
+		sourceStake:                sourceStake,
+		blockLag:                   blockLag,
+		heartbeatRoundTrip:         heartbeatRoundTrip,
+		heartbeatFailures:          heartbeatFailures,
+	}, nil
+}
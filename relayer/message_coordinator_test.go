@@ -0,0 +1,899 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/database"
+	"github.com/ava-labs/awm-relayer/messages"
+	mock_messages "github.com/ava-labs/awm-relayer/messages/mocks"
+	"github.com/ava-labs/awm-relayer/relayer/checkpoint"
+	relayerTypes "github.com/ava-labs/awm-relayer/types"
+	"github.com/ava-labs/awm-relayer/utils"
+	"github.com/ava-labs/awm-relayer/vms"
+	"github.com/ava-labs/awm-relayer/vms/evm"
+	mock_evm "github.com/ava-labs/awm-relayer/vms/evm/mocks"
+	mock_vms "github.com/ava-labs/awm-relayer/vms/mocks"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	subnetWarp "github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnroutableMessage(t *testing.T) {
+	sourceBlockchainID := ids.GenerateTestID()
+	configuredDestinationID := ids.GenerateTestID()
+	removedDestinationID := ids.GenerateTestID()
+	protocolAddress := common.HexToAddress("0xd81545385803bCD83bd59f58Ba2d2c0562387F83")
+
+	testCases := []struct {
+		name                    string
+		destinationBlockchainID ids.ID
+		expectRelayer           bool
+		expectedUnroutable      float64
+	}{
+		{
+			name:                    "configured destination",
+			destinationBlockchainID: configuredDestinationID,
+			expectRelayer:           true,
+			expectedUnroutable:      0,
+		},
+		{
+			name:                    "unroutable destination",
+			destinationBlockchainID: removedDestinationID,
+			expectRelayer:           false,
+			expectedUnroutable:      1,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			metrics, err := NewMessageCoordinatorMetrics(prometheus.NewRegistry())
+			require.NoError(t, err)
+
+			relayerID := database.NewRelayerID(
+				sourceBlockchainID,
+				configuredDestinationID,
+				database.AllAllowedAddress,
+				database.AllAllowedAddress,
+			)
+			applicationRelayers := map[common.Hash]*ApplicationRelayer{
+				relayerID.ID: {relayerID: relayerID},
+			}
+
+			unsignedMessage, err := warp.NewUnsignedMessage(0, sourceBlockchainID, []byte{})
+			require.NoError(t, err)
+			handler := mock_messages.NewMockMessageHandler(ctrl)
+			handler.EXPECT().GetMessageRoutingInfo().Return(
+				sourceBlockchainID,
+				common.Address{},
+				test.destinationBlockchainID,
+				common.Address{},
+				nil,
+			).Times(1)
+			factory := mock_messages.NewMockMessageHandlerFactory(ctrl)
+			factory.EXPECT().NewMessageHandler(unsignedMessage).Return(handler, nil).Times(1)
+
+			messageCoordinator := NewMessageCoordinator(
+				logging.NoLog{},
+				metrics,
+				map[ids.ID]map[common.Address]messages.MessageHandlerFactory{
+					sourceBlockchainID: {protocolAddress: factory},
+				},
+				applicationRelayers,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			routed, err := messageCoordinator.getAppRelayerMessageHandlers(
+				&relayerTypes.WarpMessageInfo{
+					SourceAddress:   protocolAddress,
+					UnsignedMessage: unsignedMessage,
+				},
+			)
+			require.NoError(t, err)
+			if test.expectRelayer {
+				require.Len(t, routed, 1)
+				require.NotNil(t, routed[0].appRelayer)
+				require.Equal(t, handler, routed[0].handler)
+			} else {
+				require.Empty(t, routed)
+			}
+			require.Equal(
+				t,
+				test.expectedUnroutable,
+				testutil.ToFloat64(metrics.unroutableMessageCount.WithLabelValues(sourceBlockchainID.String())),
+			)
+		})
+	}
+}
+
+func TestMaxMessageBytes(t *testing.T) {
+	sourceBlockchainID := ids.GenerateTestID()
+	destinationBlockchainID := ids.GenerateTestID()
+	protocolAddress := common.HexToAddress("0xd81545385803bCD83bd59f58Ba2d2c0562387F83")
+
+	testCases := []struct {
+		name            string
+		payloadBytes    int
+		maxMessageBytes uint64
+		expectRelayer   bool
+	}{
+		{
+			name:            "below maximum",
+			payloadBytes:    1024,
+			maxMessageBytes: 2048,
+			expectRelayer:   true,
+		},
+		{
+			name:            "at maximum",
+			payloadBytes:    2048,
+			maxMessageBytes: 2048,
+			expectRelayer:   true,
+		},
+		{
+			name:            "above maximum",
+			payloadBytes:    2049,
+			maxMessageBytes: 2048,
+			expectRelayer:   false,
+		},
+		{
+			name:          "default maximum",
+			payloadBytes:  2049,
+			expectRelayer: true,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			metrics, err := NewMessageCoordinatorMetrics(prometheus.NewRegistry())
+			require.NoError(t, err)
+
+			relayerID := database.NewRelayerID(
+				sourceBlockchainID,
+				destinationBlockchainID,
+				database.AllAllowedAddress,
+				database.AllAllowedAddress,
+			)
+			applicationRelayers := map[common.Hash]*ApplicationRelayer{
+				relayerID.ID: {
+					relayerID:        relayerID,
+					sourceBlockchain: config.SourceBlockchain{MaxMessageBytes: test.maxMessageBytes},
+				},
+			}
+
+			unsignedMessage, err := warp.NewUnsignedMessage(0, sourceBlockchainID, make([]byte, test.payloadBytes))
+			require.NoError(t, err)
+			factory := mock_messages.NewMockMessageHandlerFactory(ctrl)
+			if test.expectRelayer {
+				handler := mock_messages.NewMockMessageHandler(ctrl)
+				handler.EXPECT().GetMessageRoutingInfo().Return(
+					sourceBlockchainID,
+					common.Address{},
+					destinationBlockchainID,
+					common.Address{},
+					nil,
+				).Times(1)
+				factory.EXPECT().NewMessageHandler(unsignedMessage).Return(handler, nil).Times(1)
+			}
+
+			messageCoordinator := NewMessageCoordinator(
+				logging.NoLog{},
+				metrics,
+				map[ids.ID]map[common.Address]messages.MessageHandlerFactory{
+					sourceBlockchainID: {protocolAddress: factory},
+				},
+				applicationRelayers,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			routed, err := messageCoordinator.getAppRelayerMessageHandlers(
+				&relayerTypes.WarpMessageInfo{
+					SourceAddress:   protocolAddress,
+					UnsignedMessage: unsignedMessage,
+				},
+			)
+			require.NoError(t, err)
+			require.Equal(t, test.expectRelayer, len(routed) == 1)
+		})
+	}
+}
+
+func TestMalformedMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sourceBlockchainID := ids.GenerateTestID()
+	protocolAddress := common.HexToAddress("0xd81545385803bCD83bd59f58Ba2d2c0562387F83")
+
+	metrics, err := NewMessageCoordinatorMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	unsignedMessage, err := warp.NewUnsignedMessage(0, sourceBlockchainID, []byte{})
+	require.NoError(t, err)
+	topics, data, err := subnetWarp.PackSendWarpMessageEvent(
+		protocolAddress,
+		common.Hash(unsignedMessage.ID()),
+		unsignedMessage.Bytes(),
+	)
+	require.NoError(t, err)
+	logs := []types.Log{
+		{Address: subnetWarp.ContractAddress, Topics: topics, Data: []byte{1, 2, 3}},
+		{Address: subnetWarp.ContractAddress, Topics: topics, Data: data},
+		{Address: subnetWarp.ContractAddress, Topics: topics[:2], Data: data},
+	}
+	header := &types.Header{Number: big.NewInt(1)}
+	header.Bloom.Add(relayerTypes.WarpPrecompileLogFilter[:])
+	ethClient := mock_evm.NewMockClient(ctrl)
+	ethClient.EXPECT().FilterLogs(gomock.Any(), gomock.Any()).Return(logs, nil).Times(1)
+
+	// The well-formed message in the block is processed
+	handler := mock_messages.NewMockMessageHandler(ctrl)
+	handler.EXPECT().GetMessageRoutingInfo().Return(
+		sourceBlockchainID,
+		common.Address{},
+		ids.GenerateTestID(),
+		common.Address{},
+		nil,
+	).Times(1)
+	factory := mock_messages.NewMockMessageHandlerFactory(ctrl)
+	factory.EXPECT().NewMessageHandler(gomock.Any()).Return(handler, nil).Times(1)
+
+	messageCoordinator := NewMessageCoordinator(
+		logging.NoLog{},
+		metrics,
+		map[ids.ID]map[common.Address]messages.MessageHandlerFactory{
+			sourceBlockchainID: {protocolAddress: factory},
+		},
+		map[common.Hash]*ApplicationRelayer{},
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	errChan := make(chan error, 1)
+	messageCoordinator.ProcessBlock(sourceBlockchainID, header, evm.NewWarpSourceClient(ethClient), nil, errChan)
+	require.Empty(t, errChan)
+	require.Equal(
+		t,
+		float64(2),
+		testutil.ToFloat64(metrics.malformedMessageCount.WithLabelValues(sourceBlockchainID.String())),
+	)
+}
+
+func TestUnverifiedSourceStake(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sourceBlockchain := config.TestValidSourceBlockchainConfig
+	sourceBlockchain.MinSourceStake = 100
+	destinations := set.Of(config.TestValidDestinationBlockchainConfig.BlockchainID)
+	require.NoError(t, sourceBlockchain.Validate(&destinations))
+	sourceBlockchainID := sourceBlockchain.GetBlockchainID()
+	protocolAddress := common.HexToAddress("0xd81545385803bCD83bd59f58Ba2d2c0562387F83")
+
+	metrics, err := NewMessageCoordinatorMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+	client := &testValidatorSetClient{err: errors.New("p-chain unavailable")}
+	monitor := NewSourceStakeMonitor(
+		logging.NoLog{},
+		metrics,
+		client,
+		&config.Config{SourceBlockchains: []*config.SourceBlockchain{&sourceBlockchain}},
+	)
+	monitor.CheckStake()
+
+	unsignedMessage, err := warp.NewUnsignedMessage(0, sourceBlockchainID, []byte{})
+	require.NoError(t, err)
+	topics, data, err := subnetWarp.PackSendWarpMessageEvent(
+		protocolAddress,
+		common.Hash(unsignedMessage.ID()),
+		unsignedMessage.Bytes(),
+	)
+	require.NoError(t, err)
+	header := &types.Header{Number: big.NewInt(1)}
+	header.Bloom.Add(relayerTypes.WarpPrecompileLogFilter[:])
+	ethClient := mock_evm.NewMockClient(ctrl)
+	ethClient.EXPECT().FilterLogs(gomock.Any(), gomock.Any()).Return(
+		[]types.Log{{Address: subnetWarp.ContractAddress, Topics: topics, Data: data}},
+		nil,
+	).Times(2)
+
+	// The message is neither unpacked nor routed, since the stake is never verified to meet the minimum
+	messageCoordinator := NewMessageCoordinator(
+		logging.NoLog{},
+		metrics,
+		map[ids.ID]map[common.Address]messages.MessageHandlerFactory{
+			sourceBlockchainID: {protocolAddress: mock_messages.NewMockMessageHandlerFactory(ctrl)},
+		},
+		map[common.Hash]*ApplicationRelayer{},
+		nil,
+		monitor,
+		nil,
+		nil,
+	)
+	_, err = messageCoordinator.getAppRelayerMessageHandlers(&relayerTypes.WarpMessageInfo{
+		SourceAddress:   protocolAddress,
+		UnsignedMessage: unsignedMessage,
+	})
+	require.ErrorIs(t, err, errSourceStakeUnverified)
+
+	errChan := make(chan error, 1)
+	processBlock := func() chan struct{} {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			messageCoordinator.ProcessBlock(sourceBlockchainID, header, evm.NewWarpSourceClient(ethClient), nil, errChan)
+		}()
+		return done
+	}
+	isDone := func(done chan struct{}) func() bool {
+		return func() bool {
+			select {
+			case <-done:
+				return true
+			default:
+				return false
+			}
+		}
+	}
+	insecureSourceMessages := func() float64 {
+		return testutil.ToFloat64(metrics.insecureSourceMessageCount.WithLabelValues(sourceBlockchainID.String()))
+	}
+
+	// The block waits for the stake to be verified, and its message is skipped once the stake is verified to be
+	// below the minimum
+	done := processBlock()
+	require.Never(t, isDone(done), 50*time.Millisecond, 10*time.Millisecond)
+	client.err = nil
+	client.totalWeight = 50
+	monitor.CheckStake()
+	require.Eventually(t, isDone(done), time.Second, 10*time.Millisecond)
+	require.Equal(t, float64(1), insecureSourceMessages())
+
+	// A block waiting for the stake to be verified is not processed on shutdown
+	client.err = errors.New("p-chain unavailable")
+	monitor.CheckStake()
+	done = processBlock()
+	require.Never(t, isDone(done), 50*time.Millisecond, 10*time.Millisecond)
+	messageCoordinator.Shutdown(time.Second)
+	require.Eventually(t, isDone(done), time.Second, 10*time.Millisecond)
+	require.Equal(t, float64(1), insecureSourceMessages())
+	require.Empty(t, errChan)
+}
+
+// newStoppableAppRelayer returns an ApplicationRelayer for [relayerID] whose checkpoint manager is subscribed to
+// [ticker]. No heights are committed, so its checkpoint manager does not access the database.
+func newStoppableAppRelayer(relayerID database.RelayerID, ticker *utils.Ticker) *ApplicationRelayer {
+	writeSignal := ticker.Subscribe()
+	checkpointManager := checkpoint.NewCheckpointManager(logging.NoLog{}, nil, writeSignal, relayerID, 0)
+	checkpointManager.Run()
+	return &ApplicationRelayer{
+		relayerID:         relayerID,
+		checkpointManager: checkpointManager,
+		ticker:            ticker,
+		writeSignal:       writeSignal,
+	}
+}
+
+// requireStopped requires that [appRelayer] has been unsubscribed from its ticker if [stopped] is true.
+func requireStopped(t *testing.T, appRelayer *ApplicationRelayer, stopped bool) {
+	select {
+	case _, ok := <-appRelayer.writeSignal:
+		require.Equal(t, stopped, !ok)
+	default:
+		require.False(t, stopped)
+	}
+}
+
+func TestAddRemoveSourceBlockchain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sourceBlockchainID := ids.GenerateTestID()
+	destinationBlockchainID := ids.GenerateTestID()
+	protocolAddress := common.HexToAddress("0xd81545385803bCD83bd59f58Ba2d2c0562387F83")
+
+	metrics, err := NewMessageCoordinatorMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	relayerID := database.NewRelayerID(
+		sourceBlockchainID,
+		destinationBlockchainID,
+		database.AllAllowedAddress,
+		database.AllAllowedAddress,
+	)
+	unsignedMessage, err := warp.NewUnsignedMessage(0, sourceBlockchainID, []byte{})
+	require.NoError(t, err)
+	warpMessageInfo := &relayerTypes.WarpMessageInfo{
+		SourceAddress:   protocolAddress,
+		UnsignedMessage: unsignedMessage,
+	}
+	handler := mock_messages.NewMockMessageHandler(ctrl)
+	handler.EXPECT().GetMessageRoutingInfo().Return(
+		sourceBlockchainID,
+		common.Address{},
+		destinationBlockchainID,
+		common.Address{},
+		nil,
+	).Times(1)
+	factory := mock_messages.NewMockMessageHandlerFactory(ctrl)
+	factory.EXPECT().NewMessageHandler(unsignedMessage).Return(handler, nil).Times(1)
+
+	messageCoordinator := NewMessageCoordinator(
+		logging.NoLog{},
+		metrics,
+		make(map[ids.ID]map[common.Address]messages.MessageHandlerFactory),
+		make(map[common.Hash]*ApplicationRelayer),
+		make(map[ids.ID]ethclient.Client),
+		nil,
+		nil,
+		nil,
+	)
+
+	// Messages from the source are not relayed until it is added
+	routed, err := messageCoordinator.getAppRelayerMessageHandlers(warpMessageInfo)
+	require.NoError(t, err)
+	require.Empty(t, routed)
+
+	appRelayer := newStoppableAppRelayer(relayerID, utils.NewTicker(1))
+	messageCoordinator.AddSourceBlockchain(
+		sourceBlockchainID,
+		map[common.Address]messages.MessageHandlerFactory{protocolAddress: factory},
+		map[common.Hash]*ApplicationRelayer{relayerID.ID: appRelayer},
+		mock_evm.NewMockClient(ctrl),
+	)
+	routed, err = messageCoordinator.getAppRelayerMessageHandlers(warpMessageInfo)
+	require.NoError(t, err)
+	require.Len(t, routed, 1)
+	require.Equal(t, relayerID, routed[0].appRelayer.relayerID)
+	_, ok := messageCoordinator.getSourceClient(sourceBlockchainID)
+	require.True(t, ok)
+	requireStopped(t, appRelayer, false)
+
+	// The removed source's ApplicationRelayers are stopped
+	messageCoordinator.RemoveSourceBlockchain(sourceBlockchainID)
+	requireStopped(t, appRelayer, true)
+	routed, err = messageCoordinator.getAppRelayerMessageHandlers(warpMessageInfo)
+	require.NoError(t, err)
+	require.Empty(t, routed)
+	_, ok = messageCoordinator.getSourceClient(sourceBlockchainID)
+	require.False(t, ok)
+	require.Empty(t, messageCoordinator.applicationRelayers)
+}
+
+func TestAddressAllowlists(t *testing.T) {
+	sourceBlockchainID := ids.GenerateTestID()
+	destinationBlockchainID := ids.GenerateTestID()
+	allowedAddress := common.HexToAddress("0x0123456789abcdef0123456789abcdef01234567")
+	otherAddress := common.HexToAddress("0x27aE10273D17Cd7e80de8580A51f476960626e5f")
+
+	testCases := []struct {
+		name                       string
+		allowedOriginSenderAddress common.Address
+		allowedDestinationAddress  common.Address
+		originSenderAddress        common.Address
+		destinationAddress         common.Address
+		expectRelayer              bool
+	}{
+		{
+			name:                       "allowed origin sender",
+			allowedOriginSenderAddress: allowedAddress,
+			originSenderAddress:        allowedAddress,
+			expectRelayer:              true,
+		},
+		{
+			name:                       "disallowed origin sender",
+			allowedOriginSenderAddress: allowedAddress,
+			originSenderAddress:        otherAddress,
+			expectRelayer:              false,
+		},
+		{
+			name:                      "allowed destination address",
+			allowedDestinationAddress: allowedAddress,
+			destinationAddress:        allowedAddress,
+			expectRelayer:             true,
+		},
+		{
+			name:                      "disallowed destination address",
+			allowedDestinationAddress: allowedAddress,
+			destinationAddress:        otherAddress,
+			expectRelayer:             false,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			metrics, err := NewMessageCoordinatorMetrics(prometheus.NewRegistry())
+			require.NoError(t, err)
+
+			// Relayer IDs are only created for the allowed addresses, as is the case when allowed-origin-sender-addresses
+			// or the supported destination's addresses are configured. The zero value allows all addresses.
+			relayerID := database.NewRelayerID(
+				sourceBlockchainID,
+				destinationBlockchainID,
+				test.allowedOriginSenderAddress,
+				test.allowedDestinationAddress,
+			)
+			applicationRelayers := map[common.Hash]*ApplicationRelayer{
+				relayerID.ID: {relayerID: relayerID},
+			}
+
+			unsignedMessage, err := warp.NewUnsignedMessage(0, sourceBlockchainID, []byte{})
+			require.NoError(t, err)
+			handler := mock_messages.NewMockMessageHandler(ctrl)
+			handler.EXPECT().GetMessageRoutingInfo().Return(
+				sourceBlockchainID,
+				test.originSenderAddress,
+				destinationBlockchainID,
+				test.destinationAddress,
+				nil,
+			).Times(1)
+
+			messageCoordinator := NewMessageCoordinator(
+				logging.NoLog{},
+				metrics,
+				nil,
+				applicationRelayers,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			appRelayer, err := messageCoordinator.getMessageHandlerAppRelayer(
+				handler,
+				&relayerTypes.WarpMessageInfo{UnsignedMessage: unsignedMessage},
+			)
+			require.NoError(t, err)
+			require.Equal(t, test.expectRelayer, appRelayer != nil)
+		})
+	}
+}
+
+// multiPayloadFactory is a synthetic multi-payload message format, in which the Warp message
+// payload is a concatenation of destination blockchain IDs, each of which is a separate payload.
+type multiPayloadFactory struct {
+	*mock_messages.MockMessageHandlerFactory
+	ctrl *gomock.Controller
+}
+
+func (f *multiPayloadFactory) NewMessageHandlers(
+	unsignedMessage *warp.UnsignedMessage,
+) ([]messages.MessageHandler, error) {
+	var handlers []messages.MessageHandler
+	for payload := unsignedMessage.Payload; len(payload) >= ids.IDLen; payload = payload[ids.IDLen:] {
+		destinationBlockchainID, err := ids.ToID(payload[:ids.IDLen])
+		if err != nil {
+			return nil, err
+		}
+		handler := mock_messages.NewMockMessageHandler(f.ctrl)
+		handler.EXPECT().GetMessageRoutingInfo().Return(
+			unsignedMessage.SourceChainID,
+			common.Address{},
+			destinationBlockchainID,
+			common.Address{},
+			nil,
+		).Times(1)
+		handlers = append(handlers, handler)
+	}
+	return handlers, nil
+}
+
+func TestShutdown(t *testing.T) {
+	testCases := []struct {
+		name              string
+		finishMessages    bool
+		expectedDrained   int64
+		expectedAbandoned int64
+	}{
+		{
+			name:            "drained",
+			finishMessages:  true,
+			expectedDrained: 2,
+		},
+		{
+			name:              "timed out",
+			expectedAbandoned: 2,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			metrics, err := NewMessageCoordinatorMetrics(prometheus.NewRegistry())
+			require.NoError(t, err)
+			messageCoordinator := NewMessageCoordinator(
+				logging.NoLog{},
+				metrics,
+				make(map[ids.ID]map[common.Address]messages.MessageHandlerFactory),
+				make(map[common.Hash]*ApplicationRelayer),
+				make(map[ids.ID]ethclient.Client),
+				nil,
+				nil,
+				nil,
+			)
+
+			// A message completes before shutdown, and two are in flight when it begins
+			require.True(t, messageCoordinator.startProcessing(1))
+			messageCoordinator.finishProcessing(1)
+			require.True(t, messageCoordinator.startProcessing(2))
+			if testCase.finishMessages {
+				go func() {
+					time.Sleep(10 * time.Millisecond)
+					messageCoordinator.finishProcessing(2)
+				}()
+			}
+
+			drained, abandoned := messageCoordinator.Shutdown(100 * time.Millisecond)
+			require.Equal(t, testCase.expectedDrained, drained)
+			require.Equal(t, testCase.expectedAbandoned, abandoned)
+
+			// Deliveries are canceled, and no further messages are processed
+			require.Error(t, messageCoordinator.sendCtx.Err())
+			require.False(t, messageCoordinator.startProcessing(1))
+		})
+	}
+}
+
+func TestShutdownShadowDelivery(t *testing.T) {
+	testCases := []struct {
+		name              string
+		finishDelivery    bool
+		expectedDrained   int64
+		expectedAbandoned int64
+	}{
+		{
+			name:            "drained",
+			finishDelivery:  true,
+			expectedDrained: 1,
+		},
+		{
+			name:              "timed out",
+			expectedAbandoned: 1,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			relayerID := database.NewRelayerID(
+				ids.GenerateTestID(),
+				ids.GenerateTestID(),
+				database.AllAllowedAddress,
+				database.AllAllowedAddress,
+			)
+			appRelayerMetrics, err := NewApplicationRelayerMetrics(prometheus.NewRegistry())
+			require.NoError(t, err)
+			appRelayer := newStoppableAppRelayer(relayerID, utils.NewTicker(1))
+			appRelayer.metrics = appRelayerMetrics
+			appRelayer.shadowClient = mock_vms.NewMockDestinationClient(ctrl)
+			metrics, err := NewMessageCoordinatorMetrics(prometheus.NewRegistry())
+			require.NoError(t, err)
+			messageCoordinator := NewMessageCoordinator(
+				logging.NoLog{},
+				metrics,
+				make(map[ids.ID]map[common.Address]messages.MessageHandlerFactory),
+				map[common.Hash]*ApplicationRelayer{relayerID.ID: appRelayer},
+				make(map[ids.ID]ethclient.Client),
+				nil,
+				nil,
+				nil,
+			)
+
+			// The shadow delivery is in flight until it is released, or its context is canceled
+			release := make(chan struct{})
+			canceled := make(chan struct{})
+			handler := mock_messages.NewMockMessageHandler(ctrl)
+			handler.EXPECT().SendMessage(gomock.Any(), gomock.Any(), appRelayer.shadowClient).DoAndReturn(
+				func(ctx context.Context, _ *warp.Message, _ vms.DestinationClient) (common.Hash, error) {
+					select {
+					case <-release:
+						return common.Hash{}, nil
+					case <-ctx.Done():
+						close(canceled)
+						return common.Hash{}, ctx.Err()
+					}
+				},
+			).Times(1)
+			unsignedMessage, err := warp.NewUnsignedMessage(0, relayerID.SourceBlockchainID, nil)
+			require.NoError(t, err)
+			signedMessage, err := warp.NewMessage(unsignedMessage, &warp.BitSetSignature{})
+			require.NoError(t, err)
+			appRelayer.relayShadowMessageAsync(messageCoordinator.sendCtx, logging.NoLog{}, handler, signedMessage)
+
+			if testCase.finishDelivery {
+				go func() {
+					time.Sleep(10 * time.Millisecond)
+					close(release)
+				}()
+			}
+			drained, abandoned := messageCoordinator.Shutdown(100 * time.Millisecond)
+			require.Equal(t, testCase.expectedDrained, drained)
+			require.Equal(t, testCase.expectedAbandoned, abandoned)
+			if !testCase.finishDelivery {
+				<-canceled
+			}
+
+			// No shadow deliveries are started once shutdown has begun
+			appRelayer.relayShadowMessageAsync(messageCoordinator.sendCtx, logging.NoLog{}, handler, signedMessage)
+		})
+	}
+}
+
+func TestMultiPayloadMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sourceBlockchainID := ids.GenerateTestID()
+	destinationIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID()}
+	unconfiguredDestinationID := ids.GenerateTestID()
+	protocolAddress := common.HexToAddress("0xd81545385803bCD83bd59f58Ba2d2c0562387F83")
+
+	metrics, err := NewMessageCoordinatorMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	applicationRelayers := make(map[common.Hash]*ApplicationRelayer)
+	for _, destinationID := range destinationIDs {
+		relayerID := database.NewRelayerID(
+			sourceBlockchainID,
+			destinationID,
+			database.AllAllowedAddress,
+			database.AllAllowedAddress,
+		)
+		applicationRelayers[relayerID.ID] = &ApplicationRelayer{relayerID: relayerID}
+	}
+
+	// A single message carrying a payload for each configured destination, and one for an unconfigured destination
+	var payload []byte
+	for _, destinationID := range append(destinationIDs, unconfiguredDestinationID) {
+		payload = append(payload, destinationID[:]...)
+	}
+	unsignedMessage, err := warp.NewUnsignedMessage(0, sourceBlockchainID, payload)
+	require.NoError(t, err)
+
+	factory := &multiPayloadFactory{
+		MockMessageHandlerFactory: mock_messages.NewMockMessageHandlerFactory(ctrl),
+		ctrl:                      ctrl,
+	}
+	messageCoordinator := NewMessageCoordinator(
+		logging.NoLog{},
+		metrics,
+		map[ids.ID]map[common.Address]messages.MessageHandlerFactory{
+			sourceBlockchainID: {protocolAddress: factory},
+		},
+		applicationRelayers,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	routed, err := messageCoordinator.getAppRelayerMessageHandlers(
+		&relayerTypes.WarpMessageInfo{
+			SourceAddress:   protocolAddress,
+			UnsignedMessage: unsignedMessage,
+		},
+	)
+	require.NoError(t, err)
+
+	// Each configured payload is routed to its own application relayer
+	require.Len(t, routed, len(destinationIDs))
+	for i, r := range routed {
+		require.Equal(t, destinationIDs[i], r.appRelayer.relayerID.DestinationBlockchainID)
+	}
+	require.Equal(
+		t,
+		float64(1),
+		testutil.ToFloat64(metrics.unroutableMessageCount.WithLabelValues(sourceBlockchainID.String())),
+	)
+}
+
+func TestFetchTransactionWarpMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ethClient := mock_evm.NewMockClient(ctrl)
+	sourceBlockchainID := ids.GenerateTestID()
+	sourceAddress := common.HexToAddress("0xd81545385803bCD83bd59f58Ba2d2c0562387F83")
+	txHash := common.HexToHash("0x1234")
+
+	// A transaction that emits an unrelated log followed by two Warp messages
+	receipt := &types.Receipt{
+		Logs: []*types.Log{{Address: sourceAddress}},
+	}
+	var unsignedMessages []*warp.UnsignedMessage
+	for i := 0; i < 2; i++ {
+		unsignedMessage, err := warp.NewUnsignedMessage(0, sourceBlockchainID, []byte{byte(i)})
+		require.NoError(t, err)
+		unsignedMessages = append(unsignedMessages, unsignedMessage)
+		topics, data, err := subnetWarp.PackSendWarpMessageEvent(
+			sourceAddress,
+			common.Hash(unsignedMessage.ID()),
+			unsignedMessage.Bytes(),
+		)
+		require.NoError(t, err)
+		receipt.Logs = append(receipt.Logs, &types.Log{
+			Address: subnetWarp.ContractAddress,
+			Topics:  topics,
+			Data:    data,
+		})
+	}
+	ethClient.EXPECT().TransactionReceipt(gomock.Any(), txHash).Return(receipt, nil).Times(3)
+
+	for i, unsignedMessage := range unsignedMessages {
+		warpMessageInfo, err := FetchTransactionWarpMessage(ethClient, txHash, uint(i))
+		require.NoError(t, err)
+		require.Equal(t, sourceAddress, warpMessageInfo.SourceAddress)
+		require.Equal(t, unsignedMessage.ID(), warpMessageInfo.UnsignedMessage.ID())
+	}
+
+	_, err := FetchTransactionWarpMessage(ethClient, txHash, uint(len(unsignedMessages)))
+	require.Error(t, err)
+}
+
+func TestRecordDeliveryOutcome(t *testing.T) {
+	sourceBlockchainID := ids.GenerateTestID()
+	relayerID := database.NewRelayerID(
+		sourceBlockchainID,
+		ids.GenerateTestID(),
+		database.AllAllowedAddress,
+		database.AllAllowedAddress,
+	)
+	db, err := database.NewJSONFileStorage(logging.NoLog{}, t.TempDir(), []database.RelayerID{relayerID})
+	require.NoError(t, err)
+	deadLetters := database.NewDeadLetterStore(db)
+	metrics, err := NewMessageCoordinatorMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+	appRelayer := &ApplicationRelayer{relayerID: relayerID}
+	messageCoordinator := NewMessageCoordinator(
+		logging.NoLog{},
+		metrics,
+		make(map[ids.ID]map[common.Address]messages.MessageHandlerFactory),
+		map[common.Hash]*ApplicationRelayer{relayerID.ID: appRelayer},
+		make(map[ids.ID]ethclient.Client),
+		nil,
+		nil,
+		deadLetters,
+	)
+
+	unsignedMessage, err := warp.NewUnsignedMessage(0, sourceBlockchainID, []byte{1, 2, 3})
+	require.NoError(t, err)
+	warpMessageInfo := &relayerTypes.WarpMessageInfo{
+		SourceAddress:   common.HexToAddress("0xd81545385803bCD83bd59f58Ba2d2c0562387F83"),
+		UnsignedMessage: unsignedMessage,
+	}
+
+	// Deliveries canceled on shutdown are not recorded
+	messageCoordinator.recordDeliveryOutcome(appRelayer, warpMessageInfo, context.Canceled)
+	list, err := deadLetters.List(relayerID.ID)
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	messageCoordinator.recordDeliveryOutcome(appRelayer, warpMessageInfo, errors.New("failed to send warp message"))
+	deadLetter, err := deadLetters.Get(relayerID.ID, unsignedMessage.ID().String())
+	require.NoError(t, err)
+	require.Equal(t, "failed to send warp message", deadLetter.Reason)
+	require.Equal(t, warpMessageInfo.SourceAddress.Hex(), deadLetter.SourceAddress)
+	require.Equal(t, relayerID.DestinationBlockchainID.String(), deadLetter.DestinationBlockchainID)
+	unsignedMessageBytes, err := hexutil.Decode(deadLetter.UnsignedMessage)
+	require.NoError(t, err)
+	require.Equal(t, unsignedMessage.Bytes(), unsignedMessageBytes)
+
+	// The dead letter is removed once the message is delivered
+	messageCoordinator.recordDeliveryOutcome(appRelayer, warpMessageInfo, nil)
+	_, err = deadLetters.Get(relayerID.ID, unsignedMessage.ID().String())
+	require.ErrorIs(t, err, database.ErrKeyNotFound)
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// PendingMessages tracks the number of messages pending delivery to a destination blockchain, and bounds it by
+// pausing the processing of new source blocks while the limit is reached. Shared by all ApplicationRelayers for
+// the destination blockchain.
+type PendingMessages struct {
+	destinationBlockchainID ids.ID
+	// Zero if the number of pending messages is unlimited
+	maxPendingMessages uint64
+	gauge              prometheus.Gauge
+
+	lock    sync.Mutex
+	pending uint64
+	// Closed and replaced each time messages are removed
+	removed chan struct{}
+}
+
+func NewPendingMessages(
+	metrics *ApplicationRelayerMetrics,
+	destinationBlockchainID ids.ID,
+	maxPendingMessages uint64,
+) *PendingMessages {
+	return &PendingMessages{
+		destinationBlockchainID: destinationBlockchainID,
+		maxPendingMessages:      maxPendingMessages,
+		gauge:                   metrics.pendingMessages.WithLabelValues(destinationBlockchainID.String()),
+		removed:                 make(chan struct{}),
+	}
+}
+
+func (p *PendingMessages) add(numMessages int) {
+	if p == nil || numMessages == 0 {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.pending += uint64(numMessages)
+	p.gauge.Set(float64(p.pending))
+}
+
+func (p *PendingMessages) remove(numMessages int) {
+	if p == nil || numMessages == 0 {
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.pending -= uint64(numMessages)
+	p.gauge.Set(float64(p.pending))
+	close(p.removed)
+	p.removed = make(chan struct{})
+}
+
+// full returns true if the limit on pending messages is reached, along with a channel that is closed once
+// messages are removed.
+func (p *PendingMessages) full() (bool, <-chan struct{}) {
+	if p == nil {
+		return false, nil
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.maxPendingMessages != 0 && p.pending >= p.maxPendingMessages, p.removed
+}
+
+// WaitForPendingMessages blocks while any destination blockchain of [sourceBlockchainID] has reached its limit on
+// pending messages, so that new blocks from the source are not processed until the backlog is delivered.
+// Returns early if [ctx] is canceled.
+func (mc *MessageCoordinator) WaitForPendingMessages(ctx context.Context, sourceBlockchainID ids.ID) error {
+	mc.sourcesLock.RLock()
+	var pendingMessages []*PendingMessages
+	for _, appRelayer := range mc.applicationRelayers {
+		if appRelayer.relayerID.SourceBlockchainID == sourceBlockchainID {
+			pendingMessages = append(pendingMessages, appRelayer.pendingMessages)
+		}
+	}
+	mc.sourcesLock.RUnlock()
+
+	for _, p := range pendingMessages {
+		if full, _ := p.full(); full {
+			mc.logger.Warn(
+				"Destination has reached the maximum number of pending messages. Pausing block processing.",
+				zap.String("sourceBlockchainID", sourceBlockchainID.String()),
+				zap.String("destinationBlockchainID", p.destinationBlockchainID.String()),
+				zap.Uint64("maxPendingMessages", p.maxPendingMessages),
+			)
+		}
+		if err := p.wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// wait blocks until the limit on pending messages is no longer reached, or [ctx] is canceled.
+func (p *PendingMessages) wait(ctx context.Context) error {
+	for {
+		full, removed := p.full()
+		if !full {
+			return nil
+		}
+		select {
+		case <-removed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingMessages(t *testing.T) {
+	metrics, err := NewApplicationRelayerMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+	destinationBlockchainID := ids.GenerateTestID()
+	pendingMessages := NewPendingMessages(metrics, destinationBlockchainID, 3)
+	gauge := metrics.pendingMessages.WithLabelValues(destinationBlockchainID.String())
+
+	// Below the limit, waiting returns immediately
+	pendingMessages.add(2)
+	require.NoError(t, pendingMessages.wait(context.Background()))
+	require.Equal(t, 2.0, testutil.ToFloat64(gauge))
+
+	// At the limit, waiting blocks until enough messages are removed
+	pendingMessages.add(2)
+	require.Equal(t, 4.0, testutil.ToFloat64(gauge))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, pendingMessages.wait(ctx), context.DeadlineExceeded)
+
+	waited := make(chan error)
+	go func() {
+		waited <- pendingMessages.wait(context.Background())
+	}()
+	pendingMessages.remove(1)
+	select {
+	case <-waited:
+		require.FailNow(t, "wait returned while the limit was reached")
+	case <-time.After(10 * time.Millisecond):
+	}
+	pendingMessages.remove(1)
+	require.NoError(t, <-waited)
+	require.Equal(t, 2.0, testutil.ToFloat64(gauge))
+
+	// Unlimited pending messages never block
+	unlimited := NewPendingMessages(metrics, ids.GenerateTestID(), 0)
+	unlimited.add(100)
+	require.NoError(t, unlimited.wait(context.Background()))
+}
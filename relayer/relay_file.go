@@ -0,0 +1,109 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/config"
+	relayerTypes "github.com/ava-labs/awm-relayer/types"
+	"github.com/ava-labs/awm-relayer/utils"
+	"github.com/ava-labs/awm-relayer/vms"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// FileMessage is an unsigned Warp message captured from a source blockchain, to be relayed without
+// subscribing to the source blockchain.
+type FileMessage struct {
+	// cb58-encoded or "0x" prefixed hex-encoded source blockchain ID. Must be a configured source blockchain.
+	SourceBlockchainID string `json:"source-blockchain-id"`
+	// "0x" prefixed hex-encoded address of the contract that sent the message, such as the Teleporter messenger
+	SourceAddress string `json:"source-address"`
+	// Hex-encoded unsigned Warp message, either standalone or as emitted by the Warp precompile
+	UnsignedMessage string `json:"unsigned-message"`
+}
+
+// ReadMessageFile reads the JSON array of FileMessages at [path], and unpacks each message according to
+// the VM of its source blockchain. Returns an error if any message is invalid, so that either all or none
+// of the messages are relayed. Messages exceeding the maximum message size of their source blockchain are skipped.
+func ReadMessageFile(
+	logger logging.Logger,
+	sourceBlockchains []*config.SourceBlockchain,
+	path string,
+) ([]*relayerTypes.WarpMessageInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read message file: %w", err)
+	}
+	var fileMessages []FileMessage
+	if err := json.Unmarshal(data, &fileMessages); err != nil {
+		return nil, fmt.Errorf("failed to decode message file: %w", err)
+	}
+
+	contractMessages := make(map[ids.ID]vms.ContractMessage)
+	for _, sourceBlockchain := range sourceBlockchains {
+		contractMessages[sourceBlockchain.GetBlockchainID()] = vms.NewContractMessage(logger, *sourceBlockchain)
+	}
+
+	warpMessages := make([]*relayerTypes.WarpMessageInfo, 0, len(fileMessages))
+	for i, fileMessage := range fileMessages {
+		warpMessage, err := unpackFileMessage(contractMessages, fileMessage)
+		if errors.Is(err, relayerTypes.ErrMessageTooLarge) {
+			continue
+		}
+		if err != nil {
+			logger.Error(
+				"Invalid message in message file",
+				zap.Int("index", i),
+				zap.Error(err),
+			)
+			return nil, fmt.Errorf("invalid message at index %d: %w", i, err)
+		}
+		warpMessages = append(warpMessages, warpMessage)
+	}
+	return warpMessages, nil
+}
+
+func unpackFileMessage(
+	contractMessages map[ids.ID]vms.ContractMessage,
+	fileMessage FileMessage,
+) (*relayerTypes.WarpMessageInfo, error) {
+	sourceBlockchainID, err := utils.HexOrCB58ToID(fileMessage.SourceBlockchainID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source-blockchain-id '%s': %w", fileMessage.SourceBlockchainID, err)
+	}
+	contractMessage, ok := contractMessages[sourceBlockchainID]
+	if !ok {
+		return nil, fmt.Errorf("source blockchain %s is not configured", sourceBlockchainID)
+	}
+	if !common.IsHexAddress(fileMessage.SourceAddress) {
+		return nil, fmt.Errorf("invalid source-address '%s'", fileMessage.SourceAddress)
+	}
+	unsignedMessageBytes, err := hex.DecodeString(utils.SanitizeHexString(fileMessage.UnsignedMessage))
+	if err != nil {
+		return nil, fmt.Errorf("invalid unsigned-message hex: %w", err)
+	}
+	unsignedMessage, err := contractMessage.UnpackWarpMessage(unsignedMessageBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack unsigned-message: %w", err)
+	}
+	if unsignedMessage.SourceChainID != sourceBlockchainID {
+		return nil, fmt.Errorf(
+			"unsigned-message was sent from blockchain %s, not source-blockchain-id %s",
+			unsignedMessage.SourceChainID,
+			sourceBlockchainID,
+		)
+	}
+	return &relayerTypes.WarpMessageInfo{
+		SourceAddress:   common.HexToAddress(fileMessage.SourceAddress),
+		UnsignedMessage: unsignedMessage,
+	}, nil
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadMessageFile(t *testing.T) {
+	sourceBlockchain := config.TestValidSourceBlockchainConfig
+	destinations := set.Of(config.TestValidDestinationBlockchainConfig.BlockchainID)
+	require.NoError(t, sourceBlockchain.Validate(&destinations))
+	sourceBlockchainID := sourceBlockchain.GetBlockchainID()
+	sourceAddress := common.HexToAddress("0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf")
+
+	unsignedMessage, err := avalancheWarp.NewUnsignedMessage(1, sourceBlockchainID, []byte{1, 2, 3})
+	require.NoError(t, err)
+	otherSourceMessage, err := avalancheWarp.NewUnsignedMessage(1, ids.GenerateTestID(), []byte{1, 2, 3})
+	require.NoError(t, err)
+
+	validMessage := FileMessage{
+		SourceBlockchainID: sourceBlockchainID.String(),
+		SourceAddress:      sourceAddress.Hex(),
+		UnsignedMessage:    "0x" + hex.EncodeToString(unsignedMessage.Bytes()),
+	}
+
+	testCases := []struct {
+		name         string
+		fileMessages []FileMessage
+		expectedErr  bool
+	}{
+		{
+			name:         "valid",
+			fileMessages: []FileMessage{validMessage, validMessage},
+		},
+		{
+			name:         "empty",
+			fileMessages: []FileMessage{},
+		},
+		{
+			name: "unconfigured source blockchain",
+			fileMessages: []FileMessage{validMessage, func() FileMessage {
+				m := validMessage
+				m.SourceBlockchainID = ids.GenerateTestID().String()
+				return m
+			}()},
+			expectedErr: true,
+		},
+		{
+			name: "invalid source address",
+			fileMessages: []FileMessage{func() FileMessage {
+				m := validMessage
+				m.SourceAddress = "0x1234"
+				return m
+			}()},
+			expectedErr: true,
+		},
+		{
+			name: "invalid unsigned message",
+			fileMessages: []FileMessage{func() FileMessage {
+				m := validMessage
+				m.UnsignedMessage = "0x1234"
+				return m
+			}()},
+			expectedErr: true,
+		},
+		{
+			name: "unsigned message from another blockchain",
+			fileMessages: []FileMessage{func() FileMessage {
+				m := validMessage
+				m.UnsignedMessage = hex.EncodeToString(otherSourceMessage.Bytes())
+				return m
+			}()},
+			expectedErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			data, err := json.Marshal(testCase.fileMessages)
+			require.NoError(t, err)
+			path := filepath.Join(t.TempDir(), "messages.json")
+			require.NoError(t, os.WriteFile(path, data, 0o600))
+
+			warpMessages, err := ReadMessageFile(
+				logging.NoLog{},
+				[]*config.SourceBlockchain{&sourceBlockchain},
+				path,
+			)
+			if testCase.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, warpMessages, len(testCase.fileMessages))
+			for _, warpMessage := range warpMessages {
+				require.Equal(t, sourceAddress, warpMessage.SourceAddress)
+				require.Equal(t, unsignedMessage.ID(), warpMessage.UnsignedMessage.ID())
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"go.uber.org/zap"
+)
+
+var errUnknownRoute = errors.New("relayer is not configured to relay from the source to the destination blockchain")
+
+// RouteStatus is the runtime state of the relay of messages from a source blockchain to a destination blockchain
+type RouteStatus struct {
+	SourceBlockchainID      ids.ID `json:"source-blockchain-id"`
+	DestinationBlockchainID ids.ID `json:"destination-blockchain-id"`
+	Paused                  bool   `json:"paused"`
+}
+
+// pause stops the ApplicationRelayer from sending messages until resume is called
+func (r *ApplicationRelayer) pause() {
+	r.pauseLock.Lock()
+	defer r.pauseLock.Unlock()
+	if r.resumed == nil {
+		r.resumed = make(chan struct{})
+	}
+}
+
+// resume allows the ApplicationRelayer to send messages, releasing any buffered while paused
+func (r *ApplicationRelayer) resume() {
+	r.pauseLock.Lock()
+	defer r.pauseLock.Unlock()
+	if r.resumed != nil {
+		close(r.resumed)
+		r.resumed = nil
+	}
+}
+
+// pausedUntil returns a channel that is closed once the ApplicationRelayer is resumed, or nil if it is not paused
+func (r *ApplicationRelayer) pausedUntil() <-chan struct{} {
+	r.pauseLock.Lock()
+	defer r.pauseLock.Unlock()
+	return r.resumed
+}
+
+// waitIfPaused is consulted before sending each message. If the route is not paused, returns true.
+// Otherwise, if paused routes skip their messages, returns false. If paused routes buffer their messages,
+// blocks until the route is resumed and returns true, or returns [ctx]'s error if it is canceled first.
+func (r *ApplicationRelayer) waitIfPaused(ctx context.Context, logger logging.Logger) (bool, error) {
+	resumed := r.pausedUntil()
+	if resumed == nil {
+		return true, nil
+	}
+	if r.skipPaused {
+		logger.Info("Route is paused. Skipping message")
+		return false, nil
+	}
+	logger.Info("Route is paused. Waiting for it to be resumed")
+	select {
+	case <-resumed:
+		logger.Info("Route resumed")
+		return true, nil
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// PauseRoute pauses the relay of messages from [sourceBlockchainID] to [destinationBlockchainID] until
+// ResumeRoute is called. Messages already being delivered are unaffected. The pause also applies to the
+// ApplicationRelayers for the route created if the configuration is reloaded.
+func (mc *MessageCoordinator) PauseRoute(sourceBlockchainID ids.ID, destinationBlockchainID ids.ID) error {
+	return mc.setRoutePaused(sourceBlockchainID, destinationBlockchainID, true)
+}
+
+// ResumeRoute resumes the relay of messages from [sourceBlockchainID] to [destinationBlockchainID].
+func (mc *MessageCoordinator) ResumeRoute(sourceBlockchainID ids.ID, destinationBlockchainID ids.ID) error {
+	return mc.setRoutePaused(sourceBlockchainID, destinationBlockchainID, false)
+}
+
+func (mc *MessageCoordinator) setRoutePaused(
+	sourceBlockchainID ids.ID,
+	destinationBlockchainID ids.ID,
+	paused bool,
+) error {
+	mc.sourcesLock.Lock()
+	defer mc.sourcesLock.Unlock()
+	destinations := mc.destinations[sourceBlockchainID]
+	if !destinations.Contains(destinationBlockchainID) {
+		return errUnknownRoute
+	}
+	route := [2]ids.ID{sourceBlockchainID, destinationBlockchainID}
+	if paused {
+		mc.pausedRoutes.Add(route)
+	} else {
+		mc.pausedRoutes.Remove(route)
+	}
+	for _, appRelayer := range mc.applicationRelayers {
+		if appRelayer.relayerID.SourceBlockchainID != sourceBlockchainID ||
+			appRelayer.relayerID.DestinationBlockchainID != destinationBlockchainID {
+			continue
+		}
+		if paused {
+			appRelayer.pause()
+		} else {
+			appRelayer.resume()
+		}
+	}
+	mc.logger.Info(
+		"Updated route pause state",
+		zap.String("sourceBlockchainID", sourceBlockchainID.String()),
+		zap.String("destinationBlockchainID", destinationBlockchainID.String()),
+		zap.Bool("paused", paused),
+	)
+	return nil
+}
+
+// Routes returns the state of each route the relayer is configured to relay messages along,
+// ordered by source and then destination blockchain ID.
+func (mc *MessageCoordinator) Routes() []RouteStatus {
+	mc.sourcesLock.RLock()
+	defer mc.sourcesLock.RUnlock()
+	var routes []RouteStatus
+	for sourceBlockchainID, destinations := range mc.destinations {
+		for destinationBlockchainID := range destinations {
+			routes = append(routes, RouteStatus{
+				SourceBlockchainID:      sourceBlockchainID,
+				DestinationBlockchainID: destinationBlockchainID,
+				Paused:                  mc.pausedRoutes.Contains([2]ids.ID{sourceBlockchainID, destinationBlockchainID}),
+			})
+		}
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if c := routes[i].SourceBlockchainID.Compare(routes[j].SourceBlockchainID); c != 0 {
+			return c < 0
+		}
+		return routes[i].DestinationBlockchainID.Compare(routes[j].DestinationBlockchainID) < 0
+	})
+	return routes
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/database"
+	"github.com/ava-labs/awm-relayer/messages"
+	"github.com/ava-labs/awm-relayer/utils"
+	mock_evm "github.com/ava-labs/awm-relayer/vms/evm/mocks"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPauseRoute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sourceBlockchainID := ids.GenerateTestID()
+	destinationBlockchainID := ids.GenerateTestID()
+	otherDestinationBlockchainID := ids.GenerateTestID()
+
+	newRelayerID := func(destinationBlockchainID ids.ID) database.RelayerID {
+		return database.NewRelayerID(
+			sourceBlockchainID,
+			destinationBlockchainID,
+			database.AllAllowedAddress,
+			database.AllAllowedAddress,
+		)
+	}
+	relayerID := newRelayerID(destinationBlockchainID)
+	otherRelayerID := newRelayerID(otherDestinationBlockchainID)
+	ticker := utils.NewTicker(1)
+	appRelayer := newStoppableAppRelayer(relayerID, ticker)
+	otherAppRelayer := newStoppableAppRelayer(otherRelayerID, ticker)
+
+	messageCoordinator := NewMessageCoordinator(
+		logging.NoLog{},
+		nil,
+		make(map[ids.ID]map[common.Address]messages.MessageHandlerFactory),
+		map[common.Hash]*ApplicationRelayer{relayerID.ID: appRelayer, otherRelayerID.ID: otherAppRelayer},
+		make(map[ids.ID]ethclient.Client),
+		nil,
+		nil,
+		nil,
+	)
+
+	require.ErrorIs(t, messageCoordinator.PauseRoute(destinationBlockchainID, sourceBlockchainID), errUnknownRoute)
+	require.NoError(t, messageCoordinator.PauseRoute(sourceBlockchainID, destinationBlockchainID))
+	require.NotNil(t, appRelayer.pausedUntil())
+	require.Nil(t, otherAppRelayer.pausedUntil())
+	for _, route := range messageCoordinator.Routes() {
+		require.Equal(t, sourceBlockchainID, route.SourceBlockchainID)
+		require.Equal(t, route.DestinationBlockchainID == destinationBlockchainID, route.Paused)
+	}
+
+	// Application relayers created for a paused route when the configuration is reloaded are paused, and the
+	// replaced application relayers are stopped
+	reloadedAppRelayer := newStoppableAppRelayer(relayerID, ticker)
+	messageCoordinator.AddSourceBlockchain(
+		sourceBlockchainID,
+		make(map[common.Address]messages.MessageHandlerFactory),
+		map[common.Hash]*ApplicationRelayer{relayerID.ID: reloadedAppRelayer},
+		mock_evm.NewMockClient(ctrl),
+	)
+	resumed := reloadedAppRelayer.pausedUntil()
+	require.NotNil(t, resumed)
+	requireStopped(t, appRelayer, true)
+	requireStopped(t, otherAppRelayer, true)
+	requireStopped(t, reloadedAppRelayer, false)
+
+	require.NoError(t, messageCoordinator.ResumeRoute(sourceBlockchainID, destinationBlockchainID))
+	require.Nil(t, reloadedAppRelayer.pausedUntil())
+	require.Len(t, messageCoordinator.Routes(), 1)
+	require.False(t, messageCoordinator.Routes()[0].Paused)
+	select {
+	case <-resumed:
+	default:
+		require.FailNow(t, "buffered messages not released on resume")
+	}
+}
+
+func TestWaitIfPaused(t *testing.T) {
+	testCases := []struct {
+		name           string
+		paused         bool
+		skipPaused     bool
+		resume         bool
+		expectResumed  bool
+		expectCanceled bool
+	}{
+		{
+			name:          "not paused",
+			expectResumed: true,
+		},
+		{
+			name:       "paused route skips",
+			paused:     true,
+			skipPaused: true,
+		},
+		{
+			name:          "paused route buffers until resumed",
+			paused:        true,
+			resume:        true,
+			expectResumed: true,
+		},
+		{
+			name:           "paused route buffers until canceled",
+			paused:         true,
+			expectCanceled: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			r := &ApplicationRelayer{skipPaused: test.skipPaused}
+			if test.paused {
+				r.pause()
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			if test.resume {
+				go func() {
+					time.Sleep(10 * time.Millisecond)
+					r.resume()
+				}()
+			}
+
+			resumed, err := r.waitIfPaused(ctx, logging.NoLog{})
+			require.Equal(t, test.expectResumed, resumed)
+			if test.expectCanceled {
+				require.ErrorIs(t, err, context.DeadlineExceeded)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
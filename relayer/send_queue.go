@@ -0,0 +1,150 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"container/heap"
+	"context"
+	"math/big"
+	"sync"
+)
+
+// SendQueue limits the number of messages being sent to a destination blockchain at once. Messages waiting for a
+// send slot are admitted in order of priority, highest first, so that during a backlog the most valuable messages
+// are delivered first. Messages of equal priority, or all messages if prioritization is disabled, are admitted in
+// the order they arrived. Shared by all ApplicationRelayers for the destination blockchain.
+type SendQueue struct {
+	prioritize bool
+
+	lock    sync.Mutex
+	slots   uint64
+	waiters sendWaiters
+	// Incremented for each waiter, to admit waiters of equal priority in arrival order
+	sequence uint64
+}
+
+func NewSendQueue(maxConcurrentSends uint64, prioritize bool) *SendQueue {
+	return &SendQueue{
+		prioritize: prioritize,
+		slots:      maxConcurrentSends,
+	}
+}
+
+// acquire blocks until a send slot is available to the message, and there are no waiting messages that take
+// precedence over it. [getPriority] is only called if the message must wait and prioritization is enabled, and
+// may return nil for the lowest priority. Returns [ctx]'s error if it is canceled first.
+// Each successful call must be followed by a call to release.
+func (q *SendQueue) acquire(ctx context.Context, getPriority func() *big.Int) error {
+	q.lock.Lock()
+	if q.slots > 0 && len(q.waiters) == 0 {
+		q.slots--
+		q.lock.Unlock()
+		return nil
+	}
+	q.lock.Unlock()
+
+	// Determine the priority without holding the lock, since it may require a network request
+	var priority *big.Int
+	if q.prioritize && getPriority != nil {
+		priority = getPriority()
+	}
+	if priority == nil {
+		priority = new(big.Int)
+	}
+
+	q.lock.Lock()
+	if q.slots > 0 && len(q.waiters) == 0 {
+		q.slots--
+		q.lock.Unlock()
+		return nil
+	}
+	waiter := &sendWaiter{
+		priority: priority,
+		sequence: q.sequence,
+		ready:    make(chan struct{}),
+	}
+	q.sequence++
+	heap.Push(&q.waiters, waiter)
+	q.lock.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return nil
+	case <-ctx.Done():
+		q.lock.Lock()
+		defer q.lock.Unlock()
+		select {
+		case <-waiter.ready:
+			// The slot was handed to the waiter concurrently with the cancellation, so pass it on
+			q.releaseLocked()
+		default:
+			heap.Remove(&q.waiters, waiter.index)
+		}
+		return ctx.Err()
+	}
+}
+
+// release returns a send slot, handing it to the waiting message of highest precedence if there is one
+func (q *SendQueue) release() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.releaseLocked()
+}
+
+func (q *SendQueue) releaseLocked() {
+	if len(q.waiters) == 0 {
+		q.slots++
+		return
+	}
+	waiter := heap.Pop(&q.waiters).(*sendWaiter)
+	close(waiter.ready)
+}
+
+func (q *SendQueue) numWaiters() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return len(q.waiters)
+}
+
+type sendWaiter struct {
+	priority *big.Int
+	sequence uint64
+	// Closed once the waiter is admitted
+	ready chan struct{}
+	// Index in the heap, maintained by sendWaiters
+	index int
+}
+
+// sendWaiters implements heap.Interface, ordered by descending priority and then ascending sequence
+type sendWaiters []*sendWaiter
+
+func (w sendWaiters) Len() int { return len(w) }
+
+func (w sendWaiters) Less(i, j int) bool {
+	if c := w[i].priority.Cmp(w[j].priority); c != 0 {
+		return c > 0
+	}
+	return w[i].sequence < w[j].sequence
+}
+
+func (w sendWaiters) Swap(i, j int) {
+	w[i], w[j] = w[j], w[i]
+	w[i].index = i
+	w[j].index = j
+}
+
+func (w *sendWaiters) Push(x any) {
+	waiter := x.(*sendWaiter)
+	waiter.index = len(*w)
+	*w = append(*w, waiter)
+}
+
+func (w *sendWaiters) Pop() any {
+	old := *w
+	n := len(old)
+	waiter := old[n-1]
+	old[n-1] = nil
+	*w = old[:n-1]
+	return waiter
+}
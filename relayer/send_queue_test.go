@@ -0,0 +1,84 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendQueue(t *testing.T) {
+	testCases := []struct {
+		name       string
+		prioritize bool
+		// Priorities of the messages that wait for a send slot, in order of arrival. A nil priority is the lowest.
+		priorities []*big.Int
+		// Indices of the messages that are canceled while waiting
+		canceled []int
+		// Indices of the messages, in the order they are sent
+		expectedOrder []int
+	}{
+		{
+			name:          "prioritized",
+			prioritize:    true,
+			priorities:    []*big.Int{big.NewInt(1), big.NewInt(3), nil, big.NewInt(3), big.NewInt(2)},
+			expectedOrder: []int{1, 3, 4, 0, 2},
+		},
+		{
+			name:          "fifo",
+			priorities:    []*big.Int{big.NewInt(1), big.NewInt(3), nil, big.NewInt(3), big.NewInt(2)},
+			expectedOrder: []int{0, 1, 2, 3, 4},
+		},
+		{
+			name:          "canceled",
+			prioritize:    true,
+			priorities:    []*big.Int{big.NewInt(1), big.NewInt(3), big.NewInt(2)},
+			canceled:      []int{1},
+			expectedOrder: []int{2, 0},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			queue := NewSendQueue(1, test.prioritize)
+			// Hold the only slot, so that each message waits
+			require.NoError(t, queue.acquire(context.Background(), nil))
+
+			sent := make(chan int, len(test.priorities))
+			cancels := make([]context.CancelFunc, len(test.priorities))
+			errs := make(chan error, len(test.priorities))
+			for i, priority := range test.priorities {
+				i, priority := i, priority
+				ctx, cancel := context.WithCancel(context.Background())
+				cancels[i] = cancel
+				go func() {
+					if err := queue.acquire(ctx, func() *big.Int { return priority }); err != nil {
+						errs <- err
+						return
+					}
+					sent <- i
+				}()
+				// Wait for the message to be queued, so that messages arrive in order
+				require.Eventually(t, func() bool { return queue.numWaiters() == i+1 }, time.Second, time.Millisecond)
+			}
+			for _, i := range test.canceled {
+				cancels[i]()
+				require.ErrorIs(t, <-errs, context.Canceled)
+			}
+
+			var order []int
+			for range test.expectedOrder {
+				queue.release()
+				i := <-sent
+				order = append(order, i)
+			}
+			require.Equal(t, test.expectedOrder, order)
+			require.Zero(t, queue.numWaiters())
+		})
+	}
+}
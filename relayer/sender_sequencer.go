@@ -0,0 +1,68 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// senderSequencer serializes the processing of messages sharing an origin sender in the order in which they are
+// registered, while messages from different senders are processed concurrently.
+type senderSequencer struct {
+	lock sync.Mutex
+	// The ticket most recently registered for each sender with a message that has not finished processing
+	last map[common.Address]*sequenceTicket
+}
+
+// sequenceTicket orders a message relative to the other messages from its origin sender
+type sequenceTicket struct {
+	sequencer *senderSequencer
+	sender    common.Address
+	// Closed once the previous message from the sender has finished processing. Nil if there is none.
+	prev <-chan struct{}
+	done chan struct{}
+}
+
+func newSenderSequencer() *senderSequencer {
+	return &senderSequencer{
+		last: make(map[common.Address]*sequenceTicket),
+	}
+}
+
+// register returns the ticket of the next message from [sender]. Messages must be registered in the order
+// in which they are to be processed.
+func (s *senderSequencer) register(sender common.Address) *sequenceTicket {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	ticket := &sequenceTicket{
+		sequencer: s,
+		sender:    sender,
+		done:      make(chan struct{}),
+	}
+	if prev, ok := s.last[sender]; ok {
+		ticket.prev = prev.done
+	}
+	s.last[sender] = ticket
+	return ticket
+}
+
+// wait blocks until the previous message from the ticket's sender has finished processing.
+func (t *sequenceTicket) wait() {
+	if t.prev != nil {
+		<-t.prev
+	}
+}
+
+// release marks the ticket's message as finished processing, whether or not it was delivered, allowing
+// the next message from its sender to be processed.
+func (t *sequenceTicket) release() {
+	t.sequencer.lock.Lock()
+	defer t.sequencer.lock.Unlock()
+	if t.sequencer.last[t.sender] == t {
+		delete(t.sequencer.last, t.sender)
+	}
+	close(t.done)
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSenderSequencer(t *testing.T) {
+	senderA := common.HexToAddress("0x01")
+	senderB := common.HexToAddress("0x02")
+	sequencer := newSenderSequencer()
+
+	a1 := sequencer.register(senderA)
+	b1 := sequencer.register(senderB)
+	a2 := sequencer.register(senderA)
+	a3 := sequencer.register(senderA)
+
+	waited := func(ticket *sequenceTicket) bool {
+		waitDone := make(chan struct{})
+		go func() {
+			ticket.wait()
+			close(waitDone)
+		}()
+		select {
+		case <-waitDone:
+			return true
+		case <-time.After(50 * time.Millisecond):
+			return false
+		}
+	}
+
+	// The first message from each sender is not delayed
+	require.True(t, waited(a1))
+	require.True(t, waited(b1))
+	// Later messages wait for the previous message from their sender
+	require.False(t, waited(a2))
+	b1.release()
+	require.False(t, waited(a2))
+	a1.release()
+	require.True(t, waited(a2))
+	require.False(t, waited(a3))
+	a2.release()
+	require.True(t, waited(a3))
+	a3.release()
+
+	// Released senders are no longer tracked
+	require.Empty(t, sequencer.last)
+	require.True(t, waited(sequencer.register(senderA)))
+}
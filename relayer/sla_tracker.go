@@ -0,0 +1,78 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/awm-relayer/config"
+)
+
+type slaSample struct {
+	timestamp time.Time
+	withinSLA bool
+}
+
+// slaTracker tracks delivery latencies over a trailing window, and determines whether the
+// configured delivery SLA is met.
+type slaTracker struct {
+	maxLatency time.Duration
+	target     float64
+	window     time.Duration
+	samples    []slaSample
+	breached   bool
+	lock       *sync.Mutex
+}
+
+func newSLATracker(sla *config.DeliverySLA) *slaTracker {
+	return &slaTracker{
+		maxLatency: time.Duration(sla.MaxLatencySeconds) * time.Second,
+		target:     sla.TargetPercentage / 100,
+		window:     time.Duration(sla.WindowSeconds) * time.Second,
+		lock:       &sync.Mutex{},
+	}
+}
+
+// recordDelivery records a delivery with the given latency at time [now]. Returns the fraction of deliveries
+// within the window that met the SLA, whether the SLA is breached, and whether the breach state changed.
+func (t *slaTracker) recordDelivery(now time.Time, latency time.Duration) (float64, bool, bool) {
+	return t.record(now, latency <= t.maxLatency)
+}
+
+// recordFailure records a failed delivery at time [now], which counts against the SLA.
+func (t *slaTracker) recordFailure(now time.Time) (float64, bool, bool) {
+	return t.record(now, false)
+}
+
+func (t *slaTracker) record(now time.Time, withinSLA bool) (float64, bool, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.samples = append(t.samples, slaSample{
+		timestamp: now,
+		withinSLA: withinSLA,
+	})
+
+	// Evict samples that have fallen out of the window. Samples are appended in time order.
+	cutoff := now.Add(-t.window)
+	evict := 0
+	for evict < len(t.samples) && t.samples[evict].timestamp.Before(cutoff) {
+		evict++
+	}
+	t.samples = t.samples[evict:]
+
+	numWithinSLA := 0
+	for _, sample := range t.samples {
+		if sample.withinSLA {
+			numWithinSLA++
+		}
+	}
+	compliance := float64(numWithinSLA) / float64(len(t.samples))
+
+	breached := compliance < t.target
+	changed := breached != t.breached
+	t.breached = breached
+	return compliance, breached, changed
+}
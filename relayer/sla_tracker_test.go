@@ -0,0 +1,47 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSLATracker(t *testing.T) {
+	tracker := newSLATracker(&config.DeliverySLA{
+		MaxLatencySeconds: 30,
+		TargetPercentage:  75,
+		WindowSeconds:     60,
+	})
+	start := time.Unix(1_000_000, 0)
+
+	// Deliveries within the SLA
+	for i := 0; i < 3; i++ {
+		compliance, breached, changed := tracker.recordDelivery(start.Add(time.Duration(i)*time.Second), time.Second)
+		require.Equal(t, 1.0, compliance)
+		require.False(t, breached)
+		require.False(t, changed)
+	}
+
+	// A slow delivery brings compliance to 75%, which still meets the target
+	compliance, breached, changed := tracker.recordDelivery(start.Add(3*time.Second), time.Minute)
+	require.Equal(t, 0.75, compliance)
+	require.False(t, breached)
+	require.False(t, changed)
+
+	// A failure breaches the SLA
+	compliance, breached, changed = tracker.recordFailure(start.Add(4 * time.Second))
+	require.Equal(t, 0.6, compliance)
+	require.True(t, breached)
+	require.True(t, changed)
+
+	// Once the earlier samples fall out of the window, the SLA is met again
+	compliance, breached, changed = tracker.recordDelivery(start.Add(2*time.Minute), time.Second)
+	require.Equal(t, 1.0, compliance)
+	require.False(t, breached)
+	require.True(t, changed)
+}
@@ -0,0 +1,243 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/awm-relayer/config"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+const (
+	sourceStakeRefreshInterval = 5 * time.Minute
+	// Sources whose stake could not be verified are checked again sooner, since their messages wait until then
+	sourceStakeRetryInterval = 30 * time.Second
+)
+
+var errSourceStakeUnverified = errors.New("source subnet stake has not been verified")
+
+// ValidatorSetClient fetches the current canonical validator set of a subnet from the P-Chain
+type ValidatorSetClient interface {
+	GetCurrentCanonicalValidatorSet(subnetID ids.ID) ([]*avalancheWarp.Validator, uint64, error)
+}
+
+type monitoredSource struct {
+	subnetID ids.ID
+	minStake uint64
+	// lock guards updates to secure and verified, so that they are read consistently
+	lock sync.RWMutex
+	// Whether the most recent check verified the source's stake to be at least minStake
+	secure *atomic.Bool
+	// Closed once the most recent check succeeds. Replaced by an open channel if a check fails.
+	verified chan struct{}
+}
+
+// status returns whether the source's stake was verified by the most recent check, and if so, whether it
+// meets the minimum.
+func (s *monitoredSource) status() (bool, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	select {
+	case <-s.verified:
+		return true, s.secure.Load()
+	default:
+		return false, false
+	}
+}
+
+// SourceStakeMonitor tracks whether the total stake of each source subnet with a configured minimum stake
+// meets that minimum. Messages from sources below their minimum are skipped. Until a source's stake is verified,
+// or if the most recent check failed, its stake is unverified, and its messages wait to be relayed until it is
+// verified.
+type SourceStakeMonitor struct {
+	logger          logging.Logger
+	metrics         *MessageCoordinatorMetrics
+	validatorClient ValidatorSetClient
+	sources         map[ids.ID]*monitoredSource
+}
+
+// NewSourceStakeMonitor returns a monitor for the source blockchains that configure a minimum stake,
+// or nil if there are none.
+func NewSourceStakeMonitor(
+	logger logging.Logger,
+	metrics *MessageCoordinatorMetrics,
+	validatorClient ValidatorSetClient,
+	cfg *config.Config,
+) *SourceStakeMonitor {
+	sources := make(map[ids.ID]*monitoredSource)
+	for _, sourceBlockchain := range cfg.SourceBlockchains {
+		if sourceBlockchain.MinSourceStake == 0 {
+			continue
+		}
+		sources[sourceBlockchain.GetBlockchainID()] = &monitoredSource{
+			subnetID: sourceBlockchain.GetSubnetID(),
+			minStake: sourceBlockchain.MinSourceStake,
+			secure:   atomic.NewBool(false),
+			verified: make(chan struct{}),
+		}
+	}
+	if len(sources) == 0 {
+		return nil
+	}
+	return &SourceStakeMonitor{
+		logger:          logger,
+		metrics:         metrics,
+		validatorClient: validatorClient,
+		sources:         sources,
+	}
+}
+
+// IsSecure returns false if the source blockchain's stake is below its configured minimum, or is unverified.
+// Sources without a configured minimum are always secure.
+func (m *SourceStakeMonitor) IsSecure(sourceBlockchainID ids.ID) bool {
+	_, secure := m.status(sourceBlockchainID)
+	return secure
+}
+
+// status returns whether the source blockchain's stake is verified, and if so, whether it meets the minimum.
+// Sources without a configured minimum are always verified and secure.
+func (m *SourceStakeMonitor) status(sourceBlockchainID ids.ID) (bool, bool) {
+	if m == nil {
+		return true, true
+	}
+	source, ok := m.sources[sourceBlockchainID]
+	if !ok {
+		return true, true
+	}
+	return source.status()
+}
+
+// waitVerified blocks until the source blockchain's stake is verified. Returns false if [shutdownChan] is closed
+// first.
+func (m *SourceStakeMonitor) waitVerified(sourceBlockchainID ids.ID, shutdownChan <-chan struct{}) bool {
+	if m == nil {
+		return true
+	}
+	source, ok := m.sources[sourceBlockchainID]
+	if !ok {
+		return true
+	}
+	source.lock.RLock()
+	verified := source.verified
+	source.lock.RUnlock()
+	select {
+	case <-verified:
+		return true
+	case <-shutdownChan:
+		return false
+	}
+}
+
+// Health returns the security status of each monitored source blockchain, for use by the health check.
+func (m *SourceStakeMonitor) Health() map[ids.ID]*atomic.Bool {
+	if m == nil {
+		return nil
+	}
+	health := make(map[ids.ID]*atomic.Bool, len(m.sources))
+	for blockchainID, source := range m.sources {
+		health[blockchainID] = source.secure
+	}
+	return health
+}
+
+// Run periodically re-checks the stake of each monitored source until the context is canceled. If the stake
+// of any source is unverified, it is checked again sooner.
+func (m *SourceStakeMonitor) Run(ctx context.Context) {
+	timer := time.NewTimer(m.checkInterval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			m.CheckStake()
+			timer.Reset(m.checkInterval())
+		}
+	}
+}
+
+// checkInterval returns the time to wait before the next check of the monitored sources' stake.
+func (m *SourceStakeMonitor) checkInterval() time.Duration {
+	for _, source := range m.sources {
+		if verified, _ := source.status(); !verified {
+			return sourceStakeRetryInterval
+		}
+	}
+	return sourceStakeRefreshInterval
+}
+
+// CheckStake fetches the current stake of each monitored source from the P-Chain. If the stake of a source
+// cannot be fetched, it is unverified until the next successful check.
+func (m *SourceStakeMonitor) CheckStake() {
+	for blockchainID, source := range m.sources {
+		_, totalWeight, err := m.validatorClient.GetCurrentCanonicalValidatorSet(source.subnetID)
+		if err != nil {
+			m.logger.Warn(
+				"Failed to get source subnet validator set. Messages from this source will not be relayed "+
+					"until its stake is verified.",
+				zap.String("sourceBlockchainID", blockchainID.String()),
+				zap.String("subnetID", source.subnetID.String()),
+				zap.Error(err),
+			)
+			source.setUnverified()
+			continue
+		}
+		m.metrics.sourceStake.
+			WithLabelValues(blockchainID.String(), source.subnetID.String()).
+			Set(float64(totalWeight))
+
+		secure := totalWeight >= source.minStake
+		wasSecure := source.setVerified(secure)
+		if !secure {
+			m.logger.Warn(
+				"Source subnet stake is below the configured minimum. Messages from this source will not be relayed.",
+				zap.String("sourceBlockchainID", blockchainID.String()),
+				zap.String("subnetID", source.subnetID.String()),
+				zap.Uint64("stake", totalWeight),
+				zap.Uint64("minSourceStake", source.minStake),
+			)
+		} else if !wasSecure {
+			m.logger.Info(
+				"Source subnet stake meets the configured minimum",
+				zap.String("sourceBlockchainID", blockchainID.String()),
+				zap.String("subnetID", source.subnetID.String()),
+				zap.Uint64("stake", totalWeight),
+				zap.Uint64("minSourceStake", source.minStake),
+			)
+		}
+	}
+}
+
+// setVerified records the result of a successful check, and returns whether the source was previously secure.
+func (s *monitoredSource) setVerified(secure bool) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	wasSecure := s.secure.Swap(secure)
+	select {
+	case <-s.verified:
+	default:
+		close(s.verified)
+	}
+	return wasSecure
+}
+
+// setUnverified records a failed check.
+func (s *monitoredSource) setUnverified() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.secure.Store(false)
+	select {
+	case <-s.verified:
+		s.verified = make(chan struct{})
+	default:
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/set"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type testValidatorSetClient struct {
+	totalWeight uint64
+	err         error
+}
+
+func (c *testValidatorSetClient) GetCurrentCanonicalValidatorSet(
+	ids.ID,
+) ([]*avalancheWarp.Validator, uint64, error) {
+	return nil, c.totalWeight, c.err
+}
+
+func TestSourceStakeMonitor(t *testing.T) {
+	sourceBlockchain := config.TestValidSourceBlockchainConfig
+	sourceBlockchain.MinSourceStake = 100
+	destinations := set.Of(config.TestValidDestinationBlockchainConfig.BlockchainID)
+	require.NoError(t, sourceBlockchain.Validate(&destinations))
+	sourceBlockchainID := sourceBlockchain.GetBlockchainID()
+
+	// Sources without a minimum stake are not monitored
+	unmonitored := config.TestValidSourceBlockchainConfig
+	require.Nil(t, NewSourceStakeMonitor(
+		logging.NoLog{},
+		nil,
+		nil,
+		&config.Config{SourceBlockchains: []*config.SourceBlockchain{&unmonitored}},
+	))
+
+	metrics, err := NewMessageCoordinatorMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+	client := &testValidatorSetClient{}
+	monitor := NewSourceStakeMonitor(
+		logging.NoLog{},
+		metrics,
+		client,
+		&config.Config{SourceBlockchains: []*config.SourceBlockchain{&sourceBlockchain}},
+	)
+	require.NotNil(t, monitor)
+
+	// Sources are unverified and insecure until their stake is verified
+	require.False(t, monitor.IsSecure(sourceBlockchainID))
+	verified, _ := monitor.status(sourceBlockchainID)
+	require.False(t, verified)
+	require.True(t, monitor.IsSecure(ids.GenerateTestID()))
+
+	testCases := []struct {
+		name           string
+		totalWeight    uint64
+		err            error
+		expectedSecure bool
+		expectedStake  float64
+		// Unverified sources are rechecked sooner
+		expectUnverified bool
+	}{
+		{
+			name:           "below minimum",
+			totalWeight:    99,
+			expectedSecure: false,
+			expectedStake:  99,
+		},
+		{
+			name:           "meets minimum",
+			totalWeight:    100,
+			expectedSecure: true,
+			expectedStake:  100,
+		},
+		{
+			name:             "failed query is unverified",
+			err:              errors.New("p-chain unavailable"),
+			expectedSecure:   false,
+			expectedStake:    100,
+			expectUnverified: true,
+		},
+		{
+			name:           "verified again",
+			totalWeight:    100,
+			expectedSecure: true,
+			expectedStake:  100,
+		},
+		{
+			name:           "drops below minimum",
+			totalWeight:    50,
+			expectedSecure: false,
+			expectedStake:  50,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			client.totalWeight = test.totalWeight
+			client.err = test.err
+			monitor.CheckStake()
+
+			require.Equal(t, test.expectedSecure, monitor.IsSecure(sourceBlockchainID))
+			require.Equal(t, test.expectedSecure, monitor.Health()[sourceBlockchainID].Load())
+			verified, _ := monitor.status(sourceBlockchainID)
+			require.Equal(t, !test.expectUnverified, verified)
+			if test.expectUnverified {
+				require.Equal(t, sourceStakeRetryInterval, monitor.checkInterval())
+			} else {
+				require.Equal(t, sourceStakeRefreshInterval, monitor.checkInterval())
+			}
+			require.Equal(
+				t,
+				test.expectedStake,
+				testutil.ToFloat64(metrics.sourceStake.WithLabelValues(
+					sourceBlockchainID.String(),
+					sourceBlockchain.GetSubnetID().String(),
+				)),
+			)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/messages"
+	"github.com/ava-labs/awm-relayer/vms"
+	"go.uber.org/zap"
+)
+
+// waitIfStandby is consulted after a message is signed, before it is sent. If standby mode is not configured,
+// or this relayer holds the route's lease, returns true. Otherwise, waits for the grace period to give the
+// leader the opportunity to deliver the message, and returns true only if the message should still be sent.
+// Returns [ctx]'s error if it is canceled first.
+func (r *ApplicationRelayer) waitIfStandby(
+	ctx context.Context,
+	logger logging.Logger,
+	handler messages.MessageHandler,
+	destinationClient vms.DestinationClient,
+) (bool, error) {
+	if r.lease == nil {
+		return true, nil
+	}
+	leader, err := r.lease.TryAcquire()
+	if err != nil {
+		// Fall back to acting as a standby, so the message is still sent if it is not delivered by another relayer
+		logger.Warn(
+			"Failed to acquire lease",
+			zap.String("relayerID", r.relayerID.ID.String()),
+			zap.Error(err),
+		)
+	}
+	if leader {
+		return true, nil
+	}
+
+	logger.Debug(
+		"Relayer is on standby. Waiting for the message to be delivered by the leader",
+		zap.Duration("gracePeriod", r.standbyGracePeriod),
+	)
+	timer := time.NewTimer(r.standbyGracePeriod)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+
+	shouldSend, err := handler.ShouldSendMessage(destinationClient)
+	if err != nil {
+		logger.Error(
+			"Failed to check if message should be sent after the standby grace period",
+			zap.Error(err),
+		)
+		return false, err
+	}
+	if !shouldSend {
+		logger.Info("Message delivered by the leader during the standby grace period")
+		return false, nil
+	}
+	logger.Info(
+		"Message not delivered during the standby grace period. Sending",
+		zap.Duration("gracePeriod", r.standbyGracePeriod),
+	)
+	return true, nil
+}
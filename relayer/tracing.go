@@ -0,0 +1,68 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/ava-labs/awm-relayer/messages"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/ava-labs/awm-relayer/relayer"
+
+// Names of the spans recorded for each relayed Warp message
+const (
+	relayMessageSpanName        = "relay_message"
+	unpackMessageSpanName       = "unpack_message"
+	aggregateSignaturesSpanName = "aggregate_signatures"
+	signatureRequestSpanName    = "signature_request"
+	sendMessageSpanName         = "send_message"
+	awaitConfirmationsSpanName  = "await_confirmations"
+)
+
+// messageDelivery is a message to be relayed by an ApplicationRelayer. [ctx] carries the root span of the
+// Warp message, and [done] must be called once the delivery has completed or been skipped.
+type messageDelivery struct {
+	ctx     context.Context
+	handler messages.MessageHandler
+	done    func(err error)
+	// nil if the delivery is not ordered relative to other messages from its origin sender
+	ticket *sequenceTicket
+}
+
+// startSpan starts a span as a child of the span carried by [ctx]. If [ctx] carries no span, for example if
+// tracing is disabled, the returned span is a no-op.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	tracer := trace.SpanFromContext(ctx).TracerProvider().Tracer(tracerName)
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records [err] on [span], if non-nil, and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// endSpanAfter returns a function that records the errors passed to it on [span], and ends [span] once it
+// has been called [n] times. Used to end the root span of a Warp message once each of its deliveries completes.
+func endSpanAfter(span trace.Span, n int) func(err error) {
+	var remaining atomic.Int64
+	remaining.Store(int64(n))
+	return func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		if remaining.Add(-1) == 0 {
+			span.End()
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package relayer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestStartSpan(t *testing.T) {
+	// Without a span in the context, no span is recorded
+	_, span := startSpan(context.Background(), sendMessageSpanName)
+	require.False(t, span.SpanContext().IsValid())
+	span.End()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+	ctx, root := tracer.Start(context.Background(), relayMessageSpanName)
+	_, child := startSpan(ctx, sendMessageSpanName)
+	child.End()
+	root.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, sendMessageSpanName, spans[0].Name())
+	require.Equal(t, root.SpanContext().SpanID(), spans[0].Parent().SpanID())
+}
+
+func TestEndSpanAfter(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+	_, span := tracer.Start(context.Background(), relayMessageSpanName)
+
+	done := endSpanAfter(span, 2)
+	done(errors.New("failed to send warp message"))
+	require.Empty(t, recorder.Ended())
+
+	done(nil)
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Len(t, spans[0].Events(), 1)
+}
@@ -19,13 +19,19 @@ import (
 var (
 	WarpPrecompileLogFilter = warp.WarpABI.Events["SendWarpMessage"].ID
 	ErrInvalidLog           = errors.New("invalid warp message log")
+	ErrMessageTooLarge      = errors.New("warp message payload exceeds the maximum message size")
 )
 
 // WarpBlockInfo describes the block height and logs needed to process Warp messages.
 // WarpBlockInfo instances are populated by the subscriber, and forwarded to the Listener to process.
 type WarpBlockInfo struct {
 	BlockNumber uint64
-	Messages    []*WarpMessageInfo
+	// The block timestamp, in seconds since the Unix epoch
+	BlockTimestamp uint64
+	Messages       []*WarpMessageInfo
+	// Warp logs in the block from which a message could not be parsed. These are skipped rather than relayed,
+	// so that a malformed message does not prevent the block from being processed.
+	MalformedLogs []types.Log
 }
 
 // WarpMessageInfo describes the transaction information for the Warp message
@@ -43,36 +49,45 @@ func NewWarpBlockInfo(header *types.Header, ethClient ethclient.Client) (*WarpBl
 		logs []types.Log
 		err  error
 	)
-	// Check if the block contains warp logs, and fetch them from the client if it does
+	// Check if the block contains warp logs, and fetch them from the client if it does.
+	// The block may have just been announced by the node, and not yet be queryable. Filtering by block hash
+	// rather than number causes such queries to fail rather than return an empty result, so we retry them.
 	if header.Bloom.Test(WarpPrecompileLogFilter[:]) {
 		cctx, cancel := context.WithTimeout(context.Background(), utils.DefaultRPCRetryTimeout)
 		defer cancel()
-		logs, err = utils.CallWithRetry[[]types.Log](
+		blockHash := header.Hash()
+		logs, err = utils.CallWithBackoff[[]types.Log](
 			cctx,
+			utils.BlockReadMaxAttempts,
+			utils.BlockReadInitialBackoff,
+			utils.BlockReadMaxBackoff,
 			func() ([]types.Log, error) {
-				return ethClient.FilterLogs(context.Background(), interfaces.FilterQuery{
+				return ethClient.FilterLogs(cctx, interfaces.FilterQuery{
 					Topics:    [][]common.Hash{{WarpPrecompileLogFilter}},
 					Addresses: []common.Address{warp.ContractAddress},
-					FromBlock: header.Number,
-					ToBlock:   header.Number,
+					BlockHash: &blockHash,
 				})
 			})
 		if err != nil {
 			return nil, err
 		}
 	}
-	messages := make([]*WarpMessageInfo, len(logs))
-	for i, log := range logs {
+	messages := make([]*WarpMessageInfo, 0, len(logs))
+	var malformedLogs []types.Log
+	for _, log := range logs {
 		warpLog, err := NewWarpMessageInfo(log)
 		if err != nil {
-			return nil, err
+			malformedLogs = append(malformedLogs, log)
+			continue
 		}
-		messages[i] = warpLog
+		messages = append(messages, warpLog)
 	}
 
 	return &WarpBlockInfo{
-		BlockNumber: header.Number.Uint64(),
-		Messages:    messages,
+		BlockNumber:    header.Number.Uint64(),
+		BlockTimestamp: header.Time,
+		Messages:       messages,
+		MalformedLogs:  malformedLogs,
 	}, nil
 }
 
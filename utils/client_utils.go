@@ -5,49 +5,95 @@ package utils
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 
 	"github.com/ava-labs/subnet-evm/ethclient"
 	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/gorilla/websocket"
 )
 
 var ErrInvalidEndpoint = errors.New("invalid rpc endpoint")
 
+// Match the buffer sizes of the RPC client's default WebSocket dialer
+const websocketBufferSize = 1024
+
 // NewEthClientWithConfig returns an ethclient.Client with the internal RPC client configured with the provided options.
 func NewEthClientWithConfig(
 	ctx context.Context,
 	baseURL string, httpHeaders,
 	queryParams map[string]string,
+	tlsConfig *tls.Config,
+) (ethclient.Client, error) {
+	client, err := DialWithConfig(ctx, baseURL, httpHeaders, queryParams, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(client), nil
+}
+
+// NewEthClientWithTransport returns an ethclient.Client that sends its HTTP requests to [baseURL] with [transport].
+// The transport may rewrite the requests, for example to send them to a different endpoint.
+func NewEthClientWithTransport(
+	ctx context.Context,
+	baseURL string,
+	transport http.RoundTripper,
 ) (ethclient.Client, error) {
-	client, err := DialWithConfig(ctx, baseURL, httpHeaders, queryParams)
+	client, err := rpc.DialOptions(ctx, baseURL, rpc.WithHTTPClient(&http.Client{Transport: transport}))
 	if err != nil {
 		return nil, err
 	}
 	return ethclient.NewClient(client), nil
 }
 
-// DialWithConfig dials the provided baseURL with the provided httpHeaders and queryParams
+// DialWithConfig dials the provided baseURL with the provided httpHeaders and queryParams. If [tlsConfig] is
+// non-nil, it is used for both HTTP and WebSocket connections.
 func DialWithConfig(
 	ctx context.Context,
 	baseURL string,
 	httpHeaders map[string]string,
 	queryParams map[string]string,
+	tlsConfig *tls.Config,
 ) (*rpc.Client, error) {
-	url, err := addQueryParams(baseURL, queryParams)
+	url, err := AddQueryParams(baseURL, queryParams)
 	if err != nil {
 		return nil, err
 	}
-	client, err := rpc.DialOptions(ctx, url, newClientHeaderOptions(httpHeaders)...)
+	opts := newClientHeaderOptions(httpHeaders)
+	if tlsConfig != nil {
+		opts = append(
+			opts,
+			rpc.WithHTTPClient(&http.Client{Transport: NewHTTPTransport(tlsConfig)}),
+			rpc.WithWebsocketDialer(websocket.Dialer{
+				ReadBufferSize:  websocketBufferSize,
+				WriteBufferSize: websocketBufferSize,
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			}),
+		)
+	}
+	client, err := rpc.DialOptions(ctx, url, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return client, nil
 }
 
-// addQueryParams adds the query parameters to the url
-func addQueryParams(endpoint string, queryParams map[string]string) (string, error) {
+// NewHTTPTransport returns the default HTTP transport, configured with [tlsConfig] if it is non-nil
+func NewHTTPTransport(tlsConfig *tls.Config) http.RoundTripper {
+	if tlsConfig == nil {
+		return http.DefaultTransport
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	return transport
+}
+
+// AddQueryParams adds the query parameters to the url
+func AddQueryParams(endpoint string, queryParams map[string]string) (string, error) {
 	uri, err := url.ParseRequestURI(endpoint)
 	if err != nil {
 		return "", fmt.Errorf("%w: %v", ErrInvalidEndpoint, err)
@@ -12,12 +12,12 @@ import (
 
 func TestAddQueryParams(t *testing.T) {
 	t.Run("NoQueryParams", func(t *testing.T) {
-		newurl, err := addQueryParams("https://avalabs.com", nil)
+		newurl, err := AddQueryParams("https://avalabs.com", nil)
 		require.NoError(t, err)
 		require.Equal(t, "https://avalabs.com", newurl)
 	})
 	t.Run("TwoQueryParams", func(t *testing.T) {
-		newurl, err := addQueryParams("https://avalabs.com", map[string]string{
+		newurl, err := AddQueryParams("https://avalabs.com", map[string]string{
 			"first":  "value1",
 			"second": "value2",
 		})
@@ -25,7 +25,7 @@ func TestAddQueryParams(t *testing.T) {
 		require.Equal(t, "https://avalabs.com?first=value1&second=value2", newurl)
 	})
 	t.Run("InvalidEndpoint", func(t *testing.T) {
-		_, err := addQueryParams("invalid-endpoint", nil)
+		_, err := AddQueryParams("invalid-endpoint", nil)
 		require.True(t, errors.Is(err, ErrInvalidEndpoint))
 	})
 }
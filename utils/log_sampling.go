@@ -0,0 +1,136 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"go.uber.org/zap/zapcore"
+)
+
+// Keys of the log fields that identify the route of a log entry, in either of the casings used by the relayer
+var routeLogKeys = map[string]struct{}{
+	SourceBlockchainIDLogKey:      {},
+	DestinationBlockchainIDLogKey: {},
+	"sourceBlockchainID":          {},
+	"destinationBlockchainID":     {},
+}
+
+type sampleKey struct {
+	level   zapcore.Level
+	message string
+	route   string
+}
+
+type sampleWindow struct {
+	start time.Time
+	count uint64
+}
+
+// samplingCore limits the rate of log entries at the sampled levels. Entries are counted per one second window,
+// keyed by level, message, and the source and destination blockchain IDs attached to the entry.
+type samplingCore struct {
+	zapcore.Core
+	rates map[zapcore.Level]uint64
+	// Route fields attached to the core via With
+	route string
+
+	// Shared by the cores derived via With
+	lock    *sync.Mutex
+	windows map[sampleKey]*sampleWindow
+	now     func() time.Time
+}
+
+// NewSamplingCore wraps [core] so that at most rates[level] entries per second are written at each level in [rates],
+// per message and route. Entries at other levels are always written.
+func NewSamplingCore(core zapcore.Core, rates map[logging.Level]uint64) zapcore.Core {
+	if len(rates) == 0 {
+		return core
+	}
+	zapRates := make(map[zapcore.Level]uint64, len(rates))
+	for level, rate := range rates {
+		zapRates[zapcore.Level(level)] = rate
+	}
+	return &samplingCore{
+		Core:    core,
+		rates:   zapRates,
+		lock:    &sync.Mutex{},
+		windows: make(map[sampleKey]*sampleWindow),
+		now:     time.Now,
+	}
+}
+
+func (c *samplingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &samplingCore{
+		Core:    c.Core.With(fields),
+		rates:   c.rates,
+		route:   c.route + routeOf(fields),
+		lock:    c.lock,
+		windows: c.windows,
+		now:     c.now,
+	}
+}
+
+func (c *samplingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if _, ok := c.rates[entry.Level]; !ok {
+		return c.Core.Check(entry, checked)
+	}
+	// The route is only known once the entry's fields are written, so sampled entries are checked in Write
+	if !c.Enabled(entry.Level) {
+		return checked
+	}
+	return checked.AddCore(entry, c)
+}
+
+func (c *samplingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if rate, ok := c.rates[entry.Level]; ok && !c.sample(entry, fields, rate) {
+		return nil
+	}
+	return c.Core.Write(entry, fields)
+}
+
+// sample returns true if fewer than [rate] entries with the same key as [entry] have been written in the
+// current one second window.
+func (c *samplingCore) sample(entry zapcore.Entry, fields []zapcore.Field, rate uint64) bool {
+	key := sampleKey{
+		level:   entry.Level,
+		message: entry.Message,
+		route:   c.route + routeOf(fields),
+	}
+	start := c.now().Truncate(time.Second)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	window, ok := c.windows[key]
+	if !ok {
+		window = &sampleWindow{}
+		c.windows[key] = window
+	}
+	if !window.start.Equal(start) {
+		window.start = start
+		window.count = 0
+	}
+	window.count++
+	return window.count <= rate
+}
+
+// routeOf returns the values of the route fields in [fields]
+func routeOf(fields []zapcore.Field) string {
+	var route string
+	for _, field := range fields {
+		if _, ok := routeLogKeys[field.Key]; !ok {
+			continue
+		}
+		switch field.Type {
+		case zapcore.StringType:
+			route += field.Key + "=" + field.String + ";"
+		case zapcore.StringerType:
+			route += field.Key + "=" + fmt.Sprint(field.Interface) + ";"
+		}
+	}
+	return route
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSamplingCore(t *testing.T) {
+	// The relayer logs at avalanchego's levels, which differ from zap's
+	infoLevel := zapcore.Level(logging.Info)
+	testCases := []struct {
+		name     string
+		log      func(logger *zap.Logger)
+		advance  bool
+		expected int
+	}{
+		{
+			name: "sampled per second",
+			log: func(logger *zap.Logger) {
+				for i := 0; i < 5; i++ {
+					logger.Log(infoLevel, "Relayed message", zap.String("sourceBlockchainID", "A"))
+				}
+			},
+			expected: 2,
+		},
+		{
+			name: "sampled per route",
+			log: func(logger *zap.Logger) {
+				for i := 0; i < 3; i++ {
+					logger.Log(infoLevel, "Relayed message", zap.String("destinationBlockchainID", "A"))
+					logger.Log(infoLevel, "Relayed message", zap.String("destinationBlockchainID", "B"))
+				}
+			},
+			expected: 4,
+		},
+		{
+			name: "sampled per route attached via With",
+			log: func(logger *zap.Logger) {
+				for i := 0; i < 3; i++ {
+					logger.With(zap.String(SourceBlockchainIDLogKey, "A")).Log(infoLevel, "Relayed message")
+					logger.With(zap.String(SourceBlockchainIDLogKey, "B")).Log(infoLevel, "Relayed message")
+				}
+			},
+			expected: 4,
+		},
+		{
+			name: "sampled per message",
+			log: func(logger *zap.Logger) {
+				for i := 0; i < 3; i++ {
+					logger.Log(infoLevel, "Relayed message")
+					logger.Log(infoLevel, "Processed block")
+				}
+			},
+			expected: 4,
+		},
+		{
+			name: "window reset",
+			log: func(logger *zap.Logger) {
+				for i := 0; i < 3; i++ {
+					logger.Log(infoLevel, "Relayed message")
+				}
+			},
+			advance:  true,
+			expected: 4,
+		},
+		{
+			name: "warnings not sampled",
+			log: func(logger *zap.Logger) {
+				for i := 0; i < 5; i++ {
+					logger.Log(zapcore.Level(logging.Warn), "Failed to relay message")
+				}
+			},
+			expected: 5,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			observedCore, observedLogs := observer.New(zapcore.Level(logging.Verbo))
+			core := NewSamplingCore(observedCore, map[logging.Level]uint64{logging.Info: 2}).(*samplingCore)
+			now := time.Unix(1000, 0)
+			core.now = func() time.Time { return now }
+			logger := zap.New(core)
+
+			testCase.log(logger)
+			if testCase.advance {
+				now = now.Add(time.Second)
+				testCase.log(logger)
+			}
+			require.Equal(t, testCase.expected, observedLogs.Len())
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"go.uber.org/zap"
+)
+
+// Keys of the fields attached to each log line about a relayed message, so that relay events may be queried
+// consistently by log pipelines.
+const (
+	SourceBlockchainIDLogKey      = "source_blockchain_id"
+	DestinationBlockchainIDLogKey = "destination_blockchain_id"
+	WarpMessageIDLogKey           = "warp_message_id"
+	// The message ID assigned by the message protocol, such as the Teleporter message ID
+	MessageIDLogKey = "message_id"
+)
+
+var _ logging.Logger = &fieldsLogger{}
+
+// fieldsLogger attaches a fixed set of fields to each log line
+type fieldsLogger struct {
+	logging.Logger
+	fields []zap.Field
+}
+
+// LoggerWithFields returns a logger that attaches [fields] to each log line written by [logger]
+func LoggerWithFields(logger logging.Logger, fields ...zap.Field) logging.Logger {
+	if l, ok := logger.(*fieldsLogger); ok {
+		return &fieldsLogger{
+			Logger: l.Logger,
+			fields: l.withFields(fields),
+		}
+	}
+	return &fieldsLogger{
+		Logger: logger,
+		fields: fields,
+	}
+}
+
+// NewMessageLogger returns a logger that attaches the fields identifying a relayed Warp message
+// to each log line written by [logger]
+func NewMessageLogger(
+	logger logging.Logger,
+	sourceBlockchainID ids.ID,
+	destinationBlockchainID ids.ID,
+	warpMessageID ids.ID,
+) logging.Logger {
+	return LoggerWithFields(
+		logger,
+		zap.String(SourceBlockchainIDLogKey, sourceBlockchainID.String()),
+		zap.String(DestinationBlockchainIDLogKey, destinationBlockchainID.String()),
+		zap.String(WarpMessageIDLogKey, warpMessageID.String()),
+	)
+}
+
+func (l *fieldsLogger) withFields(fields []zap.Field) []zap.Field {
+	return append(append(make([]zap.Field, 0, len(l.fields)+len(fields)), l.fields...), fields...)
+}
+
+func (l *fieldsLogger) Fatal(msg string, fields ...zap.Field) {
+	l.Logger.Fatal(msg, l.withFields(fields)...)
+}
+
+func (l *fieldsLogger) Error(msg string, fields ...zap.Field) {
+	l.Logger.Error(msg, l.withFields(fields)...)
+}
+
+func (l *fieldsLogger) Warn(msg string, fields ...zap.Field) {
+	l.Logger.Warn(msg, l.withFields(fields)...)
+}
+
+func (l *fieldsLogger) Info(msg string, fields ...zap.Field) {
+	l.Logger.Info(msg, l.withFields(fields)...)
+}
+
+func (l *fieldsLogger) Trace(msg string, fields ...zap.Field) {
+	l.Logger.Trace(msg, l.withFields(fields)...)
+}
+
+func (l *fieldsLogger) Debug(msg string, fields ...zap.Field) {
+	l.Logger.Debug(msg, l.withFields(fields)...)
+}
+
+func (l *fieldsLogger) Verbo(msg string, fields ...zap.Field) {
+	l.Logger.Verbo(msg, l.withFields(fields)...)
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNewMessageLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.NewLogger(
+		"awm-relayer-test",
+		logging.NewWrappedCore(logging.Info, &nopCloser{&buf}, logging.JSON.ConsoleEncoder()),
+	)
+	sourceBlockchainID := ids.GenerateTestID()
+	destinationBlockchainID := ids.GenerateTestID()
+	warpMessageID := ids.GenerateTestID()
+
+	messageLogger := NewMessageLogger(logger, sourceBlockchainID, destinationBlockchainID, warpMessageID)
+	messageLogger = LoggerWithFields(messageLogger, zap.String(MessageIDLogKey, "teleporter-id"))
+	messageLogger.Info("Relaying message", zap.String("txHash", "0x01"))
+
+	var line map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	require.Equal(t, "info", line["level"])
+	require.Contains(t, line, "timestamp")
+	require.Equal(t, "Relaying message", line["msg"])
+	require.Equal(t, sourceBlockchainID.String(), line[SourceBlockchainIDLogKey])
+	require.Equal(t, destinationBlockchainID.String(), line[DestinationBlockchainIDLogKey])
+	require.Equal(t, warpMessageID.String(), line[WarpMessageIDLogKey])
+	require.Equal(t, "teleporter-id", line[MessageIDLogKey])
+	require.Equal(t, "0x01", line["txHash"])
+}
+
+type nopCloser struct {
+	*bytes.Buffer
+}
+
+func (*nopCloser) Close() error {
+	return nil
+}
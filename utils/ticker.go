@@ -10,7 +10,8 @@ import (
 
 // Ticker is a timer that can be subscribed to. When the timer ticks,
 // all subscribers will receive a signal on the channel they were given
-// when subscribing.
+// when subscribing. Subscribers that have not yet received the previous
+// signal are not signaled again, so a slow subscriber does not block the others.
 type Ticker struct {
 	interval      time.Duration
 	subscriptions []chan struct{}
@@ -28,17 +29,38 @@ func (t *Ticker) Subscribe() chan struct{} {
 	t.lock.Lock()
 	defer t.lock.Unlock()
 
-	sub := make(chan struct{})
+	sub := make(chan struct{}, 1)
 	t.subscriptions = append(t.subscriptions, sub)
 	return sub
 }
 
+// Unsubscribe stops signaling [sub], and closes it. Does nothing if [sub] is not subscribed.
+func (t *Ticker) Unsubscribe(sub chan struct{}) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for i, s := range t.subscriptions {
+		if s == sub {
+			t.subscriptions = append(t.subscriptions[:i], t.subscriptions[i+1:]...)
+			close(sub)
+			return
+		}
+	}
+}
+
 func (t *Ticker) Run() {
 	for range time.Tick(t.interval) {
-		t.lock.Lock()
-		for _, sub := range t.subscriptions {
-			sub <- struct{}{}
+		t.tick()
+	}
+}
+
+func (t *Ticker) tick() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for _, sub := range t.subscriptions {
+		select {
+		case sub <- struct{}{}:
+		default:
 		}
-		t.lock.Unlock()
 	}
 }
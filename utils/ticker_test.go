@@ -0,0 +1,36 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTickerUnsubscribe(t *testing.T) {
+	ticker := NewTicker(1)
+	sub := ticker.Subscribe()
+	otherSub := ticker.Subscribe()
+
+	// Ticks are not blocked by subscribers that have not received the previous signal
+	ticker.tick()
+	ticker.tick()
+	require.Len(t, sub, 1)
+	require.Len(t, otherSub, 1)
+	<-otherSub
+
+	// Unsubscribed channels are closed, and are no longer signaled
+	ticker.Unsubscribe(sub)
+	<-sub
+	_, ok := <-sub
+	require.False(t, ok)
+	ticker.tick()
+	require.Len(t, otherSub, 1)
+	require.Len(t, ticker.subscriptions, 1)
+
+	// Unsubscribing again does nothing
+	ticker.Unsubscribe(sub)
+	require.Len(t, ticker.subscriptions, 1)
+}
@@ -28,6 +28,12 @@ var (
 
 const (
 	DefaultRPCRetryTimeout = 5 * time.Second
+
+	// Bounds on retrying reads of blocks that have just been announced by the node, but may not yet be
+	// queryable due to the node's internal indexing lag.
+	BlockReadMaxAttempts    = 6
+	BlockReadInitialBackoff = 100 * time.Millisecond
+	BlockReadMaxBackoff     = 2 * time.Second
 )
 
 //
@@ -81,6 +87,45 @@ func CallWithRetry[T any](ctx context.Context, f func() (T, error)) (T, error) {
 	}
 }
 
+// Calls f until it returns a non-error result, the context is canceled, or f has been called [maxAttempts] times.
+// If [maxAttempts] is 0, f is called until it succeeds or the context is canceled.
+// The delay between calls starts at [initialBackoff], and doubles after each call up to [maxBackoff].
+// Returns the error from the last call if all attempts fail.
+func CallWithBackoff[T any](
+	ctx context.Context,
+	maxAttempts int,
+	initialBackoff time.Duration,
+	maxBackoff time.Duration,
+	f func() (T, error),
+) (T, error) {
+	var (
+		t   T
+		err error
+	)
+	backoff := initialBackoff
+	for attempt := 1; maxAttempts == 0 || attempt <= maxAttempts; attempt++ {
+		t, err = f()
+		if err == nil {
+			return t, nil
+		}
+		if attempt == maxAttempts {
+			break
+		}
+
+		// Wait for the next round.
+		select {
+		case <-ctx.Done():
+			return *new(T), ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+	return *new(T), err
+}
+
 //
 // Generic Utils
 //
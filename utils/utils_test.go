@@ -4,8 +4,11 @@
 package utils
 
 import (
+	"context"
+	"errors"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -135,3 +138,59 @@ func TestCheckStakeWeightExceedsThreshold(t *testing.T) {
 		})
 	}
 }
+
+func TestCallWithBackoff(t *testing.T) {
+	errNotFound := errors.New("not found")
+	testCases := []struct {
+		name          string
+		failures      int
+		maxAttempts   int
+		expectedCalls int
+		expectError   bool
+	}{
+		{
+			name:          "succeeds immediately",
+			failures:      0,
+			maxAttempts:   3,
+			expectedCalls: 1,
+		},
+		{
+			name:          "succeeds after retries",
+			failures:      2,
+			maxAttempts:   3,
+			expectedCalls: 3,
+		},
+		{
+			name:          "fails after max attempts",
+			failures:      5,
+			maxAttempts:   3,
+			expectedCalls: 3,
+			expectError:   true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			calls := 0
+			res, err := CallWithBackoff[int](
+				context.Background(),
+				testCase.maxAttempts,
+				time.Millisecond,
+				2*time.Millisecond,
+				func() (int, error) {
+					calls++
+					if calls <= testCase.failures {
+						return 0, errNotFound
+					}
+					return calls, nil
+				},
+			)
+			require.Equal(t, testCase.expectedCalls, calls)
+			if testCase.expectError {
+				require.ErrorIs(t, err, errNotFound)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, testCase.expectedCalls, res)
+			}
+		})
+	}
+}
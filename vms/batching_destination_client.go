@@ -0,0 +1,205 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vms
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// batchingDestinationClient wraps a DestinationClient, coalescing concurrent deliveries to the same receiver
+// into batch transactions. Deliveries are only batched together if they allow the same sender addresses.
+// SendTx blocks until the batch containing the message has been sent, so callers observe the same completion
+// semantics as an individual delivery. All other methods are passed through to the wrapped client.
+type batchingDestinationClient struct {
+	DestinationClient
+	logger       logging.Logger
+	batchSize    int
+	batchTimeout time.Duration
+
+	// The batch being filled for each receiver address and set of allowed senders
+	lock    sync.Mutex
+	batches map[string]*deliveryBatch
+}
+
+type batchedDelivery struct {
+	ctx           context.Context
+	signedMessage *warp.Message
+	gasLimit      uint64
+	callData      []byte
+}
+
+type deliveryBatch struct {
+	key        string
+	toAddress  string
+	deliveries []batchedDelivery
+
+	// Closed once the batch has been sent, after which txHash and err are set
+	done   chan struct{}
+	txHash common.Hash
+	err    error
+}
+
+// NewBatchingDestinationClient wraps [client] such that up to [batchSize] deliveries to the same receiver
+// are sent in a single transaction. A batch is sent once it is full, or [batchTimeout] after its first
+// delivery was added. Batches of a single delivery are sent with SendTx.
+func NewBatchingDestinationClient(
+	logger logging.Logger,
+	client DestinationClient,
+	batchSize int,
+	batchTimeout time.Duration,
+) DestinationClient {
+	return &batchingDestinationClient{
+		DestinationClient: client,
+		logger:            logger,
+		batchSize:         batchSize,
+		batchTimeout:      batchTimeout,
+		batches:           make(map[string]*deliveryBatch),
+	}
+}
+
+// SendTx adds the delivery to the batch for [toAddress], and returns once that batch has been sent.
+// The returned hash is that of the batch transaction.
+func (c *batchingDestinationClient) SendTx(
+	ctx context.Context,
+	signedMessage *warp.Message,
+	toAddress string,
+	gasLimit uint64,
+	callData []byte,
+) (common.Hash, error) {
+	key := batchKey(ctx, toAddress)
+	c.lock.Lock()
+	batch, ok := c.batches[key]
+	if !ok {
+		batch = &deliveryBatch{
+			key:       key,
+			toAddress: toAddress,
+			done:      make(chan struct{}),
+		}
+		c.batches[key] = batch
+		time.AfterFunc(c.batchTimeout, func() { c.flush(batch) })
+	}
+	batch.deliveries = append(batch.deliveries, batchedDelivery{
+		ctx:           ctx,
+		signedMessage: signedMessage,
+		gasLimit:      gasLimit,
+		callData:      callData,
+	})
+	full := len(batch.deliveries) >= c.batchSize
+	if full {
+		delete(c.batches, key)
+	}
+	c.lock.Unlock()
+
+	if full {
+		c.send(batch)
+	}
+	<-batch.done
+	return batch.txHash, batch.err
+}
+
+// batchKey identifies the batch that a delivery to [toAddress] with the allowed senders of [ctx] is added to.
+// The batch is sent from a single sender address, so must only contain deliveries that allow the same senders.
+func batchKey(ctx context.Context, toAddress string) string {
+	allowedSenders := AllowedSenders(ctx)
+	senders := make([]string, 0, len(allowedSenders))
+	for _, sender := range allowedSenders {
+		senders = append(senders, sender.Hex())
+	}
+	slices.Sort(senders)
+	return toAddress + "/" + strings.Join(senders, ",")
+}
+
+// flush sends [batch] if it is still being filled
+func (c *batchingDestinationClient) flush(batch *deliveryBatch) {
+	c.lock.Lock()
+	if c.batches[batch.key] != batch {
+		c.lock.Unlock()
+		return
+	}
+	delete(c.batches, batch.key)
+	c.lock.Unlock()
+
+	c.send(batch)
+}
+
+// send delivers the messages in [batch], and releases the callers waiting on it
+func (c *batchingDestinationClient) send(batch *deliveryBatch) {
+	defer close(batch.done)
+
+	if len(batch.deliveries) == 1 {
+		delivery := batch.deliveries[0]
+		batch.txHash, batch.err = c.DestinationClient.SendTx(
+			delivery.ctx,
+			delivery.signedMessage,
+			batch.toAddress,
+			delivery.gasLimit,
+			delivery.callData,
+		)
+		return
+	}
+
+	var (
+		signedMessages = make([]*warp.Message, 0, len(batch.deliveries))
+		callData       = make([][]byte, 0, len(batch.deliveries))
+		gasLimit       uint64
+	)
+	for _, delivery := range batch.deliveries {
+		signedMessages = append(signedMessages, delivery.signedMessage)
+		callData = append(callData, delivery.callData)
+		gasLimit += delivery.gasLimit
+	}
+	// The batch is sent with the context of its first delivery. The deliveries of a destination client
+	// share the same parent context, so are canceled together, and those of a batch allow the same senders.
+	batch.txHash, batch.err = c.DestinationClient.SendBatch(
+		batch.deliveries[0].ctx,
+		signedMessages,
+		batch.toAddress,
+		gasLimit,
+		callData,
+	)
+	if batch.err != nil {
+		return
+	}
+	c.logger.Debug(
+		"Sent batch transaction",
+		zap.String("destinationBlockchainID", c.DestinationBlockchainID().String()),
+		zap.String("toAddress", batch.toAddress),
+		zap.Int("numMessages", len(signedMessages)),
+		zap.String("txHash", batch.txHash.String()),
+	)
+}
+
+// UpdateSigners updates the signing keys of the wrapped client.
+func (c *batchingDestinationClient) UpdateSigners(destinationBlockchain *config.DestinationBlockchain) error {
+	return UpdateSigners(c.DestinationClient, destinationBlockchain)
+}
+
+// WaitForConfirmations waits for confirmations of a transaction sent by the wrapped client.
+func (c *batchingDestinationClient) WaitForConfirmations(
+	ctx context.Context,
+	txHash common.Hash,
+	confirmations uint64,
+) error {
+	return WaitForConfirmations(ctx, c.DestinationClient, txHash, confirmations)
+}
+
+// SendCall issues the transaction via the wrapped client. Calls are not batched.
+func (c *batchingDestinationClient) SendCall(
+	ctx context.Context,
+	toAddress string,
+	gasLimit uint64,
+	callData []byte,
+) (common.Hash, error) {
+	return SendCall(ctx, c.DestinationClient, toAddress, gasLimit, callData)
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vms
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	mock_vms "github.com/ava-labs/awm-relayer/vms/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBatchingDestinationClient(t *testing.T) {
+	receiver1 := "0x27aE10273D17Cd7e80de8580A51f476960626e5f"
+	receiver2 := "0x0123456789abcdef0123456789abcdef01234567"
+	batchTxHash := common.HexToHash("0x01")
+	txHash := common.HexToHash("0x02")
+
+	testCases := []struct {
+		name           string
+		receivers      []string
+		allowedSenders [][]common.Address
+		expectBatch    int
+		expectSendTx   int
+	}{
+		{
+			name:        "full batch",
+			receivers:   []string{receiver1, receiver1},
+			expectBatch: 1,
+		},
+		{
+			name:         "partial batch sent after timeout",
+			receivers:    []string{receiver1},
+			expectSendTx: 1,
+		},
+		{
+			name:         "different receivers",
+			receivers:    []string{receiver1, receiver2},
+			expectSendTx: 2,
+		},
+		{
+			name:      "same allowed senders",
+			receivers: []string{receiver1, receiver1},
+			allowedSenders: [][]common.Address{
+				{common.HexToAddress("0x01"), common.HexToAddress("0x02")},
+				{common.HexToAddress("0x02"), common.HexToAddress("0x01")},
+			},
+			expectBatch: 1,
+		},
+		{
+			name:      "different allowed senders",
+			receivers: []string{receiver1, receiver1},
+			allowedSenders: [][]common.Address{
+				{common.HexToAddress("0x01")},
+				{common.HexToAddress("0x02")},
+			},
+			expectSendTx: 2,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockClient := mock_vms.NewMockDestinationClient(ctrl)
+			mockClient.EXPECT().DestinationBlockchainID().Return(ids.GenerateTestID()).AnyTimes()
+			mockClient.EXPECT().SendBatch(gomock.Any(), gomock.Len(2), receiver1, uint64(200_000), gomock.Len(2)).
+				Return(batchTxHash, nil).
+				Times(testCase.expectBatch)
+			mockClient.EXPECT().SendTx(gomock.Any(), gomock.Any(), gomock.Any(), uint64(100_000), gomock.Any()).
+				Return(txHash, nil).
+				Times(testCase.expectSendTx)
+
+			client := NewBatchingDestinationClient(logging.NoLog{}, mockClient, 2, 10*time.Millisecond)
+
+			var wg sync.WaitGroup
+			for i, receiver := range testCase.receivers {
+				ctx := context.Background()
+				if testCase.allowedSenders != nil {
+					ctx = WithAllowedSenders(ctx, testCase.allowedSenders[i])
+				}
+				wg.Add(1)
+				go func(receiver string) {
+					defer wg.Done()
+					hash, err := client.SendTx(ctx, &warp.Message{}, receiver, 100_000, []byte{})
+					require.NoError(t, err)
+					if testCase.expectBatch > 0 {
+						require.Equal(t, batchTxHash, hash)
+					} else {
+						require.Equal(t, txHash, hash)
+					}
+				}(receiver)
+			}
+			wg.Wait()
+		})
+	}
+}
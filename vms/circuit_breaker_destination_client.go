@@ -0,0 +1,263 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vms
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/vms/evm"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+type circuitState int
+
+// The values of circuitState are those reported by the circuit breaker state metric
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitClosed:
+		return "closed"
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// circuitBreakerDestinationClient wraps a DestinationClient, suspending sends to the destination after
+// [failureThreshold] consecutive failures. While the circuit is open, sends block until the cooldown has
+// elapsed rather than being attempted, so that messages remain buffered by their application relayers.
+// The circuit then half-opens, and a single trial send is attempted. If it succeeds the circuit closes,
+// otherwise it is opened again with twice the previous cooldown, up to [maxCooldown].
+type circuitBreakerDestinationClient struct {
+	DestinationClient
+	logger           logging.Logger
+	metrics          *evm.DestinationClientMetrics
+	failureThreshold uint64
+	cooldown         time.Duration
+	maxCooldown      time.Duration
+
+	lock                sync.Mutex
+	state               circuitState
+	consecutiveFailures uint64
+	currentCooldown     time.Duration
+	openUntil           time.Time
+	trialInFlight       bool
+	// Closed and replaced each time the state changes, to wake sends waiting for the circuit
+	stateChanged chan struct{}
+}
+
+// NewCircuitBreakerDestinationClient wraps [client] with a circuit breaker configured by [destinationBlockchain].
+// [metrics] may be nil.
+func NewCircuitBreakerDestinationClient(
+	logger logging.Logger,
+	metrics *evm.DestinationClientMetrics,
+	client DestinationClient,
+	destinationBlockchain *config.DestinationBlockchain,
+) DestinationClient {
+	c := &circuitBreakerDestinationClient{
+		DestinationClient: client,
+		logger:            logger,
+		metrics:           metrics,
+		failureThreshold:  destinationBlockchain.CircuitBreakerFailureThreshold,
+		cooldown:          destinationBlockchain.GetCircuitBreakerCooldown(),
+		maxCooldown:       destinationBlockchain.GetCircuitBreakerMaxCooldown(),
+		state:             circuitClosed,
+		stateChanged:      make(chan struct{}),
+	}
+	c.recordState()
+	return c
+}
+
+// SendTx sends the transaction via the wrapped client once the circuit allows it.
+func (c *circuitBreakerDestinationClient) SendTx(
+	ctx context.Context,
+	signedMessage *warp.Message,
+	toAddress string,
+	gasLimit uint64,
+	callData []byte,
+) (common.Hash, error) {
+	trial, err := c.acquire(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	txHash, err := c.DestinationClient.SendTx(ctx, signedMessage, toAddress, gasLimit, callData)
+	c.release(ctx, trial, err)
+	return txHash, err
+}
+
+// SendBatch sends the batch transaction via the wrapped client once the circuit allows it.
+func (c *circuitBreakerDestinationClient) SendBatch(
+	ctx context.Context,
+	signedMessages []*warp.Message,
+	toAddress string,
+	gasLimit uint64,
+	callData [][]byte,
+) (common.Hash, error) {
+	trial, err := c.acquire(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	txHash, err := c.DestinationClient.SendBatch(ctx, signedMessages, toAddress, gasLimit, callData)
+	c.release(ctx, trial, err)
+	return txHash, err
+}
+
+// SendCall issues the transaction via the wrapped client once the circuit allows it.
+func (c *circuitBreakerDestinationClient) SendCall(
+	ctx context.Context,
+	toAddress string,
+	gasLimit uint64,
+	callData []byte,
+) (common.Hash, error) {
+	trial, err := c.acquire(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	txHash, err := SendCall(ctx, c.DestinationClient, toAddress, gasLimit, callData)
+	c.release(ctx, trial, err)
+	return txHash, err
+}
+
+// UpdateSigners updates the signing keys of the wrapped client.
+func (c *circuitBreakerDestinationClient) UpdateSigners(destinationBlockchain *config.DestinationBlockchain) error {
+	return UpdateSigners(c.DestinationClient, destinationBlockchain)
+}
+
+// WaitForConfirmations waits for confirmations of a transaction sent by the wrapped client.
+func (c *circuitBreakerDestinationClient) WaitForConfirmations(
+	ctx context.Context,
+	txHash common.Hash,
+	confirmations uint64,
+) error {
+	return WaitForConfirmations(ctx, c.DestinationClient, txHash, confirmations)
+}
+
+// acquire blocks until a send may be attempted, or [ctx] is canceled. Returns true if the send is the trial
+// send of a half-open circuit, in which case no other sends are attempted until it completes.
+func (c *circuitBreakerDestinationClient) acquire(ctx context.Context) (bool, error) {
+	for {
+		c.lock.Lock()
+		if c.state == circuitOpen && !time.Now().Before(c.openUntil) {
+			c.setState(circuitHalfOpen)
+		}
+		var timer *time.Timer
+		var wait <-chan time.Time
+		switch c.state {
+		case circuitClosed:
+			c.lock.Unlock()
+			return false, nil
+		case circuitHalfOpen:
+			if !c.trialInFlight {
+				c.trialInFlight = true
+				c.lock.Unlock()
+				return true, nil
+			}
+		case circuitOpen:
+			timer = time.NewTimer(time.Until(c.openUntil))
+			wait = timer.C
+		}
+		stateChanged := c.stateChanged
+		c.lock.Unlock()
+
+		select {
+		case <-ctx.Done():
+		case <-stateChanged:
+		case <-wait:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+	}
+}
+
+// release records the outcome of a send acquired with acquire, opening or closing the circuit as required.
+// Sends that are skipped, or whose context is canceled, do not indicate the health of the destination.
+func (c *circuitBreakerDestinationClient) release(ctx context.Context, trial bool, err error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if trial {
+		c.trialInFlight = false
+	}
+
+	if err == nil || errors.Is(err, ErrMessageAlreadyReceived) {
+		c.consecutiveFailures = 0
+		if trial {
+			c.logger.Info(
+				"Trial send succeeded. Closing circuit breaker",
+				zap.String("destinationBlockchainID", c.DestinationBlockchainID().String()),
+			)
+			c.currentCooldown = 0
+			c.setState(circuitClosed)
+		}
+		return
+	}
+	if ctx.Err() != nil || errors.Is(err, ErrDryRun) || errors.Is(err, ErrCallNotSupported) {
+		if trial {
+			// Allow another send to be the trial
+			c.setState(circuitHalfOpen)
+		}
+		return
+	}
+
+	c.consecutiveFailures++
+	switch {
+	case trial:
+		c.currentCooldown = min(2*c.currentCooldown, c.maxCooldown)
+	case c.state == circuitClosed && c.consecutiveFailures >= c.failureThreshold:
+		c.currentCooldown = c.cooldown
+	default:
+		return
+	}
+	c.openUntil = time.Now().Add(c.currentCooldown)
+	c.logger.Warn(
+		"Opening circuit breaker after consecutive send failures",
+		zap.String("destinationBlockchainID", c.DestinationBlockchainID().String()),
+		zap.Uint64("consecutiveFailures", c.consecutiveFailures),
+		zap.Duration("cooldown", c.currentCooldown),
+		zap.Error(err),
+	)
+	c.setState(circuitOpen)
+}
+
+// setState transitions the circuit to [state], and wakes any sends waiting for the circuit.
+// Must be called with the lock held.
+func (c *circuitBreakerDestinationClient) setState(state circuitState) {
+	if c.state != state {
+		c.logger.Debug(
+			"Circuit breaker state changed",
+			zap.String("destinationBlockchainID", c.DestinationBlockchainID().String()),
+			zap.Stringer("from", c.state),
+			zap.Stringer("to", state),
+		)
+	}
+	c.state = state
+	close(c.stateChanged)
+	c.stateChanged = make(chan struct{})
+	c.recordState()
+}
+
+func (c *circuitBreakerDestinationClient) recordState() {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.SetCircuitBreakerState(c.DestinationBlockchainID(), float64(c.state))
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vms
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/awm-relayer/config"
+	mock_vms "github.com/ava-labs/awm-relayer/vms/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCircuitBreakerDestinationClient(t *testing.T) {
+	errSend := errors.New("failed to send transaction")
+	txHash := common.HexToHash("0x01")
+
+	testCases := []struct {
+		name string
+		// Results of the sends issued by the wrapped client, in order
+		results          []error
+		expectedState    circuitState
+		expectedCooldown time.Duration
+	}{
+		{
+			name:          "failures below threshold",
+			results:       []error{errSend, nil, errSend},
+			expectedState: circuitClosed,
+		},
+		{
+			name:             "opens after consecutive failures",
+			results:          []error{errSend, errSend},
+			expectedState:    circuitOpen,
+			expectedCooldown: 10 * time.Millisecond,
+		},
+		{
+			name:          "skipped sends do not count as failures",
+			results:       []error{errSend, ErrMessageAlreadyReceived, errSend},
+			expectedState: circuitClosed,
+		},
+		{
+			name:          "trial send success closes",
+			results:       []error{errSend, errSend, nil},
+			expectedState: circuitClosed,
+		},
+		{
+			name:             "trial send failure doubles cooldown",
+			results:          []error{errSend, errSend, errSend},
+			expectedState:    circuitOpen,
+			expectedCooldown: 20 * time.Millisecond,
+		},
+		{
+			name:             "cooldown is capped",
+			results:          []error{errSend, errSend, errSend, errSend, errSend},
+			expectedState:    circuitOpen,
+			expectedCooldown: 30 * time.Millisecond,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockClient := mock_vms.NewMockDestinationClient(ctrl)
+			mockClient.EXPECT().DestinationBlockchainID().Return(ids.GenerateTestID()).AnyTimes()
+			for _, result := range testCase.results {
+				mockClient.EXPECT().SendTx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(txHash, result)
+			}
+
+			client := NewCircuitBreakerDestinationClient(
+				logging.NoLog{},
+				nil,
+				mockClient,
+				&config.DestinationBlockchain{CircuitBreakerFailureThreshold: 2},
+			).(*circuitBreakerDestinationClient)
+			client.cooldown = 10 * time.Millisecond
+			client.maxCooldown = 30 * time.Millisecond
+
+			for _, expected := range testCase.results {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				_, err := client.SendTx(ctx, &warp.Message{}, "", 100_000, []byte{})
+				cancel()
+				require.ErrorIs(t, err, expected)
+			}
+			require.Equal(t, testCase.expectedState, client.state)
+			require.Equal(t, testCase.expectedCooldown, client.currentCooldown)
+			require.False(t, client.trialInFlight)
+
+			if testCase.expectedState == circuitOpen {
+				// Sends are not attempted while the circuit is open
+				ctx, cancel := context.WithTimeout(context.Background(), testCase.expectedCooldown/2)
+				defer cancel()
+				_, err := client.SendTx(ctx, &warp.Message{}, "", 100_000, []byte{})
+				require.ErrorIs(t, err, context.DeadlineExceeded)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/awm-relayer/config"
 	"github.com/ava-labs/awm-relayer/vms/evm"
+	"github.com/ava-labs/awm-relayer/vms/pchain"
 )
 
 type ContractMessage interface {
@@ -19,6 +20,8 @@ func NewContractMessage(logger logging.Logger, subnetInfo config.SourceBlockchai
 	switch config.ParseVM(subnetInfo.VM) {
 	case config.EVM:
 		return evm.NewContractMessage(logger, subnetInfo)
+	case config.P_CHAIN:
+		return pchain.NewContractMessage(logger, subnetInfo)
 	default:
 		return nil
 	}
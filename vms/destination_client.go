@@ -6,7 +6,10 @@
 package vms
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -23,32 +26,227 @@ import (
 type DestinationClient interface {
 	// SendTx constructs the transaction from warp primitives, and sends to the configured destination chain endpoint.
 	// Returns the hash of the sent transaction.
-	// TODO: Make generic for any VM.
-	SendTx(signedMessage *warp.Message, toAddress string, gasLimit uint64, callData []byte) (common.Hash, error)
+	//
+	// [signedMessage] is the aggregate-signed Warp message to deliver. [toAddress] and [callData] are produced
+	// by the message handler for the destination, and are opaque to the relayer: a non-EVM implementation may
+	// interpret them however its VM requires, for example as a hex-encoded account or contract identifier and
+	// a serialized transaction payload. [gasLimit] is an upper bound on the execution cost of the delivery,
+	// and may be ignored by VMs without a gas model.
+	//
+	// SendTx must not return until the transaction has been accepted by the destination chain, or has
+	// definitively failed, or [ctx] is canceled. A nil error is treated as a successful delivery, and allows the source block to be
+	// checkpointed. The returned hash identifies the transaction in logs, and may be a VM-specific transaction
+	// ID encoded into 32 bytes.
+	SendTx(
+		ctx context.Context,
+		signedMessage *warp.Message,
+		toAddress string,
+		gasLimit uint64,
+		callData []byte,
+	) (common.Hash, error)
+
+	// SendBatch delivers [signedMessages] to [toAddress] in a single transaction, and returns its hash.
+	// [callData] holds the call data produced for the individual delivery of each message, in the same order,
+	// and [gasLimit] is the sum of their gas limits. The receiver at [toAddress] must support batch delivery.
+	//
+	// SendBatch has the same completion semantics as SendTx. A nil error is treated as the successful
+	// delivery of every message in the batch.
+	SendBatch(
+		ctx context.Context,
+		signedMessages []*warp.Message,
+		toAddress string,
+		gasLimit uint64,
+		callData [][]byte,
+	) (common.Hash, error)
 
 	// Client returns the underlying client for the destination chain
 	Client() interface{}
 
-	// SenderAddress returns the address of the relayer on the destination chain
-	SenderAddress() common.Address
+	// SenderAddresses returns the addresses of the relayer on the destination chain. Each delivery may be
+	// sent from any one of them. Clients with a single signing key return a single address.
+	SenderAddresses() []common.Address
 
 	// DestinationBlockchainID returns the ID of the destination chain
 	DestinationBlockchainID() ids.ID
 }
 
-func NewDestinationClient(logger logging.Logger, subnetInfo *config.DestinationBlockchain) (DestinationClient, error) {
-	switch config.ParseVM(subnetInfo.VM) {
-	case config.EVM:
-		return evm.NewDestinationClient(logger, subnetInfo)
-	default:
-		return nil, fmt.Errorf("invalid vm")
+// ErrSignerUpdateNotSupported is returned when updating the signing keys of a destination client that does not
+// support doing so. The client must be recreated to use new keys.
+var ErrSignerUpdateNotSupported = errors.New("destination client does not support updating signing keys")
+
+// SignerUpdater is implemented by DestinationClients whose signing keys may be replaced while running,
+// for example when the relayer configuration is reloaded.
+type SignerUpdater interface {
+	// UpdateSigners replaces the client's signing keys with those configured for [destinationBlockchain].
+	UpdateSigners(destinationBlockchain *config.DestinationBlockchain) error
+}
+
+// UpdateSigners replaces the signing keys of [client] with those configured for [destinationBlockchain],
+// or returns ErrSignerUpdateNotSupported if the client does not implement SignerUpdater.
+func UpdateSigners(client DestinationClient, destinationBlockchain *config.DestinationBlockchain) error {
+	updater, ok := client.(SignerUpdater)
+	if !ok {
+		return ErrSignerUpdateNotSupported
 	}
+	return updater.UpdateSigners(destinationBlockchain)
 }
 
-// CreateDestinationClients creates destination clients for all subnets configured as destinations
+// ErrConfirmationsNotSupported is returned when waiting for confirmations of a transaction sent by a destination
+// client that cannot determine the depth of its transactions.
+var ErrConfirmationsNotSupported = errors.New("destination client does not support waiting for confirmations")
+
+// ErrTxReorged is returned when a delivery transaction is no longer included in the destination chain before
+// reaching the required number of confirmations. The message must be delivered again. Destination clients for
+// other VMs should return this error as well.
+var ErrTxReorged = evm.ErrTxReorged
+
+// ErrMessageAlreadyReceived is returned when a destination client finds, immediately before issuing a delivery,
+// that the destination has already received the message. The message need not be delivered again.
+var ErrMessageAlreadyReceived = evm.ErrMessageAlreadyReceived
+
+// ErrNoAllowedSender is returned when none of a destination client's sender addresses is allowed to send
+// a delivery restricted by WithAllowedSenders.
+var ErrNoAllowedSender = evm.ErrNoAllowedSender
+
+// WithAllowedSenders returns a copy of [ctx] that restricts the sender addresses that may send a delivery
+// to [senders]. Destination clients with multiple sender addresses send the delivery from one that is allowed.
+// An empty [senders] leaves the sender address unrestricted.
+func WithAllowedSenders(ctx context.Context, senders []common.Address) context.Context {
+	return evm.WithAllowedSenders(ctx, senders)
+}
+
+// AllowedSenders returns the sender addresses that [ctx] allows to send a delivery, or nil if any
+// address may send it.
+func AllowedSenders(ctx context.Context) []common.Address {
+	return evm.AllowedSenders(ctx)
+}
+
+// NonceAllocator hands out the nonces of destination sender accounts whose signing keys are shared with other
+// relayers. Destination clients for other VMs with account nonces may use it as well.
+type NonceAllocator = evm.NonceAllocator
+
+// ErrSharedNoncesNotSupported is returned when a destination configured to share nonces with other relayers
+// is served by a destination client that does not implement NonceAllocatorSetter.
+var ErrSharedNoncesNotSupported = errors.New("destination client does not support sharing nonces")
+
+// NonceAllocatorSetter is implemented by DestinationClients that can allocate the nonces of their transactions
+// via a NonceAllocator shared with other relayers.
+type NonceAllocatorSetter interface {
+	// SetNonceAllocator configures the client to allocate nonces via [allocator]. Called before the client
+	// sends any transactions.
+	SetNonceAllocator(allocator NonceAllocator)
+}
+
+// ConfirmationWaiter is implemented by DestinationClients that can determine the number of blocks built on top of
+// the block including a delivery transaction.
+type ConfirmationWaiter interface {
+	// WaitForConfirmations blocks until the transaction [txHash] is included in a block with at least
+	// [confirmations] blocks built on top of it, or [ctx] is canceled. Returns ErrTxReorged if the transaction
+	// is no longer included in the destination chain.
+	WaitForConfirmations(ctx context.Context, txHash common.Hash, confirmations uint64) error
+}
+
+// WaitForConfirmations waits for the transaction [txHash] sent by [client] to reach [confirmations], or returns
+// ErrConfirmationsNotSupported if the client does not implement ConfirmationWaiter.
+func WaitForConfirmations(
+	ctx context.Context,
+	client DestinationClient,
+	txHash common.Hash,
+	confirmations uint64,
+) error {
+	waiter, ok := client.(ConfirmationWaiter)
+	if !ok {
+		return ErrConfirmationsNotSupported
+	}
+	return waiter.WaitForConfirmations(ctx, txHash, confirmations)
+}
+
+// ErrCallNotSupported is returned when issuing a transaction that does not deliver a Warp message via a
+// destination client that does not support doing so.
+var ErrCallNotSupported = errors.New("destination client does not support sending transactions without a Warp message")
+
+// CallSender is implemented by DestinationClients that can issue transactions that do not deliver a Warp message,
+// for example to call a messaging protocol's contracts on the destination chain.
+type CallSender interface {
+	// SendCall issues a transaction calling [toAddress] with [callData], and returns its hash.
+	// SendCall has the same completion semantics as SendTx.
+	SendCall(ctx context.Context, toAddress string, gasLimit uint64, callData []byte) (common.Hash, error)
+}
+
+// SendCall issues a transaction calling [toAddress] with [callData] via [client], or returns ErrCallNotSupported
+// if the client does not implement CallSender.
+func SendCall(
+	ctx context.Context,
+	client DestinationClient,
+	toAddress string,
+	gasLimit uint64,
+	callData []byte,
+) (common.Hash, error) {
+	sender, ok := client.(CallSender)
+	if !ok {
+		return common.Hash{}, ErrCallNotSupported
+	}
+	return sender.SendCall(ctx, toAddress, gasLimit, callData)
+}
+
+// DestinationClientFactory constructs a DestinationClient for [destinationBlockchain].
+// [metrics] may be nil, for example for shadow destination clients. Any background goroutines of the client
+// should return once [ctx] is canceled.
+type DestinationClientFactory func(
+	ctx context.Context,
+	logger logging.Logger,
+	metrics *evm.DestinationClientMetrics,
+	destinationBlockchain *config.DestinationBlockchain,
+) (DestinationClient, error)
+
+var (
+	destinationClientFactoriesLock sync.RWMutex
+	destinationClientFactories     = map[config.VM]DestinationClientFactory{
+		config.EVM: func(
+			ctx context.Context,
+			logger logging.Logger,
+			metrics *evm.DestinationClientMetrics,
+			destinationBlockchain *config.DestinationBlockchain,
+		) (DestinationClient, error) {
+			return evm.NewDestinationClient(ctx, logger, metrics, destinationBlockchain)
+		},
+	}
+)
+
+// RegisterDestinationClientFactory sets the factory used to construct destination clients for [vm],
+// replacing any existing factory. Non-native VMs should first be registered with config.RegisterVM.
+// Must be called before the relayer creates its destination clients, typically from an init function.
+func RegisterDestinationClientFactory(vm config.VM, factory DestinationClientFactory) {
+	destinationClientFactoriesLock.Lock()
+	defer destinationClientFactoriesLock.Unlock()
+	destinationClientFactories[vm] = factory
+}
+
+func NewDestinationClient(
+	ctx context.Context,
+	logger logging.Logger,
+	metrics *evm.DestinationClientMetrics,
+	subnetInfo *config.DestinationBlockchain,
+) (DestinationClient, error) {
+	vm := config.ParseVM(subnetInfo.VM)
+	destinationClientFactoriesLock.RLock()
+	factory, ok := destinationClientFactories[vm]
+	destinationClientFactoriesLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no destination client registered for vm %s", subnetInfo.VM)
+	}
+	return factory(ctx, logger, metrics, subnetInfo)
+}
+
+// CreateDestinationClients creates destination clients for all subnets configured as destinations.
+// [nonceAllocator] is used by the destinations configured to share nonces, and may otherwise be nil.
+// The clients' background goroutines run until [ctx] is canceled.
 func CreateDestinationClients(
+	ctx context.Context,
 	logger logging.Logger,
+	metrics *evm.DestinationClientMetrics,
 	relayerConfig config.Config,
+	nonceAllocator NonceAllocator,
 ) (map[ids.ID]DestinationClient, error) {
 	destinationClients := make(map[ids.ID]DestinationClient)
 	for _, subnetInfo := range relayerConfig.DestinationBlockchains {
@@ -69,7 +267,7 @@ func CreateDestinationClients(
 			continue
 		}
 
-		destinationClient, err := NewDestinationClient(logger, subnetInfo)
+		destinationClient, err := NewDestinationClient(ctx, logger, metrics, subnetInfo)
 		if err != nil {
 			logger.Error(
 				"Could not create destination client",
@@ -78,8 +276,87 @@ func CreateDestinationClients(
 			)
 			return nil, err
 		}
+		if subnetInfo.ShareNonces {
+			setter, ok := destinationClient.(NonceAllocatorSetter)
+			if !ok || nonceAllocator == nil {
+				logger.Error(
+					"Could not configure shared nonces",
+					zap.String("blockchainID", blockchainID.String()),
+				)
+				return nil, ErrSharedNoncesNotSupported
+			}
+			setter.SetNonceAllocator(nonceAllocator)
+		}
 
+		if relayerConfig.DryRun {
+			destinationClient = NewDryRunDestinationClient(logger, destinationClient)
+		}
+		if subnetInfo.CircuitBreakerEnabled() {
+			destinationClient = NewCircuitBreakerDestinationClient(logger, metrics, destinationClient, subnetInfo)
+		}
+		// Waiting for the rate limiter is not a send failure, so the rate limiter wraps the circuit breaker.
+		// Batches are sent in a single transaction, so count as a single send.
+		if subnetInfo.RateLimitEnabled() {
+			destinationClient = NewRateLimitedDestinationClient(logger, metrics, destinationClient, subnetInfo)
+		}
+		if subnetInfo.BatchingEnabled() {
+			destinationClient = NewBatchingDestinationClient(
+				logger,
+				destinationClient,
+				int(subnetInfo.BatchSize),
+				subnetInfo.GetBatchTimeout(),
+			)
+		}
 		destinationClients[blockchainID] = destinationClient
 	}
 	return destinationClients, nil
 }
+
+// CreateShadowDestinationClients creates destination clients for the shadow endpoints of all subnets configured
+// as destinations. Destinations without a shadow endpoint are omitted. The clients' background goroutines run
+// until [ctx] is canceled.
+func CreateShadowDestinationClients(
+	ctx context.Context,
+	logger logging.Logger,
+	relayerConfig config.Config,
+) (map[ids.ID]DestinationClient, error) {
+	shadowClients := make(map[ids.ID]DestinationClient)
+	for _, subnetInfo := range relayerConfig.DestinationBlockchains {
+		if !subnetInfo.HasShadowEndpoint() {
+			continue
+		}
+		blockchainID := subnetInfo.GetBlockchainID()
+		if _, ok := shadowClients[blockchainID]; ok {
+			continue
+		}
+
+		// The shadow client is identical to the destination client, but issues transactions to the shadow endpoint
+		shadowInfo := *subnetInfo
+		shadowInfo.RPCEndpoint = subnetInfo.ShadowEndpoint
+		shadowInfo.FallbackRPCEndpoints = nil
+		shadowInfo.StaleHeadTimeoutSeconds = 0
+		shadowInfo.EndpointHealthCheckIntervalSeconds = 0
+		// Shadow deliveries are best effort. They are only submitted to the shadow endpoint,
+		// dropped transactions are not re-submitted, stuck transactions are not replaced, and nonce gaps are not filled.
+		shadowInfo.PendingTxTimeoutSeconds = 0
+		shadowInfo.InclusionDeadlineSeconds = 0
+		shadowInfo.FillNonceGaps = false
+		shadowInfo.ShareNonces = false
+		shadowInfo.BroadcastToAllEndpoints = false
+		shadowClient, err := NewDestinationClient(ctx, logger, nil, &shadowInfo)
+		if err != nil {
+			logger.Error(
+				"Could not create shadow destination client",
+				zap.String("blockchainID", blockchainID.String()),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+
+		if relayerConfig.DryRun {
+			shadowClient = NewDryRunDestinationClient(logger, shadowClient)
+		}
+		shadowClients[blockchainID] = shadowClient
+	}
+	return shadowClients, nil
+}
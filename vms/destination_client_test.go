@@ -0,0 +1,47 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vms
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/vms/evm"
+	mock_vms "github.com/ava-labs/awm-relayer/vms/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRegisterDestinationClientFactory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockClient := mock_vms.NewMockDestinationClient(ctrl)
+
+	testVM := config.RegisterVM("test-vm")
+	require.Equal(t, testVM, config.ParseVM("test-vm"))
+	require.Equal(t, "test-vm", testVM.String())
+
+	destinationBlockchain := config.TestValidDestinationBlockchainConfig
+	destinationBlockchain.VM = "test-vm"
+
+	// No factory is registered for the VM yet
+	_, err := NewDestinationClient(context.Background(), logging.NoLog{}, nil, &destinationBlockchain)
+	require.Error(t, err)
+
+	RegisterDestinationClientFactory(
+		testVM,
+		func(
+			context.Context,
+			logging.Logger,
+			*evm.DestinationClientMetrics,
+			*config.DestinationBlockchain,
+		) (DestinationClient, error) {
+			return mockClient, nil
+		},
+	)
+	client, err := NewDestinationClient(context.Background(), logging.NoLog{}, nil, &destinationBlockchain)
+	require.NoError(t, err)
+	require.Equal(t, mockClient, client)
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vms
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.uber.org/zap"
+)
+
+// ErrDryRun is returned by destination clients in dry-run mode in place of issuing a transaction.
+var ErrDryRun = errors.New("dry run: transaction not sent")
+
+// dryRunDestinationClient wraps a DestinationClient, logging the transactions that would have been
+// issued rather than sending them. All other methods are passed through to the wrapped client.
+type dryRunDestinationClient struct {
+	DestinationClient
+	logger logging.Logger
+}
+
+func NewDryRunDestinationClient(logger logging.Logger, client DestinationClient) DestinationClient {
+	return &dryRunDestinationClient{
+		DestinationClient: client,
+		logger:            logger,
+	}
+}
+
+// SendTx logs the transaction that would have been sent, and returns ErrDryRun.
+func (c *dryRunDestinationClient) SendTx(
+	_ context.Context,
+	signedMessage *warp.Message,
+	toAddress string,
+	gasLimit uint64,
+	callData []byte,
+) (common.Hash, error) {
+	c.logger.Info(
+		"Dry run. Skipping transaction",
+		zap.String("destinationBlockchainID", c.DestinationBlockchainID().String()),
+		zap.String("toAddress", toAddress),
+		zap.Uint64("gasLimit", gasLimit),
+		zap.String("warpMessageID", signedMessage.ID().String()),
+		zap.String("signedMessage", hexutil.Encode(signedMessage.Bytes())),
+		zap.String("callData", hexutil.Encode(callData)),
+	)
+	return common.Hash{}, ErrDryRun
+}
+
+// SendBatch logs the batch transaction that would have been sent, and returns ErrDryRun.
+func (c *dryRunDestinationClient) SendBatch(
+	_ context.Context,
+	signedMessages []*warp.Message,
+	toAddress string,
+	gasLimit uint64,
+	callData [][]byte,
+) (common.Hash, error) {
+	warpMessageIDs := make([]string, 0, len(signedMessages))
+	for _, signedMessage := range signedMessages {
+		warpMessageIDs = append(warpMessageIDs, signedMessage.ID().String())
+	}
+	c.logger.Info(
+		"Dry run. Skipping batch transaction",
+		zap.String("destinationBlockchainID", c.DestinationBlockchainID().String()),
+		zap.String("toAddress", toAddress),
+		zap.Uint64("gasLimit", gasLimit),
+		zap.Strings("warpMessageIDs", warpMessageIDs),
+	)
+	return common.Hash{}, ErrDryRun
+}
+
+// SendCall logs the transaction that would have been sent, and returns ErrDryRun.
+func (c *dryRunDestinationClient) SendCall(
+	_ context.Context,
+	toAddress string,
+	gasLimit uint64,
+	callData []byte,
+) (common.Hash, error) {
+	c.logger.Info(
+		"Dry run. Skipping transaction",
+		zap.String("destinationBlockchainID", c.DestinationBlockchainID().String()),
+		zap.String("toAddress", toAddress),
+		zap.Uint64("gasLimit", gasLimit),
+		zap.String("callData", hexutil.Encode(callData)),
+	)
+	return common.Hash{}, ErrDryRun
+}
+
+// UpdateSigners updates the signing keys of the wrapped client.
+func (c *dryRunDestinationClient) UpdateSigners(destinationBlockchain *config.DestinationBlockchain) error {
+	return UpdateSigners(c.DestinationClient, destinationBlockchain)
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrNoAllowedSender is returned when none of the destination client's sender accounts is allowed to send
+// a delivery restricted by WithAllowedSenders.
+var ErrNoAllowedSender = errors.New("no sender account is allowed to send the delivery")
+
+type allowedSendersKey struct{}
+
+// WithAllowedSenders returns a copy of [ctx] that restricts the sender accounts that may send a delivery
+// to [senders]. An empty [senders] leaves the sender account unrestricted.
+func WithAllowedSenders(ctx context.Context, senders []common.Address) context.Context {
+	return context.WithValue(ctx, allowedSendersKey{}, senders)
+}
+
+// AllowedSenders returns the sender accounts that [ctx] allows to send a delivery, or nil if any
+// account may send it.
+func AllowedSenders(ctx context.Context) []common.Address {
+	senders, _ := ctx.Value(allowedSendersKey{}).([]common.Address)
+	return senders
+}
+
+func isAllowedSender(allowedSenders []common.Address, sender common.Address) bool {
+	if len(allowedSenders) == 0 {
+		return true
+	}
+	for _, allowed := range allowedSenders {
+		if allowed == sender {
+			return true
+		}
+	}
+	return false
+}
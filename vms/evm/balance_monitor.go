@@ -0,0 +1,86 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+const balanceRPCTimeout = 10 * time.Second
+
+// watchBalances periodically reports the balance of each sender account, starting immediately, until [ctx] is
+// canceled.
+func (c *destinationClient) watchBalances(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		c.checkBalances()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkBalances sets the balance gauge of each sender account, and warns when an account's balance
+// falls below the low balance threshold.
+func (c *destinationClient) checkBalances() {
+	c.accountsLock.RLock()
+	addresses := make([]common.Address, 0, len(c.accounts))
+	for _, account := range c.accounts {
+		addresses = append(addresses, account.signer.Address())
+	}
+	c.accountsLock.RUnlock()
+
+	for _, address := range addresses {
+		ctx, cancel := context.WithTimeout(context.Background(), balanceRPCTimeout)
+		balance, err := c.client.BalanceAt(ctx, address, nil)
+		cancel()
+		if err != nil {
+			c.logger.Warn(
+				"Failed to get account balance",
+				zap.String("destinationBlockchainID", c.destinationBlockchainID.String()),
+				zap.String("address", address.String()),
+				zap.Error(err),
+			)
+			continue
+		}
+		balanceFloat, _ := new(big.Float).SetInt(balance).Float64()
+		c.metrics.accountBalance.
+			WithLabelValues(c.destinationBlockchainID.String(), address.String()).
+			Set(balanceFloat)
+
+		if c.lowBalanceThreshold == nil {
+			continue
+		}
+		// Only log when the balance crosses the threshold, rather than on every check
+		low := balance.Cmp(c.lowBalanceThreshold) < 0
+		if low == c.lowBalanceAccounts[address] {
+			continue
+		}
+		c.lowBalanceAccounts[address] = low
+		if low {
+			c.logger.Warn(
+				"Account balance is below the low balance threshold",
+				zap.String("destinationBlockchainID", c.destinationBlockchainID.String()),
+				zap.String("address", address.String()),
+				zap.String("balance", balance.String()),
+				zap.String("lowBalanceThreshold", c.lowBalanceThreshold.String()),
+			)
+		} else {
+			c.logger.Info(
+				"Account balance is above the low balance threshold",
+				zap.String("destinationBlockchainID", c.destinationBlockchainID.String()),
+				zap.String("address", address.String()),
+				zap.String("balance", balance.String()),
+			)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	mock_ethclient "github.com/ava-labs/awm-relayer/vms/evm/mocks"
+	"github.com/ava-labs/awm-relayer/vms/evm/signer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCheckBalances(t *testing.T) {
+	txSigner, err := signer.NewTxSigner(destinationSubnet.AccountPrivateKey)
+	require.NoError(t, err)
+	address := txSigner.Address()
+
+	testCases := []struct {
+		name                string
+		lowBalanceThreshold *big.Int
+		// The balance returned by each successive check
+		balances        []*big.Int
+		balanceErr      error
+		expectedBalance float64
+		expectedLow     bool
+	}{
+		{
+			name:            "no threshold",
+			balances:        []*big.Int{big.NewInt(10)},
+			expectedBalance: 10,
+		},
+		{
+			name:                "above threshold",
+			lowBalanceThreshold: big.NewInt(5),
+			balances:            []*big.Int{big.NewInt(10)},
+			expectedBalance:     10,
+		},
+		{
+			name:                "below threshold",
+			lowBalanceThreshold: big.NewInt(5),
+			balances:            []*big.Int{big.NewInt(10), big.NewInt(4)},
+			expectedBalance:     4,
+			expectedLow:         true,
+		},
+		{
+			name:                "recovered above threshold",
+			lowBalanceThreshold: big.NewInt(5),
+			balances:            []*big.Int{big.NewInt(4), big.NewInt(5)},
+			expectedBalance:     5,
+		},
+		{
+			name:                "balance error",
+			lowBalanceThreshold: big.NewInt(5),
+			balances:            []*big.Int{nil},
+			balanceErr:          fmt.Errorf("connection refused"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockClient := mock_ethclient.NewMockClient(ctrl)
+			metrics, err := NewDestinationClientMetrics(prometheus.NewRegistry())
+			require.NoError(t, err)
+			destinationBlockchainID := ids.GenerateTestID()
+			destinationClient := &destinationClient{
+				logger:                  logging.NoLog{},
+				client:                  mockClient,
+				destinationBlockchainID: destinationBlockchainID,
+				accounts:                []*senderAccount{{signer: txSigner}},
+				metrics:                 metrics,
+				lowBalanceThreshold:     test.lowBalanceThreshold,
+				lowBalanceAccounts:      make(map[common.Address]bool),
+			}
+
+			for _, balance := range test.balances {
+				mockClient.EXPECT().BalanceAt(gomock.Any(), address, nil).Return(balance, test.balanceErr).Times(1)
+				destinationClient.checkBalances()
+			}
+			require.Equal(
+				t,
+				test.expectedBalance,
+				testutil.ToFloat64(metrics.accountBalance.WithLabelValues(destinationBlockchainID.String(), address.String())),
+			)
+			require.Equal(t, test.expectedLow, destinationClient.lowBalanceAccounts[address])
+		})
+	}
+}
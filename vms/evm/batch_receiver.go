@@ -0,0 +1,39 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+)
+
+// The entrypoint implemented by receivers that support batch delivery. [calls] holds the call data produced
+// for the individual delivery of each message, in the order the messages are included in the transaction's
+// Warp predicates. The receiver is responsible for delivering the i'th call with the i'th Warp message.
+const batchReceiverABIJSON = `[{
+	"type": "function",
+	"name": "receiveBatch",
+	"inputs": [
+		{"name": "calls", "type": "bytes[]"}
+	],
+	"outputs": [],
+	"stateMutability": "nonpayable"
+}]`
+
+var batchReceiverABI abi.ABI
+
+func init() {
+	var err error
+	batchReceiverABI, err = abi.JSON(strings.NewReader(batchReceiverABIJSON))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// packReceiveBatch packs the call data of each delivery in a batch into a single call to the receiver's
+// batch entrypoint.
+func packReceiveBatch(callData [][]byte) ([]byte, error) {
+	return batchReceiverABI.Pack("receiveBatch", callData)
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// Interval at which the depth of a delivery transaction is checked while waiting for confirmations
+var confirmationCheckInterval = 2 * time.Second
+
+// ErrTxReorged is returned when a delivery transaction is neither included in a block nor pending in the mempool
+var ErrTxReorged = errors.New("delivery transaction is no longer included in the destination chain")
+
+// WaitForConfirmations blocks until the transaction [txHash] is included in a block with at least [confirmations]
+// blocks built on top of it. A transaction that is reorged out of its block but returned to the mempool is waited on
+// until it is included again. Returns ErrTxReorged if the transaction is no longer known to the destination chain.
+func (c *destinationClient) WaitForConfirmations(
+	ctx context.Context,
+	txHash common.Hash,
+	confirmations uint64,
+) error {
+	ticker := time.NewTicker(confirmationCheckInterval)
+	defer ticker.Stop()
+	for {
+		confirmed, err := c.checkConfirmations(ctx, txHash, confirmations)
+		if errors.Is(err, ErrTxReorged) {
+			c.logger.Warn(
+				"Delivery transaction reorged out before reaching the required confirmations",
+				zap.String("destinationBlockchainID", c.destinationBlockchainID.String()),
+				zap.String("txID", txHash.String()),
+				zap.Uint64("confirmations", confirmations),
+			)
+			return err
+		}
+		if err != nil {
+			// Transient RPC failures are retried on the next tick
+			c.logger.Warn(
+				"Failed to check transaction confirmations",
+				zap.String("destinationBlockchainID", c.destinationBlockchainID.String()),
+				zap.String("txID", txHash.String()),
+				zap.Error(err),
+			)
+		}
+		if confirmed {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkConfirmations returns true if the transaction [txHash] has reached [confirmations], or ErrTxReorged if it is
+// neither included in a block nor pending.
+func (c *destinationClient) checkConfirmations(
+	ctx context.Context,
+	txHash common.Hash,
+	confirmations uint64,
+) (bool, error) {
+	callCtx, cancel := context.WithTimeout(ctx, pendingTxRPCTimeout)
+	defer cancel()
+	txHash = c.currentTxHash(txHash)
+	receipt, err := c.client.TransactionReceipt(callCtx, txHash)
+	if errors.Is(err, interfaces.NotFound) {
+		// The transaction may have been returned to the mempool after being reorged out
+		_, _, err = c.client.TransactionByHash(callCtx, txHash)
+		if errors.Is(err, interfaces.NotFound) {
+			return false, ErrTxReorged
+		}
+		return false, err
+	}
+	if err != nil {
+		return false, err
+	}
+	head, err := c.client.BlockNumber(callCtx)
+	if err != nil {
+		return false, err
+	}
+	return head >= receipt.BlockNumber.Uint64()+confirmations, nil
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	mock_ethclient "github.com/ava-labs/awm-relayer/vms/evm/mocks"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWaitForConfirmations(t *testing.T) {
+	confirmationCheckInterval = time.Millisecond
+	txHash := common.Hash{1}
+	const (
+		txBlock       = uint64(100)
+		confirmations = uint64(3)
+	)
+
+	type check struct {
+		receiptErr error
+		pending    bool
+		headErr    error
+		head       uint64
+	}
+	testCases := []struct {
+		name        string
+		checks      []check
+		expectedErr error
+	}{
+		{
+			name:   "already confirmed",
+			checks: []check{{head: txBlock + confirmations}},
+		},
+		{
+			name: "confirmed after new blocks",
+			checks: []check{
+				{head: txBlock},
+				{headErr: fmt.Errorf("connection reset")},
+				{head: txBlock + confirmations},
+			},
+		},
+		{
+			name: "reincluded after reorg",
+			checks: []check{
+				{head: txBlock + 1},
+				{receiptErr: interfaces.NotFound, pending: true},
+				{head: txBlock + confirmations + 1},
+			},
+		},
+		{
+			name: "reorged out",
+			checks: []check{
+				{head: txBlock + 1},
+				{receiptErr: interfaces.NotFound},
+			},
+			expectedErr: ErrTxReorged,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockClient := mock_ethclient.NewMockClient(ctrl)
+			destinationClient := &destinationClient{
+				logger: logging.NoLog{},
+				client: mockClient,
+			}
+
+			var calls []any
+			for _, c := range test.checks {
+				if c.receiptErr != nil {
+					calls = append(calls, mockClient.EXPECT().TransactionReceipt(gomock.Any(), txHash).
+						Return(nil, c.receiptErr))
+					txErr := interfaces.NotFound
+					if c.pending {
+						txErr = nil
+					}
+					calls = append(calls, mockClient.EXPECT().TransactionByHash(gomock.Any(), txHash).
+						Return(nil, c.pending, txErr))
+					continue
+				}
+				calls = append(calls, mockClient.EXPECT().TransactionReceipt(gomock.Any(), txHash).
+					Return(&types.Receipt{BlockNumber: new(big.Int).SetUint64(txBlock)}, nil))
+				calls = append(calls, mockClient.EXPECT().BlockNumber(gomock.Any()).Return(c.head, c.headErr))
+			}
+			gomock.InOrder(calls...)
+
+			err := destinationClient.WaitForConfirmations(context.Background(), txHash, confirmations)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -9,17 +9,20 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/awm-relayer/config"
+	relayerTypes "github.com/ava-labs/awm-relayer/types"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
 	"go.uber.org/zap"
 )
 
 type contractMessage struct {
-	logger logging.Logger
+	logger          logging.Logger
+	maxMessageBytes uint64
 }
 
 func NewContractMessage(logger logging.Logger, subnetInfo config.SourceBlockchain) *contractMessage {
 	return &contractMessage{
-		logger: logger,
+		logger:          logger,
+		maxMessageBytes: subnetInfo.GetMaxMessageBytes(),
 	}
 }
 
@@ -43,6 +46,15 @@ func (m *contractMessage) UnpackWarpMessage(unsignedMsgBytes []byte) (*avalanche
 			return nil, err
 		}
 	}
+	if uint64(len(unsignedMsg.Payload)) > m.maxMessageBytes {
+		m.logger.Warn(
+			"Warp message payload exceeds the maximum message size. Not relaying.",
+			zap.String("warpMessageID", unsignedMsg.ID().String()),
+			zap.Int("payloadBytes", len(unsignedMsg.Payload)),
+			zap.Uint64("maxMessageBytes", m.maxMessageBytes),
+		)
+		return nil, relayerTypes.ErrMessageTooLarge
+	}
 
 	return unsignedMsg, nil
 }
@@ -50,13 +50,12 @@ func TestUnpack(t *testing.T) {
 			logging.JSON.ConsoleEncoder(),
 		),
 	)
-	m := NewContractMessage(logger, config.SourceBlockchain{})
-
 	testCases := []struct {
-		name        string
-		input       string
-		networkID   uint32
-		expectError bool
+		name            string
+		input           string
+		networkID       uint32
+		maxMessageBytes uint64
+		expectError     bool
 	}{
 		{
 			name:        "valid log data",
@@ -80,6 +79,12 @@ func TestUnpack(t *testing.T) {
 			input:       "ab000000053968786a235cbcfb6e57321b94378e95939b773a9626acf7a8cc440075c02c7268000002220000000000010000001452718d4ea91a6dd9a68940dbd687efa32315d11600000200000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000010000000000000000000000008db97c7cece249c2b98bdc0226cc4c2a57bf52fcb1d32d469938520383696931c26b9753662db74ad33c012f41e337aa828f1b74000000000000000000000000abcedf1234abcedf1234abcedf1234abcedf12340000000000000000000000000000000000000000000000000000000000002710000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000001200000000000000000000000000000000000000000000000000000000000000180000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000001000000000000000000000000a100ff48a37cab9f87c8b5da933da46ea1a5fb80000000000000000000000000000000000000000000000000000000000000002acafebabecafebabecafebabecafebabecafebabecafebabecafebabecafebabecafebabecafebabecafe00000000000000000000000000000000000000000000", //nolint:lll
 			expectError: true,
 		},
+		{
+			name:            "log data exceeding maximum message size",
+			input:           "0000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000024c00000000053968786a235cbcfb6e57321b94378e95939b773a9626acf7a8cc440075c02c7268000002220000000000010000001452718d4ea91a6dd9a68940dbd687efa32315d11600000200000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000010000000000000000000000008db97c7cece249c2b98bdc0226cc4c2a57bf52fcb1d32d469938520383696931c26b9753662db74ad33c012f41e337aa828f1b74000000000000000000000000abcedf1234abcedf1234abcedf1234abcedf12340000000000000000000000000000000000000000000000000000000000002710000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000001200000000000000000000000000000000000000000000000000000000000000180000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000001000000000000000000000000a100ff48a37cab9f87c8b5da933da46ea1a5fb80000000000000000000000000000000000000000000000000000000000000002acafebabecafebabecafebabecafebabecafebabecafebabecafebabecafebabecafebabecafebabecafe000000000000000000000000000000000000000000000000000000000000000000000000000000000000", //nolint:lll
+			maxMessageBytes: 32,
+			expectError:     true,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -87,6 +92,7 @@ func TestUnpack(t *testing.T) {
 			input, err := hex.DecodeString(testCase.input)
 			require.NoError(t, err)
 
+			m := NewContractMessage(logger, config.SourceBlockchain{MaxMessageBytes: testCase.maxMessageBytes})
 			msg, err := m.UnpackWarpMessage(input)
 			if testCase.expectError {
 				require.Error(t, err)
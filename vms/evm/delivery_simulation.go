@@ -0,0 +1,68 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// Revert reason of a delivery of a message that the receiver has already received, such as that of the
+// Teleporter messenger's receiveCrossChainMessage
+const messageAlreadyReceivedRevertReason = "message already received"
+
+// ErrMessageAlreadyReceived is returned when the simulation of a delivery immediately before it is issued shows
+// that the destination has already received the message, for example from another relayer.
+var ErrMessageAlreadyReceived = errors.New("message already received by the destination")
+
+// simulateDelivery calls [to] with [callData] from [from] via eth_call, with [signedMessage] included as a
+// predicate. Returns ErrMessageAlreadyReceived if the call reverts because the message has already been received.
+// Other simulation failures are logged, and do not prevent the delivery from being issued.
+func (c *destinationClient) simulateDelivery(
+	ctx context.Context,
+	from common.Address,
+	to common.Address,
+	signedMessage *avalancheWarp.Message,
+	gasLimit uint64,
+	callData []byte,
+) error {
+	_, err := c.client.CallContract(ctx, interfaces.CallMsg{
+		From:       from,
+		To:         &to,
+		Gas:        gasLimit,
+		Data:       callData,
+		AccessList: predicateAccessList([]*avalancheWarp.Message{signedMessage}),
+	}, nil)
+	switch {
+	case err == nil:
+		c.logger.Debug(
+			"Simulated delivery",
+			zap.String("destinationBlockchainID", c.destinationBlockchainID.String()),
+			zap.String("warpMessageID", signedMessage.ID().String()),
+		)
+		return nil
+	case strings.Contains(err.Error(), messageAlreadyReceivedRevertReason):
+		c.logger.Info(
+			"Simulated delivery found the message already received. Skipping delivery.",
+			zap.String("destinationBlockchainID", c.destinationBlockchainID.String()),
+			zap.String("warpMessageID", signedMessage.ID().String()),
+			zap.Error(err),
+		)
+		return ErrMessageAlreadyReceived
+	default:
+		c.logger.Warn(
+			"Simulated delivery failed. Sending transaction regardless.",
+			zap.String("destinationBlockchainID", c.destinationBlockchainID.String()),
+			zap.String("warpMessageID", signedMessage.ID().String()),
+			zap.Error(err),
+		)
+		return nil
+	}
+}
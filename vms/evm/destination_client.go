@@ -7,8 +7,14 @@ package evm
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
+	"math/rand"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -20,17 +26,28 @@ import (
 	"github.com/ava-labs/subnet-evm/ethclient"
 	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
 	predicateutils "github.com/ava-labs/subnet-evm/predicate"
+	evmutils "github.com/ava-labs/subnet-evm/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"go.uber.org/zap"
 )
 
 const (
-	// Set the max fee to twice the estimated base fee.
-	// TODO: Revisit this constant factor when we add profit determination, or make it configurable
-	BaseFeeFactor        = 2
-	MaxPriorityFeePerGas = 2500000000 // 2.5 gwei
+	// Error returned by an endpoint whose mempool already contains the submitted transaction
+	alreadyKnownErrorString = "already known"
+
+	// Errors returned by an endpoint if the transaction nonce does not match the account state
+	nonceTooLowErrorString  = "nonce too low"
+	nonceTooHighErrorString = "nonce too high"
+
+	initialSendRetryBackoff = 500 * time.Millisecond
+
+	// Interval on which a transaction deferred due to the gas price cap is reattempted
+	gasPriceDeferralInterval = 10 * time.Second
 )
 
+// errGasPriceAboveCap is returned when a transaction would be priced above the destination's maximum gas price
+var errGasPriceAboveCap = errors.New("gas price exceeds max-gas-price-wei")
+
 // Client interface wraps the ethclient.Client interface for mocking purposes.
 type Client interface {
 	ethclient.Client
@@ -39,28 +56,94 @@ type Client interface {
 // Implements DestinationClient
 type destinationClient struct {
 	client                  ethclient.Client
-	lock                    *sync.Mutex
 	destinationBlockchainID ids.ID
-	signer                  signer.Signer
 	evmChainID              *big.Int
-	currentNonce            uint64
 	logger                  logging.Logger
+
+	// Each message is sent from the next account in round-robin order.
+	// accountsLock guards the accounts slice, which is replaced when the signing keys are updated.
+	accountsLock sync.RWMutex
+	accounts     []*senderAccount
+	nextAccount  atomic.Uint64
+
+	// If set, deliveries are submitted through the smart account's execute entrypoint
+	smartAccount *config.SmartAccount
+
+	// Factor applied to the gas limit of each transaction
+	gasLimitMultiplier float64
+
+	// If set, the gas limit of each transaction is estimated, increased by gasEstimateBufferPercentage percent,
+	// and floored at the gas limit specified by the caller
+	estimateGas                 bool
+	gasEstimateBufferPercentage uint64
+
+	// If set, each delivery of a single Warp message is simulated via eth_call immediately before it is issued,
+	// and skipped if the destination has already received the message
+	simulateBeforeSend bool
+
+	// Dynamic fee parameters. The max fee per gas is set to baseFeeFactor times the estimated base fee,
+	// plus maxPriorityFeePerGas. If priorityFeeCapped is set, the suggested tip is capped at maxPriorityFeePerGas.
+	baseFeeFactor        *big.Int
+	maxPriorityFeePerGas *big.Int
+	priorityFeeCapped    bool
+
+	// If set, transactions are not priced above maxGasPrice. Sends are deferred while the destination's
+	// gas price, or base fee plus tip, exceeds it.
+	maxGasPrice *big.Int
+
+	// Set if the destination chain reports no base fee, in which case legacy gas pricing is used
+	legacyPricing bool
+
+	// Failed submissions are retried up to maxSendRetries times, with exponential backoff bounded by maxRetryBackoff
+	maxSendRetries  uint64
+	maxRetryBackoff time.Duration
+
+	// Additional clients to which signed transactions are broadcast concurrently with client
+	broadcastClients []ethclient.Client
+
+	// Set if client fails over between multiple RPC endpoints
+	rpcFailover bool
+
+	// If set, nonce gaps detected when resyncing a sender account's nonce are filled with self-transfers
+	fillNonceGaps bool
+
+	// If set, the nonce of each transaction is allocated by nonceAllocator, so that other relayers sharing
+	// the signing keys do not issue transactions with the same nonces. The locally tracked nonce is the minimum.
+	nonceAllocator NonceAllocator
+
+	// Pending transaction watchdog state. pendingTxs is nil if the watchdog is disabled.
+	metrics          *DestinationClientMetrics
+	pendingTxTimeout time.Duration
+	pendingTxsLock   sync.Mutex
+	pendingTxs       map[pendingTxKey]*pendingTx
+
+	// If inclusionDeadline is non-zero, pending transactions are replaced with increased fees by the watchdog.
+	// replacedTxs maps the hashes of replaced transactions to the pending transaction that replaced them.
+	inclusionDeadline time.Duration
+	feeBumpPercentage uint64
+	maxFeeBumps       uint64
+	replacedTxs       map[common.Hash]*pendingTx
+
+	// Balance monitor state. If lowBalanceThreshold is set, lowBalanceAccounts records the accounts whose
+	// balance was below it when last checked. Only accessed by the balance monitor.
+	lowBalanceThreshold *big.Int
+	lowBalanceAccounts  map[common.Address]bool
 }
 
+// NewDestinationClient creates a client for [destinationBlockchain]. The client's background goroutines, which
+// watch its pending transactions and report its balances, run until [ctx] is canceled.
 func NewDestinationClient(
+	ctx context.Context,
 	logger logging.Logger,
+	metrics *DestinationClientMetrics,
 	destinationBlockchain *config.DestinationBlockchain,
 ) (*destinationClient, error) {
 	// Dial the destination RPC endpoint
-	client, err := utils.NewEthClientWithConfig(
-		context.Background(),
-		destinationBlockchain.RPCEndpoint.BaseURL,
-		destinationBlockchain.RPCEndpoint.HTTPHeaders,
-		destinationBlockchain.RPCEndpoint.QueryParams,
-	)
+	client, err := newDestinationEthClient(logger, metrics, destinationBlockchain)
 	if err != nil {
 		logger.Error(
 			"Failed to dial rpc endpoint",
+			zap.Object("endpoint", &destinationBlockchain.RPCEndpoint),
 			zap.Error(err),
 		)
 		return nil, err
@@ -75,136 +158,759 @@ func NewDestinationClient(
 		return nil, err
 	}
 
-	sgnr, err := signer.NewSigner(destinationBlockchain)
+	var broadcastClients []ethclient.Client
+	if destinationBlockchain.BroadcastToAllEndpoints {
+		for _, endpoint := range destinationBlockchain.BroadcastEndpoints {
+			broadcastClient, err := utils.NewEthClientWithConfig(
+				ctx,
+				endpoint.BaseURL,
+				endpoint.HTTPHeaders,
+				endpoint.QueryParams,
+				destinationBlockchain.GetTLSConfig(),
+			)
+			if err != nil {
+				logger.Error(
+					"Failed to dial broadcast rpc endpoint",
+					zap.Object("endpoint", &endpoint),
+					zap.Error(err),
+				)
+				return nil, err
+			}
+			broadcastClients = append(broadcastClients, broadcastClient)
+		}
+	}
+
+	accounts, err := newSenderAccounts(logger, client, destinationID, destinationBlockchain, nil)
 	if err != nil {
-		logger.Error(
-			"Failed to create signer",
-			zap.Error(err),
-		)
 		return nil, err
 	}
 
-	nonce, err := client.NonceAt(context.Background(), sgnr.Address(), nil)
+	evmChainID, err := client.ChainID(ctx)
 	if err != nil {
 		logger.Error(
-			"Failed to get nonce",
+			"Failed to get chain ID from destination chain endpoint",
 			zap.Error(err),
 		)
 		return nil, err
 	}
 
-	evmChainID, err := client.ChainID(context.Background())
+	// Chains that have not activated EIP-1559 report no base fee in their block headers
+	header, err := client.HeaderByNumber(ctx, nil)
 	if err != nil {
 		logger.Error(
-			"Failed to get chain ID from destination chain endpoint",
+			"Failed to get latest header",
 			zap.Error(err),
 		)
 		return nil, err
 	}
+	legacyPricing := header.BaseFee == nil
+
+	gasLimitMultiplier := destinationBlockchain.GetGasLimitMultiplier()
+	if gasLimitMultiplier != destinationBlockchain.GasLimitMultiplier && destinationBlockchain.GasLimitMultiplier != 0 {
+		logger.Warn(
+			"Clamped configured gas limit multiplier",
+			zap.String("blockchainID", destinationID.String()),
+			zap.Float64("configuredGasLimitMultiplier", destinationBlockchain.GasLimitMultiplier),
+			zap.Float64("gasLimitMultiplier", gasLimitMultiplier),
+		)
+	}
 
 	logger.Info(
 		"Initialized destination client",
 		zap.String("blockchainID", destinationID.String()),
 		zap.String("evmChainID", evmChainID.String()),
-		zap.Uint64("nonce", nonce),
+		zap.Int("numSenderAccounts", len(accounts)),
+		zap.Float64("gasLimitMultiplier", gasLimitMultiplier),
+		zap.Bool("legacyPricing", legacyPricing),
 	)
 
-	return &destinationClient{
-		client:                  client,
-		lock:                    new(sync.Mutex),
-		destinationBlockchainID: destinationID,
-		signer:                  sgnr,
-		evmChainID:              evmChainID,
-		currentNonce:            nonce,
-		logger:                  logger,
-	}, nil
+	c := &destinationClient{
+		client:                      client,
+		destinationBlockchainID:     destinationID,
+		evmChainID:                  evmChainID,
+		logger:                      logger,
+		accounts:                    accounts,
+		smartAccount:                destinationBlockchain.SmartAccount,
+		gasLimitMultiplier:          gasLimitMultiplier,
+		estimateGas:                 destinationBlockchain.EstimateGas,
+		gasEstimateBufferPercentage: destinationBlockchain.GetGasEstimateBufferPercentage(),
+		simulateBeforeSend:          destinationBlockchain.SimulateBeforeSend,
+		baseFeeFactor:               new(big.Int).SetUint64(destinationBlockchain.GetBaseFeeFactor()),
+		maxPriorityFeePerGas:        new(big.Int).SetUint64(destinationBlockchain.GetMaxPriorityFeePerGas()),
+		priorityFeeCapped:           destinationBlockchain.MaxPriorityFeePerGas != 0,
+		maxGasPrice:                 destinationBlockchain.GetMaxGasPrice(),
+		legacyPricing:               legacyPricing,
+		maxSendRetries:              destinationBlockchain.MaxSendRetries,
+		maxRetryBackoff:             destinationBlockchain.GetMaxRetryBackoff(),
+		broadcastClients:            broadcastClients,
+		rpcFailover:                 destinationBlockchain.HasFallbackRPCEndpoints(),
+		fillNonceGaps:               destinationBlockchain.FillNonceGaps,
+		metrics:                     metrics,
+		pendingTxTimeout:            time.Duration(destinationBlockchain.PendingTxTimeoutSeconds) * time.Second,
+		inclusionDeadline:           time.Duration(destinationBlockchain.InclusionDeadlineSeconds) * time.Second,
+		feeBumpPercentage:           destinationBlockchain.GetFeeBumpPercentage(),
+		maxFeeBumps:                 destinationBlockchain.GetMaxFeeBumps(),
+		lowBalanceThreshold:         destinationBlockchain.GetLowBalanceThreshold(),
+		lowBalanceAccounts:          make(map[common.Address]bool),
+	}
+	if c.inclusionDeadline > 0 {
+		c.replacedTxs = make(map[common.Hash]*pendingTx)
+	}
+	if c.pendingTxTimeout > 0 || c.inclusionDeadline > 0 {
+		c.pendingTxs = make(map[pendingTxKey]*pendingTx)
+		go c.watchPendingTxs(ctx)
+	}
+	// Shadow clients are created without metrics, and do not report balances
+	if metrics != nil {
+		go c.watchBalances(ctx, destinationBlockchain.GetBalanceCheckInterval())
+	}
+	return c, nil
+}
+
+// newDestinationEthClient dials the RPC endpoints of [destinationBlockchain]. If fallback endpoints are configured,
+// requests that fail to reach the active endpoint are retried with the next endpoint. Requests that reach an
+// endpoint are not retried, so transactions that revert or are rejected are not resubmitted to other endpoints.
+func newDestinationEthClient(
+	logger logging.Logger,
+	metrics *DestinationClientMetrics,
+	destinationBlockchain *config.DestinationBlockchain,
+) (ethclient.Client, error) {
+	if !destinationBlockchain.HasFallbackRPCEndpoints() {
+		return utils.NewEthClientWithConfig(
+			context.Background(),
+			destinationBlockchain.RPCEndpoint.BaseURL,
+			destinationBlockchain.RPCEndpoint.HTTPHeaders,
+			destinationBlockchain.RPCEndpoint.QueryParams,
+			destinationBlockchain.GetTLSConfig(),
+		)
+	}
+	transport, err := newFailoverTransport(
+		context.Background(),
+		logger,
+		destinationBlockchain.GetBlockchainID(),
+		metrics.activeEndpoint,
+		metrics.failoverCount,
+		destinationBlockchain.GetRPCEndpoints(),
+		destinationBlockchain.GetStaleHeadTimeout(),
+		destinationBlockchain.GetEndpointHealthCheckInterval(),
+		destinationBlockchain.GetTLSConfig(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return utils.NewEthClientWithTransport(context.Background(), destinationBlockchain.RPCEndpoint.BaseURL, transport)
 }
 
+// newSenderAccounts creates a sender account for each signing key configured for [destinationBlockchain].
+// Accounts in [existing] with the same address are reused, so that their locally tracked nonces are preserved.
+// The nonces of new accounts are fetched from the destination chain.
+func newSenderAccounts(
+	logger logging.Logger,
+	client ethclient.Client,
+	destinationID ids.ID,
+	destinationBlockchain *config.DestinationBlockchain,
+	existing []*senderAccount,
+) ([]*senderAccount, error) {
+	signers, err := signer.NewSigners(destinationBlockchain)
+	if err != nil {
+		logger.Error(
+			"Failed to create signer",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	existingAccounts := make(map[common.Address]*senderAccount, len(existing))
+	for _, account := range existing {
+		existingAccounts[account.signer.Address()] = account
+	}
+	accounts := make([]*senderAccount, 0, len(signers))
+	for _, sgnr := range signers {
+		if account, ok := existingAccounts[sgnr.Address()]; ok {
+			accounts = append(accounts, account)
+			continue
+		}
+		nonce, err := client.NonceAt(context.Background(), sgnr.Address(), nil)
+		if err != nil {
+			logger.Error(
+				"Failed to get nonce",
+				zap.String("address", sgnr.Address().String()),
+				zap.Error(err),
+			)
+			return nil, err
+		}
+		logger.Info(
+			"Initialized sender account",
+			zap.String("blockchainID", destinationID.String()),
+			zap.String("address", sgnr.Address().String()),
+			zap.Uint64("nonce", nonce),
+		)
+		accounts = append(accounts, &senderAccount{
+			signer:       sgnr,
+			currentNonce: nonce,
+		})
+	}
+	return accounts, nil
+}
+
+// UpdateSigners replaces the sender accounts with those for the signing keys configured for
+// [destinationBlockchain]. Deliveries already being sent from a removed account are unaffected.
+func (c *destinationClient) UpdateSigners(destinationBlockchain *config.DestinationBlockchain) error {
+	c.accountsLock.RLock()
+	existing := c.accounts
+	c.accountsLock.RUnlock()
+
+	accounts, err := newSenderAccounts(c.logger, c.client, c.destinationBlockchainID, destinationBlockchain, existing)
+	if err != nil {
+		return err
+	}
+
+	c.accountsLock.Lock()
+	c.accounts = accounts
+	c.accountsLock.Unlock()
+	c.logger.Info(
+		"Updated sender accounts",
+		zap.String("blockchainID", c.destinationBlockchainID.String()),
+		zap.Int("numSenderAccounts", len(accounts)),
+	)
+	return nil
+}
+
+// SendTx issues a transaction delivering [signedMessage] to [toAddress]. Failed attempts are retried with
+// exponential backoff and jitter, up to the configured maximum. The error from the final attempt is returned
+// if all attempts fail. If multiple sender accounts are configured, each message is sent from the next account
+// in round-robin order that is allowed by [ctx] (see WithAllowedSenders), and all attempts for the message use
+// the same account.
 func (c *destinationClient) SendTx(
+	ctx context.Context,
 	signedMessage *avalancheWarp.Message,
 	toAddress string,
 	gasLimit uint64,
 	callData []byte,
 ) (common.Hash, error) {
-	// Get the current base fee estimation, which is based on the previous blocks gas usage.
-	baseFee, err := c.client.EstimateBaseFee(context.Background())
+	return c.send(ctx, []*avalancheWarp.Message{signedMessage}, toAddress, gasLimit, callData)
+}
+
+// SendBatch issues a single transaction delivering each of [signedMessages] through the batch entrypoint
+// of the receiver at [toAddress]. The messages are included as Warp predicates in the given order.
+// Retries and sender account selection are as for SendTx.
+func (c *destinationClient) SendBatch(
+	ctx context.Context,
+	signedMessages []*avalancheWarp.Message,
+	toAddress string,
+	gasLimit uint64,
+	callData [][]byte,
+) (common.Hash, error) {
+	if len(signedMessages) == 0 || len(signedMessages) != len(callData) {
+		return common.Hash{}, fmt.Errorf(
+			"invalid batch of %d messages with %d call data",
+			len(signedMessages),
+			len(callData),
+		)
+	}
+	batchCallData, err := packReceiveBatch(callData)
 	if err != nil {
 		c.logger.Error(
-			"Failed to get base fee",
+			"Failed to pack batch call data",
 			zap.Error(err),
 		)
 		return common.Hash{}, err
 	}
+	return c.send(ctx, signedMessages, toAddress, gasLimit, batchCallData)
+}
 
-	// Get the suggested gas tip cap of the network
-	// TODO: Add a configurable ceiling to this value
-	gasTipCap, err := c.client.SuggestGasTipCap(context.Background())
+// SendCall issues a transaction calling [toAddress] with [callData], without a Warp predicate.
+// Retries and sender account selection are as for SendTx.
+func (c *destinationClient) SendCall(
+	ctx context.Context,
+	toAddress string,
+	gasLimit uint64,
+	callData []byte,
+) (common.Hash, error) {
+	return c.send(ctx, nil, toAddress, gasLimit, callData)
+}
+
+// send issues a transaction delivering [signedMessages], retrying failed attempts as described by SendTx.
+// Stops retrying once [ctx] is canceled.
+func (c *destinationClient) send(
+	ctx context.Context,
+	signedMessages []*avalancheWarp.Message,
+	toAddress string,
+	gasLimit uint64,
+	callData []byte,
+) (common.Hash, error) {
+	account, err := c.selectAccount(AllowedSenders(ctx))
 	if err != nil {
-		c.logger.Error(
-			"Failed to get gas tip cap",
+		c.logger.Warn(
+			"No sender account allowed to send the transaction",
+			zap.String("destinationBlockchainID", c.destinationBlockchainID.String()),
 			zap.Error(err),
 		)
 		return common.Hash{}, err
 	}
+	backoff := min(initialSendRetryBackoff, c.maxRetryBackoff)
+	for attempt := uint64(0); ; {
+		txHash, err := c.sendTxAttempt(ctx, account, signedMessages, toAddress, gasLimit, callData)
+		if err == nil {
+			return txHash, nil
+		}
+		if errors.Is(err, ErrMessageAlreadyReceived) {
+			return common.Hash{}, err
+		}
+		// Deferred sends are not failed attempts, so are reattempted until the gas price falls below the cap
+		if errors.Is(err, errGasPriceAboveCap) {
+			if err := c.deferSend(ctx, err); err != nil {
+				return common.Hash{}, err
+			}
+			continue
+		}
+		if attempt == c.maxSendRetries {
+			if c.maxSendRetries > 0 {
+				c.logger.Error(
+					"Exhausted transaction submission retries",
+					zap.String("destinationBlockchainID", c.destinationBlockchainID.String()),
+					zap.Uint64("maxSendRetries", c.maxSendRetries),
+					zap.Error(err),
+				)
+			}
+			return common.Hash{}, err
+		}
 
-	to := common.HexToAddress(toAddress)
-	gasFeeCap := baseFee.Mul(baseFee, big.NewInt(BaseFeeFactor))
-	gasFeeCap.Add(gasFeeCap, big.NewInt(MaxPriorityFeePerGas))
-
-	// Synchronize nonce access so that we send transactions in nonce order.
-	// Hold the lock until the transaction is sent to minimize the chance of
-	// an out-of-order transaction being dropped from the mempool.
-	c.lock.Lock()
-	defer c.lock.Unlock()
-
-	// Construct the actual transaction to broadcast on the destination chain
-	tx := predicateutils.NewPredicateTx(
-		c.evmChainID,
-		c.currentNonce,
-		&to,
-		gasLimit,
-		gasFeeCap,
-		gasTipCap,
-		big.NewInt(0),
-		callData,
-		types.AccessList{},
-		warp.ContractAddress,
-		signedMessage.Bytes(),
+		// Wait between backoff/2 and backoff before the next attempt
+		wait := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+		c.logger.Warn(
+			"Failed to send transaction. Retrying.",
+			zap.String("destinationBlockchainID", c.destinationBlockchainID.String()),
+			zap.Uint64("attempt", attempt+1),
+			zap.Duration("wait", wait),
+			zap.Error(err),
+		)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			c.logger.Warn(
+				"Abandoned transaction submission retries",
+				zap.String("destinationBlockchainID", c.destinationBlockchainID.String()),
+				zap.Error(ctx.Err()),
+			)
+			return common.Hash{}, errors.Join(err, ctx.Err())
+		}
+		backoff = min(2*backoff, c.maxRetryBackoff)
+		attempt++
+	}
+}
+
+// selectAccount returns the next sender account in round-robin order that is among [allowedSenders].
+// Any account may be selected if [allowedSenders] is empty, or if the transactions are sent through a
+// smart account, which is then the sender that must be allowed.
+func (c *destinationClient) selectAccount(allowedSenders []common.Address) (*senderAccount, error) {
+	c.accountsLock.RLock()
+	defer c.accountsLock.RUnlock()
+	numAccounts := uint64(len(c.accounts))
+	next := c.nextAccount.Add(1) - 1
+	if c.smartAccount != nil {
+		return c.accounts[next%numAccounts], nil
+	}
+	for i := uint64(0); i < numAccounts; i++ {
+		account := c.accounts[(next+i)%numAccounts]
+		if isAllowedSender(allowedSenders, account.signer.Address()) {
+			return account, nil
+		}
+	}
+	return nil, ErrNoAllowedSender
+}
+
+func (c *destinationClient) sendTxAttempt(
+	ctx context.Context,
+	account *senderAccount,
+	signedMessages []*avalancheWarp.Message,
+	toAddress string,
+	gasLimit uint64,
+	callData []byte,
+) (common.Hash, error) {
+	var (
+		gasFeeCap, gasTipCap, gasPrice *big.Int
+		err                            error
 	)
+	if c.legacyPricing {
+		gasPrice, err = c.suggestGasPrice(ctx)
+		if err != nil {
+			return common.Hash{}, err
+		}
+	} else {
+		gasFeeCap, gasTipCap, err = c.estimateDynamicFees(ctx)
+		if err != nil {
+			return common.Hash{}, err
+		}
+	}
+
+	to := common.HexToAddress(toAddress)
+	if c.smartAccount != nil {
+		// Route the delivery through the smart account, so that it is the caller of [to]
+		callData, err = packSmartAccountExecute(to, callData)
+		if err != nil {
+			c.logger.Error(
+				"Failed to pack smart account execute call data",
+				zap.Error(err),
+			)
+			return common.Hash{}, err
+		}
+		to = c.smartAccount.GetAddress()
+		gasLimit += c.smartAccount.ExecuteGasOverhead
+	}
+	if c.gasLimitMultiplier > 1 {
+		adjustedGasLimit := uint64(float64(gasLimit) * c.gasLimitMultiplier)
+		c.logger.Debug(
+			"Applied gas limit multiplier",
+			zap.String("destinationBlockchainID", c.destinationBlockchainID.String()),
+			zap.Uint64("gasLimit", gasLimit),
+			zap.Uint64("adjustedGasLimit", adjustedGasLimit),
+			zap.Float64("gasLimitMultiplier", c.gasLimitMultiplier),
+		)
+		gasLimit = adjustedGasLimit
+	}
+	if c.estimateGas {
+		gasLimit = c.estimateGasLimit(ctx, account.signer.Address(), to, signedMessages, gasLimit, callData)
+	}
+	// Batched deliveries are not simulated, since a single received message would not prevent delivering the rest
+	if c.simulateBeforeSend && len(signedMessages) == 1 {
+		err = c.simulateDelivery(ctx, account.signer.Address(), to, signedMessages[0], gasLimit, callData)
+		if err != nil {
+			return common.Hash{}, err
+		}
+	}
+
+	account.lock.Lock()
+	defer account.lock.Unlock()
+
+	if c.nonceAllocator != nil {
+		nonce, err := c.nonceAllocator.AllocateNonce(
+			c.destinationBlockchainID,
+			account.signer.Address(),
+			account.currentNonce,
+		)
+		if err != nil {
+			c.logger.Error(
+				"Failed to allocate nonce",
+				zap.String("sender", account.signer.Address().String()),
+				zap.Error(err),
+			)
+			return common.Hash{}, err
+		}
+		account.currentNonce = nonce
+	}
+
+	// Construct the actual transaction to broadcast on the destination chain. The Warp messages are included
+	// as predicates in order, so that the i'th message is read from the Warp precompile at index i.
+	// The predicate of the last message is appended by the transaction constructor. Transactions that do not
+	// deliver a Warp message carry no predicate.
+	last := len(signedMessages) - 1
+	var accessList types.AccessList
+	for i := 0; i < last; i++ {
+		accessList = append(accessList, types.AccessTuple{
+			Address:     warp.ContractAddress,
+			StorageKeys: evmutils.BytesToHashSlice(predicateutils.PackPredicate(signedMessages[i].Bytes())),
+		})
+	}
+	var tx *types.Transaction
+	switch {
+	case len(signedMessages) == 0 && c.legacyPricing:
+		tx = types.NewTx(&types.LegacyTx{
+			Nonce:    account.currentNonce,
+			To:       &to,
+			Gas:      gasLimit,
+			GasPrice: gasPrice,
+			Value:    big.NewInt(0),
+			Data:     callData,
+		})
+	case len(signedMessages) == 0:
+		tx = types.NewTx(&types.DynamicFeeTx{
+			ChainID:   c.evmChainID,
+			Nonce:     account.currentNonce,
+			To:        &to,
+			Gas:       gasLimit,
+			GasFeeCap: gasFeeCap,
+			GasTipCap: gasTipCap,
+			Value:     big.NewInt(0),
+			Data:      callData,
+		})
+	case c.legacyPricing:
+		tx = newLegacyPredicateTx(
+			c.evmChainID,
+			account.currentNonce,
+			&to,
+			gasLimit,
+			gasPrice,
+			callData,
+			accessList,
+			warp.ContractAddress,
+			signedMessages[last].Bytes(),
+		)
+	default:
+		tx = predicateutils.NewPredicateTx(
+			c.evmChainID,
+			account.currentNonce,
+			&to,
+			gasLimit,
+			gasFeeCap,
+			gasTipCap,
+			big.NewInt(0),
+			callData,
+			accessList,
+			warp.ContractAddress,
+			signedMessages[last].Bytes(),
+		)
+	}
 
 	// Sign and send the transaction on the destination chain
-	signedTx, err := c.signer.SignTx(tx, c.evmChainID)
+	signedTx, err := account.signer.SignTx(tx, c.evmChainID)
 	if err != nil {
 		c.logger.Error(
 			"Failed to sign transaction",
 			zap.Error(err),
 		)
+		c.releaseNonce(account)
 		return common.Hash{}, err
 	}
 
-	if err := c.client.SendTransaction(context.Background(), signedTx); err != nil {
+	if err := c.sendTransaction(ctx, signedTx); err != nil {
 		c.logger.Error(
 			"Failed to send transaction",
 			zap.Error(err),
 		)
+		if isNonceError(err) {
+			c.resyncNonce(ctx, account)
+		} else {
+			c.releaseNonce(account)
+		}
 		return common.Hash{}, err
 	}
 	c.logger.Info(
 		"Sent transaction",
 		zap.String("txID", signedTx.Hash().String()),
-		zap.Uint64("nonce", c.currentNonce),
+		zap.String("sender", account.signer.Address().String()),
+		zap.Uint64("nonce", account.currentNonce),
 	)
-	c.currentNonce++
+	account.currentNonce++
+
+	if c.pendingTxs != nil {
+		c.trackPendingTx(account, tx, signedTx.Hash())
+	}
 
 	return signedTx.Hash(), nil
 }
 
+// SetNonceAllocator configures the client to allocate the nonces of its transactions via [allocator].
+// Must be called before the client sends transactions.
+func (c *destinationClient) SetNonceAllocator(allocator NonceAllocator) {
+	c.nonceAllocator = allocator
+}
+
+// releaseNonce returns the current nonce of [account], which was allocated for a transaction that was not sent,
+// to the nonce allocator. Otherwise, the nonce would remain unused, and later transactions from the account would
+// not be included. Must be called with the account lock held.
+func (c *destinationClient) releaseNonce(account *senderAccount) {
+	if c.nonceAllocator == nil {
+		return
+	}
+	err := c.nonceAllocator.ReleaseNonce(c.destinationBlockchainID, account.signer.Address(), account.currentNonce)
+	if err != nil {
+		c.logger.Warn(
+			"Failed to release nonce",
+			zap.String("sender", account.signer.Address().String()),
+			zap.Uint64("nonce", account.currentNonce),
+			zap.Error(err),
+		)
+	}
+}
+
+func isNonceError(err error) bool {
+	return strings.Contains(err.Error(), nonceTooLowErrorString) || strings.Contains(err.Error(), nonceTooHighErrorString)
+}
+
+// estimateDynamicFees returns the max fee per gas and max priority fee per gas to use for an EIP-1559
+// transaction, based on the destination chain's current base fee estimate and suggested tip.
+func (c *destinationClient) estimateDynamicFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	// Get the current base fee estimation, which is based on the previous blocks gas usage.
+	baseFee, err := c.client.EstimateBaseFee(ctx)
+	if err != nil {
+		c.logger.Error(
+			"Failed to get base fee",
+			zap.Error(err),
+		)
+		return nil, nil, err
+	}
+
+	// Get the suggested gas tip cap of the network
+	gasTipCap, err := c.client.SuggestGasTipCap(ctx)
+	if err != nil {
+		c.logger.Error(
+			"Failed to get gas tip cap",
+			zap.Error(err),
+		)
+		return nil, nil, err
+	}
+	if c.priorityFeeCapped && gasTipCap.Cmp(c.maxPriorityFeePerGas) > 0 {
+		c.logger.Debug(
+			"Capped suggested gas tip",
+			zap.String("suggestedGasTipCap", gasTipCap.String()),
+			zap.String("maxPriorityFeePerGas", c.maxPriorityFeePerGas.String()),
+		)
+		gasTipCap = new(big.Int).Set(c.maxPriorityFeePerGas)
+	}
+
+	gasFeeCap := new(big.Int).Mul(baseFee, c.baseFeeFactor)
+	gasFeeCap.Add(gasFeeCap, c.maxPriorityFeePerGas)
+	if c.maxGasPrice == nil {
+		return gasFeeCap, gasTipCap, nil
+	}
+
+	// Defer the transaction if the price it would pay exceeds the cap. Otherwise, limit the max fee per gas to the
+	// cap, so that the price paid does not exceed it if the base fee rises before the transaction is included.
+	if price := new(big.Int).Add(baseFee, gasTipCap); price.Cmp(c.maxGasPrice) > 0 {
+		return nil, nil, fmt.Errorf(
+			"%w: base fee %s plus tip %s exceeds %s",
+			errGasPriceAboveCap,
+			baseFee,
+			gasTipCap,
+			c.maxGasPrice,
+		)
+	}
+	if gasFeeCap.Cmp(c.maxGasPrice) > 0 {
+		gasFeeCap = new(big.Int).Set(c.maxGasPrice)
+	}
+	return gasFeeCap, gasTipCap, nil
+}
+
+// suggestGasPrice returns the gas price to use for a legacy transaction, which is the destination chain's
+// suggested gas price. Returns errGasPriceAboveCap if it exceeds the cap.
+func (c *destinationClient) suggestGasPrice(ctx context.Context) (*big.Int, error) {
+	gasPrice, err := c.client.SuggestGasPrice(ctx)
+	if err != nil {
+		c.logger.Error(
+			"Failed to get gas price",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if c.maxGasPrice != nil && gasPrice.Cmp(c.maxGasPrice) > 0 {
+		return nil, fmt.Errorf("%w: gas price %s exceeds %s", errGasPriceAboveCap, gasPrice, c.maxGasPrice)
+	}
+	return gasPrice, nil
+}
+
+// deferSend waits for gasPriceDeferralInterval before a send deferred due to [err] is reattempted, or returns
+// the error joined with ctx.Err() if [ctx] is canceled first.
+func (c *destinationClient) deferSend(ctx context.Context, err error) error {
+	c.logger.Warn(
+		"Gas price exceeds the cap. Deferring transaction.",
+		zap.String("destinationBlockchainID", c.destinationBlockchainID.String()),
+		zap.Duration("wait", gasPriceDeferralInterval),
+		zap.Error(err),
+	)
+	if c.metrics != nil {
+		c.metrics.gasPriceDeferralCount.
+			WithLabelValues(c.destinationBlockchainID.String()).
+			Inc()
+	}
+	select {
+	case <-time.After(gasPriceDeferralInterval):
+		return nil
+	case <-ctx.Done():
+		return errors.Join(err, ctx.Err())
+	}
+}
+
+// newLegacyPredicateTx is the legacy gas pricing equivalent of predicateutils.NewPredicateTx. Predicates are
+// carried in the access list, so an access list transaction is used rather than a pre-EIP-2930 transaction.
+func newLegacyPredicateTx(
+	chainID *big.Int,
+	nonce uint64,
+	to *common.Address,
+	gas uint64,
+	gasPrice *big.Int,
+	data []byte,
+	accessList types.AccessList,
+	predicateAddress common.Address,
+	predicateBytes []byte,
+) *types.Transaction {
+	accessList = append(accessList, types.AccessTuple{
+		Address:     predicateAddress,
+		StorageKeys: evmutils.BytesToHashSlice(predicateutils.PackPredicate(predicateBytes)),
+	})
+	return types.NewTx(&types.AccessListTx{
+		ChainID:    chainID,
+		Nonce:      nonce,
+		To:         to,
+		Gas:        gas,
+		GasPrice:   gasPrice,
+		Value:      big.NewInt(0),
+		Data:       data,
+		AccessList: accessList,
+	})
+}
+
+// sendTransaction submits the signed transaction to the destination RPC endpoint. If broadcast clients are
+// configured, the transaction is submitted to all endpoints concurrently, and sendTransaction returns as soon
+// as any endpoint accepts it. Endpoints that already know of the transaction, for example via gossip from
+// another endpoint, are considered to have accepted it. Every endpoint receives the same signed transaction,
+// so this is a single submission with respect to nonce accounting. The same applies to a client that fails over
+// between RPC endpoints, which may submit the transaction to an endpoint that has already received it.
+func (c *destinationClient) sendTransaction(ctx context.Context, signedTx *types.Transaction) error {
+	if len(c.broadcastClients) == 0 {
+		err := c.client.SendTransaction(ctx, signedTx)
+		if err != nil && c.rpcFailover && strings.Contains(err.Error(), alreadyKnownErrorString) {
+			return nil
+		}
+		return err
+	}
+
+	clients := append([]ethclient.Client{c.client}, c.broadcastClients...)
+	results := make(chan error, len(clients))
+	for _, client := range clients {
+		go func(client ethclient.Client) {
+			err := client.SendTransaction(ctx, signedTx)
+			if err != nil && strings.Contains(err.Error(), alreadyKnownErrorString) {
+				err = nil
+			}
+			results <- err
+		}(client)
+	}
+
+	var errs []error
+	for range clients {
+		err := <-results
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
 func (c *destinationClient) Client() interface{} {
+	if c.replacedTxs != nil {
+		return &replacementFollowingClient{
+			Client:        c.client,
+			currentTxHash: c.currentTxHash,
+		}
+	}
 	return c.client
 }
 
-func (c *destinationClient) SenderAddress() common.Address {
-	return c.signer.Address()
+// SenderAddresses returns the addresses that call the message protocol contract on the destination
+// chain. This is the smart account address if one is configured, otherwise the signers' addresses.
+func (c *destinationClient) SenderAddresses() []common.Address {
+	if c.smartAccount != nil {
+		return []common.Address{c.smartAccount.GetAddress()}
+	}
+	c.accountsLock.RLock()
+	defer c.accountsLock.RUnlock()
+	addresses := make([]common.Address, 0, len(c.accounts))
+	for _, account := range c.accounts {
+		addresses = append(addresses, account.signer.Address())
+	}
+	return addresses
 }
 
 func (c *destinationClient) DestinationBlockchainID() ids.ID {
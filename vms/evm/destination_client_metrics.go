@@ -0,0 +1,148 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ErrFailedToCreateDestinationClientMetrics = errors.New("failed to create destination client metrics")
+)
+
+type DestinationClientMetrics struct {
+	droppedTxResubmissionCount *prometheus.CounterVec
+	feeBumpCount               *prometheus.CounterVec
+	activeEndpoint             *prometheus.GaugeVec
+	failoverCount              *prometheus.CounterVec
+	accountBalance             *prometheus.GaugeVec
+	circuitBreakerState        *prometheus.GaugeVec
+	rateLimitedSeconds         *prometheus.CounterVec
+	gasPriceDeferralCount      *prometheus.CounterVec
+}
+
+func NewDestinationClientMetrics(registerer prometheus.Registerer) (*DestinationClientMetrics, error) {
+	droppedTxResubmissionCount := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "dropped_tx_resubmission_count",
+			Help: "Number of transactions re-submitted after being dropped from the destination chain's mempool",
+		},
+		[]string{"destination_chain_id"},
+	)
+	if droppedTxResubmissionCount == nil {
+		return nil, ErrFailedToCreateDestinationClientMetrics
+	}
+	registerer.MustRegister(droppedTxResubmissionCount)
+
+	feeBumpCount := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tx_fee_bump_count",
+			Help: "Number of transactions replaced with increased fees after not being included within the deadline",
+		},
+		[]string{"destination_chain_id"},
+	)
+	if feeBumpCount == nil {
+		return nil, ErrFailedToCreateDestinationClientMetrics
+	}
+	registerer.MustRegister(feeBumpCount)
+
+	activeEndpoint := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "destination_rpc_endpoint_active",
+			Help: "Set to 1 for the RPC endpoint a destination client is sending requests to, and 0 for its other endpoints",
+		},
+		[]string{"destination_chain_id", "endpoint"},
+	)
+	if activeEndpoint == nil {
+		return nil, ErrFailedToCreateDestinationClientMetrics
+	}
+	registerer.MustRegister(activeEndpoint)
+
+	failoverCount := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "destination_rpc_endpoint_failover_count",
+			Help: "Number of times a destination client switched the RPC endpoint it sends requests to",
+		},
+		[]string{"destination_chain_id"},
+	)
+	if failoverCount == nil {
+		return nil, ErrFailedToCreateDestinationClientMetrics
+	}
+	registerer.MustRegister(failoverCount)
+
+	accountBalance := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "destination_account_balance",
+			Help: "Balance in wei of each account signing transactions on a destination chain",
+		},
+		[]string{"destination_chain_id", "address"},
+	)
+	if accountBalance == nil {
+		return nil, ErrFailedToCreateDestinationClientMetrics
+	}
+	registerer.MustRegister(accountBalance)
+
+	circuitBreakerState := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "destination_circuit_breaker_state",
+			Help: "State of the circuit breaker for sends to a destination chain: 0 if closed, 1 if open, and 2 if half-open",
+		},
+		[]string{"destination_chain_id"},
+	)
+	if circuitBreakerState == nil {
+		return nil, ErrFailedToCreateDestinationClientMetrics
+	}
+	registerer.MustRegister(circuitBreakerState)
+
+	rateLimitedSeconds := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "destination_send_rate_limited_seconds",
+			Help: "Total time in seconds that sends to a destination chain waited for its send rate limit",
+		},
+		[]string{"destination_chain_id"},
+	)
+	if rateLimitedSeconds == nil {
+		return nil, ErrFailedToCreateDestinationClientMetrics
+	}
+	registerer.MustRegister(rateLimitedSeconds)
+
+	gasPriceDeferralCount := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tx_gas_price_deferral_count",
+			Help: "Number of times a transaction was deferred because the destination's gas price exceeded the cap",
+		},
+		[]string{"destination_chain_id"},
+	)
+	if gasPriceDeferralCount == nil {
+		return nil, ErrFailedToCreateDestinationClientMetrics
+	}
+	registerer.MustRegister(gasPriceDeferralCount)
+
+	return &DestinationClientMetrics{
+		droppedTxResubmissionCount: droppedTxResubmissionCount,
+		feeBumpCount:               feeBumpCount,
+		activeEndpoint:             activeEndpoint,
+		failoverCount:              failoverCount,
+		accountBalance:             accountBalance,
+		circuitBreakerState:        circuitBreakerState,
+		rateLimitedSeconds:         rateLimitedSeconds,
+		gasPriceDeferralCount:      gasPriceDeferralCount,
+	}, nil
+}
+
+// SetCircuitBreakerState records the state of the circuit breaker for sends to [destinationBlockchainID].
+// Destination clients for other VMs share the circuit breaker, so the metric is set from outside this package.
+func (m *DestinationClientMetrics) SetCircuitBreakerState(destinationBlockchainID ids.ID, state float64) {
+	m.circuitBreakerState.WithLabelValues(destinationBlockchainID.String()).Set(state)
+}
+
+// AddRateLimitedTime records that a send to [destinationBlockchainID] waited [waited] for the send rate limit.
+// Destination clients for other VMs share the rate limiter, so the metric is set from outside this package.
+func (m *DestinationClientMetrics) AddRateLimitedTime(destinationBlockchainID ids.ID, waited time.Duration) {
+	m.rateLimitedSeconds.WithLabelValues(destinationBlockchainID.String()).Add(waited.Seconds())
+}
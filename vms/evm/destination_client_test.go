@@ -4,16 +4,29 @@
 package evm
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"math/big"
-	"sync"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/utils"
 	mock_ethclient "github.com/ava-labs/awm-relayer/vms/evm/mocks"
 	"github.com/ava-labs/awm-relayer/vms/evm/signer"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -81,11 +94,12 @@ func TestSendTx(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockClient := mock_ethclient.NewMockClient(ctrl)
 			destinationClient := &destinationClient{
-				lock:       &sync.Mutex{},
-				logger:     logging.NoLog{},
-				client:     mockClient,
-				evmChainID: big.NewInt(5),
-				signer:     txSigner,
+				logger:               logging.NoLog{},
+				client:               mockClient,
+				evmChainID:           big.NewInt(5),
+				baseFeeFactor:        big.NewInt(2),
+				maxPriorityFeePerGas: big.NewInt(2500000000),
+				accounts:             []*senderAccount{{signer: txSigner}},
 			}
 			warpMsg := &avalancheWarp.Message{}
 			toAddress := "0x27aE10273D17Cd7e80de8580A51f476960626e5f"
@@ -104,7 +118,7 @@ func TestSendTx(t *testing.T) {
 				).Times(test.sendTransactionTimes),
 			)
 
-			_, err := destinationClient.SendTx(warpMsg, toAddress, 0, []byte{})
+			_, err := destinationClient.SendTx(context.Background(), warpMsg, toAddress, 0, []byte{})
 			if test.expectError {
 				require.Error(t, err)
 			} else {
@@ -113,3 +127,923 @@ func TestSendTx(t *testing.T) {
 		})
 	}
 }
+
+func TestSendTxSmartAccount(t *testing.T) {
+	txSigner, err := signer.NewTxSigner(destinationSubnet.AccountPrivateKey)
+	require.NoError(t, err)
+
+	smartAccount := &config.SmartAccount{
+		Address:            "0x0123456789abcdef0123456789abcdef01234567",
+		ExecuteGasOverhead: 50_000,
+	}
+	require.NoError(t, smartAccount.Validate())
+
+	ctrl := gomock.NewController(t)
+	mockClient := mock_ethclient.NewMockClient(ctrl)
+	destinationClient := &destinationClient{
+		logger:               logging.NoLog{},
+		client:               mockClient,
+		evmChainID:           big.NewInt(5),
+		baseFeeFactor:        big.NewInt(2),
+		maxPriorityFeePerGas: big.NewInt(2500000000),
+		accounts:             []*senderAccount{{signer: txSigner}},
+		smartAccount:         smartAccount,
+	}
+	require.Equal(t, smartAccount.GetAddress(), destinationClient.SenderAddresses()[0])
+
+	toAddress := common.HexToAddress("0x27aE10273D17Cd7e80de8580A51f476960626e5f")
+	callData := []byte{1, 2, 3, 4}
+	expectedCallData, err := packSmartAccountExecute(toAddress, callData)
+	require.NoError(t, err)
+
+	mockClient.EXPECT().EstimateBaseFee(gomock.Any()).Return(new(big.Int), nil).Times(1)
+	mockClient.EXPECT().SuggestGasTipCap(gomock.Any()).Return(new(big.Int), nil).Times(1)
+	mockClient.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, tx *types.Transaction) error {
+			require.Equal(t, smartAccount.GetAddress(), *tx.To())
+			require.Equal(t, expectedCallData, tx.Data())
+			require.Equal(t, uint64(100_000+50_000), tx.Gas())
+			return nil
+		},
+	).Times(1)
+
+	_, err = destinationClient.SendTx(
+		context.Background(),
+		&avalancheWarp.Message{},
+		toAddress.Hex(),
+		100_000,
+		callData,
+	)
+	require.NoError(t, err)
+}
+
+func TestSendBatch(t *testing.T) {
+	txSigner, err := signer.NewTxSigner(destinationSubnet.AccountPrivateKey)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	mockClient := mock_ethclient.NewMockClient(ctrl)
+	destinationClient := &destinationClient{
+		logger:               logging.NoLog{},
+		client:               mockClient,
+		evmChainID:           big.NewInt(5),
+		baseFeeFactor:        big.NewInt(2),
+		maxPriorityFeePerGas: big.NewInt(2500000000),
+		accounts:             []*senderAccount{{signer: txSigner}},
+	}
+
+	toAddress := common.HexToAddress("0x27aE10273D17Cd7e80de8580A51f476960626e5f")
+	callData := [][]byte{{1, 2}, {3, 4}}
+	expectedCallData, err := packReceiveBatch(callData)
+	require.NoError(t, err)
+
+	mockClient.EXPECT().EstimateBaseFee(gomock.Any()).Return(new(big.Int), nil).Times(1)
+	mockClient.EXPECT().SuggestGasTipCap(gomock.Any()).Return(new(big.Int), nil).Times(1)
+	mockClient.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, tx *types.Transaction) error {
+			require.Equal(t, toAddress, *tx.To())
+			require.Equal(t, expectedCallData, tx.Data())
+			require.Equal(t, uint64(200_000), tx.Gas())
+			// Each message is included as a separate Warp predicate
+			require.Len(t, tx.AccessList(), 2)
+			for _, tuple := range tx.AccessList() {
+				require.Equal(t, warp.ContractAddress, tuple.Address)
+			}
+			return nil
+		},
+	).Times(1)
+
+	_, err = destinationClient.SendBatch(
+		context.Background(),
+		[]*avalancheWarp.Message{{}, {}},
+		toAddress.Hex(),
+		200_000,
+		callData,
+	)
+	require.NoError(t, err)
+
+	// The number of messages must match the number of call data
+	_, err = destinationClient.SendBatch(
+		context.Background(),
+		[]*avalancheWarp.Message{{}},
+		toAddress.Hex(),
+		100_000,
+		callData,
+	)
+	require.Error(t, err)
+}
+
+func TestSendTxRoundRobin(t *testing.T) {
+	signer1, err := signer.NewTxSigner(destinationSubnet.AccountPrivateKey)
+	require.NoError(t, err)
+	signer2, err := signer.NewTxSigner("0x1234567890123456789012345678901234567890123456789012345678901234")
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	mockClient := mock_ethclient.NewMockClient(ctrl)
+	destinationClient := &destinationClient{
+		logger:               logging.NoLog{},
+		client:               mockClient,
+		evmChainID:           big.NewInt(5),
+		baseFeeFactor:        big.NewInt(2),
+		maxPriorityFeePerGas: big.NewInt(2500000000),
+		accounts: []*senderAccount{
+			{signer: signer1, currentNonce: 10},
+			{signer: signer2, currentNonce: 20},
+		},
+	}
+	require.Equal(t, []common.Address{signer1.Address(), signer2.Address()}, destinationClient.SenderAddresses())
+
+	// Each message is sent from the next account, using that account's nonce
+	var sentTxs []*types.Transaction
+	mockClient.EXPECT().EstimateBaseFee(gomock.Any()).Return(new(big.Int), nil).Times(3)
+	mockClient.EXPECT().SuggestGasTipCap(gomock.Any()).Return(new(big.Int), nil).Times(3)
+	mockClient.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, tx *types.Transaction) error {
+			sentTxs = append(sentTxs, tx)
+			return nil
+		},
+	).Times(3)
+
+	toAddress := "0x27aE10273D17Cd7e80de8580A51f476960626e5f"
+	for i := 0; i < 3; i++ {
+		_, err = destinationClient.SendTx(context.Background(), &avalancheWarp.Message{}, toAddress, 100_000, []byte{})
+		require.NoError(t, err)
+	}
+
+	txSigner := types.LatestSignerForChainID(big.NewInt(5))
+	expected := []struct {
+		sender common.Address
+		nonce  uint64
+	}{
+		{signer1.Address(), 10},
+		{signer2.Address(), 20},
+		{signer1.Address(), 11},
+	}
+	for i, tx := range sentTxs {
+		sender, err := types.Sender(txSigner, tx)
+		require.NoError(t, err)
+		require.Equal(t, expected[i].sender, sender)
+		require.Equal(t, expected[i].nonce, tx.Nonce())
+	}
+}
+
+func TestSendTxAllowedSenders(t *testing.T) {
+	signer1, err := signer.NewTxSigner(destinationSubnet.AccountPrivateKey)
+	require.NoError(t, err)
+	signer2, err := signer.NewTxSigner("0x1234567890123456789012345678901234567890123456789012345678901234")
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	mockClient := mock_ethclient.NewMockClient(ctrl)
+	destinationClient := &destinationClient{
+		logger:               logging.NoLog{},
+		client:               mockClient,
+		evmChainID:           big.NewInt(5),
+		baseFeeFactor:        big.NewInt(2),
+		maxPriorityFeePerGas: big.NewInt(2500000000),
+		accounts: []*senderAccount{
+			{signer: signer1, currentNonce: 10},
+			{signer: signer2, currentNonce: 20},
+		},
+	}
+
+	// Each message is sent from the next account that is allowed, skipping the others
+	var sentTxs []*types.Transaction
+	mockClient.EXPECT().EstimateBaseFee(gomock.Any()).Return(new(big.Int), nil).Times(2)
+	mockClient.EXPECT().SuggestGasTipCap(gomock.Any()).Return(new(big.Int), nil).Times(2)
+	mockClient.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, tx *types.Transaction) error {
+			sentTxs = append(sentTxs, tx)
+			return nil
+		},
+	).Times(2)
+
+	toAddress := "0x27aE10273D17Cd7e80de8580A51f476960626e5f"
+	ctx := WithAllowedSenders(context.Background(), []common.Address{signer2.Address()})
+	for i := 0; i < 2; i++ {
+		_, err = destinationClient.SendTx(ctx, &avalancheWarp.Message{}, toAddress, 100_000, []byte{})
+		require.NoError(t, err)
+	}
+
+	txSigner := types.LatestSignerForChainID(big.NewInt(5))
+	for i, tx := range sentTxs {
+		sender, err := types.Sender(txSigner, tx)
+		require.NoError(t, err)
+		require.Equal(t, signer2.Address(), sender)
+		require.Equal(t, uint64(20+i), tx.Nonce())
+	}
+
+	// No transaction is sent if none of the accounts is allowed
+	ctx = WithAllowedSenders(context.Background(), []common.Address{common.HexToAddress("0x01")})
+	_, err = destinationClient.SendTx(ctx, &avalancheWarp.Message{}, toAddress, 100_000, []byte{})
+	require.ErrorIs(t, err, ErrNoAllowedSender)
+}
+
+func TestUpdateSigners(t *testing.T) {
+	signer1, err := signer.NewTxSigner(destinationSubnet.AccountPrivateKey)
+	require.NoError(t, err)
+	newKey := "0x1234567890123456789012345678901234567890123456789012345678901234"
+	signer2, err := signer.NewTxSigner(newKey)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	mockClient := mock_ethclient.NewMockClient(ctrl)
+	existingAccount := &senderAccount{signer: signer1, currentNonce: 10}
+	destinationClient := &destinationClient{
+		logger:   logging.NoLog{},
+		client:   mockClient,
+		accounts: []*senderAccount{existingAccount},
+	}
+
+	// Only the nonce of the new account is fetched. The existing account keeps its locally tracked nonce.
+	mockClient.EXPECT().NonceAt(gomock.Any(), signer2.Address(), gomock.Nil()).Return(uint64(20), nil).Times(1)
+	updated := destinationSubnet
+	updated.AccountPrivateKeys = []string{newKey}
+	require.NoError(t, destinationClient.UpdateSigners(&updated))
+	require.Equal(t, []common.Address{signer1.Address(), signer2.Address()}, destinationClient.SenderAddresses())
+	require.Same(t, existingAccount, destinationClient.accounts[0])
+	require.Equal(t, uint64(20), destinationClient.accounts[1].currentNonce)
+
+	// Removing a key removes its account
+	updated = destinationSubnet
+	updated.AccountPrivateKey = ""
+	updated.AccountPrivateKeys = []string{newKey}
+	require.NoError(t, destinationClient.UpdateSigners(&updated))
+	require.Equal(t, []common.Address{signer2.Address()}, destinationClient.SenderAddresses())
+}
+
+// testNonceAllocator allocates nonces from next, and records the released nonces. As in the database,
+// a released nonce is allocated again if no greater nonce has been allocated since.
+type testNonceAllocator struct {
+	next     uint64
+	released []uint64
+}
+
+func (a *testNonceAllocator) AllocateNonce(_ ids.ID, _ common.Address, minNonce uint64) (uint64, error) {
+	nonce := max(a.next, minNonce)
+	a.next = nonce + 1
+	return nonce, nil
+}
+
+func (a *testNonceAllocator) ReleaseNonce(_ ids.ID, _ common.Address, nonce uint64) error {
+	a.released = append(a.released, nonce)
+	if a.next == nonce+1 {
+		a.next = nonce
+	}
+	return nil
+}
+
+func TestSendTxSharedNonces(t *testing.T) {
+	txSigner, err := signer.NewTxSigner(destinationSubnet.AccountPrivateKey)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	mockClient := mock_ethclient.NewMockClient(ctrl)
+	// Another relayer sharing the key has already issued transactions up to nonce 9
+	allocator := &testNonceAllocator{next: 10}
+	account := &senderAccount{signer: txSigner, currentNonce: 5}
+	destinationClient := &destinationClient{
+		logger:               logging.NoLog{},
+		client:               mockClient,
+		evmChainID:           big.NewInt(5),
+		baseFeeFactor:        big.NewInt(2),
+		maxPriorityFeePerGas: big.NewInt(2500000000),
+		accounts:             []*senderAccount{account},
+	}
+	destinationClient.SetNonceAllocator(allocator)
+
+	mockClient.EXPECT().EstimateBaseFee(gomock.Any()).Return(new(big.Int), nil).Times(3)
+	mockClient.EXPECT().SuggestGasTipCap(gomock.Any()).Return(new(big.Int), nil).Times(3)
+	var sentNonces []uint64
+	sendErrs := []error{nil, fmt.Errorf("connection refused"), nil}
+	mockClient.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, tx *types.Transaction) error {
+			sentNonces = append(sentNonces, tx.Nonce())
+			err := sendErrs[0]
+			sendErrs = sendErrs[1:]
+			return err
+		},
+	).Times(3)
+
+	toAddress := "0x27aE10273D17Cd7e80de8580A51f476960626e5f"
+	for _, expectError := range []bool{false, true, false} {
+		_, err = destinationClient.SendTx(context.Background(), &avalancheWarp.Message{}, toAddress, 100_000, []byte{})
+		require.Equal(t, expectError, err != nil)
+	}
+	// The nonce of the failed transaction is released and reused
+	require.Equal(t, []uint64{10, 11, 11}, sentNonces)
+	require.Equal(t, []uint64{11}, allocator.released)
+	require.Equal(t, uint64(12), account.currentNonce)
+}
+
+func TestSendTxGasLimitMultiplier(t *testing.T) {
+	txSigner, err := signer.NewTxSigner(destinationSubnet.AccountPrivateKey)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	mockClient := mock_ethclient.NewMockClient(ctrl)
+	destinationClient := &destinationClient{
+		logger:               logging.NoLog{},
+		client:               mockClient,
+		evmChainID:           big.NewInt(5),
+		baseFeeFactor:        big.NewInt(2),
+		maxPriorityFeePerGas: big.NewInt(2500000000),
+		accounts:             []*senderAccount{{signer: txSigner}},
+		gasLimitMultiplier:   1.5,
+	}
+
+	mockClient.EXPECT().EstimateBaseFee(gomock.Any()).Return(new(big.Int), nil).Times(1)
+	mockClient.EXPECT().SuggestGasTipCap(gomock.Any()).Return(new(big.Int), nil).Times(1)
+	mockClient.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, tx *types.Transaction) error {
+			require.Equal(t, uint64(150_000), tx.Gas())
+			return nil
+		},
+	).Times(1)
+
+	toAddress := "0x27aE10273D17Cd7e80de8580A51f476960626e5f"
+	_, err = destinationClient.SendTx(context.Background(), &avalancheWarp.Message{}, toAddress, 100_000, []byte{})
+	require.NoError(t, err)
+}
+
+func TestSendTxEstimateGas(t *testing.T) {
+	txSigner, err := signer.NewTxSigner(destinationSubnet.AccountPrivateKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name             string
+		estimate         uint64
+		estimateErr      error
+		expectedGasLimit uint64
+	}{
+		{
+			name:             "estimate with buffer",
+			estimate:         200_000,
+			expectedGasLimit: 240_000,
+		},
+		{
+			name:             "message gas limit floor",
+			estimate:         50_000,
+			expectedGasLimit: 100_000,
+		},
+		{
+			name:             "estimation failed",
+			estimateErr:      fmt.Errorf("execution reverted"),
+			expectedGasLimit: 100_000,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockClient := mock_ethclient.NewMockClient(ctrl)
+			destinationClient := &destinationClient{
+				logger:                      logging.NoLog{},
+				client:                      mockClient,
+				evmChainID:                  big.NewInt(5),
+				baseFeeFactor:               big.NewInt(2),
+				maxPriorityFeePerGas:        big.NewInt(2500000000),
+				accounts:                    []*senderAccount{{signer: txSigner}},
+				estimateGas:                 true,
+				gasEstimateBufferPercentage: 20,
+			}
+
+			toAddress := "0x27aE10273D17Cd7e80de8580A51f476960626e5f"
+			signedMessage := &avalancheWarp.Message{}
+			mockClient.EXPECT().EstimateBaseFee(gomock.Any()).Return(new(big.Int), nil).Times(1)
+			mockClient.EXPECT().SuggestGasTipCap(gomock.Any()).Return(new(big.Int), nil).Times(1)
+			mockClient.EXPECT().EstimateGas(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, msg interfaces.CallMsg) (uint64, error) {
+					// The Warp message must be included as a predicate for the estimate to succeed
+					require.Equal(t, txSigner.Address(), msg.From)
+					require.Equal(t, common.HexToAddress(toAddress), *msg.To)
+					require.Len(t, msg.AccessList, 1)
+					require.Equal(t, warp.ContractAddress, msg.AccessList[0].Address)
+					return test.estimate, test.estimateErr
+				},
+			).Times(1)
+			mockClient.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, tx *types.Transaction) error {
+					require.Equal(t, test.expectedGasLimit, tx.Gas())
+					return nil
+				},
+			).Times(1)
+
+			_, err = destinationClient.SendTx(context.Background(), signedMessage, toAddress, 100_000, []byte{})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSendTxSimulateBeforeSend(t *testing.T) {
+	txSigner, err := signer.NewTxSigner(destinationSubnet.AccountPrivateKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		simulateErr   error
+		expectedSends int
+		expectedErr   error
+	}{
+		{
+			name:          "simulation succeeded",
+			expectedSends: 1,
+		},
+		{
+			name:          "message already received",
+			simulateErr:   fmt.Errorf("execution reverted: TeleporterMessenger: message already received"),
+			expectedSends: 0,
+			expectedErr:   ErrMessageAlreadyReceived,
+		},
+		{
+			name:          "simulation reverted",
+			simulateErr:   fmt.Errorf("execution reverted: TeleporterMessenger: invalid warp message"),
+			expectedSends: 1,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockClient := mock_ethclient.NewMockClient(ctrl)
+			destinationClient := &destinationClient{
+				logger:               logging.NoLog{},
+				client:               mockClient,
+				evmChainID:           big.NewInt(5),
+				baseFeeFactor:        big.NewInt(2),
+				maxPriorityFeePerGas: big.NewInt(2500000000),
+				accounts:             []*senderAccount{{signer: txSigner}},
+				simulateBeforeSend:   true,
+				// The delivery is not retried if the message has already been received
+				maxSendRetries:  2,
+				maxRetryBackoff: time.Millisecond,
+			}
+
+			toAddress := "0x27aE10273D17Cd7e80de8580A51f476960626e5f"
+			signedMessage := &avalancheWarp.Message{}
+			mockClient.EXPECT().EstimateBaseFee(gomock.Any()).Return(new(big.Int), nil).Times(1)
+			mockClient.EXPECT().SuggestGasTipCap(gomock.Any()).Return(new(big.Int), nil).Times(1)
+			mockClient.EXPECT().CallContract(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, msg interfaces.CallMsg, _ *big.Int) ([]byte, error) {
+					require.Equal(t, txSigner.Address(), msg.From)
+					require.Equal(t, common.HexToAddress(toAddress), *msg.To)
+					require.Equal(t, uint64(100_000), msg.Gas)
+					require.Len(t, msg.AccessList, 1)
+					return nil, test.simulateErr
+				},
+			).Times(1)
+			mockClient.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return(nil).Times(test.expectedSends)
+
+			_, err = destinationClient.SendTx(context.Background(), signedMessage, toAddress, 100_000, []byte{})
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestSendTxFees(t *testing.T) {
+	txSigner, err := signer.NewTxSigner(destinationSubnet.AccountPrivateKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name              string
+		legacyPricing     bool
+		priorityFeeCapped bool
+		maxGasPrice       int64
+		suggestedTip      int64
+		expectedTxType    uint8
+		expectedGasFeeCap int64
+		expectedGasTipCap int64
+	}{
+		{
+			name:              "dynamic fee",
+			suggestedTip:      50,
+			expectedTxType:    types.DynamicFeeTxType,
+			expectedGasFeeCap: 3*100 + 20,
+			expectedGasTipCap: 50,
+		},
+		{
+			name:              "dynamic fee with capped tip",
+			priorityFeeCapped: true,
+			suggestedTip:      50,
+			expectedTxType:    types.DynamicFeeTxType,
+			expectedGasFeeCap: 3*100 + 20,
+			expectedGasTipCap: 20,
+		},
+		{
+			name:              "dynamic fee clamped to max gas price",
+			maxGasPrice:       200,
+			suggestedTip:      50,
+			expectedTxType:    types.DynamicFeeTxType,
+			expectedGasFeeCap: 200,
+			expectedGasTipCap: 50,
+		},
+		{
+			name:              "legacy pricing",
+			legacyPricing:     true,
+			expectedTxType:    types.AccessListTxType,
+			expectedGasFeeCap: 200,
+			expectedGasTipCap: 200,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockClient := mock_ethclient.NewMockClient(ctrl)
+			destinationClient := &destinationClient{
+				logger:               logging.NoLog{},
+				client:               mockClient,
+				evmChainID:           big.NewInt(5),
+				accounts:             []*senderAccount{{signer: txSigner}},
+				baseFeeFactor:        big.NewInt(3),
+				maxPriorityFeePerGas: big.NewInt(20),
+				priorityFeeCapped:    test.priorityFeeCapped,
+				legacyPricing:        test.legacyPricing,
+			}
+			if test.maxGasPrice != 0 {
+				destinationClient.maxGasPrice = big.NewInt(test.maxGasPrice)
+			}
+
+			if test.legacyPricing {
+				mockClient.EXPECT().SuggestGasPrice(gomock.Any()).Return(big.NewInt(200), nil).Times(1)
+			} else {
+				mockClient.EXPECT().EstimateBaseFee(gomock.Any()).Return(big.NewInt(100), nil).Times(1)
+				mockClient.EXPECT().SuggestGasTipCap(gomock.Any()).Return(big.NewInt(test.suggestedTip), nil).Times(1)
+			}
+			mockClient.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, tx *types.Transaction) error {
+					require.Equal(t, test.expectedTxType, tx.Type())
+					require.Equal(t, big.NewInt(test.expectedGasFeeCap), tx.GasFeeCap())
+					require.Equal(t, big.NewInt(test.expectedGasTipCap), tx.GasTipCap())
+					require.Len(t, tx.AccessList(), 1)
+					return nil
+				},
+			).Times(1)
+
+			toAddress := "0x27aE10273D17Cd7e80de8580A51f476960626e5f"
+			_, err = destinationClient.SendTx(context.Background(), &avalancheWarp.Message{}, toAddress, 100_000, []byte{})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSendTxRetry(t *testing.T) {
+	txSigner, err := signer.NewTxSigner(destinationSubnet.AccountPrivateKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name               string
+		maxSendRetries     uint64
+		sendErrs           []error
+		expectedNonceTimes int
+		expectedNonces     []uint64
+		expectError        bool
+	}{
+		{
+			name:           "succeeds after transient error",
+			maxSendRetries: 2,
+			sendErrs:       []error{fmt.Errorf("connection reset"), nil},
+			expectedNonces: []uint64{10, 10},
+		},
+		{
+			name:               "resyncs nonce after nonce error",
+			maxSendRetries:     2,
+			sendErrs:           []error{fmt.Errorf("nonce too low: next nonce 12, tx nonce 10"), nil},
+			expectedNonceTimes: 1,
+			expectedNonces:     []uint64{10, 12},
+		},
+		{
+			name:           "exhausts retries",
+			maxSendRetries: 2,
+			sendErrs: []error{
+				fmt.Errorf("connection reset"),
+				fmt.Errorf("connection reset"),
+				fmt.Errorf("connection reset"),
+			},
+			expectedNonces: []uint64{10, 10, 10},
+			expectError:    true,
+		},
+		{
+			name:           "retries disabled",
+			sendErrs:       []error{fmt.Errorf("connection reset")},
+			expectedNonces: []uint64{10},
+			expectError:    true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockClient := mock_ethclient.NewMockClient(ctrl)
+			destinationClient := &destinationClient{
+				logger:               logging.NoLog{},
+				client:               mockClient,
+				evmChainID:           big.NewInt(5),
+				accounts:             []*senderAccount{{signer: txSigner, currentNonce: 10}},
+				baseFeeFactor:        big.NewInt(2),
+				maxPriorityFeePerGas: big.NewInt(2500000000),
+				maxSendRetries:       test.maxSendRetries,
+				maxRetryBackoff:      time.Millisecond,
+			}
+
+			attempts := len(test.sendErrs)
+			mockClient.EXPECT().EstimateBaseFee(gomock.Any()).Return(new(big.Int), nil).Times(attempts)
+			mockClient.EXPECT().SuggestGasTipCap(gomock.Any()).Return(new(big.Int), nil).Times(attempts)
+			mockClient.EXPECT().NonceAt(gomock.Any(), txSigner.Address(), pendingBlockNumber).
+				Return(uint64(12), nil).
+				Times(test.expectedNonceTimes)
+			var nonces []uint64
+			mockClient.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, tx *types.Transaction) error {
+					nonces = append(nonces, tx.Nonce())
+					return test.sendErrs[len(nonces)-1]
+				},
+			).Times(attempts)
+
+			toAddress := "0x27aE10273D17Cd7e80de8580A51f476960626e5f"
+			_, err = destinationClient.SendTx(context.Background(), &avalancheWarp.Message{}, toAddress, 100_000, []byte{})
+			if test.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, test.expectedNonces, nonces)
+		})
+	}
+}
+
+func TestSendTxRetryCanceled(t *testing.T) {
+	txSigner, err := signer.NewTxSigner(destinationSubnet.AccountPrivateKey)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	mockClient := mock_ethclient.NewMockClient(ctrl)
+	destinationClient := &destinationClient{
+		logger:               logging.NoLog{},
+		client:               mockClient,
+		evmChainID:           big.NewInt(5),
+		accounts:             []*senderAccount{{signer: txSigner}},
+		baseFeeFactor:        big.NewInt(2),
+		maxPriorityFeePerGas: big.NewInt(2500000000),
+		maxSendRetries:       5,
+		maxRetryBackoff:      time.Minute,
+	}
+
+	// No further attempts are made once the context is canceled
+	mockClient.EXPECT().EstimateBaseFee(gomock.Any()).Return(new(big.Int), nil).Times(1)
+	mockClient.EXPECT().SuggestGasTipCap(gomock.Any()).Return(new(big.Int), nil).Times(1)
+	mockClient.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return(fmt.Errorf("connection reset")).Times(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	toAddress := "0x27aE10273D17Cd7e80de8580A51f476960626e5f"
+	_, err = destinationClient.SendTx(ctx, &avalancheWarp.Message{}, toAddress, 100_000, []byte{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSendTxGasPriceAboveCap(t *testing.T) {
+	txSigner, err := signer.NewTxSigner(destinationSubnet.AccountPrivateKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		legacyPricing bool
+	}{
+		{
+			name: "dynamic fee",
+		},
+		{
+			name:          "legacy pricing",
+			legacyPricing: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockClient := mock_ethclient.NewMockClient(ctrl)
+			destinationClient := &destinationClient{
+				logger:               logging.NoLog{},
+				client:               mockClient,
+				evmChainID:           big.NewInt(5),
+				accounts:             []*senderAccount{{signer: txSigner}},
+				baseFeeFactor:        big.NewInt(2),
+				maxPriorityFeePerGas: big.NewInt(20),
+				maxGasPrice:          big.NewInt(100),
+				legacyPricing:        test.legacyPricing,
+			}
+
+			// The send is deferred rather than failed, until the context is canceled
+			if test.legacyPricing {
+				mockClient.EXPECT().SuggestGasPrice(gomock.Any()).Return(big.NewInt(101), nil).Times(1)
+			} else {
+				mockClient.EXPECT().EstimateBaseFee(gomock.Any()).Return(big.NewInt(90), nil).Times(1)
+				mockClient.EXPECT().SuggestGasTipCap(gomock.Any()).Return(big.NewInt(20), nil).Times(1)
+			}
+			mockClient.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Times(0)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			toAddress := "0x27aE10273D17Cd7e80de8580A51f476960626e5f"
+			_, err = destinationClient.SendTx(ctx, &avalancheWarp.Message{}, toAddress, 100_000, []byte{})
+			require.ErrorIs(t, err, errGasPriceAboveCap)
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+		})
+	}
+}
+
+func TestSendTxBroadcast(t *testing.T) {
+	txSigner, err := signer.NewTxSigner(destinationSubnet.AccountPrivateKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		primaryErr    error
+		broadcastErrs []error
+		expectError   bool
+		expectedNonce uint64
+	}{
+		{
+			name:          "all accepted",
+			broadcastErrs: []error{nil, nil},
+			expectedNonce: 1,
+		},
+		{
+			name:          "accepted by broadcast endpoint only",
+			primaryErr:    fmt.Errorf("connection refused"),
+			broadcastErrs: []error{fmt.Errorf("timeout"), nil},
+			expectedNonce: 1,
+		},
+		{
+			name:          "already known",
+			primaryErr:    fmt.Errorf("connection refused"),
+			broadcastErrs: []error{fmt.Errorf("already known")},
+			expectedNonce: 1,
+		},
+		{
+			name:          "rejected by all endpoints",
+			primaryErr:    fmt.Errorf("connection refused"),
+			broadcastErrs: []error{fmt.Errorf("timeout")},
+			expectError:   true,
+			expectedNonce: 0,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockClient := mock_ethclient.NewMockClient(ctrl)
+			mockClient.EXPECT().EstimateBaseFee(gomock.Any()).Return(new(big.Int), nil).Times(1)
+			mockClient.EXPECT().SuggestGasTipCap(gomock.Any()).Return(new(big.Int), nil).Times(1)
+
+			// Every endpoint receives the same signed transaction
+			var (
+				txHashes = make(chan common.Hash, len(test.broadcastErrs)+1)
+				clients  []ethclient.Client
+			)
+			sendTx := func(err error) func(context.Context, *types.Transaction) error {
+				return func(_ context.Context, tx *types.Transaction) error {
+					txHashes <- tx.Hash()
+					return err
+				}
+			}
+			mockClient.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).
+				DoAndReturn(sendTx(test.primaryErr)).
+				MaxTimes(1)
+			for _, broadcastErr := range test.broadcastErrs {
+				broadcastClient := mock_ethclient.NewMockClient(ctrl)
+				broadcastClient.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(sendTx(broadcastErr)).
+					MaxTimes(1)
+				clients = append(clients, broadcastClient)
+			}
+
+			destinationClient := &destinationClient{
+				logger:               logging.NoLog{},
+				client:               mockClient,
+				evmChainID:           big.NewInt(5),
+				baseFeeFactor:        big.NewInt(2),
+				maxPriorityFeePerGas: big.NewInt(2500000000),
+				accounts:             []*senderAccount{{signer: txSigner}},
+				broadcastClients:     clients,
+			}
+			txHash, err := destinationClient.SendTx(
+				context.Background(),
+				&avalancheWarp.Message{},
+				"0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+				0,
+				[]byte{},
+			)
+			if test.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, <-txHashes, txHash)
+			}
+			require.Equal(t, test.expectedNonce, destinationClient.accounts[0].currentNonce)
+		})
+	}
+}
+
+func TestSendTxRPCFailover(t *testing.T) {
+	txSigner, err := signer.NewTxSigner(destinationSubnet.AccountPrivateKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		// The JSON-RPC error returned by the fallback endpoint to eth_sendRawTransaction, if any
+		fallbackErr   string
+		expectError   bool
+		expectedNonce uint64
+	}{
+		{
+			name:          "accepted by fallback endpoint",
+			expectedNonce: 1,
+		},
+		{
+			name:          "already known by fallback endpoint",
+			fallbackErr:   "already known",
+			expectedNonce: 1,
+		},
+		{
+			name:          "rejected by fallback endpoint",
+			fallbackErr:   "insufficient funds for gas * price + value",
+			expectError:   true,
+			expectedNonce: 0,
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			// The primary endpoint fails to respond to every request
+			primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+			}))
+			defer primary.Close()
+			var rawTxs [][]byte
+			fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					ID     json.RawMessage `json:"id"`
+					Method string          `json:"method"`
+					Params []hexutil.Bytes `json:"params"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				switch {
+				case req.Method == "eth_gasPrice":
+					fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":"0x1"}`, req.ID)
+				case test.fallbackErr != "":
+					fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":-32000,"message":%q}}`, req.ID, test.fallbackErr)
+				default:
+					rawTxs = append(rawTxs, req.Params[0])
+					fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":"0x%064x"}`, req.ID, 0)
+				}
+			}))
+			defer fallback.Close()
+
+			metrics, err := NewDestinationClientMetrics(prometheus.NewRegistry())
+			require.NoError(t, err)
+			transport, err := newFailoverTransport(
+				context.Background(),
+				logging.NoLog{},
+				ids.GenerateTestID(),
+				metrics.activeEndpoint,
+				metrics.failoverCount,
+				[]config.APIConfig{{BaseURL: primary.URL}, {BaseURL: fallback.URL}},
+				0,
+				time.Hour,
+				nil,
+			)
+			require.NoError(t, err)
+			client, err := utils.NewEthClientWithTransport(context.Background(), primary.URL, transport)
+			require.NoError(t, err)
+
+			destinationClient := &destinationClient{
+				logger:        logging.NoLog{},
+				client:        client,
+				evmChainID:    big.NewInt(5),
+				legacyPricing: true,
+				accounts:      []*senderAccount{{signer: txSigner}},
+				rpcFailover:   true,
+			}
+			txHash, err := destinationClient.SendTx(
+				context.Background(),
+				&avalancheWarp.Message{},
+				"0x27aE10273D17Cd7e80de8580A51f476960626e5f",
+				0,
+				[]byte{},
+			)
+			if test.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			if test.fallbackErr == "" {
+				require.Len(t, rawTxs, 1)
+				tx := new(types.Transaction)
+				require.NoError(t, tx.UnmarshalBinary(rawTxs[0]))
+				require.Equal(t, tx.Hash(), txHash)
+			}
+			require.Equal(t, test.expectedNonce, destinationClient.accounts[0].currentNonce)
+		})
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/utils"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// rpcEndpoint is one of the RPC endpoints a client may send requests to
+type rpcEndpoint struct {
+	baseURL string
+	url     *url.URL
+	headers map[string]string
+	// Queries the endpoint directly to check its health
+	client ethclient.Client
+
+	// Updated by health checks
+	healthy          bool
+	latestHeight     uint64
+	latestHeightTime time.Time
+}
+
+// failoverTransport sends the JSON-RPC requests of a client to its active RPC endpoint. If the active
+// endpoint fails to respond, the request is retried with the other endpoints in order of preference, and the
+// first to respond becomes the active endpoint. The health of each endpoint is re-evaluated periodically,
+// so that the client returns to the most preferred healthy endpoint once it recovers.
+type failoverTransport struct {
+	logger              logging.Logger
+	blockchainID        string
+	activeEndpoint      *prometheus.GaugeVec
+	failoverCount       *prometheus.CounterVec
+	base                http.RoundTripper
+	staleHeadTimeout    time.Duration
+	healthCheckInterval time.Duration
+
+	// Guards the fields below, and the health of the endpoints
+	lock            sync.Mutex
+	endpoints       []*rpcEndpoint
+	active          int
+	lastHealthCheck time.Time
+	checkingHealth  bool
+}
+
+// newFailoverTransport returns a transport that fails over between [endpoints], in order of preference.
+// The active endpoint and failovers are reported by [activeEndpoint] and [failoverCount], labeled
+// by [blockchainID]. If [tlsConfig] is non-nil, it is used for the connections to each endpoint.
+func newFailoverTransport(
+	ctx context.Context,
+	logger logging.Logger,
+	blockchainID ids.ID,
+	activeEndpoint *prometheus.GaugeVec,
+	failoverCount *prometheus.CounterVec,
+	endpoints []config.APIConfig,
+	staleHeadTimeout time.Duration,
+	healthCheckInterval time.Duration,
+	tlsConfig *tls.Config,
+) (*failoverTransport, error) {
+	t := &failoverTransport{
+		logger:              logger,
+		blockchainID:        blockchainID.String(),
+		activeEndpoint:      activeEndpoint,
+		failoverCount:       failoverCount,
+		base:                utils.NewHTTPTransport(tlsConfig),
+		staleHeadTimeout:    staleHeadTimeout,
+		healthCheckInterval: healthCheckInterval,
+		lastHealthCheck:     time.Now(),
+	}
+	for _, endpoint := range endpoints {
+		rawURL, err := utils.AddQueryParams(endpoint.BaseURL, endpoint.QueryParams)
+		if err != nil {
+			return nil, err
+		}
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, err
+		}
+		client, err := utils.NewEthClientWithConfig(
+			ctx,
+			endpoint.BaseURL,
+			endpoint.HTTPHeaders,
+			endpoint.QueryParams,
+			tlsConfig,
+		)
+		if err != nil {
+			return nil, err
+		}
+		t.endpoints = append(t.endpoints, &rpcEndpoint{
+			baseURL: endpoint.BaseURL,
+			url:     u,
+			headers: endpoint.HTTPHeaders,
+			client:  client,
+			healthy: true,
+		})
+	}
+	t.setActiveEndpointMetric()
+	return t, nil
+}
+
+// RoundTrip sends [req] to the active endpoint, failing over to the other endpoints if it does not respond.
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.maybeCheckHealth()
+
+	order := t.attemptOrder()
+	var (
+		resp *http.Response
+		err  error
+	)
+	for i, index := range order {
+		resp, err = t.send(req, t.endpoints[index])
+		if err == nil && resp.StatusCode < http.StatusInternalServerError {
+			t.setActive(index, "previous endpoint failed to respond")
+			return resp, nil
+		}
+		// The request is not retried if it was canceled, or if there are no other endpoints to try
+		if i == len(order)-1 || req.Context().Err() != nil {
+			return resp, err
+		}
+		if err == nil {
+			err = fmt.Errorf("unexpected status: %s", resp.Status)
+			resp.Body.Close()
+		}
+		t.logger.Warn(
+			"RPC endpoint failed to respond. Retrying with the next endpoint.",
+			zap.String("blockchainID", t.blockchainID),
+			zap.String("endpoint", t.endpoints[index].baseURL),
+			zap.Error(err),
+		)
+		t.setHealthy(index, false)
+	}
+	return resp, err
+}
+
+// send sends a copy of [req] to [endpoint]
+func (t *failoverTransport) send(req *http.Request, endpoint *rpcEndpoint) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	u := *endpoint.url
+	r.URL = &u
+	r.Host = u.Host
+	for key, value := range endpoint.headers {
+		r.Header.Set(key, value)
+	}
+	return t.base.RoundTrip(r)
+}
+
+// attemptOrder returns the indices of the endpoints in the order they are tried: the active endpoint,
+// followed by the healthy endpoints and then the unhealthy endpoints, each in order of preference.
+func (t *failoverTransport) attemptOrder() []int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	order := make([]int, 0, len(t.endpoints))
+	order = append(order, t.active)
+	for _, healthy := range []bool{true, false} {
+		for i, endpoint := range t.endpoints {
+			if i != t.active && endpoint.healthy == healthy {
+				order = append(order, i)
+			}
+		}
+	}
+	return order
+}
+
+func (t *failoverTransport) setHealthy(index int, healthy bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.endpoints[index].healthy = healthy
+}
+
+// setActive makes [index] the active endpoint, logging the failover if it was not already active
+func (t *failoverTransport) setActive(index int, reason string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.endpoints[index].healthy = true
+	if index == t.active {
+		return
+	}
+	t.logger.Warn(
+		"Failing over to RPC endpoint",
+		zap.String("blockchainID", t.blockchainID),
+		zap.String("from", t.endpoints[t.active].baseURL),
+		zap.String("to", t.endpoints[index].baseURL),
+		zap.String("reason", reason),
+	)
+	t.active = index
+	t.failoverCount.WithLabelValues(t.blockchainID).Inc()
+	t.setActiveEndpointMetric()
+}
+
+// setActiveEndpointMetric must be called with lock held
+func (t *failoverTransport) setActiveEndpointMetric() {
+	for i, endpoint := range t.endpoints {
+		active := 0.0
+		if i == t.active {
+			active = 1
+		}
+		t.activeEndpoint.WithLabelValues(t.blockchainID, endpoint.baseURL).Set(active)
+	}
+}
+
+// maybeCheckHealth checks the health of the endpoints in the background if the health check interval
+// has elapsed since the last check.
+func (t *failoverTransport) maybeCheckHealth() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.checkingHealth || time.Since(t.lastHealthCheck) < t.healthCheckInterval {
+		return
+	}
+	t.checkingHealth = true
+	go func() {
+		t.checkHealth(context.Background())
+		t.lock.Lock()
+		t.checkingHealth = false
+		t.lastHealthCheck = time.Now()
+		t.lock.Unlock()
+	}()
+}
+
+// checkHealth queries the latest block of each endpoint. An endpoint is healthy if it responds, and its
+// latest block has advanced within the stale head timeout, if one is configured. The most preferred healthy
+// endpoint becomes the active endpoint.
+func (t *failoverTransport) checkHealth(ctx context.Context) {
+	now := time.Now()
+	heights := make([]uint64, len(t.endpoints))
+	errs := make([]error, len(t.endpoints))
+	for i, endpoint := range t.endpoints {
+		cctx, cancel := context.WithTimeout(ctx, utils.DefaultRPCRetryTimeout)
+		heights[i], errs[i] = endpoint.client.BlockNumber(cctx)
+		cancel()
+	}
+
+	t.lock.Lock()
+	preferred, reason := -1, ""
+	for i, endpoint := range t.endpoints {
+		healthy := errs[i] == nil
+		if healthy && (endpoint.latestHeightTime.IsZero() || heights[i] > endpoint.latestHeight) {
+			endpoint.latestHeight = heights[i]
+			endpoint.latestHeightTime = now
+		}
+		if healthy && t.staleHeadTimeout != 0 && now.Sub(endpoint.latestHeightTime) > t.staleHeadTimeout {
+			healthy = false
+			if i == t.active {
+				reason = "active endpoint head is stale"
+			}
+		} else if !healthy && i == t.active {
+			reason = "active endpoint failed health check"
+		}
+		if endpoint.healthy != healthy {
+			t.logger.Info(
+				"RPC endpoint health changed",
+				zap.String("blockchainID", t.blockchainID),
+				zap.String("endpoint", endpoint.baseURL),
+				zap.Bool("healthy", healthy),
+				zap.Uint64("latestHeight", endpoint.latestHeight),
+				zap.Error(errs[i]),
+			)
+		}
+		endpoint.healthy = healthy
+		if healthy && preferred == -1 {
+			preferred = i
+		}
+	}
+	t.lock.Unlock()
+
+	if preferred == -1 {
+		return
+	}
+	if reason == "" {
+		reason = "preferred endpoint recovered"
+	}
+	t.setActive(preferred, reason)
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+// newTestRPCServer returns a server that responds to eth_blockNumber with [height], or with an error status
+// if [up] is false.
+func newTestRPCServer(t *testing.T, height *atomic.Uint64, up *atomic.Bool) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !up.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":"0x%x"}`, req.ID, height.Load())
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFailoverTransport(t *testing.T) {
+	primaryHeight, fallbackHeight := atomic.NewUint64(100), atomic.NewUint64(200)
+	primaryUp, fallbackUp := atomic.NewBool(false), atomic.NewBool(true)
+	primary := newTestRPCServer(t, primaryHeight, primaryUp)
+	fallback := newTestRPCServer(t, fallbackHeight, fallbackUp)
+
+	blockchainID := ids.GenerateTestID()
+	metrics, err := NewSourceClientMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+	transport, err := newFailoverTransport(
+		context.Background(),
+		logging.NoLog{},
+		blockchainID,
+		metrics.activeEndpoint,
+		metrics.failoverCount,
+		[]config.APIConfig{{BaseURL: primary.URL}, {BaseURL: fallback.URL}},
+		time.Minute,
+		time.Hour,
+		nil,
+	)
+	require.NoError(t, err)
+	client, err := utils.NewEthClientWithTransport(context.Background(), primary.URL, transport)
+	require.NoError(t, err)
+
+	requireActive := func(active string, failovers float64) {
+		for _, endpoint := range []string{primary.URL, fallback.URL} {
+			expected := 0.0
+			if endpoint == active {
+				expected = 1
+			}
+			require.Equal(t, expected, testutil.ToFloat64(metrics.activeEndpoint.WithLabelValues(blockchainID.String(), endpoint)))
+		}
+		require.Equal(t, failovers, testutil.ToFloat64(metrics.failoverCount.WithLabelValues(blockchainID.String())))
+	}
+	requireActive(primary.URL, 0)
+
+	// Requests fail over to the fallback endpoint while the primary is down
+	height, err := client.BlockNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(200), height)
+	requireActive(fallback.URL, 1)
+
+	// The fallback endpoint remains active until the health check finds the primary has recovered
+	primaryUp.Store(true)
+	height, err = client.BlockNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(200), height)
+	transport.checkHealth(context.Background())
+	requireActive(primary.URL, 2)
+	height, err = client.BlockNumber(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), height)
+
+	// A primary endpoint whose head has not advanced within the stale head timeout is failed over
+	transport.endpoints[0].latestHeightTime = time.Now().Add(-2 * time.Minute)
+	fallbackHeight.Store(201)
+	transport.checkHealth(context.Background())
+	requireActive(fallback.URL, 3)
+
+	// If all endpoints are down, requests fail
+	primaryUp.Store(false)
+	fallbackUp.Store(false)
+	_, err = client.BlockNumber(context.Background())
+	require.Error(t, err)
+}
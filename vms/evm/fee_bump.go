@@ -0,0 +1,168 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// How long the hashes of replaced transactions continue to be resolved to their replacements after the
+// replacement is included, so that callers waiting on the original hash observe the inclusion.
+const replacedTxRetention = 10 * time.Minute
+
+// bumpTxFees returns a copy of [tx] with its fees increased by [percentage] percent, and by at least 1 wei,
+// so that it replaces [tx] in the mempool.
+func bumpTxFees(tx *types.Transaction, percentage uint64) *types.Transaction {
+	bump := func(fee *big.Int) *big.Int {
+		bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+percentage))
+		bumped.Div(bumped, big.NewInt(100))
+		if bumped.Cmp(fee) <= 0 {
+			bumped.Add(fee, big.NewInt(1))
+		}
+		return bumped
+	}
+	switch tx.Type() {
+	case types.LegacyTxType:
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: bump(tx.GasPrice()),
+			Gas:      tx.Gas(),
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		})
+	case types.AccessListTxType:
+		return types.NewTx(&types.AccessListTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasPrice:   bump(tx.GasPrice()),
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		})
+	default:
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  bump(tx.GasTipCap()),
+			GasFeeCap:  bump(tx.GasFeeCap()),
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		})
+	}
+}
+
+// bumpPendingTxFees replaces the pending transaction [ptx] with one with the same nonce and increased fees.
+func (c *destinationClient) bumpPendingTxFees(ctx context.Context, ptx *pendingTx) {
+	// Hold the nonce lock so that the replacement is not interleaved with new transactions
+	ptx.account.lock.Lock()
+	defer ptx.account.lock.Unlock()
+
+	bumpedTx := bumpTxFees(ptx.tx, c.feeBumpPercentage)
+	if c.maxGasPrice != nil && bumpedTx.GasFeeCap().Cmp(c.maxGasPrice) > 0 {
+		// Leave the transaction pending, as once the maximum number of fee bumps is reached
+		c.logger.Warn(
+			"Not replacing pending transaction. Increased fees would exceed the gas price cap.",
+			zap.String("txID", ptx.hash.String()),
+			zap.Uint64("nonce", bumpedTx.Nonce()),
+			zap.Stringer("gasFeeCap", bumpedTx.GasFeeCap()),
+			zap.Stringer("maxGasPrice", c.maxGasPrice),
+		)
+		ptx.feeBumps = c.maxFeeBumps
+		return
+	}
+	signedTx, err := ptx.account.signer.SignTx(bumpedTx, c.evmChainID)
+	if err != nil {
+		c.logger.Error(
+			"Failed to sign fee replacement transaction",
+			zap.String("txID", ptx.hash.String()),
+			zap.Error(err),
+		)
+		return
+	}
+	if err := c.sendTransaction(ctx, signedTx); err != nil {
+		c.logger.Error(
+			"Failed to send fee replacement transaction",
+			zap.String("txID", ptx.hash.String()),
+			zap.Error(err),
+		)
+		return
+	}
+	c.logger.Warn(
+		"Replaced transaction not included within the deadline with increased fees",
+		zap.String("replacedTxID", ptx.hash.String()),
+		zap.String("txID", signedTx.Hash().String()),
+		zap.Uint64("nonce", bumpedTx.Nonce()),
+		zap.Uint64("feeBumps", ptx.feeBumps+1),
+		zap.Stringer("gasFeeCap", bumpedTx.GasFeeCap()),
+		zap.Stringer("gasTipCap", bumpedTx.GasTipCap()),
+	)
+	if c.metrics != nil {
+		c.metrics.feeBumpCount.
+			WithLabelValues(c.destinationBlockchainID.String()).
+			Inc()
+	}
+
+	ptx.feeBumps++
+	c.replacePendingTx(ptx, bumpedTx, signedTx.Hash())
+}
+
+// replacePendingTx records that [ptx] has been replaced by [tx], with hash [hash]. Receipts requested
+// for the transaction's previous hashes are resolved to the replacement.
+func (c *destinationClient) replacePendingTx(ptx *pendingTx, tx *types.Transaction, hash common.Hash) {
+	c.pendingTxsLock.Lock()
+	defer c.pendingTxsLock.Unlock()
+	if c.replacedTxs != nil && hash != ptx.hash {
+		c.replacedTxs[ptx.hash] = ptx
+	}
+	ptx.tx = tx
+	ptx.hash = hash
+	ptx.sentAt = time.Now()
+	ptx.reportedPending = false
+}
+
+// currentTxHash returns the hash of the transaction that replaced [txHash], or [txHash] if it was not replaced.
+func (c *destinationClient) currentTxHash(txHash common.Hash) common.Hash {
+	c.pendingTxsLock.Lock()
+	defer c.pendingTxsLock.Unlock()
+	if ptx, ok := c.replacedTxs[txHash]; ok {
+		return ptx.hash
+	}
+	return txHash
+}
+
+// pruneReplacedTxs stops resolving the hashes of replaced transactions whose replacement was included
+// more than replacedTxRetention ago.
+func (c *destinationClient) pruneReplacedTxs() {
+	c.pendingTxsLock.Lock()
+	defer c.pendingTxsLock.Unlock()
+	for hash, ptx := range c.replacedTxs {
+		if !ptx.untrackedAt.IsZero() && time.Since(ptx.untrackedAt) > replacedTxRetention {
+			delete(c.replacedTxs, hash)
+		}
+	}
+}
+
+// replacementFollowingClient resolves the hashes of replaced transactions to their replacements when
+// fetching receipts, so that callers of Client may wait on the hash returned by SendTx.
+type replacementFollowingClient struct {
+	ethclient.Client
+	currentTxHash func(common.Hash) common.Hash
+}
+
+func (c *replacementFollowingClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return c.Client.TransactionReceipt(ctx, c.currentTxHash(txHash))
+}
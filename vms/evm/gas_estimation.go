@@ -0,0 +1,66 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	predicateutils "github.com/ava-labs/subnet-evm/predicate"
+	evmutils "github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// estimateGasLimit estimates the gas used by calling [to] with [callData] from [from], with [signedMessages]
+// included as predicates, and returns the estimate increased by the configured buffer percentage. [gasLimit]
+// serves as a floor, and is returned as is if estimation fails.
+func (c *destinationClient) estimateGasLimit(
+	ctx context.Context,
+	from common.Address,
+	to common.Address,
+	signedMessages []*avalancheWarp.Message,
+	gasLimit uint64,
+	callData []byte,
+) uint64 {
+	estimate, err := c.client.EstimateGas(ctx, interfaces.CallMsg{
+		From:       from,
+		To:         &to,
+		Data:       callData,
+		AccessList: predicateAccessList(signedMessages),
+	})
+	if err != nil {
+		c.logger.Warn(
+			"Failed to estimate gas. Using the message gas limit.",
+			zap.String("destinationBlockchainID", c.destinationBlockchainID.String()),
+			zap.Uint64("gasLimit", gasLimit),
+			zap.Error(err),
+		)
+		return gasLimit
+	}
+	bufferedEstimate := estimate + estimate*c.gasEstimateBufferPercentage/100
+	c.logger.Debug(
+		"Estimated gas limit",
+		zap.String("destinationBlockchainID", c.destinationBlockchainID.String()),
+		zap.Uint64("estimate", estimate),
+		zap.Uint64("bufferedEstimate", bufferedEstimate),
+		zap.Uint64("gasLimit", gasLimit),
+	)
+	return max(bufferedEstimate, gasLimit)
+}
+
+// predicateAccessList returns the access list including each of [signedMessages] as a Warp predicate, in order
+func predicateAccessList(signedMessages []*avalancheWarp.Message) types.AccessList {
+	var accessList types.AccessList
+	for _, signedMessage := range signedMessages {
+		accessList = append(accessList, types.AccessTuple{
+			Address:     warp.ContractAddress,
+			StorageKeys: evmutils.BytesToHashSlice(predicateutils.PackPredicate(signedMessage.Bytes())),
+		})
+	}
+	return accessList
+}
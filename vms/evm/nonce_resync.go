@@ -0,0 +1,115 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"go.uber.org/zap"
+)
+
+// Requests the nonce of an account including the transactions in the destination chain's mempool
+var pendingBlockNumber = big.NewInt(int64(rpc.PendingBlockNumber))
+
+// resyncNonce resynchronizes the locally tracked nonce of [account] with its pending nonce on the destination
+// chain, after the destination rejected a transaction due to a nonce mismatch. If the local nonce is behind,
+// it is advanced to the pending nonce. If it is ahead, the transactions issued with the nonces in between
+// were dropped, and later transactions would never be executed. The gap is then filled with self-transfers if
+// fillNonceGaps is set, and otherwise the local nonce is reset to the pending nonce.
+// Must be called with the account's lock held.
+func (c *destinationClient) resyncNonce(ctx context.Context, account *senderAccount) {
+	pendingNonce, err := c.client.NonceAt(ctx, account.signer.Address(), pendingBlockNumber)
+	if err != nil {
+		c.logger.Error(
+			"Failed to get pending nonce",
+			zap.String("destinationBlockchainID", c.destinationBlockchainID.String()),
+			zap.String("sender", account.signer.Address().String()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	previousNonce := account.currentNonce
+	nonce := pendingNonce
+	if c.fillNonceGaps && previousNonce > pendingNonce {
+		// Stop at the first self-transfer that fails, so that the next transaction fills the rest of the gap
+		for ; nonce < previousNonce; nonce++ {
+			if err := c.fillNonce(ctx, account, nonce); err != nil {
+				break
+			}
+		}
+	}
+	c.logger.Info(
+		"Resynced nonce",
+		zap.String("destinationBlockchainID", c.destinationBlockchainID.String()),
+		zap.String("sender", account.signer.Address().String()),
+		zap.Uint64("previousNonce", previousNonce),
+		zap.Uint64("pendingNonce", pendingNonce),
+		zap.Uint64("nonce", nonce),
+		zap.Uint64("filledNonces", nonce-pendingNonce),
+	)
+	account.currentNonce = nonce
+}
+
+// fillNonce issues a zero-value transfer from [account] to itself with the given nonce.
+func (c *destinationClient) fillNonce(ctx context.Context, account *senderAccount, nonce uint64) error {
+	address := account.signer.Address()
+	var tx *types.Transaction
+	if c.legacyPricing {
+		gasPrice, err := c.suggestGasPrice(ctx)
+		if err != nil {
+			return err
+		}
+		tx = types.NewTx(&types.LegacyTx{
+			Nonce:    nonce,
+			To:       &address,
+			Gas:      params.TxGas,
+			GasPrice: gasPrice,
+			Value:    big.NewInt(0),
+		})
+	} else {
+		gasFeeCap, gasTipCap, err := c.estimateDynamicFees(ctx)
+		if err != nil {
+			return err
+		}
+		tx = types.NewTx(&types.DynamicFeeTx{
+			ChainID:   c.evmChainID,
+			Nonce:     nonce,
+			To:        &address,
+			Gas:       params.TxGas,
+			GasFeeCap: gasFeeCap,
+			GasTipCap: gasTipCap,
+			Value:     big.NewInt(0),
+		})
+	}
+
+	signedTx, err := account.signer.SignTx(tx, c.evmChainID)
+	if err != nil {
+		c.logger.Error(
+			"Failed to sign nonce gap transaction",
+			zap.Error(err),
+		)
+		return err
+	}
+	if err := c.sendTransaction(ctx, signedTx); err != nil {
+		c.logger.Error(
+			"Failed to send nonce gap transaction",
+			zap.String("sender", address.String()),
+			zap.Uint64("nonce", nonce),
+			zap.Error(err),
+		)
+		return err
+	}
+	c.logger.Info(
+		"Sent nonce gap transaction",
+		zap.String("txID", signedTx.Hash().String()),
+		zap.String("sender", address.String()),
+		zap.Uint64("nonce", nonce),
+	)
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	mock_ethclient "github.com/ava-labs/awm-relayer/vms/evm/mocks"
+	"github.com/ava-labs/awm-relayer/vms/evm/signer"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestResyncNonce(t *testing.T) {
+	txSigner, err := signer.NewTxSigner(destinationSubnet.AccountPrivateKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		localNonce    uint64
+		pendingNonce  uint64
+		pendingErr    error
+		fillNonceGaps bool
+		// The error returned for each self-transfer filling the nonce gap
+		fillErrs           []error
+		expectedFillNonces []uint64
+		expectedLocalNonce uint64
+	}{
+		{
+			name:               "local nonce behind",
+			localNonce:         10,
+			pendingNonce:       12,
+			expectedLocalNonce: 12,
+		},
+		{
+			name:               "local nonce behind with gap filling",
+			localNonce:         10,
+			pendingNonce:       12,
+			fillNonceGaps:      true,
+			expectedLocalNonce: 12,
+		},
+		{
+			name:               "gap reset",
+			localNonce:         12,
+			pendingNonce:       10,
+			expectedLocalNonce: 10,
+		},
+		{
+			name:               "gap filled",
+			localNonce:         12,
+			pendingNonce:       10,
+			fillNonceGaps:      true,
+			fillErrs:           []error{nil, nil},
+			expectedFillNonces: []uint64{10, 11},
+			expectedLocalNonce: 12,
+		},
+		{
+			name:               "gap partially filled",
+			localNonce:         12,
+			pendingNonce:       10,
+			fillNonceGaps:      true,
+			fillErrs:           []error{nil, fmt.Errorf("connection reset")},
+			expectedFillNonces: []uint64{10, 11},
+			expectedLocalNonce: 11,
+		},
+		{
+			name:               "pending nonce unavailable",
+			localNonce:         12,
+			pendingErr:         fmt.Errorf("connection reset"),
+			fillNonceGaps:      true,
+			expectedLocalNonce: 12,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockClient := mock_ethclient.NewMockClient(ctrl)
+			account := &senderAccount{signer: txSigner, currentNonce: test.localNonce}
+			destinationClient := &destinationClient{
+				logger:               logging.NoLog{},
+				client:               mockClient,
+				evmChainID:           big.NewInt(5),
+				baseFeeFactor:        big.NewInt(2),
+				maxPriorityFeePerGas: big.NewInt(2500000000),
+				fillNonceGaps:        test.fillNonceGaps,
+			}
+
+			mockClient.EXPECT().NonceAt(gomock.Any(), txSigner.Address(), pendingBlockNumber).
+				Return(test.pendingNonce, test.pendingErr).
+				Times(1)
+			fills := len(test.fillErrs)
+			mockClient.EXPECT().EstimateBaseFee(gomock.Any()).Return(new(big.Int), nil).Times(fills)
+			mockClient.EXPECT().SuggestGasTipCap(gomock.Any()).Return(new(big.Int), nil).Times(fills)
+			var fillNonces []uint64
+			mockClient.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, tx *types.Transaction) error {
+					require.Equal(t, txSigner.Address(), *tx.To())
+					require.Zero(t, tx.Value().Sign())
+					fillNonces = append(fillNonces, tx.Nonce())
+					return test.fillErrs[len(fillNonces)-1]
+				},
+			).Times(fills)
+
+			account.lock.Lock()
+			destinationClient.resyncNonce(context.Background(), account)
+			account.lock.Unlock()
+
+			require.Equal(t, test.expectedFillNonces, fillNonces)
+			require.Equal(t, test.expectedLocalNonce, account.currentNonce)
+		})
+	}
+}
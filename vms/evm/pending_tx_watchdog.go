@@ -0,0 +1,214 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+const (
+	pendingTxCheckInterval = 5 * time.Second
+	pendingTxRPCTimeout    = 10 * time.Second
+)
+
+type pendingTxKey struct {
+	sender common.Address
+	nonce  uint64
+}
+
+// A transaction issued by the relayer that has not yet been observed in a block
+type pendingTx struct {
+	// The account that issued the transaction
+	account *senderAccount
+	// The unsigned transaction, so that it may be re-signed on re-submission
+	tx     *types.Transaction
+	hash   common.Hash
+	sentAt time.Time
+	// Set once the transaction has been reported as still pending past the timeout
+	reportedPending bool
+	// Number of times the transaction has been replaced with increased fees
+	feeBumps uint64
+	// Set once the transaction is no longer tracked
+	untrackedAt time.Time
+}
+
+func (ptx *pendingTx) key() pendingTxKey {
+	return pendingTxKey{
+		sender: ptx.account.signer.Address(),
+		nonce:  ptx.tx.Nonce(),
+	}
+}
+
+func (c *destinationClient) trackPendingTx(account *senderAccount, tx *types.Transaction, hash common.Hash) {
+	c.pendingTxsLock.Lock()
+	defer c.pendingTxsLock.Unlock()
+	ptx := &pendingTx{
+		account: account,
+		tx:      tx,
+		hash:    hash,
+		sentAt:  time.Now(),
+	}
+	c.pendingTxs[ptx.key()] = ptx
+}
+
+func (c *destinationClient) untrackPendingTx(ptx *pendingTx) {
+	c.pendingTxsLock.Lock()
+	defer c.pendingTxsLock.Unlock()
+	delete(c.pendingTxs, ptx.key())
+	ptx.untrackedAt = time.Now()
+}
+
+// watchPendingTxs periodically checks the transactions issued by this client, and re-submits
+// any that have been dropped from the mempool without being included in a block, or that have not
+// been included within the inclusion deadline. Returns once [ctx] is canceled.
+func (c *destinationClient) watchPendingTxs(ctx context.Context) {
+	ticker := time.NewTicker(pendingTxCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.checkPendingTxs()
+		}
+	}
+}
+
+func (c *destinationClient) checkPendingTxs() {
+	c.pendingTxsLock.Lock()
+	txs := make([]*pendingTx, 0, len(c.pendingTxs))
+	for _, ptx := range c.pendingTxs {
+		txs = append(txs, ptx)
+	}
+	c.pendingTxsLock.Unlock()
+
+	for _, ptx := range txs {
+		ctx, cancel := context.WithTimeout(context.Background(), pendingTxRPCTimeout)
+		c.checkPendingTx(ctx, ptx)
+		cancel()
+	}
+	c.pruneReplacedTxs()
+}
+
+// checkPendingTx distinguishes between a transaction that has been included in a block,
+// one that is still pending in the mempool, and one that has been dropped. Dropped transactions
+// are re-signed and re-submitted with the same nonce once [pendingTxTimeout] has elapsed. Pending
+// transactions are replaced with increased fees once [inclusionDeadline] has elapsed, up to [maxFeeBumps] times.
+func (c *destinationClient) checkPendingTx(ctx context.Context, ptx *pendingTx) {
+	nonce := ptx.tx.Nonce()
+	_, err := c.client.TransactionReceipt(ctx, ptx.hash)
+	if err == nil {
+		c.untrackPendingTx(ptx)
+		return
+	}
+	if !errors.Is(err, interfaces.NotFound) {
+		c.logger.Warn(
+			"Failed to get transaction receipt",
+			zap.String("txID", ptx.hash.String()),
+			zap.Error(err),
+		)
+		return
+	}
+	elapsed := time.Since(ptx.sentAt)
+	dueForFeeBump := c.inclusionDeadline > 0 && elapsed >= c.inclusionDeadline && ptx.feeBumps < c.maxFeeBumps
+	dueForCheck := c.pendingTxTimeout > 0 && elapsed >= c.pendingTxTimeout
+	if !dueForFeeBump && !dueForCheck {
+		return
+	}
+
+	_, isPending, err := c.client.TransactionByHash(ctx, ptx.hash)
+	if err == nil {
+		if isPending && dueForFeeBump {
+			c.bumpPendingTxFees(ctx, ptx)
+			return
+		}
+		if isPending && !ptx.reportedPending {
+			ptx.reportedPending = true
+			c.logger.Warn(
+				"Transaction still pending after timeout",
+				zap.String("txID", ptx.hash.String()),
+				zap.Uint64("nonce", nonce),
+			)
+		}
+		// Otherwise, the transaction was included after the receipt was requested
+		return
+	}
+	if !errors.Is(err, interfaces.NotFound) {
+		c.logger.Warn(
+			"Failed to get transaction",
+			zap.String("txID", ptx.hash.String()),
+			zap.Error(err),
+		)
+		return
+	}
+	if !dueForCheck {
+		return
+	}
+
+	// The transaction has been dropped. If the nonce has since been consumed, then another
+	// transaction with the same nonce was accepted, and there is nothing to re-submit.
+	confirmedNonce, err := c.client.NonceAt(ctx, ptx.account.signer.Address(), nil)
+	if err != nil {
+		c.logger.Warn(
+			"Failed to get nonce",
+			zap.Error(err),
+		)
+		return
+	}
+	if confirmedNonce > nonce {
+		c.logger.Warn(
+			"Dropped transaction's nonce was consumed by another transaction",
+			zap.String("txID", ptx.hash.String()),
+			zap.Uint64("nonce", nonce),
+		)
+		c.untrackPendingTx(ptx)
+		return
+	}
+
+	c.resubmitDroppedTx(ctx, ptx)
+}
+
+func (c *destinationClient) resubmitDroppedTx(ctx context.Context, ptx *pendingTx) {
+	// Hold the nonce lock so that the re-submission is not interleaved with new transactions
+	ptx.account.lock.Lock()
+	defer ptx.account.lock.Unlock()
+
+	signedTx, err := ptx.account.signer.SignTx(ptx.tx, c.evmChainID)
+	if err != nil {
+		c.logger.Error(
+			"Failed to sign dropped transaction",
+			zap.String("txID", ptx.hash.String()),
+			zap.Error(err),
+		)
+		return
+	}
+	if err := c.sendTransaction(ctx, signedTx); err != nil {
+		c.logger.Error(
+			"Failed to re-submit dropped transaction",
+			zap.String("txID", ptx.hash.String()),
+			zap.Error(err),
+		)
+		return
+	}
+	c.logger.Warn(
+		"Re-submitted dropped transaction",
+		zap.String("droppedTxID", ptx.hash.String()),
+		zap.String("txID", signedTx.Hash().String()),
+		zap.Uint64("nonce", ptx.tx.Nonce()),
+	)
+	if c.metrics != nil {
+		c.metrics.droppedTxResubmissionCount.
+			WithLabelValues(c.destinationBlockchainID.String()).
+			Inc()
+	}
+
+	c.replacePendingTx(ptx, ptx.tx, signedTx.Hash())
+}
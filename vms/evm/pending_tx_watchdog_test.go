@@ -0,0 +1,280 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	mock_ethclient "github.com/ava-labs/awm-relayer/vms/evm/mocks"
+	"github.com/ava-labs/awm-relayer/vms/evm/signer"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCheckPendingTx(t *testing.T) {
+	txSigner, err := signer.NewTxSigner(destinationSubnet.AccountPrivateKey)
+	require.NoError(t, err)
+
+	const txNonce = uint64(3)
+	testCases := []struct {
+		name              string
+		elapsed           time.Duration
+		receiptErr        error
+		checkTx           bool
+		txIsPending       bool
+		txErr             error
+		confirmedNonce    uint64
+		checkNonce        bool
+		inclusionDeadline time.Duration
+		feeBumps          uint64
+		expectResubmitted bool
+		expectFeeBump     bool
+		expectTracked     bool
+	}{
+		{
+			name:          "included",
+			elapsed:       time.Minute,
+			expectTracked: false,
+		},
+		{
+			name:          "before timeout",
+			elapsed:       0,
+			receiptErr:    interfaces.NotFound,
+			expectTracked: true,
+		},
+		{
+			name:          "still pending",
+			elapsed:       time.Minute,
+			receiptErr:    interfaces.NotFound,
+			checkTx:       true,
+			txIsPending:   true,
+			expectTracked: true,
+		},
+		{
+			name:              "still pending after inclusion deadline",
+			elapsed:           time.Minute,
+			receiptErr:        interfaces.NotFound,
+			checkTx:           true,
+			txIsPending:       true,
+			inclusionDeadline: 10 * time.Second,
+			expectFeeBump:     true,
+			expectTracked:     true,
+		},
+		{
+			name:              "still pending after max fee bumps",
+			elapsed:           time.Minute,
+			receiptErr:        interfaces.NotFound,
+			checkTx:           true,
+			txIsPending:       true,
+			inclusionDeadline: 10 * time.Second,
+			feeBumps:          3,
+			expectTracked:     true,
+		},
+		{
+			name:              "dropped",
+			elapsed:           time.Minute,
+			receiptErr:        interfaces.NotFound,
+			checkTx:           true,
+			txErr:             interfaces.NotFound,
+			checkNonce:        true,
+			confirmedNonce:    txNonce,
+			expectResubmitted: true,
+			expectTracked:     true,
+		},
+		{
+			name:           "dropped and nonce consumed",
+			elapsed:        time.Minute,
+			receiptErr:     interfaces.NotFound,
+			checkTx:        true,
+			txErr:          interfaces.NotFound,
+			checkNonce:     true,
+			confirmedNonce: txNonce + 1,
+			expectTracked:  false,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockClient := mock_ethclient.NewMockClient(ctrl)
+			metrics, err := NewDestinationClientMetrics(prometheus.NewRegistry())
+			require.NoError(t, err)
+			destinationBlockchainID := ids.GenerateTestID()
+			destinationClient := &destinationClient{
+				logger:                  logging.NoLog{},
+				client:                  mockClient,
+				destinationBlockchainID: destinationBlockchainID,
+				evmChainID:              big.NewInt(5),
+				accounts:                []*senderAccount{{signer: txSigner}},
+				metrics:                 metrics,
+				pendingTxTimeout:        30 * time.Second,
+				pendingTxs:              make(map[pendingTxKey]*pendingTx),
+				inclusionDeadline:       test.inclusionDeadline,
+				feeBumpPercentage:       10,
+				maxFeeBumps:             3,
+				replacedTxs:             make(map[common.Hash]*pendingTx),
+			}
+
+			tx := types.NewTx(&types.DynamicFeeTx{
+				Nonce:     txNonce,
+				GasFeeCap: big.NewInt(100),
+				GasTipCap: big.NewInt(10),
+			})
+			destinationClient.trackPendingTx(destinationClient.accounts[0], tx, tx.Hash())
+			ptx := destinationClient.pendingTxs[pendingTxKey{sender: txSigner.Address(), nonce: txNonce}]
+			ptx.sentAt = time.Now().Add(-test.elapsed)
+			ptx.feeBumps = test.feeBumps
+
+			mockClient.EXPECT().TransactionReceipt(gomock.Any(), tx.Hash()).Return(
+				&types.Receipt{},
+				test.receiptErr,
+			).Times(1)
+			if test.checkTx {
+				mockClient.EXPECT().TransactionByHash(gomock.Any(), tx.Hash()).Return(
+					tx,
+					test.txIsPending,
+					test.txErr,
+				).Times(1)
+			}
+			if test.checkNonce {
+				mockClient.EXPECT().NonceAt(gomock.Any(), txSigner.Address(), nil).Return(
+					test.confirmedNonce,
+					nil,
+				).Times(1)
+			}
+			if test.expectFeeBump {
+				mockClient.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, signedTx *types.Transaction) error {
+						require.Equal(t, txNonce, signedTx.Nonce())
+						require.Equal(t, big.NewInt(110), signedTx.GasFeeCap())
+						require.Equal(t, big.NewInt(11), signedTx.GasTipCap())
+						return nil
+					},
+				).Times(1)
+			}
+			if test.expectResubmitted {
+				mockClient.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, signedTx *types.Transaction) error {
+						require.Equal(t, txNonce, signedTx.Nonce())
+						return nil
+					},
+				).Times(1)
+			}
+
+			destinationClient.checkPendingTx(context.Background(), ptx)
+
+			_, tracked := destinationClient.pendingTxs[pendingTxKey{sender: txSigner.Address(), nonce: txNonce}]
+			require.Equal(t, test.expectTracked, tracked)
+			resubmissions := testutil.ToFloat64(
+				metrics.droppedTxResubmissionCount.WithLabelValues(destinationBlockchainID.String()),
+			)
+			if test.expectResubmitted {
+				require.Equal(t, float64(1), resubmissions)
+				require.WithinDuration(t, time.Now(), ptx.sentAt, time.Second)
+			} else {
+				require.Zero(t, resubmissions)
+			}
+			feeBumps := testutil.ToFloat64(metrics.feeBumpCount.WithLabelValues(destinationBlockchainID.String()))
+			if test.expectFeeBump {
+				require.Equal(t, float64(1), feeBumps)
+				require.Equal(t, test.feeBumps+1, ptx.feeBumps)
+				require.NotEqual(t, tx.Hash(), ptx.hash)
+			} else {
+				require.Zero(t, feeBumps)
+			}
+			// Receipts requested for the original transaction are fetched for its replacement
+			require.Equal(t, ptx.hash, destinationClient.currentTxHash(tx.Hash()))
+		})
+	}
+}
+
+func TestBumpTxFees(t *testing.T) {
+	to := common.HexToAddress("0x27aE10273D17Cd7e80de8580A51f476960626e5f")
+	testCases := []struct {
+		name              string
+		tx                *types.Transaction
+		expectedGasPrice  *big.Int
+		expectedGasFeeCap *big.Int
+		expectedGasTipCap *big.Int
+	}{
+		{
+			name:              "legacy",
+			tx:                types.NewTx(&types.LegacyTx{Nonce: 1, To: &to, GasPrice: big.NewInt(1000)}),
+			expectedGasPrice:  big.NewInt(1200),
+			expectedGasFeeCap: big.NewInt(1200),
+			expectedGasTipCap: big.NewInt(1200),
+		},
+		{
+			name: "access list",
+			tx: types.NewTx(&types.AccessListTx{
+				ChainID:    big.NewInt(5),
+				Nonce:      1,
+				To:         &to,
+				GasPrice:   big.NewInt(1000),
+				AccessList: types.AccessList{{Address: to}},
+			}),
+			expectedGasPrice:  big.NewInt(1200),
+			expectedGasFeeCap: big.NewInt(1200),
+			expectedGasTipCap: big.NewInt(1200),
+		},
+		{
+			name: "dynamic fee",
+			tx: types.NewTx(&types.DynamicFeeTx{
+				ChainID:    big.NewInt(5),
+				Nonce:      1,
+				To:         &to,
+				GasFeeCap:  big.NewInt(1000),
+				GasTipCap:  big.NewInt(1),
+				AccessList: types.AccessList{{Address: to}},
+			}),
+			expectedGasPrice:  big.NewInt(1200),
+			expectedGasFeeCap: big.NewInt(1200),
+			// Fees too small to be increased by the percentage are increased by 1 wei
+			expectedGasTipCap: big.NewInt(2),
+		},
+	}
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			bumped := bumpTxFees(test.tx, 20)
+			require.Equal(t, test.tx.Type(), bumped.Type())
+			require.Equal(t, test.tx.Nonce(), bumped.Nonce())
+			require.Equal(t, test.tx.To(), bumped.To())
+			require.Equal(t, test.tx.AccessList(), bumped.AccessList())
+			require.Equal(t, test.expectedGasPrice, bumped.GasPrice())
+			require.Equal(t, test.expectedGasFeeCap, bumped.GasFeeCap())
+			require.Equal(t, test.expectedGasTipCap, bumped.GasTipCap())
+		})
+	}
+}
+
+func TestWatchPendingTxsStops(t *testing.T) {
+	destinationClient := &destinationClient{
+		logger:     logging.NoLog{},
+		pendingTxs: make(map[pendingTxKey]*pendingTx),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		destinationClient.watchPendingTxs(ctx)
+	}()
+
+	// The watchdog returns once its context is canceled
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.FailNow(t, "pending transaction watchdog did not return")
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/utils"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"go.uber.org/zap"
+)
+
+// pollingSubscriber implements Subscriber by polling an RPC endpoint for new blocks, for RPC providers that
+// do not support WebSocket subscriptions. Historical blocks are processed in the same way as by subscriber.
+type pollingSubscriber struct {
+	*subscriber
+
+	// The latest block is polled every interval, and up to batchSize new blocks are processed per poll
+	interval  time.Duration
+	batchSize uint64
+
+	// Receives the error that stopped the poll loop started by the latest call to Subscribe
+	errs chan error
+	// Stops the poll loop. Nil until Subscribe is called.
+	stop context.CancelFunc
+}
+
+// NewPollingSubscriber returns a subscriber that polls [ethClient] for new blocks every [interval].
+// Failed attempts to start polling are retried with an exponential backoff starting at [initialBackoff],
+// up to [maxBackoff].
+func NewPollingSubscriber(
+	logger logging.Logger,
+	blockchainID ids.ID,
+	ethClient ethclient.Client,
+	initialBackoff time.Duration,
+	maxBackoff time.Duration,
+	interval time.Duration,
+	batchSize uint64,
+) *pollingSubscriber {
+	return &pollingSubscriber{
+		subscriber: NewSubscriber(logger, blockchainID, ethClient, initialBackoff, maxBackoff),
+		interval:   interval,
+		batchSize:  batchSize,
+		errs:       make(chan error, 1),
+	}
+}
+
+// Subscribe starts polling for blocks following the latest block. Fetching the latest block is retried with
+// exponential backoff until it succeeds, [ctx] is canceled, or [maxResubscribeAttempts] attempts have failed.
+// Loops forever iff maxResubscribeAttempts == 0
+func (p *pollingSubscriber) Subscribe(ctx context.Context, maxResubscribeAttempts int) error {
+	if p.stop != nil {
+		p.stop()
+	}
+	attempt := 0
+	latestHeight, err := utils.CallWithBackoff(
+		ctx,
+		maxResubscribeAttempts,
+		p.initialBackoff,
+		p.maxBackoff,
+		func() (uint64, error) {
+			attempt++
+			latestHeight, err := p.ethClient.BlockNumber(ctx)
+			if err != nil {
+				p.logger.Warn(
+					"Failed to get latest block",
+					zap.Int("attempt", attempt),
+					zap.String("blockchainID", p.blockchainID.String()),
+					zap.Error(err),
+				)
+			}
+			return latestHeight, err
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start polling node after %d attempts: %w", attempt, err)
+	}
+
+	// The poll loop outlives [ctx], which only bounds the attempts to start it
+	pollCtx, stop := context.WithCancel(context.Background())
+	p.stop = stop
+	p.errs = make(chan error, 1)
+	go p.poll(pollCtx, latestHeight+1, p.errs)
+
+	p.logger.Info(
+		"Polling for new blocks",
+		zap.String("blockchainID", p.blockchainID.String()),
+		zap.Uint64("fromBlockHeight", latestHeight+1),
+		zap.Duration("interval", p.interval),
+	)
+	return nil
+}
+
+// poll writes the headers of the blocks from [nextHeight] onwards to the headers channel as they are produced,
+// until [ctx] is canceled. Exits after writing to [errs] if the blocks cannot be fetched.
+func (p *pollingSubscriber) poll(ctx context.Context, nextHeight uint64, errs chan<- error) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		latestHeight, err := p.ethClient.BlockNumber(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			errs <- fmt.Errorf("failed to get latest block: %w", err)
+			return
+		}
+		if latestHeight < nextHeight {
+			continue
+		}
+		toHeight := min(latestHeight, nextHeight+p.batchSize-1)
+		if err := p.processBlockRange(
+			new(big.Int).SetUint64(nextHeight),
+			new(big.Int).SetUint64(toHeight),
+		); err != nil {
+			errs <- fmt.Errorf("failed to process block range: %w", err)
+			return
+		}
+		nextHeight = toHeight + 1
+	}
+}
+
+func (p *pollingSubscriber) Err() <-chan error {
+	return p.errs
+}
+
+// Cancel stops polling and closes the client.
+func (p *pollingSubscriber) Cancel() {
+	if p.stop != nil {
+		p.stop()
+	}
+	p.ethClient.Close()
+}
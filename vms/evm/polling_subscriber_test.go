@@ -0,0 +1,59 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	mock_ethclient "github.com/ava-labs/awm-relayer/vms/evm/mocks"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPollingSubscriber(t *testing.T) {
+	mockEthClient := mock_ethclient.NewMockClient(gomock.NewController(t))
+	subscriber := NewPollingSubscriber(
+		logging.NoLog{},
+		ids.GenerateTestID(),
+		mockEthClient,
+		time.Millisecond,
+		4*time.Millisecond,
+		time.Millisecond,
+		2,
+	)
+
+	// Polling starts from the block following the latest block. The blocks produced since are processed in
+	// batches of at most two blocks per poll, until the node fails.
+	nodeErr := errors.New("connection reset")
+	gomock.InOrder(
+		mockEthClient.EXPECT().BlockNumber(gomock.Any()).Return(uint64(10), nil),
+		mockEthClient.EXPECT().BlockNumber(gomock.Any()).Return(uint64(10), nil),
+		mockEthClient.EXPECT().BlockNumber(gomock.Any()).Return(uint64(13), nil),
+		mockEthClient.EXPECT().BlockNumber(gomock.Any()).Return(uint64(13), nil),
+		mockEthClient.EXPECT().BlockNumber(gomock.Any()).Return(uint64(0), nodeErr),
+	)
+	mockEthClient.EXPECT().HeaderByNumber(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, number *big.Int) (*types.Header, error) {
+			return &types.Header{Number: number}, nil
+		},
+	).Times(3)
+
+	require.NoError(t, subscriber.Subscribe(context.Background(), 1))
+	for _, expectedHeight := range []uint64{11, 12, 13} {
+		header := <-subscriber.Headers()
+		require.Equal(t, expectedHeight, header.Number.Uint64())
+	}
+	require.ErrorIs(t, <-subscriber.Err(), nodeErr)
+	require.Empty(t, subscriber.Headers())
+
+	mockEthClient.EXPECT().Close()
+	subscriber.Cancel()
+}
@@ -0,0 +1,31 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/awm-relayer/vms/evm/signer"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// NonceAllocator hands out the nonces of sender accounts whose signing keys are shared with other relayers.
+type NonceAllocator interface {
+	// AllocateNonce returns the next nonce of [address] on [destinationBlockchainID], which is at least [minNonce].
+	AllocateNonce(destinationBlockchainID ids.ID, address common.Address, minNonce uint64) (uint64, error)
+	// ReleaseNonce returns an allocated [nonce] that was not used, so that it may be allocated again.
+	ReleaseNonce(destinationBlockchainID ids.ID, address common.Address, nonce uint64) error
+}
+
+// senderAccount is a signing key used to issue transactions on the destination chain,
+// along with its locally tracked nonce.
+type senderAccount struct {
+	signer signer.Signer
+	// Synchronizes nonce access so that transactions from this account are sent in nonce order.
+	// Held until the transaction is sent to minimize the chance of an out-of-order transaction
+	// being dropped from the mempool.
+	lock         sync.Mutex
+	currentNonce uint64
+}
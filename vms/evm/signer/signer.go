@@ -16,9 +16,24 @@ type Signer interface {
 	Address() common.Address
 }
 
-func NewSigner(destinationBlockchain *config.DestinationBlockchain) (Signer, error) {
-	if destinationBlockchain.AccountPrivateKey == "" {
-		return NewKMSSigner(destinationBlockchain.KMSAWSRegion, destinationBlockchain.KMSKeyID)
+// NewSigners returns a signer for each of the destination blockchain's private keys, or a single KMS signer
+// if no private keys are configured.
+func NewSigners(destinationBlockchain *config.DestinationBlockchain) ([]Signer, error) {
+	privateKeys := destinationBlockchain.GetAccountPrivateKeys()
+	if len(privateKeys) == 0 {
+		sgnr, err := NewKMSSigner(destinationBlockchain.KMSAWSRegion, destinationBlockchain.KMSKeyID)
+		if err != nil {
+			return nil, err
+		}
+		return []Signer{sgnr}, nil
 	}
-	return NewTxSigner(destinationBlockchain.AccountPrivateKey)
+	signers := make([]Signer, 0, len(privateKeys))
+	for _, privateKey := range privateKeys {
+		sgnr, err := NewTxSigner(privateKey)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, sgnr)
+	}
+	return signers, nil
 }
@@ -0,0 +1,43 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The execute entrypoint implemented by ERC-4337 style smart accounts, such as SimpleAccount.
+// The account forwards the call to [dest] with [value] and [func] as call data, and reverts
+// if the caller is not an owner of the account or its entrypoint.
+const smartAccountABIJSON = `[{
+	"type": "function",
+	"name": "execute",
+	"inputs": [
+		{"name": "dest", "type": "address"},
+		{"name": "value", "type": "uint256"},
+		{"name": "func", "type": "bytes"}
+	],
+	"outputs": [],
+	"stateMutability": "nonpayable"
+}]`
+
+var smartAccountABI abi.ABI
+
+func init() {
+	var err error
+	smartAccountABI, err = abi.JSON(strings.NewReader(smartAccountABIJSON))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// packSmartAccountExecute wraps the delivery call data in a call to the smart account's execute
+// entrypoint, such that the smart account is the caller of [to].
+func packSmartAccountExecute(to common.Address, callData []byte) ([]byte, error) {
+	return smartAccountABI.Pack("execute", to, big.NewInt(0), callData)
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/config"
+	relayerTypes "github.com/ava-labs/awm-relayer/types"
+	"github.com/ava-labs/awm-relayer/utils"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+)
+
+// NewSourceClient returns a client for the RPC endpoints of [sourceBlockchain]. If fallback endpoints are
+// configured, the client fails over between them.
+func NewSourceClient(
+	ctx context.Context,
+	logger logging.Logger,
+	metrics *SourceClientMetrics,
+	sourceBlockchain *config.SourceBlockchain,
+) (ethclient.Client, error) {
+	if !sourceBlockchain.HasFallbackRPCEndpoints() {
+		return utils.NewEthClientWithConfig(
+			ctx,
+			sourceBlockchain.RPCEndpoint.BaseURL,
+			sourceBlockchain.RPCEndpoint.HTTPHeaders,
+			sourceBlockchain.RPCEndpoint.QueryParams,
+			sourceBlockchain.GetTLSConfig(),
+		)
+	}
+	transport, err := newFailoverTransport(
+		ctx,
+		logger,
+		sourceBlockchain.GetBlockchainID(),
+		metrics.activeEndpoint,
+		metrics.failoverCount,
+		sourceBlockchain.GetRPCEndpoints(),
+		sourceBlockchain.GetStaleHeadTimeout(),
+		sourceBlockchain.GetEndpointHealthCheckInterval(),
+		sourceBlockchain.GetTLSConfig(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return utils.NewEthClientWithTransport(ctx, sourceBlockchain.RPCEndpoint.BaseURL, transport)
+}
+
+// warpSourceClient reads the Warp messages sent on an EVM source blockchain from the logs of the Warp precompile
+type warpSourceClient struct {
+	client ethclient.Client
+}
+
+// NewWarpSourceClient returns a reader of the Warp messages sent on an EVM source blockchain, which fetches
+// the Warp precompile logs of each block via [client]
+func NewWarpSourceClient(client ethclient.Client) *warpSourceClient {
+	return &warpSourceClient{
+		client: client,
+	}
+}
+
+// WarpBlockInfo returns the Warp messages sent in the block with header [header]. The logs are only fetched if
+// the header's bloom filter indicates that the block contains Warp messages.
+func (c *warpSourceClient) WarpBlockInfo(header *types.Header) (*relayerTypes.WarpBlockInfo, error) {
+	return relayerTypes.NewWarpBlockInfo(header, c.client)
+}
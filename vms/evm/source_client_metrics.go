@@ -0,0 +1,50 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	ErrFailedToCreateSourceClientMetrics = errors.New("failed to create source client metrics")
+)
+
+type SourceClientMetrics struct {
+	activeEndpoint *prometheus.GaugeVec
+	failoverCount  *prometheus.CounterVec
+}
+
+func NewSourceClientMetrics(registerer prometheus.Registerer) (*SourceClientMetrics, error) {
+	activeEndpoint := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "source_rpc_endpoint_active",
+			Help: "Set to 1 for the RPC endpoint a source client is sending requests to, and 0 for its other endpoints",
+		},
+		[]string{"source_chain_id", "endpoint"},
+	)
+	if activeEndpoint == nil {
+		return nil, ErrFailedToCreateSourceClientMetrics
+	}
+	registerer.MustRegister(activeEndpoint)
+
+	failoverCount := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "source_rpc_endpoint_failover_count",
+			Help: "Number of times a source client switched the RPC endpoint it sends requests to",
+		},
+		[]string{"source_chain_id"},
+	)
+	if failoverCount == nil {
+		return nil, ErrFailedToCreateSourceClientMetrics
+	}
+	registerer.MustRegister(failoverCount)
+
+	return &SourceClientMetrics{
+		activeEndpoint: activeEndpoint,
+		failoverCount:  failoverCount,
+	}, nil
+}
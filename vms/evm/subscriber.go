@@ -11,6 +11,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/utils"
 	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ava-labs/subnet-evm/ethclient"
 	"github.com/ava-labs/subnet-evm/interfaces"
@@ -20,7 +21,6 @@ import (
 const (
 	// Max buffer size for ethereum subscription channels
 	maxClientSubscriptionBuffer = 20000
-	subscribeRetryTimeout       = 1 * time.Second
 	MaxBlocksPerRequest         = 200
 )
 
@@ -31,16 +31,29 @@ type subscriber struct {
 	headers      chan *types.Header
 	sub          interfaces.Subscription
 
+	// Bounds on the delay between subscription attempts
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+
 	logger logging.Logger
 }
 
-// NewSubscriber returns a subscriber
-func NewSubscriber(logger logging.Logger, blockchainID ids.ID, ethClient ethclient.Client) *subscriber {
+// NewSubscriber returns a subscriber. Failed subscription attempts are retried with an exponential
+// backoff starting at [initialBackoff], up to [maxBackoff].
+func NewSubscriber(
+	logger logging.Logger,
+	blockchainID ids.ID,
+	ethClient ethclient.Client,
+	initialBackoff time.Duration,
+	maxBackoff time.Duration,
+) *subscriber {
 	return &subscriber{
-		blockchainID: blockchainID,
-		ethClient:    ethClient,
-		logger:       logger,
-		headers:      make(chan *types.Header, maxClientSubscriptionBuffer),
+		blockchainID:   blockchainID,
+		ethClient:      ethClient,
+		logger:         logger,
+		headers:        make(chan *types.Header, maxClientSubscriptionBuffer),
+		initialBackoff: initialBackoff,
+		maxBackoff:     maxBackoff,
 	}
 }
 
@@ -74,28 +87,46 @@ func (s *subscriber) ProcessFromHeight(height *big.Int, done chan bool) {
 		return
 	}
 
-	bigLatestBlockHeight := big.NewInt(0).SetUint64(latestBlockHeight)
+	// Clamp to the latest known block because we've already subscribed to new blocks and we don't
+	// want to double-process any blocks created after that subscription but before the determination
+	// of this "latest"
+	done <- s.processHeightRange(height, big.NewInt(0).SetUint64(latestBlockHeight))
+}
 
+// Process logs from [fromHeight] to [toHeight], inclusive. Limits the number of blocks retrieved
+// in a single request in the same way as ProcessFromHeight.
+// Writes true to the done channel when finished, or false if an error occurs
+func (s *subscriber) ProcessHeightRange(fromHeight *big.Int, toHeight *big.Int, done chan bool) {
+	defer close(done)
+	if fromHeight == nil || toHeight == nil {
+		s.logger.Error("Cannot process logs from nil height")
+		done <- false
+		return
+	}
+	s.logger.Info(
+		"Processing historical logs in range",
+		zap.String("fromBlockHeight", fromHeight.String()),
+		zap.String("toBlockHeight", toHeight.String()),
+		zap.String("blockchainID", s.blockchainID.String()),
+	)
+	done <- s.processHeightRange(fromHeight, toHeight)
+}
+
+func (s *subscriber) processHeightRange(height *big.Int, latestHeight *big.Int) bool {
 	//nolint:lll
-	for fromBlock := big.NewInt(0).Set(height); fromBlock.Cmp(bigLatestBlockHeight) <= 0; fromBlock.Add(fromBlock, big.NewInt(MaxBlocksPerRequest)) {
+	for fromBlock := big.NewInt(0).Set(height); fromBlock.Cmp(latestHeight) <= 0; fromBlock.Add(fromBlock, big.NewInt(MaxBlocksPerRequest)) {
 		toBlock := big.NewInt(0).Add(fromBlock, big.NewInt(MaxBlocksPerRequest-1))
-
-		// clamp to latest known block because we've already subscribed
-		// to new blocks and we don't want to double-process any blocks
-		// created after that subscription but before the determination
-		// of this "latest"
-		if toBlock.Cmp(bigLatestBlockHeight) > 0 {
-			toBlock.Set(bigLatestBlockHeight)
+		if toBlock.Cmp(latestHeight) > 0 {
+			toBlock.Set(latestHeight)
 		}
 
-		err = s.processBlockRange(fromBlock, toBlock)
+		err := s.processBlockRange(fromBlock, toBlock)
 		if err != nil {
 			s.logger.Error("Failed to process block range", zap.Error(err))
-			done <- false
-			return
+			return false
 		}
 	}
-	done <- true
+	return true
 }
 
 // Process Warp messages from the block range [fromBlock, toBlock], inclusive
@@ -103,7 +134,17 @@ func (s *subscriber) processBlockRange(
 	fromBlock, toBlock *big.Int,
 ) error {
 	for i := fromBlock.Int64(); i <= toBlock.Int64(); i++ {
-		header, err := s.ethClient.HeaderByNumber(context.Background(), big.NewInt(i))
+		// The latest block may have just been announced by the node, and not yet be queryable.
+		cctx, cancel := context.WithTimeout(context.Background(), utils.DefaultRPCRetryTimeout)
+		header, err := utils.CallWithBackoff[*types.Header](
+			cctx,
+			utils.BlockReadMaxAttempts,
+			utils.BlockReadInitialBackoff,
+			utils.BlockReadMaxBackoff,
+			func() (*types.Header, error) {
+				return s.ethClient.HeaderByNumber(cctx, big.NewInt(i))
+			})
+		cancel()
 		if err != nil {
 			s.logger.Error(
 				"Failed to get header by number",
@@ -117,45 +158,46 @@ func (s *subscriber) processBlockRange(
 	return nil
 }
 
-// Loops forever iff maxResubscribeAttempts == 0
-func (s *subscriber) Subscribe(maxResubscribeAttempts int) error {
-	// Retry subscribing until successful. Attempt to resubscribe maxResubscribeAttempts times
-	attempt := 1
-	for {
-		// Unsubscribe before resubscribing
-		// s.sub should only be nil on the first call to Subscribe
-		if s.sub != nil {
-			s.sub.Unsubscribe()
-		}
-		err := s.subscribe()
-		if err == nil {
-			s.logger.Info(
-				"Successfully subscribed",
-				zap.String("blockchainID", s.blockchainID.String()),
-			)
-			return nil
-		}
-
-		s.logger.Warn(
-			"Failed to subscribe to node",
-			zap.Int("attempt", attempt),
-			zap.String("blockchainID", s.blockchainID.String()),
-			zap.Error(err),
-		)
-
-		if attempt == maxResubscribeAttempts {
-			break
-		}
-
-		time.Sleep(subscribeRetryTimeout)
-		attempt++
+// Retries with exponential backoff until subscribed, [ctx] is canceled, or [maxResubscribeAttempts]
+// attempts have failed. Loops forever iff maxResubscribeAttempts == 0
+func (s *subscriber) Subscribe(ctx context.Context, maxResubscribeAttempts int) error {
+	attempt := 0
+	_, err := utils.CallWithBackoff(
+		ctx,
+		maxResubscribeAttempts,
+		s.initialBackoff,
+		s.maxBackoff,
+		func() (struct{}, error) {
+			attempt++
+			// Unsubscribe before resubscribing
+			// s.sub should only be nil on the first call to Subscribe
+			if s.sub != nil {
+				s.sub.Unsubscribe()
+			}
+			err := s.subscribe(ctx)
+			if err != nil {
+				s.logger.Warn(
+					"Failed to subscribe to node",
+					zap.Int("attempt", attempt),
+					zap.String("blockchainID", s.blockchainID.String()),
+					zap.Error(err),
+				)
+			}
+			return struct{}{}, err
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to node after %d attempts: %w", attempt, err)
 	}
-
-	return fmt.Errorf("failed to subscribe to node with all %d attempts", maxResubscribeAttempts)
+	s.logger.Info(
+		"Successfully subscribed",
+		zap.String("blockchainID", s.blockchainID.String()),
+	)
+	return nil
 }
 
-func (s *subscriber) subscribe() error {
-	sub, err := s.ethClient.SubscribeNewHead(context.Background(), s.headers)
+func (s *subscriber) subscribe(ctx context.Context) error {
+	sub, err := s.ethClient.SubscribeNewHead(ctx, s.headers)
 	if err != nil {
 		s.logger.Error(
 			"Failed to subscribe to logs",
@@ -177,6 +219,11 @@ func (s *subscriber) Err() <-chan error {
 	return s.sub.Err()
 }
 
+// Cancel unsubscribes from new headers and closes the client. The ethclient manages both the log and
+// err channels, so they are not closed.
 func (s *subscriber) Cancel() {
-	// Nothing to do here, the ethclient manages both the log and err channels
+	if s.sub != nil {
+		s.sub.Unsubscribe()
+	}
+	s.ethClient.Close()
 }
@@ -4,8 +4,11 @@
 package evm
 
 import (
+	"context"
+	"errors"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -31,7 +34,7 @@ func makeSubscriberWithMockEthClient(t *testing.T) (*subscriber, *mock_ethclient
 	mockEthClient := mock_ethclient.NewMockClient(gomock.NewController(t))
 	blockchainID, err := ids.FromString(sourceSubnet.BlockchainID)
 	require.NoError(t, err)
-	subscriber := NewSubscriber(logger, blockchainID, mockEthClient)
+	subscriber := NewSubscriber(logger, blockchainID, mockEthClient, time.Millisecond, 4*time.Millisecond)
 
 	return subscriber, mockEthClient
 }
@@ -99,3 +102,101 @@ func TestProcessFromHeight(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessHeightRange(t *testing.T) {
+	testCases := []struct {
+		name string
+		from int64
+		to   int64
+	}{
+		{
+			name: "single block",
+			from: 100,
+			to:   100,
+		},
+		{
+			name: "greater than max blocks",
+			from: 700,
+			to:   1000,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			subscriberUnderTest, mockEthClient := makeSubscriberWithMockEthClient(t)
+
+			// The range is bounded, so the latest block is not queried
+			for i := tc.from; i <= tc.to; i++ {
+				mockEthClient.EXPECT().HeaderByNumber(
+					gomock.Any(),
+					big.NewInt(i),
+				).Return(&types.Header{
+					Number: big.NewInt(i),
+				}, nil).Times(1)
+			}
+			done := make(chan bool, 1)
+			subscriberUnderTest.ProcessHeightRange(big.NewInt(tc.from), big.NewInt(tc.to), done)
+			result := <-done
+			require.True(t, result)
+		})
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	testCases := []struct {
+		name           string
+		failedAttempts int
+		maxAttempts    int
+		expectedErr    bool
+	}{
+		{
+			name:           "first attempt",
+			failedAttempts: 0,
+			maxAttempts:    3,
+		},
+		{
+			name:           "succeeds after retries",
+			failedAttempts: 2,
+			maxAttempts:    3,
+		},
+		{
+			name:           "retries indefinitely",
+			failedAttempts: 5,
+			maxAttempts:    0,
+		},
+		{
+			name:           "all attempts fail",
+			failedAttempts: 3,
+			maxAttempts:    3,
+			expectedErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			subscriberUnderTest, mockEthClient := makeSubscriberWithMockEthClient(t)
+
+			errSubscribe := errors.New("connection refused")
+			failures := mockEthClient.
+				EXPECT().
+				SubscribeNewHead(gomock.Any(), gomock.Any()).
+				Return(nil, errSubscribe).
+				Times(tc.failedAttempts)
+			if !tc.expectedErr {
+				mockEthClient.
+					EXPECT().
+					SubscribeNewHead(gomock.Any(), gomock.Any()).
+					Return(nil, nil).
+					After(failures).
+					Times(1)
+			}
+
+			err := subscriberUnderTest.Subscribe(context.Background(), tc.maxAttempts)
+			if tc.expectedErr {
+				require.ErrorIs(t, err, errSubscribe)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -10,10 +10,12 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	ids "github.com/ava-labs/avalanchego/ids"
 	warp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	config "github.com/ava-labs/awm-relayer/config"
 	common "github.com/ethereum/go-ethereum/common"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -69,31 +71,158 @@ func (mr *MockDestinationClientMockRecorder) DestinationBlockchainID() *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestinationBlockchainID", reflect.TypeOf((*MockDestinationClient)(nil).DestinationBlockchainID))
 }
 
+// SendBatch mocks base method.
+func (m *MockDestinationClient) SendBatch(ctx context.Context, signedMessages []*warp.Message, toAddress string, gasLimit uint64, callData [][]byte) (common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBatch", ctx, signedMessages, toAddress, gasLimit, callData)
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendBatch indicates an expected call of SendBatch.
+func (mr *MockDestinationClientMockRecorder) SendBatch(ctx, signedMessages, toAddress, gasLimit, callData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBatch", reflect.TypeOf((*MockDestinationClient)(nil).SendBatch), ctx, signedMessages, toAddress, gasLimit, callData)
+}
+
 // SendTx mocks base method.
-func (m *MockDestinationClient) SendTx(signedMessage *warp.Message, toAddress string, gasLimit uint64, callData []byte) (common.Hash, error) {
+func (m *MockDestinationClient) SendTx(ctx context.Context, signedMessage *warp.Message, toAddress string, gasLimit uint64, callData []byte) (common.Hash, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendTx", signedMessage, toAddress, gasLimit, callData)
+	ret := m.ctrl.Call(m, "SendTx", ctx, signedMessage, toAddress, gasLimit, callData)
 	ret0, _ := ret[0].(common.Hash)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SendTx indicates an expected call of SendTx.
-func (mr *MockDestinationClientMockRecorder) SendTx(signedMessage, toAddress, gasLimit, callData any) *gomock.Call {
+func (mr *MockDestinationClientMockRecorder) SendTx(ctx, signedMessage, toAddress, gasLimit, callData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTx", reflect.TypeOf((*MockDestinationClient)(nil).SendTx), ctx, signedMessage, toAddress, gasLimit, callData)
+}
+
+// SenderAddresses mocks base method.
+func (m *MockDestinationClient) SenderAddresses() []common.Address {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SenderAddresses")
+	ret0, _ := ret[0].([]common.Address)
+	return ret0
+}
+
+// SenderAddresses indicates an expected call of SenderAddresses.
+func (mr *MockDestinationClientMockRecorder) SenderAddresses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SenderAddresses", reflect.TypeOf((*MockDestinationClient)(nil).SenderAddresses))
+}
+
+// MockSignerUpdater is a mock of SignerUpdater interface.
+type MockSignerUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockSignerUpdaterMockRecorder
+}
+
+// MockSignerUpdaterMockRecorder is the mock recorder for MockSignerUpdater.
+type MockSignerUpdaterMockRecorder struct {
+	mock *MockSignerUpdater
+}
+
+// NewMockSignerUpdater creates a new mock instance.
+func NewMockSignerUpdater(ctrl *gomock.Controller) *MockSignerUpdater {
+	mock := &MockSignerUpdater{ctrl: ctrl}
+	mock.recorder = &MockSignerUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSignerUpdater) EXPECT() *MockSignerUpdaterMockRecorder {
+	return m.recorder
+}
+
+// UpdateSigners mocks base method.
+func (m *MockSignerUpdater) UpdateSigners(destinationBlockchain *config.DestinationBlockchain) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSigners", destinationBlockchain)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSigners indicates an expected call of UpdateSigners.
+func (mr *MockSignerUpdaterMockRecorder) UpdateSigners(destinationBlockchain any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTx", reflect.TypeOf((*MockDestinationClient)(nil).SendTx), signedMessage, toAddress, gasLimit, callData)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSigners", reflect.TypeOf((*MockSignerUpdater)(nil).UpdateSigners), destinationBlockchain)
+}
+
+// MockConfirmationWaiter is a mock of ConfirmationWaiter interface.
+type MockConfirmationWaiter struct {
+	ctrl     *gomock.Controller
+	recorder *MockConfirmationWaiterMockRecorder
+}
+
+// MockConfirmationWaiterMockRecorder is the mock recorder for MockConfirmationWaiter.
+type MockConfirmationWaiterMockRecorder struct {
+	mock *MockConfirmationWaiter
+}
+
+// NewMockConfirmationWaiter creates a new mock instance.
+func NewMockConfirmationWaiter(ctrl *gomock.Controller) *MockConfirmationWaiter {
+	mock := &MockConfirmationWaiter{ctrl: ctrl}
+	mock.recorder = &MockConfirmationWaiterMockRecorder{mock}
+	return mock
 }
 
-// SenderAddress mocks base method.
-func (m *MockDestinationClient) SenderAddress() common.Address {
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConfirmationWaiter) EXPECT() *MockConfirmationWaiterMockRecorder {
+	return m.recorder
+}
+
+// WaitForConfirmations mocks base method.
+func (m *MockConfirmationWaiter) WaitForConfirmations(ctx context.Context, txHash common.Hash, confirmations uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SenderAddress")
-	ret0, _ := ret[0].(common.Address)
+	ret := m.ctrl.Call(m, "WaitForConfirmations", ctx, txHash, confirmations)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SenderAddress indicates an expected call of SenderAddress.
-func (mr *MockDestinationClientMockRecorder) SenderAddress() *gomock.Call {
+// WaitForConfirmations indicates an expected call of WaitForConfirmations.
+func (mr *MockConfirmationWaiterMockRecorder) WaitForConfirmations(ctx, txHash, confirmations any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitForConfirmations", reflect.TypeOf((*MockConfirmationWaiter)(nil).WaitForConfirmations), ctx, txHash, confirmations)
+}
+
+// MockCallSender is a mock of CallSender interface.
+type MockCallSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockCallSenderMockRecorder
+}
+
+// MockCallSenderMockRecorder is the mock recorder for MockCallSender.
+type MockCallSenderMockRecorder struct {
+	mock *MockCallSender
+}
+
+// NewMockCallSender creates a new mock instance.
+func NewMockCallSender(ctrl *gomock.Controller) *MockCallSender {
+	mock := &MockCallSender{ctrl: ctrl}
+	mock.recorder = &MockCallSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCallSender) EXPECT() *MockCallSenderMockRecorder {
+	return m.recorder
+}
+
+// SendCall mocks base method.
+func (m *MockCallSender) SendCall(ctx context.Context, toAddress string, gasLimit uint64, callData []byte) (common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCall", ctx, toAddress, gasLimit, callData)
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendCall indicates an expected call of SendCall.
+func (mr *MockCallSenderMockRecorder) SendCall(ctx, toAddress, gasLimit, callData any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SenderAddress", reflect.TypeOf((*MockDestinationClient)(nil).SenderAddress))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCall", reflect.TypeOf((*MockCallSender)(nil).SendCall), ctx, toAddress, gasLimit, callData)
 }
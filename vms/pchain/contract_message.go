@@ -0,0 +1,63 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pchain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/awm-relayer/config"
+	relayerTypes "github.com/ava-labs/awm-relayer/types"
+	"go.uber.org/zap"
+)
+
+// ErrNotPChainMessage is returned when unpacking a Warp message that was not sent by the P-Chain
+var ErrNotPChainMessage = errors.New("warp message was not sent by the P-Chain")
+
+type contractMessage struct {
+	logger          logging.Logger
+	maxMessageBytes uint64
+}
+
+func NewContractMessage(logger logging.Logger, subnetInfo config.SourceBlockchain) *contractMessage {
+	return &contractMessage{
+		logger:          logger,
+		maxMessageBytes: subnetInfo.GetMaxMessageBytes(),
+	}
+}
+
+func (m *contractMessage) UnpackWarpMessage(unsignedMsgBytes []byte) (*avalancheWarp.UnsignedMessage, error) {
+	// Unlike messages sent via the subnet-evm Warp precompile, P-Chain messages are not ABI encoded in an
+	// event log, so are always parsed as standalone messages.
+	unsignedMsg, err := avalancheWarp.ParseUnsignedMessage(unsignedMsgBytes)
+	if err != nil {
+		m.logger.Error(
+			"Failed parsing P-Chain unsigned message",
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	if unsignedMsg.SourceChainID != constants.PlatformChainID {
+		m.logger.Error(
+			"Warp message was not sent by the P-Chain",
+			zap.String("warpMessageID", unsignedMsg.ID().String()),
+			zap.String("sourceBlockchainID", unsignedMsg.SourceChainID.String()),
+		)
+		return nil, fmt.Errorf("%w: source blockchain %s", ErrNotPChainMessage, unsignedMsg.SourceChainID)
+	}
+	if uint64(len(unsignedMsg.Payload)) > m.maxMessageBytes {
+		m.logger.Warn(
+			"Warp message payload exceeds the maximum message size. Not relaying.",
+			zap.String("warpMessageID", unsignedMsg.ID().String()),
+			zap.Int("payloadBytes", len(unsignedMsg.Payload)),
+			zap.Uint64("maxMessageBytes", m.maxMessageBytes),
+		)
+		return nil, relayerTypes.ErrMessageTooLarge
+	}
+
+	return unsignedMsg, nil
+}
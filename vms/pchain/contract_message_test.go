@@ -0,0 +1,66 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pchain
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warpPayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/awm-relayer/config"
+	relayerTypes "github.com/ava-labs/awm-relayer/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpack(t *testing.T) {
+	// P-Chain messages are sent by the P-Chain itself, so carry no source address
+	payload, err := warpPayload.NewAddressedCall(nil, []byte{1, 2, 3})
+	require.NoError(t, err)
+	pChainMessage, err := warp.NewUnsignedMessage(constants.UnitTestID, constants.PlatformChainID, payload.Bytes())
+	require.NoError(t, err)
+	subnetMessage, err := warp.NewUnsignedMessage(constants.UnitTestID, ids.GenerateTestID(), payload.Bytes())
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		input           []byte
+		maxMessageBytes uint64
+		expectedErr     error
+	}{
+		{
+			name:  "valid message",
+			input: pChainMessage.Bytes(),
+		},
+		{
+			name:        "message from another blockchain",
+			input:       subnetMessage.Bytes(),
+			expectedErr: ErrNotPChainMessage,
+		},
+		{
+			name:            "message exceeding maximum message size",
+			input:           pChainMessage.Bytes(),
+			maxMessageBytes: 1,
+			expectedErr:     relayerTypes.ErrMessageTooLarge,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			m := NewContractMessage(logging.NoLog{}, config.SourceBlockchain{MaxMessageBytes: testCase.maxMessageBytes})
+			msg, err := m.UnpackWarpMessage(testCase.input)
+			require.ErrorIs(t, err, testCase.expectedErr)
+			if testCase.expectedErr == nil {
+				require.Equal(t, pChainMessage.ID(), msg.ID())
+			}
+		})
+	}
+
+	// Messages that are not valid unsigned Warp messages cannot be unpacked
+	m := NewContractMessage(logging.NoLog{}, config.SourceBlockchain{})
+	_, err = m.UnpackWarpMessage([]byte{1, 2, 3})
+	require.Error(t, err)
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vms
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ava-labs/awm-relayer/vms/evm"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// rateLimitedDestinationClient wraps a DestinationClient, limiting the rate at which transactions are submitted
+// via the wrapped client with a token bucket. The bucket holds up to one second's worth of sends, so that short
+// bursts are not delayed. Sends in excess of the rate wait for a token, rather than failing, so that messages
+// remain buffered by their application relayers.
+type rateLimitedDestinationClient struct {
+	DestinationClient
+	logger  logging.Logger
+	metrics *evm.DestinationClientMetrics
+	limiter *rate.Limiter
+}
+
+// NewRateLimitedDestinationClient wraps [client] with the send rate limit configured by [destinationBlockchain].
+// [metrics] may be nil.
+func NewRateLimitedDestinationClient(
+	logger logging.Logger,
+	metrics *evm.DestinationClientMetrics,
+	client DestinationClient,
+	destinationBlockchain *config.DestinationBlockchain,
+) DestinationClient {
+	burst := max(1, int(math.Ceil(destinationBlockchain.MaxSendsPerSecond)))
+	return &rateLimitedDestinationClient{
+		DestinationClient: client,
+		logger:            logger,
+		metrics:           metrics,
+		limiter:           rate.NewLimiter(rate.Limit(destinationBlockchain.MaxSendsPerSecond), burst),
+	}
+}
+
+// SendTx sends the transaction via the wrapped client once the rate limit allows it.
+func (c *rateLimitedDestinationClient) SendTx(
+	ctx context.Context,
+	signedMessage *warp.Message,
+	toAddress string,
+	gasLimit uint64,
+	callData []byte,
+) (common.Hash, error) {
+	if err := c.wait(ctx); err != nil {
+		return common.Hash{}, err
+	}
+	return c.DestinationClient.SendTx(ctx, signedMessage, toAddress, gasLimit, callData)
+}
+
+// SendBatch sends the batch transaction via the wrapped client once the rate limit allows it.
+func (c *rateLimitedDestinationClient) SendBatch(
+	ctx context.Context,
+	signedMessages []*warp.Message,
+	toAddress string,
+	gasLimit uint64,
+	callData [][]byte,
+) (common.Hash, error) {
+	if err := c.wait(ctx); err != nil {
+		return common.Hash{}, err
+	}
+	return c.DestinationClient.SendBatch(ctx, signedMessages, toAddress, gasLimit, callData)
+}
+
+// SendCall issues the transaction via the wrapped client once the rate limit allows it.
+func (c *rateLimitedDestinationClient) SendCall(
+	ctx context.Context,
+	toAddress string,
+	gasLimit uint64,
+	callData []byte,
+) (common.Hash, error) {
+	if err := c.wait(ctx); err != nil {
+		return common.Hash{}, err
+	}
+	return SendCall(ctx, c.DestinationClient, toAddress, gasLimit, callData)
+}
+
+// UpdateSigners updates the signing keys of the wrapped client.
+func (c *rateLimitedDestinationClient) UpdateSigners(destinationBlockchain *config.DestinationBlockchain) error {
+	return UpdateSigners(c.DestinationClient, destinationBlockchain)
+}
+
+// WaitForConfirmations waits for confirmations of a transaction sent by the wrapped client.
+func (c *rateLimitedDestinationClient) WaitForConfirmations(
+	ctx context.Context,
+	txHash common.Hash,
+	confirmations uint64,
+) error {
+	return WaitForConfirmations(ctx, c.DestinationClient, txHash, confirmations)
+}
+
+// wait blocks until a send may be issued without exceeding the rate limit, or [ctx] is canceled.
+// Unlike rate.Limiter.Wait, a send is not failed early if [ctx]'s deadline would pass before it may be issued.
+func (c *rateLimitedDestinationClient) wait(ctx context.Context) error {
+	reservation := c.limiter.Reserve()
+	delay := reservation.Delay()
+	if delay == 0 {
+		return nil
+	}
+	c.logger.Debug(
+		"Send rate limit reached. Waiting to send",
+		zap.String("destinationBlockchainID", c.DestinationBlockchainID().String()),
+		zap.Duration("delay", delay),
+	)
+
+	start := time.Now()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		// Return the token, so that later sends are not delayed by a send that was not issued
+		reservation.Cancel()
+		c.recordWait(time.Since(start))
+		return ctx.Err()
+	case <-timer.C:
+		c.recordWait(time.Since(start))
+		return nil
+	}
+}
+
+func (c *rateLimitedDestinationClient) recordWait(waited time.Duration) {
+	if c.metrics == nil {
+		return
+	}
+	c.metrics.AddRateLimitedTime(c.DestinationBlockchainID(), waited)
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vms
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/awm-relayer/config"
+	mock_vms "github.com/ava-labs/awm-relayer/vms/mocks"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRateLimitedDestinationClient(t *testing.T) {
+	txHash := common.HexToHash("0x01")
+
+	testCases := []struct {
+		name              string
+		maxSendsPerSecond float64
+		sends             int
+		// Lower bound on the time taken to issue all of the sends
+		minElapsed time.Duration
+	}{
+		{
+			name:              "sends within burst are not delayed",
+			maxSendsPerSecond: 50,
+			sends:             3,
+		},
+		{
+			name:              "fractional rate allows a single send",
+			maxSendsPerSecond: 0.5,
+			sends:             1,
+		},
+		{
+			name:              "sends beyond burst are spaced by the rate",
+			maxSendsPerSecond: 1,
+			sends:             2,
+			minElapsed:        time.Second,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockClient := mock_vms.NewMockDestinationClient(ctrl)
+			mockClient.EXPECT().DestinationBlockchainID().Return(ids.GenerateTestID()).AnyTimes()
+			mockClient.EXPECT().SendTx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(txHash, nil).
+				Times(testCase.sends)
+
+			client := NewRateLimitedDestinationClient(
+				logging.NoLog{},
+				nil,
+				mockClient,
+				&config.DestinationBlockchain{MaxSendsPerSecond: testCase.maxSendsPerSecond},
+			)
+
+			start := time.Now()
+			for i := 0; i < testCase.sends; i++ {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				hash, err := client.SendTx(ctx, &warp.Message{}, "", 100_000, []byte{})
+				cancel()
+				require.NoError(t, err)
+				require.Equal(t, txHash, hash)
+			}
+			require.GreaterOrEqual(t, time.Since(start), testCase.minElapsed)
+
+			// Once the bucket is empty, a send that cannot be issued before the context is canceled
+			// is not issued, and returns the context's error
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			for i := 0; i < int(testCase.maxSendsPerSecond); i++ {
+				mockClient.EXPECT().SendTx(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(txHash, nil).
+					MaxTimes(1)
+			}
+			var err error
+			for err == nil {
+				_, err = client.SendTx(ctx, &warp.Message{}, "", 100_000, []byte{})
+			}
+			require.ErrorIs(t, err, context.DeadlineExceeded)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package vms
+
+import (
+	"github.com/ava-labs/awm-relayer/config"
+	relayerTypes "github.com/ava-labs/awm-relayer/types"
+	"github.com/ava-labs/awm-relayer/vms/evm"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+)
+
+// SourceClient reads the Warp messages sent in the blocks of a source blockchain. Each VM provides its own
+// implementation, since the way in which Warp messages are sent and encoded differs between VMs. The listener
+// and message coordinator process the blocks received by the Subscriber via a SourceClient, without regard to
+// the source's VM.
+type SourceClient interface {
+	// WarpBlockInfo returns the Warp messages sent in the block with header [header], as received by the
+	// Subscriber. Messages that cannot be parsed are returned separately, rather than as an error.
+	WarpBlockInfo(header *types.Header) (*relayerTypes.WarpBlockInfo, error)
+}
+
+// NewSourceClient returns a concrete SourceClient according to the VM specified by [sourceBlockchain], which
+// reads blocks via [ethClient]
+func NewSourceClient(sourceBlockchain *config.SourceBlockchain, ethClient ethclient.Client) SourceClient {
+	switch config.ParseVM(sourceBlockchain.VM) {
+	case config.EVM:
+		return evm.NewWarpSourceClient(ethClient)
+	default:
+		return nil
+	}
+}
@@ -4,9 +4,9 @@
 package vms
 
 import (
+	"context"
 	"math/big"
 
-	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/awm-relayer/config"
 	"github.com/ava-labs/awm-relayer/vms/evm"
@@ -22,10 +22,15 @@ type Subscriber interface {
 	// Writes true to the channel on success, false on failure
 	ProcessFromHeight(height *big.Int, done chan bool)
 
+	// ProcessHeightRange processes events from {fromHeight} to {toHeight}, inclusive.
+	// Writes true to the channel on success, false on failure
+	ProcessHeightRange(fromHeight *big.Int, toHeight *big.Int, done chan bool)
+
 	// Subscribe registers a subscription. After Subscribe is called,
 	// log events that match [filter] are written to the channel returned
-	// by Logs
-	Subscribe(maxResubscribeAttempts int) error
+	// by Logs. Failed attempts are retried with exponential backoff, indefinitely
+	// if [maxResubscribeAttempts] is 0, until [ctx] is canceled.
+	Subscribe(ctx context.Context, maxResubscribeAttempts int) error
 
 	// Headers returns the channel that the subscription writes block headers to
 	Headers() <-chan *types.Header
@@ -38,11 +43,44 @@ type Subscriber interface {
 	Cancel()
 }
 
-// NewSubscriber returns a concrete Subscriber according to the VM specified by [subnetInfo]
-func NewSubscriber(logger logging.Logger, vm config.VM, blockchainID ids.ID, ethClient ethclient.Client) Subscriber {
-	switch vm {
+// NewSubscriber returns a concrete Subscriber according to the VM specified by [sourceBlockchain]
+func NewSubscriber(
+	logger logging.Logger,
+	sourceBlockchain *config.SourceBlockchain,
+	ethClient ethclient.Client,
+) Subscriber {
+	switch config.ParseVM(sourceBlockchain.VM) {
+	case config.EVM:
+		return evm.NewSubscriber(
+			logger,
+			sourceBlockchain.GetBlockchainID(),
+			ethClient,
+			sourceBlockchain.GetReconnectInitialBackoff(),
+			sourceBlockchain.GetReconnectMaxBackoff(),
+		)
+	default:
+		return nil
+	}
+}
+
+// NewPollingSubscriber returns a concrete Subscriber according to the VM specified by [sourceBlockchain],
+// which polls [ethClient] for new blocks rather than subscribing to them.
+func NewPollingSubscriber(
+	logger logging.Logger,
+	sourceBlockchain *config.SourceBlockchain,
+	ethClient ethclient.Client,
+) Subscriber {
+	switch config.ParseVM(sourceBlockchain.VM) {
 	case config.EVM:
-		return evm.NewSubscriber(logger, blockchainID, ethClient)
+		return evm.NewPollingSubscriber(
+			logger,
+			sourceBlockchain.GetBlockchainID(),
+			ethClient,
+			sourceBlockchain.GetReconnectInitialBackoff(),
+			sourceBlockchain.GetReconnectMaxBackoff(),
+			sourceBlockchain.GetPollingInterval(),
+			sourceBlockchain.GetPollingBatchSize(),
+		)
 	default:
 		return nil
 	}